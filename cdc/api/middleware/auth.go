@@ -0,0 +1,165 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/soheilhy/cmux"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type tlsConnKey struct{}
+
+// ConnContext keeps the TLS connection in the context of requests, it is used
+// as http.Server.ConnContext. The connections accepted from cmux are not
+// *tls.Conn, so the client certificates can't be found in http.Request.TLS.
+func ConnContext(ctx context.Context, c net.Conn) context.Context {
+	if mc, ok := c.(*cmux.MuxConn); ok {
+		c = mc.Conn
+	}
+	if tc, ok := c.(*tls.Conn); ok {
+		return context.WithValue(ctx, tlsConnKey{}, tc)
+	}
+	return ctx
+}
+
+// peerCommonName returns the common name of the client certificate.
+func peerCommonName(c *gin.Context) string {
+	state := c.Request.TLS
+	if state == nil {
+		if tc, ok := c.Request.Context().Value(tlsConnKey{}).(*tls.Conn); ok {
+			s := tc.ConnectionState()
+			state = &s
+		}
+	}
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return ""
+	}
+	return state.PeerCertificates[0].Subject.CommonName
+}
+
+// authenticator authenticates the requesters of the HTTP API.
+type authenticator struct {
+	cfg *config.AuthConfig
+	// selfCommonName is the common name of the certificate of TiCDC servers,
+	// requests forwarded by other captures are authenticated with it.
+	selfCommonName string
+}
+
+func newAuthenticator(conf *config.ServerConfig) *authenticator {
+	a := &authenticator{cfg: conf.Auth}
+	if !a.cfg.IsEnabled() || conf.Security == nil {
+		return a
+	}
+	cn, err := conf.Security.SelfCommonName()
+	if err != nil {
+		log.Warn("failed to get the common name of the server certificate", zap.Error(err))
+	}
+	a.selfCommonName = cn
+	return a
+}
+
+// authenticate returns the role of the requester. An empty role is returned
+// if the request carries no credential.
+func (a *authenticator) authenticate(c *gin.Context) (config.AuthRole, error) {
+	header := c.GetHeader("Authorization")
+	if strings.HasPrefix(header, "Bearer ") {
+		hash := sha256.Sum256([]byte(strings.TrimPrefix(header, "Bearer ")))
+		tokenHash := hex.EncodeToString(hash[:])
+		for _, token := range a.cfg.Tokens {
+			if subtle.ConstantTimeCompare([]byte(tokenHash), []byte(strings.ToLower(token.TokenHash))) == 1 {
+				return token.Role, nil
+			}
+		}
+		return "", errors.ErrAPIUnauthenticated.GenWithStackByArgs("invalid token")
+	}
+	if name, password, ok := c.Request.BasicAuth(); ok {
+		for _, user := range a.cfg.Users {
+			if user.Name != name {
+				continue
+			}
+			if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+				break
+			}
+			return user.Role, nil
+		}
+		return "", errors.ErrAPIUnauthenticated.GenWithStackByArgs("invalid user or password")
+	}
+	if header != "" {
+		return "", errors.ErrAPIUnauthenticated.GenWithStackByArgs("unsupported authorization scheme")
+	}
+	if cn := peerCommonName(c); cn != "" {
+		if cn == a.selfCommonName {
+			return config.AuthRoleAdmin, nil
+		}
+		for _, cert := range a.cfg.Certs {
+			if cert.CommonName == cn {
+				return cert.Role, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// AuthMiddleware authenticates the requester and checks its role, GET and HEAD
+// requests require readRole, and other requests require writeRole.
+// All requests are allowed if the authentication is not enabled.
+func AuthMiddleware(readRole, writeRole config.AuthRole) gin.HandlerFunc {
+	return authMiddleware(newAuthenticator(config.GetGlobalServerConfig()), readRole, writeRole)
+}
+
+func authMiddleware(auth *authenticator, readRole, writeRole config.AuthRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !auth.cfg.IsEnabled() {
+			c.Next()
+			return
+		}
+		required := writeRole
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			required = readRole
+		}
+		role, err := auth.authenticate(c)
+		if err == nil && role == "" {
+			err = errors.ErrAPIUnauthenticated.GenWithStackByArgs("no credential is provided")
+		}
+		if err != nil {
+			c.Header("WWW-Authenticate", `Basic realm="TiCDC"`)
+			c.IndentedJSON(http.StatusUnauthorized, model.NewHTTPError(err))
+			c.Abort()
+			return
+		}
+		if !role.Covers(required) {
+			c.IndentedJSON(http.StatusForbidden, model.NewHTTPError(
+				errors.ErrAPIPermissionDenied.GenWithStackByArgs(
+					role, c.Request.Method, c.Request.URL.Path)))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tokenHash := sha256.Sum256([]byte("admin-token"))
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.Nil(t, err)
+	auth := &authenticator{
+		cfg: &config.AuthConfig{
+			Tokens: []*config.AuthTokenConfig{{
+				TokenHash: hex.EncodeToString(tokenHash[:]),
+				Role:      config.AuthRoleAdmin,
+			}},
+			Users: []*config.AuthUserConfig{{
+				Name:         "ops",
+				PasswordHash: string(passwordHash),
+				Role:         config.AuthRoleOperator,
+			}},
+			Certs: []*config.AuthCertConfig{{
+				CommonName: "reader",
+				Role:       config.AuthRoleReadOnly,
+			}},
+		},
+		selfCommonName: "ticdc",
+	}
+	router := gin.New()
+	group := router.Group("/test")
+	group.Use(authMiddleware(auth, config.AuthRoleReadOnly, config.AuthRoleOperator))
+	group.GET("", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	group.POST("", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	serve := func(method string, prepare func(req *http.Request)) int {
+		req, err := http.NewRequestWithContext(context.Background(), method, "/test", nil)
+		require.Nil(t, err)
+		prepare(req)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	withCert := func(cn string) func(req *http.Request) {
+		return func(req *http.Request) {
+			req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{
+				{Subject: pkix.Name{CommonName: cn}},
+			}}
+		}
+	}
+
+	// No credential.
+	require.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, func(req *http.Request) {}))
+
+	// Bearer token.
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer admin-token")
+	}))
+	require.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer invalid-token")
+	}))
+
+	// HTTP basic authentication.
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, func(req *http.Request) {
+		req.SetBasicAuth("ops", "password")
+	}))
+	require.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, func(req *http.Request) {
+		req.SetBasicAuth("ops", "invalid-password")
+	}))
+	require.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, func(req *http.Request) {
+		req.SetBasicAuth("unknown", "password")
+	}))
+
+	// Client certificates.
+	require.Equal(t, http.StatusOK, serve(http.MethodGet, withCert("reader")))
+	require.Equal(t, http.StatusForbidden, serve(http.MethodPost, withCert("reader")))
+	require.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, withCert("unknown")))
+	// Requests forwarded by other captures.
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, withCert("ticdc")))
+
+	// All requests are allowed if the authentication is disabled.
+	auth.cfg = &config.AuthConfig{}
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, func(req *http.Request) {}))
+}
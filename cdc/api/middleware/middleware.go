@@ -109,3 +109,12 @@ func CheckServerReadyMiddleware(capture capture.Capture) gin.HandlerFunc {
 		}
 	}
 }
+
+// AdminJobOrigin returns the origin of the admin job issued by the request,
+// requests sent by `cdc cli` carry the client version header.
+func AdminJobOrigin(c *gin.Context) model.AdminJobOrigin {
+	if c.GetHeader(ClientVersionHeader) != "" {
+		return model.AdminJobOriginCLI
+	}
+	return model.AdminJobOriginAPI
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/pingcap/tiflow/cdc/capture"
+	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -57,3 +58,22 @@ func TestCheckServerReadyMiddleware(t *testing.T) {
 	router.ServeHTTP(w, req)
 	require.Equal(t, http.StatusOK, w.Code)
 }
+
+func TestAdminJobOrigin(t *testing.T) {
+	var origin model.AdminJobOrigin
+	router := gin.New()
+	router.POST("/test", func(c *gin.Context) {
+		origin = AdminJobOrigin(c)
+		c.Status(http.StatusOK)
+	})
+
+	req, err := http.NewRequestWithContext(context.Background(),
+		"POST", "/test", nil)
+	require.Nil(t, err)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, model.AdminJobOriginAPI, origin)
+
+	req.Header.Set(ClientVersionHeader, "v6.3.0")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, model.AdminJobOriginCLI, origin)
+}
@@ -32,6 +32,7 @@ import (
 
 	"github.com/pingcap/tiflow/cdc/capture"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/logutil"
 )
@@ -87,11 +88,15 @@ func RegisterOwnerAPIRoutes(router *gin.Engine, capture capture.Capture) {
 	owner.Use(middleware.ErrorHandleMiddleware())
 	owner.Use(middleware.LogMiddleware())
 
-	owner.POST("/resign", gin.WrapF(ownerAPI.handleResignOwner))
-	owner.POST("/admin", gin.WrapF(ownerAPI.handleChangefeedAdmin))
-	owner.POST("/rebalance_trigger", gin.WrapF(ownerAPI.handleRebalanceTrigger))
-	owner.POST("/move_table", gin.WrapF(ownerAPI.handleMoveTable))
-	owner.POST("/changefeed/query", gin.WrapF(ownerAPI.handleChangefeedQuery))
+	readOnly := middleware.AuthMiddleware(config.AuthRoleReadOnly, config.AuthRoleReadOnly)
+	operator := middleware.AuthMiddleware(config.AuthRoleReadOnly, config.AuthRoleOperator)
+	admin := middleware.AuthMiddleware(config.AuthRoleAdmin, config.AuthRoleAdmin)
+
+	owner.POST("/resign", admin, gin.WrapF(ownerAPI.handleResignOwner))
+	owner.POST("/admin", operator, gin.WrapF(ownerAPI.handleChangefeedAdmin))
+	owner.POST("/rebalance_trigger", operator, gin.WrapF(ownerAPI.handleRebalanceTrigger))
+	owner.POST("/move_table", operator, gin.WrapF(ownerAPI.handleMoveTable))
+	owner.POST("/changefeed/query", readOnly, gin.WrapF(ownerAPI.handleChangefeedQuery))
 }
 
 func handleOwnerResp(w http.ResponseWriter, err error) {
@@ -139,8 +144,9 @@ func (h *ownerAPI) handleChangefeedAdmin(w http.ResponseWriter, req *http.Reques
 		return
 	}
 	job := model.AdminJob{
-		CfID: model.DefaultChangeFeedID(req.Form.Get(OpVarChangefeedID)),
-		Type: model.AdminJobType(typ),
+		CfID:   model.DefaultChangeFeedID(req.Form.Get(OpVarChangefeedID)),
+		Type:   model.AdminJobType(typ),
+		Origin: model.AdminJobOriginAPI,
 	}
 
 	err = api.HandleOwnerJob(req.Context(), h.capture, job)
@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/capture"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/owner"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/logutil"
 	"github.com/pingcap/tiflow/pkg/retry"
@@ -77,13 +78,16 @@ func RegisterOpenAPIRoutes(router *gin.Engine, api OpenAPI) {
 	v1.Use(middleware.LogMiddleware())
 	v1.Use(middleware.ErrorHandleMiddleware())
 
+	admin := middleware.AuthMiddleware(config.AuthRoleAdmin, config.AuthRoleAdmin)
+
 	// common API
 	v1.GET("/status", api.ServerStatus)
 	v1.GET("/health", api.Health)
-	v1.POST("/log", SetLogLevel)
+	v1.POST("/log", admin, SetLogLevel)
 
 	// changefeed API
 	changefeedGroup := v1.Group("/changefeeds")
+	changefeedGroup.Use(middleware.AuthMiddleware(config.AuthRoleReadOnly, config.AuthRoleOperator))
 	changefeedGroup.Use(middleware.ForwardToOwnerMiddleware(api.capture))
 	changefeedGroup.GET("", api.ListChangefeed)
 	changefeedGroup.GET("/:changefeed_id", api.GetChangefeed)
@@ -97,17 +101,20 @@ func RegisterOpenAPIRoutes(router *gin.Engine, api OpenAPI) {
 
 	// owner API
 	ownerGroup := v1.Group("/owner")
+	ownerGroup.Use(admin)
 	ownerGroup.Use(middleware.ForwardToOwnerMiddleware(api.capture))
 	ownerGroup.POST("/resign", api.ResignOwner)
 
 	// processor API
 	processorGroup := v1.Group("/processors")
+	processorGroup.Use(middleware.AuthMiddleware(config.AuthRoleReadOnly, config.AuthRoleReadOnly))
 	processorGroup.Use(middleware.ForwardToOwnerMiddleware(api.capture))
 	processorGroup.GET("", api.ListProcessor)
 	processorGroup.GET("/:changefeed_id/:capture_id", api.GetProcessor)
 
 	// capture API
 	captureGroup := v1.Group("/captures")
+	captureGroup.Use(middleware.AuthMiddleware(config.AuthRoleReadOnly, config.AuthRoleAdmin))
 	captureGroup.Use(middleware.ForwardToOwnerMiddleware(api.capture))
 	captureGroup.GET("", api.ListCapture)
 	captureGroup.PUT("/drain", api.DrainCapture)
@@ -353,8 +360,9 @@ func (h *OpenAPI) PauseChangefeed(c *gin.Context) {
 	}
 
 	job := model.AdminJob{
-		CfID: changefeedID,
-		Type: model.AdminStop,
+		CfID:   changefeedID,
+		Type:   model.AdminStop,
+		Origin: middleware.AdminJobOrigin(c),
 	}
 
 	if err := api.HandleOwnerJob(ctx, h.capture, job); err != nil {
@@ -390,8 +398,9 @@ func (h *OpenAPI) ResumeChangefeed(c *gin.Context) {
 	}
 
 	job := model.AdminJob{
-		CfID: changefeedID,
-		Type: model.AdminResume,
+		CfID:   changefeedID,
+		Type:   model.AdminResume,
+		Origin: middleware.AdminJobOrigin(c),
 	}
 
 	if err := api.HandleOwnerJob(ctx, h.capture, job); err != nil {
@@ -485,8 +494,9 @@ func (h *OpenAPI) RemoveChangefeed(c *gin.Context) {
 	}
 
 	job := model.AdminJob{
-		CfID: changefeedID,
-		Type: model.AdminRemove,
+		CfID:   changefeedID,
+		Type:   model.AdminRemove,
+		Origin: middleware.AdminJobOrigin(c),
 	}
 
 	if err := api.HandleOwnerJob(ctx, h.capture, job); err != nil {
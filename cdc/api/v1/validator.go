@@ -157,6 +157,10 @@ func verifyCreateChangefeedConfig(
 	if err != nil {
 		return nil, err
 	}
+	err = sink.ValidateTables(info.SinkURI, replicaConfig, tableInfos)
+	if err != nil {
+		return nil, err
+	}
 	if !replicaConfig.ForceReplicate && !changefeedConfig.IgnoreIneligibleTable {
 		if len(ineligibleTables) != 0 {
 			return nil, cerror.ErrTableIneligible.GenWithStackByArgs(ineligibleTables)
@@ -220,6 +224,11 @@ func VerifyUpdateChangefeedConfig(ctx context.Context,
 			return nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByCause(err)
 		}
 	}
+	if newInfo.SyncPointEnabled {
+		if err := sink.ValidateSyncPoint(newInfo.SinkURI, newInfo.Config); err != nil {
+			return nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByCause(err)
+		}
+	}
 
 	if !diff.Changed(oldInfo, newInfo) {
 		return nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByArgs("changefeed config is the same with the old one, do nothing")
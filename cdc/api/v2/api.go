@@ -17,22 +17,25 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/pingcap/tiflow/cdc/api/middleware"
 	"github.com/pingcap/tiflow/cdc/capture"
+	"github.com/pingcap/tiflow/pkg/config"
 )
 
 // OpenAPIV2 provides CDC v2 APIs
 type OpenAPIV2 struct {
 	capture capture.Capture
 	helpers APIV2Helpers
+
+	dataVerifications *dataVerificationJobs
 }
 
 // NewOpenAPIV2 creates a new OpenAPIV2.
 func NewOpenAPIV2(c capture.Capture) OpenAPIV2 {
-	return OpenAPIV2{c, APIV2HelpersImpl{}}
+	return OpenAPIV2{c, APIV2HelpersImpl{}, newDataVerificationJobs()}
 }
 
 // NewOpenAPIV2ForTest creates a new OpenAPIV2.
 func NewOpenAPIV2ForTest(c capture.Capture, h APIV2Helpers) OpenAPIV2 {
-	return OpenAPIV2{c, h}
+	return OpenAPIV2{c, h, newDataVerificationJobs()}
 }
 
 // RegisterOpenAPIV2Routes registers routes for OpenAPI
@@ -43,25 +46,68 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 	v2.Use(middleware.LogMiddleware())
 	v2.Use(middleware.ErrorHandleMiddleware())
 
+	readOnly := middleware.AuthMiddleware(config.AuthRoleReadOnly, config.AuthRoleReadOnly)
+	operator := middleware.AuthMiddleware(config.AuthRoleReadOnly, config.AuthRoleOperator)
+	admin := middleware.AuthMiddleware(config.AuthRoleAdmin, config.AuthRoleAdmin)
+
+	// common APIs
+	v2.POST("/tso", readOnly, api.QueryTso)
+	v2.GET("/status", api.serverStatus)
+	v2.GET("/health", middleware.ForwardToOwnerMiddleware(api.capture), api.health)
+	v2.POST("/log", admin, api.setLogLevel)
+
 	// changefeed apis
 	changefeedGroup := v2.Group("/changefeeds")
+	changefeedGroup.Use(operator)
 	changefeedGroup.Use(middleware.ForwardToOwnerMiddleware(api.capture))
+	changefeedGroup.GET("", api.listChangeFeeds)
+	changefeedGroup.GET("/:changefeed_id", api.getChangeFeed)
 	changefeedGroup.POST("", api.createChangefeed)
 	changefeedGroup.PUT("/:changefeed_id", api.updateChangefeed)
+	changefeedGroup.DELETE("/:changefeed_id", api.deleteChangefeed)
 	changefeedGroup.GET("/:changefeed_id/meta_info", api.getChangeFeedMetaInfo)
+	changefeedGroup.POST("/:changefeed_id/pause", api.pauseChangefeed)
 	changefeedGroup.POST("/:changefeed_id/resume", api.resumeChangefeed)
+	changefeedGroup.POST("/:changefeed_id/tables/rebalance_table", api.rebalanceTables)
+	changefeedGroup.POST("/:changefeed_id/tables/move_table", api.moveTable)
+	changefeedGroup.GET("/:changefeed_id/tables", api.listTableStats)
+	changefeedGroup.GET("/:changefeed_id/events", api.listChangefeedEvents)
+	changefeedGroup.POST("/:changefeed_id/verify_data", api.verifyChangefeedData)
+	changefeedGroup.GET("/:changefeed_id/verify_data", api.getDataVerification)
 
 	verifyTableGroup := v2.Group("/verify_table")
+	verifyTableGroup.Use(readOnly)
 	verifyTableGroup.Use(middleware.ForwardToOwnerMiddleware(api.capture))
 	verifyTableGroup.POST("", api.verifyTable)
 
+	// owner apis
+	ownerGroup := v2.Group("/owner")
+	ownerGroup.Use(admin)
+	ownerGroup.Use(middleware.ForwardToOwnerMiddleware(api.capture))
+	ownerGroup.POST("/resign", api.resignOwner)
+
+	// processor apis
+	processorGroup := v2.Group("/processors")
+	processorGroup.Use(readOnly)
+	processorGroup.Use(middleware.ForwardToOwnerMiddleware(api.capture))
+	processorGroup.GET("", api.listProcessors)
+	processorGroup.GET("/:changefeed_id/:capture_id", api.getProcessor)
+	// the table statistics are served by the capture itself, it must not be
+	// forwarded to the owner.
+	v2.GET("/processors/:changefeed_id/:capture_id/tables", readOnly, api.getProcessorTableStats)
+
+	// capture apis
+	captureGroup := v2.Group("/captures")
+	captureGroup.Use(middleware.AuthMiddleware(config.AuthRoleReadOnly, config.AuthRoleAdmin))
+	captureGroup.Use(middleware.ForwardToOwnerMiddleware(api.capture))
+	captureGroup.GET("", api.listCaptures)
+	captureGroup.PUT("/:capture_id/drain", api.drainCapture)
+
 	// unsafe apis
 	unsafeGroup := v2.Group("/unsafe")
+	unsafeGroup.Use(admin)
 	unsafeGroup.Use(middleware.ForwardToOwnerMiddleware(api.capture))
 	unsafeGroup.GET("/metadata", api.CDCMetaData)
 	unsafeGroup.POST("/resolve_lock", api.ResolveLock)
 	unsafeGroup.DELETE("/service_gc_safepoint", api.DeleteServiceGcSafePoint)
-
-	// common APIs
-	v2.POST("/tso", api.QueryTso)
 }
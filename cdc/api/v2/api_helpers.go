@@ -15,10 +15,13 @@ package v2
 
 import (
 	"context"
+	"net"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
+	dmysql "github.com/go-sql-driver/mysql"
 	"github.com/google/uuid"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
@@ -28,10 +31,12 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/owner"
 	"github.com/pingcap/tiflow/cdc/sink"
+	"github.com/pingcap/tiflow/cdc/verification"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/filter"
 	"github.com/pingcap/tiflow/pkg/security"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
 	"github.com/pingcap/tiflow/pkg/txnutil/gc"
 	"github.com/pingcap/tiflow/pkg/version"
 	"github.com/r3labs/diff"
@@ -56,6 +61,17 @@ type APIV2Helpers interface {
 		kvStorage tidbkv.Storage,
 	) (*model.ChangeFeedInfo, error)
 
+	// preflightChangefeedConfig checks the changefeedConfig without creating
+	// the changefeed, and reports the tables and the problems found
+	preflightChangefeedConfig(
+		ctx context.Context,
+		cfg *ChangefeedConfig,
+		pdClient pd.Client,
+		statusProvider owner.StatusProvider,
+		gcServiceID string,
+		kvStorage tidbkv.Storage,
+	) (*ChangefeedPreflightResult, error)
+
 	// verifyUpdateChangefeedConfig verifies the changefeed update config,
 	// and returns a pair of valid changefeedInfo & upstreamInfo
 	verifyUpdateChangefeedConfig(
@@ -100,6 +116,15 @@ type APIV2Helpers interface {
 		storage tidbkv.Storage, startTs uint64) (ineligibleTables,
 		eligibleTables []model.TableName, err error,
 	)
+
+	// verifyChangefeedData compares the rows of the tables replicated by the
+	// changefeed between the upstream and the MySQL sink of the changefeed
+	verifyChangefeedData(
+		ctx context.Context,
+		changefeedID model.ChangeFeedID,
+		info *model.ChangeFeedInfo,
+		cfg *DataVerificationConfig,
+	) (*verification.DiffReport, error)
 }
 
 // APIV2HelpersImpl is an implementation of AVIV2Helpers interface
@@ -226,6 +251,10 @@ func (APIV2HelpersImpl) verifyCreateChangefeedConfig(
 	if err != nil {
 		return nil, errors.Cause(err)
 	}
+	err = sink.ValidateTables(cfg.SinkURI, replicaCfg, tableInfos)
+	if err != nil {
+		return nil, err
+	}
 	if !replicaCfg.ForceReplicate && !cfg.ReplicaConfig.IgnoreIneligibleTable {
 		if err != nil {
 			return nil, err
@@ -236,6 +265,11 @@ func (APIV2HelpersImpl) verifyCreateChangefeedConfig(
 	}
 
 	// verify sink
+	if cfg.SyncPointEnabled {
+		if err := sink.ValidateSyncPoint(cfg.SinkURI, replicaCfg); err != nil {
+			return nil, err
+		}
+	}
 	if err := sink.Validate(ctx, cfg.SinkURI, replicaCfg); err != nil {
 		return nil, err
 	}
@@ -257,6 +291,164 @@ func (APIV2HelpersImpl) verifyCreateChangefeedConfig(
 	}, nil
 }
 
+// preflightChangefeedConfig checks the ChangefeedConfig like
+// verifyCreateChangefeedConfig does, but it reports the problems instead of
+// returning an error, and it neither sets the service GC safepoint nor
+// writes anything to the sink.
+func (APIV2HelpersImpl) preflightChangefeedConfig(
+	ctx context.Context,
+	cfg *ChangefeedConfig,
+	pdClient pd.Client,
+	statusProvider owner.StatusProvider,
+	gcServiceID string,
+	kvStorage tidbkv.Storage,
+) (*ChangefeedPreflightResult, error) {
+	result := &ChangefeedPreflightResult{
+		EligibleTables:   make([]PreflightTable, 0),
+		IneligibleTables: make([]PreflightTable, 0),
+		Problems:         make([]string, 0),
+	}
+	addProblem := func(err error) {
+		result.Problems = append(result.Problems, err.Error())
+	}
+
+	// verify sinkURI
+	if cfg.SinkURI == "" {
+		addProblem(cerror.ErrSinkURIInvalid.GenWithStackByArgs(
+			"sink_uri is empty, cannot create a changefeed without sink_uri"))
+		return result, nil
+	}
+
+	// verify changefeedID, the ID is only used to check the GC safepoint
+	// if it is not specified.
+	changefeedID := model.DefaultChangeFeedID(cfg.ID)
+	if cfg.ID == "" {
+		changefeedID.ID = uuid.New().String()
+	} else if err := model.ValidateChangefeedID(cfg.ID); err != nil {
+		addProblem(cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid changefeed_id: %s", cfg.ID))
+	} else {
+		cfStatus, err := statusProvider.GetChangeFeedStatus(ctx, changefeedID)
+		if err != nil && cerror.ErrChangeFeedNotExists.NotEqual(err) {
+			return nil, err
+		}
+		if cfStatus != nil {
+			addProblem(cerror.ErrChangeFeedAlreadyExists.GenWithStackByArgs(cfg.ID))
+		}
+	}
+	if cfg.Namespace != "" {
+		if err := model.ValidateNamespace(cfg.Namespace); err != nil {
+			addProblem(cerror.ErrAPIInvalidParam.GenWithStack(
+				"invalid namespace: %s", cfg.Namespace))
+		}
+	}
+
+	// verify start ts
+	startTs := cfg.StartTs
+	if startTs == 0 {
+		ts, logical, err := pdClient.GetTS(ctx)
+		if err != nil {
+			return nil, cerror.ErrPDEtcdAPIError.GenWithStackByArgs(
+				"fail to get ts from pd client")
+		}
+		startTs = oracle.ComposeTS(ts, logical)
+	}
+	if err := gc.CheckChangefeedStartTsSafety(
+		ctx, pdClient, gcServiceID, changefeedID, startTs); err != nil {
+		if !cerror.ErrStartTsBeforeGC.Equal(err) {
+			return nil, cerror.ErrPDEtcdAPIError.Wrap(err)
+		}
+		addProblem(err)
+	}
+
+	// verify target ts
+	if cfg.TargetTs > 0 && cfg.TargetTs <= startTs {
+		addProblem(cerror.ErrTargetTsBeforeStartTs.GenWithStackByArgs(
+			cfg.TargetTs, startTs))
+	}
+
+	// verify replicaConfig
+	replicaCfg := cfg.ReplicaConfig.ToInternalReplicaConfig()
+	sinkURIParsed, err := url.Parse(cfg.SinkURI)
+	if err != nil {
+		addProblem(cerror.WrapError(cerror.ErrSinkURIInvalid, err))
+		return result, nil
+	}
+	if err := replicaCfg.ValidateAndAdjust(sinkURIParsed); err != nil {
+		addProblem(err)
+		return result, nil
+	}
+	if !replicaCfg.EnableOldValue {
+		for _, fp := range config.ForceEnableOldValueProtocols {
+			if replicaCfg.Sink.Protocol == fp {
+				replicaCfg.EnableOldValue = true
+				break
+			}
+		}
+		if replicaCfg.ForceReplicate {
+			addProblem(cerror.ErrOldValueNotEnabled.GenWithStackByArgs(
+				"if use force replicate, old value feature must be enabled"))
+		}
+	}
+
+	// verify filters against the schema snapshot at the start ts
+	f, err := filter.NewFilter(replicaCfg, "")
+	if err != nil {
+		addProblem(errors.Cause(err))
+		return result, nil
+	}
+	tableInfos, ineligibleTables, eligibleTables, err := entry.VerifyTables(
+		f, kvStorage, startTs)
+	if err != nil {
+		return nil, errors.Cause(err)
+	}
+	if err := f.Verify(tableInfos); err != nil {
+		addProblem(errors.Cause(err))
+	}
+	if err := sink.ValidateTables(cfg.SinkURI, replicaCfg, tableInfos); err != nil {
+		addProblem(err)
+	}
+	allTables := make([]model.TableName, 0, len(eligibleTables)+len(ineligibleTables))
+	allTables = append(allTables, eligibleTables...)
+	allTables = append(allTables, ineligibleTables...)
+	replicatedTables := eligibleTables
+	if replicaCfg.ForceReplicate {
+		replicatedTables = allTables
+	} else if len(ineligibleTables) != 0 && !cfg.ReplicaConfig.IgnoreIneligibleTable {
+		addProblem(cerror.ErrTableIneligible.GenWithStackByArgs(ineligibleTables))
+	}
+
+	// verify sink
+	if cfg.SyncPointEnabled {
+		if err := sink.ValidateSyncPoint(cfg.SinkURI, replicaCfg); err != nil {
+			addProblem(err)
+		}
+	}
+	dispatches, err := sink.GetTableDispatches(cfg.SinkURI, replicaCfg, allTables)
+	if err != nil {
+		addProblem(err)
+	}
+	result.Problems = append(result.Problems,
+		sink.Preflight(ctx, cfg.SinkURI, replicaCfg, replicatedTables)...)
+
+	toPreflightTables := func(tables []model.TableName) []PreflightTable {
+		preflightTables := make([]PreflightTable, 0, len(tables))
+		for _, table := range tables {
+			dispatch := dispatches[table]
+			preflightTables = append(preflightTables, PreflightTable{
+				Schema:        table.Schema,
+				Table:         table.Table,
+				Topic:         dispatch.Topic,
+				PartitionRule: dispatch.PartitionRule,
+			})
+		}
+		return preflightTables
+	}
+	result.EligibleTables = toPreflightTables(eligibleTables)
+	result.IneligibleTables = toPreflightTables(ineligibleTables)
+	return result, nil
+}
+
 // verifyUpstream verifies the upstream config before updating a changefeed
 func (h APIV2HelpersImpl) verifyUpstream(ctx context.Context,
 	changefeedConfig *ChangefeedConfig,
@@ -344,6 +536,14 @@ func (APIV2HelpersImpl) verifyUpdateChangefeedConfig(
 			return nil, nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByCause(err)
 		}
 	}
+	if err := sink.ValidateTables(newInfo.SinkURI, newInfo.Config, tableInfos); err != nil {
+		return nil, nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByCause(err)
+	}
+	if newInfo.SyncPointEnabled {
+		if err := sink.ValidateSyncPoint(newInfo.SinkURI, newInfo.Config); err != nil {
+			return nil, nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByCause(err)
+		}
+	}
 	if cfg.Engine != "" {
 		newInfo.Engine = cfg.Engine
 	}
@@ -454,3 +654,61 @@ func (h APIV2HelpersImpl) getVerfiedTables(replicaConfig *config.ReplicaConfig,
 		VerifyTables(f, storage, startTs)
 	return
 }
+
+func (h APIV2HelpersImpl) verifyChangefeedData(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	info *model.ChangeFeedInfo,
+	cfg *DataVerificationConfig,
+) (*verification.DiffReport, error) {
+	f, err := filter.NewFilter(info.Config, "")
+	if err != nil {
+		return nil, err
+	}
+	upstream, err := pmysql.CreateMySQLDBConn(ctx, getUpstreamTiDBDSN(cfg.UpstreamTiDB))
+	if err != nil {
+		return nil, err
+	}
+	defer upstream.Close()
+
+	// the downstream is connected in the same way as the MySQL sink of the
+	// changefeed, so that the DSN can not be specified by users.
+	sinkURI, err := url.Parse(info.SinkURI)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	sinkCfg := pmysql.NewConfig()
+	if err := sinkCfg.Apply(ctx, changefeedID, sinkURI, info.Config); err != nil {
+		return nil, err
+	}
+	dsn, err := pmysql.GenerateDSN(ctx, sinkURI, sinkCfg, pmysql.CreateMySQLDBConn)
+	if err != nil {
+		return nil, err
+	}
+	downstream, err := pmysql.CreateMySQLDBConn(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+	defer downstream.Close()
+
+	return verification.DiffData(ctx, upstream, downstream, &verification.DiffConfig{
+		Filter:       f,
+		ChunkSize:    cfg.ChunkSize,
+		SyncPointTs:  cfg.SyncPointTs,
+		ChangefeedID: changefeedID,
+	})
+}
+
+// getUpstreamTiDBDSN builds the DSN of the upstream TiDB server. Only the
+// address and the credential come from users, other parameters such as
+// allowAllFiles keep the defaults of the driver.
+func getUpstreamTiDBDSN(cfg *TiDBConfig) string {
+	dsnCfg := dmysql.NewConfig()
+	dsnCfg.User = cfg.User
+	dsnCfg.Passwd = cfg.Password
+	dsnCfg.Net = "tcp"
+	dsnCfg.Addr = net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	dsnCfg.Timeout = dataVerificationDialTimeout
+	dsnCfg.Params = map[string]string{"charset": "utf8mb4"}
+	return dsnCfg.FormatDSN()
+}
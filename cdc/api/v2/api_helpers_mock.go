@@ -12,6 +12,7 @@ import (
 	kv "github.com/pingcap/tidb/kv"
 	model "github.com/pingcap/tiflow/cdc/model"
 	owner "github.com/pingcap/tiflow/cdc/owner"
+	verification "github.com/pingcap/tiflow/cdc/verification"
 	config "github.com/pingcap/tiflow/pkg/config"
 	security "github.com/pingcap/tiflow/pkg/security"
 	client "github.com/tikv/pd/client"
@@ -86,6 +87,36 @@ func (mr *MockAPIV2HelpersMockRecorder) getVerfiedTables(replicaConfig, storage,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getVerfiedTables", reflect.TypeOf((*MockAPIV2Helpers)(nil).getVerfiedTables), replicaConfig, storage, startTs)
 }
 
+// preflightChangefeedConfig mocks base method.
+func (m *MockAPIV2Helpers) preflightChangefeedConfig(ctx context.Context, cfg *ChangefeedConfig, pdClient client.Client, statusProvider owner.StatusProvider, gcServiceID string, kvStorage kv.Storage) (*ChangefeedPreflightResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "preflightChangefeedConfig", ctx, cfg, pdClient, statusProvider, gcServiceID, kvStorage)
+	ret0, _ := ret[0].(*ChangefeedPreflightResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// preflightChangefeedConfig indicates an expected call of preflightChangefeedConfig.
+func (mr *MockAPIV2HelpersMockRecorder) preflightChangefeedConfig(ctx, cfg, pdClient, statusProvider, gcServiceID, kvStorage interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "preflightChangefeedConfig", reflect.TypeOf((*MockAPIV2Helpers)(nil).preflightChangefeedConfig), ctx, cfg, pdClient, statusProvider, gcServiceID, kvStorage)
+}
+
+// verifyChangefeedData mocks base method.
+func (m *MockAPIV2Helpers) verifyChangefeedData(ctx context.Context, changefeedID model.ChangeFeedID, info *model.ChangeFeedInfo, cfg *DataVerificationConfig) (*verification.DiffReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "verifyChangefeedData", ctx, changefeedID, info, cfg)
+	ret0, _ := ret[0].(*verification.DiffReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// verifyChangefeedData indicates an expected call of verifyChangefeedData.
+func (mr *MockAPIV2HelpersMockRecorder) verifyChangefeedData(ctx, changefeedID, info, cfg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "verifyChangefeedData", reflect.TypeOf((*MockAPIV2Helpers)(nil).verifyChangefeedData), ctx, changefeedID, info, cfg)
+}
+
 // verifyCreateChangefeedConfig mocks base method.
 func (m *MockAPIV2Helpers) verifyCreateChangefeedConfig(ctx context.Context, cfg *ChangefeedConfig, pdClient client.Client, statusProvider owner.StatusProvider, ensureGCServiceID string, kvStorage kv.Storage) (*model.ChangeFeedInfo, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +64,13 @@ type mockStatusProvider struct {
 	owner.StatusProvider
 	changefeedStatus *model.ChangeFeedStatus
 	changefeedInfo   *model.ChangeFeedInfo
+	changefeedInfos  map[model.ChangeFeedID]*model.ChangeFeedInfo
+	changefeedStats  map[model.ChangeFeedID]*model.ChangeFeedStatus
+	taskStatuses     map[model.CaptureID]*model.TaskStatus
+	taskPositions    map[model.CaptureID]*model.TaskPosition
+	processors       []*model.ProcInfoSnap
+	captures         []*model.CaptureInfo
+	healthy          bool
 	err              error
 }
 
@@ -80,3 +87,50 @@ func (m *mockStatusProvider) GetChangeFeedInfo(ctx context.Context,
 ) (*model.ChangeFeedInfo, error) {
 	return m.changefeedInfo, m.err
 }
+
+// GetAllChangeFeedStatuses returns all mock changefeeds' runtime status.
+func (m *mockStatusProvider) GetAllChangeFeedStatuses(ctx context.Context) (
+	map[model.ChangeFeedID]*model.ChangeFeedStatus, error,
+) {
+	return m.changefeedStats, m.err
+}
+
+// GetAllChangeFeedInfo returns all mock changefeeds' info.
+func (m *mockStatusProvider) GetAllChangeFeedInfo(ctx context.Context) (
+	map[model.ChangeFeedID]*model.ChangeFeedInfo, error,
+) {
+	return m.changefeedInfos, m.err
+}
+
+// GetAllTaskStatuses returns the mock task statuses.
+func (m *mockStatusProvider) GetAllTaskStatuses(ctx context.Context,
+	changefeedID model.ChangeFeedID,
+) (map[model.CaptureID]*model.TaskStatus, error) {
+	return m.taskStatuses, m.err
+}
+
+// GetTaskPositions returns the mock task positions.
+func (m *mockStatusProvider) GetTaskPositions(ctx context.Context,
+	changefeedID model.ChangeFeedID,
+) (map[model.CaptureID]*model.TaskPosition, error) {
+	return m.taskPositions, m.err
+}
+
+// GetProcessors returns the mock processors.
+func (m *mockStatusProvider) GetProcessors(ctx context.Context) (
+	[]*model.ProcInfoSnap, error,
+) {
+	return m.processors, m.err
+}
+
+// GetCaptures returns the mock captures.
+func (m *mockStatusProvider) GetCaptures(ctx context.Context) (
+	[]*model.CaptureInfo, error,
+) {
+	return m.captures, m.err
+}
+
+// IsHealthy returns the mock health status.
+func (m *mockStatusProvider) IsHealthy(ctx context.Context) (bool, error) {
+	return m.healthy, m.err
+}
@@ -0,0 +1,128 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// listCaptures lists all captures
+// @Summary List captures
+// @Description list all captures in cdc cluster
+// @Tags capture
+// @Accept json
+// @Produce json
+// @Success 200 {object} v2.ListResponse[v2.Capture]
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/captures [get]
+func (h *OpenAPIV2) listCaptures(c *gin.Context) {
+	ctx := c.Request.Context()
+	captureInfos, err := h.capture.StatusProvider().GetCaptures(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	info, err := h.capture.Info()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	ownerID := info.ID
+	clusterID := h.capture.GetEtcdClient().GetClusterID()
+
+	captures := make([]Capture, 0, len(captureInfos))
+	for _, captureInfo := range captureInfos {
+		captures = append(captures, Capture{
+			ID:            captureInfo.ID,
+			IsOwner:       captureInfo.ID == ownerID,
+			AdvertiseAddr: captureInfo.AdvertiseAddr,
+			ClusterID:     clusterID,
+			Labels:        captureInfo.Labels,
+		})
+	}
+	c.JSON(http.StatusOK, &ListResponse[Capture]{
+		Total: len(captures),
+		Items: captures,
+	})
+}
+
+// drainCapture removes all tables at the given capture.
+// @Summary Drain captures
+// @Description Drain all tables at the target captures in cdc cluster
+// @Tags capture
+// @Accept json
+// @Produce json
+// @Param capture_id path string true "capture_id"
+// @Success 202 {object} v2.DrainCaptureResp
+// @Failure 503,500,400 {object} model.HTTPError
+// @Router /api/v2/captures/{capture_id}/drain [put]
+func (h *OpenAPIV2) drainCapture(c *gin.Context) {
+	target := c.Param(apiOpVarCaptureID)
+	if err := model.ValidateChangefeedID(target); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid capture_id: %s",
+			target))
+		return
+	}
+
+	ctx := c.Request.Context()
+	captures, err := h.capture.StatusProvider().GetCaptures(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	// drain capture only work if there is at least two alive captures,
+	// it cannot work properly if it has only one capture.
+	if len(captures) <= 1 {
+		_ = c.Error(cerror.ErrSchedulerRequestFailed.
+			GenWithStackByArgs("only one capture alive"))
+		return
+	}
+	found := false
+	for _, capture := range captures {
+		if capture.ID == target {
+			found = true
+			break
+		}
+	}
+	if !found {
+		_ = c.Error(cerror.ErrCaptureNotExist.GenWithStackByArgs(target))
+		return
+	}
+
+	// only owner handle api request, so this must be the owner.
+	ownerInfo, err := h.capture.Info()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if ownerInfo.ID == target {
+		_ = c.Error(cerror.ErrSchedulerRequestFailed.
+			GenWithStackByArgs("cannot drain the owner"))
+		return
+	}
+
+	resp, err := api.HandleOwnerDrainCapture(ctx, h.capture, target)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusServiceUnavailable, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, &DrainCaptureResp{
+		CurrentTableCount: resp.CurrentTableCount,
+	})
+}
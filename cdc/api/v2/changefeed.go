@@ -15,7 +15,11 @@ package v2
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,24 +27,50 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/api/middleware"
 	"github.com/pingcap/tiflow/cdc/capture"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/httputil"
+	"github.com/pingcap/tiflow/pkg/retry"
 	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/pingcap/tiflow/pkg/txnutil/gc"
 	"github.com/pingcap/tiflow/pkg/upstream"
 	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
 )
 
-const apiOpVarChangefeedID = "changefeed_id"
+const (
+	// apiOpVarChangefeedState is the key of changefeed state in HTTP API
+	apiOpVarChangefeedState = "state"
+	// apiOpVarChangefeedID is the key of changefeed ID in HTTP API
+	apiOpVarChangefeedID = "changefeed_id"
+	// apiOpVarCaptureID is the key of capture ID in HTTP API
+	apiOpVarCaptureID = "capture_id"
+	// apiOpVarDryRun is the key of dry run flag in HTTP API
+	apiOpVarDryRun = "dry_run"
+)
 
 // createChangefeed handles create changefeed request,
-// it returns the changefeed's changefeedInfo that it just created
+// it returns the changefeed's changefeedInfo that it just created.
+// If dry_run is true, the changefeed is only validated and not created,
+// it returns the pre-flight validation result instead.
 func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 	ctx := c.Request.Context()
 	cfg := &ChangefeedConfig{ReplicaConfig: GetDefaultReplicaConfig()}
 
+	dryRun := false
+	if dryRunStr := c.Query(apiOpVarDryRun); dryRunStr != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+				"invalid dry_run: %s", dryRunStr))
+			return
+		}
+	}
 	if err := c.BindJSON(&cfg); err != nil {
 		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
 		return
@@ -73,6 +103,21 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 	// We should not close kvStorage since all kvStorage in cdc is the same one.
 	// defer kvStorage.Close()
 	// TODO: We should get a kvStorage from upstream instead of creating a new one
+	if dryRun {
+		result, err := h.helpers.preflightChangefeedConfig(
+			ctx,
+			cfg,
+			pdClient,
+			h.capture.StatusProvider(),
+			h.capture.GetEtcdClient().GetEnsureGCServiceID(gc.EnsureGCServiceChecking),
+			kvStorage)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, result)
+		return
+	}
 	info, err := h.helpers.verifyCreateChangefeedConfig(
 		ctx,
 		cfg,
@@ -364,6 +409,7 @@ func (h *OpenAPIV2) resumeChangefeed(c *gin.Context) {
 		CfID:                  changefeedID,
 		Type:                  model.AdminResume,
 		OverwriteCheckpointTs: cfg.OverwriteCheckpointTs,
+		Origin:                middleware.AdminJobOrigin(c),
 	}
 
 	if err := api.HandleOwnerJob(ctx, h.capture, job); err != nil {
@@ -376,16 +422,499 @@ func (h *OpenAPIV2) resumeChangefeed(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
-func toAPIModel(info *model.ChangeFeedInfo, maskSinkURI bool) *ChangeFeedInfo {
-	var runningError *RunningError
-	if info.Error != nil {
-		runningError = &RunningError{
-			Addr:    info.Error.Addr,
-			Code:    info.Error.Code,
-			Message: info.Error.Message,
+// listChangeFeeds lists all changgefeeds in cdc cluster
+// @Summary List changefeed
+// @Description list all changefeeds in cdc cluster
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param state query string false "state"
+// @Success 200 {object} v2.ListResponse[v2.ChangefeedCommonInfo]
+// @Failure 500 {object} model.HTTPError
+// @Router /api/v2/changefeeds [get]
+func (h *OpenAPIV2) listChangeFeeds(c *gin.Context) {
+	ctx := c.Request.Context()
+	state := c.Query(apiOpVarChangefeedState)
+	statuses, err := h.capture.StatusProvider().GetAllChangeFeedStatuses(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	infos, err := h.capture.StatusProvider().GetAllChangeFeedInfo(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	changefeeds := make([]model.ChangeFeedID, 0, len(statuses))
+	for cfID := range statuses {
+		changefeeds = append(changefeeds, cfID)
+	}
+	sort.Slice(changefeeds, func(i, j int) bool {
+		if changefeeds[i].Namespace == changefeeds[j].Namespace {
+			return changefeeds[i].ID < changefeeds[j].ID
+		}
+		return changefeeds[i].Namespace < changefeeds[j].Namespace
+	})
+
+	commonInfos := make([]ChangefeedCommonInfo, 0, len(changefeeds))
+	for _, cfID := range changefeeds {
+		cfInfo, exist := infos[cfID]
+		if !exist {
+			// If a changefeed info does not exists, skip it
+			continue
+		}
+		if !cfInfo.State.IsNeeded(state) {
+			continue
+		}
+
+		commonInfo := ChangefeedCommonInfo{
+			UpstreamID: cfInfo.UpstreamID,
+			Namespace:  cfID.Namespace,
+			ID:         cfID.ID,
+			FeedState:  cfInfo.State,
+		}
+		if cfInfo.State != model.StateNormal {
+			commonInfo.RunningError = toAPIRunningError(cfInfo.Error)
+		}
+		if cfStatus := statuses[cfID]; cfStatus != nil {
+			commonInfo.CheckpointTSO = cfStatus.CheckpointTs
+			commonInfo.CheckpointTime = model.JSONTime(
+				oracle.GetTimeFromTS(cfStatus.CheckpointTs))
+		}
+		commonInfos = append(commonInfos, commonInfo)
+	}
+	c.JSON(http.StatusOK, &ListResponse[ChangefeedCommonInfo]{
+		Total: len(commonInfos),
+		Items: commonInfos,
+	})
+}
+
+// getChangeFeed get detailed info of a changefeed
+// @Summary Get changefeed
+// @Description get detail information of a changefeed
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Success 200 {object} v2.ChangefeedDetail
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id} [get]
+func (h *OpenAPIV2) getChangeFeed(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	info, err := h.capture.StatusProvider().GetChangeFeedInfo(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	status, err := h.capture.StatusProvider().GetChangeFeedStatus(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	taskStatus := make([]CaptureTaskStatus, 0)
+	if info.State == model.StateNormal {
+		processorInfos, err := h.capture.StatusProvider().
+			GetAllTaskStatuses(ctx, changefeedID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		for captureID, status := range processorInfos {
+			tables := make([]int64, 0, len(status.Tables))
+			for tableID := range status.Tables {
+				tables = append(tables, tableID)
+			}
+			taskStatus = append(taskStatus,
+				CaptureTaskStatus{CaptureID: captureID, Tables: tables})
+		}
+	}
+	sinkURI, err := util.MaskSinkURI(info.SinkURI)
+	if err != nil {
+		log.Error("failed to mask sink URI", zap.Error(err))
+	}
+
+	detail := &ChangefeedDetail{
+		UpstreamID:     info.UpstreamID,
+		Namespace:      changefeedID.Namespace,
+		ID:             changefeedID.ID,
+		SinkURI:        sinkURI,
+		CreateTime:     model.JSONTime(info.CreateTime),
+		StartTs:        info.StartTs,
+		ResolvedTs:     status.ResolvedTs,
+		TargetTs:       info.TargetTs,
+		CheckpointTSO:  status.CheckpointTs,
+		CheckpointTime: model.JSONTime(oracle.GetTimeFromTS(status.CheckpointTs)),
+		Engine:         info.Engine,
+		FeedState:      info.State,
+		CreatorVersion: info.CreatorVersion,
+		TaskStatus:     taskStatus,
+	}
+	if info.State != model.StateNormal {
+		detail.RunningError = toAPIRunningError(info.Error)
+	}
+	c.JSON(http.StatusOK, detail)
+}
+
+// pauseChangefeed pauses a changefeed
+// @Summary Pause a changefeed
+// @Description Pause a changefeed
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Success 200
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/pause [post]
+func (h *OpenAPIV2) pauseChangefeed(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	// check if the changefeed exists
+	_, err := h.capture.StatusProvider().GetChangeFeedStatus(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	job := model.AdminJob{
+		CfID:   changefeedID,
+		Type:   model.AdminStop,
+		Origin: middleware.AdminJobOrigin(c),
+	}
+	if err := api.HandleOwnerJob(ctx, h.capture, job); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// deleteChangefeed removes a changefeed
+// @Summary Remove a changefeed
+// @Description Remove a changefeed
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Success 200
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id} [delete]
+func (h *OpenAPIV2) deleteChangefeed(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	// check if the changefeed exists
+	_, err := h.capture.StatusProvider().GetChangeFeedStatus(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	job := model.AdminJob{
+		CfID:   changefeedID,
+		Type:   model.AdminRemove,
+		Origin: middleware.AdminJobOrigin(c),
+	}
+	if err := api.HandleOwnerJob(ctx, h.capture, job); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Owner needs at least two ticks to remove a changefeed,
+	// we need to wait for it.
+	err = retry.Do(ctx, func() error {
+		_, err := h.capture.StatusProvider().GetChangeFeedStatus(ctx, changefeedID)
+		if err != nil {
+			if cerror.ErrChangeFeedNotExists.Equal(err) {
+				return nil
+			}
+			return err
+		}
+		return cerror.ErrChangeFeedDeletionUnfinished.GenWithStackByArgs(changefeedID)
+	},
+		retry.WithMaxTries(100),         // max retry duration is 1 minute
+		retry.WithBackoffBaseDelay(600), // default owner tick interval is 200ms
+		retry.WithIsRetryableErr(cerror.IsRetryableError))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// rebalanceTables rebalances all tables of a changefeed
+// @Summary rebalance tables
+// @Description rebalance all tables of a changefeed
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Success 200
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/tables/rebalance_table [post]
+func (h *OpenAPIV2) rebalanceTables(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	// check if the changefeed exists
+	_, err := h.capture.StatusProvider().GetChangeFeedStatus(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := api.HandleOwnerBalance(ctx, h.capture, changefeedID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// moveTable moves a table to the target capture
+// @Summary move table
+// @Description move one table to the target capture
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Param MoveTable body v2.MoveTableReq true "move table request"
+// @Success 200
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/tables/move_table [post]
+func (h *OpenAPIV2) moveTable(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	// check if the changefeed exists
+	_, err := h.capture.StatusProvider().GetChangeFeedStatus(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req := MoveTableReq{}
+	if err := c.BindJSON(&req); err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+		return
+	}
+	if err := model.ValidateChangefeedID(req.CaptureID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid capture_id: %s",
+			req.CaptureID))
+		return
+	}
+
+	err = api.HandleOwnerScheduleTable(
+		ctx, h.capture, changefeedID, req.CaptureID, req.TableID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// listTableStats lists the replication statistics of all tables of a changefeed
+// @Summary List table statistics
+// @Description list the replication statistics of all tables of a changefeed
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Success 200 {object} v2.ListResponse[v2.TableStats]
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/tables [get]
+func (h *OpenAPIV2) listTableStats(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	// check if the changefeed exists
+	statusProvider := h.capture.StatusProvider()
+	_, err := statusProvider.GetChangeFeedStatus(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	ownerInfo, err := h.capture.Info()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	captures, err := statusProvider.GetCaptures(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	items := make([]TableStats, 0)
+	for _, cp := range captures {
+		var stats []TableStats
+		if cp.ID == ownerInfo.ID {
+			var localStats []model.TableStats
+			localStats, err = h.capture.QueryTableStats(ctx, changefeedID)
+			for _, s := range localStats {
+				stats = append(stats, toAPITableStats(s))
+			}
+		} else {
+			stats, err = queryRemoteTableStats(ctx, cp, changefeedID,
+				c.GetHeader("Authorization"))
 		}
+		if err != nil {
+			log.Warn("query table stats failed",
+				zap.String("namespace", changefeedID.Namespace),
+				zap.String("changefeed", changefeedID.ID),
+				zap.String("captureID", cp.ID),
+				zap.Error(err))
+			_ = c.Error(err)
+			return
+		}
+		items = append(items, stats...)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].TableID < items[j].TableID
+	})
+	c.JSON(http.StatusOK, &ListResponse[TableStats]{
+		Total: len(items),
+		Items: items,
+	})
+}
+
+// listChangefeedEvents lists the event history of a changefeed
+// @Summary List changefeed events
+// @Description list the state changes, admin jobs, errors and config updates of a changefeed, the oldest event comes first
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Success 200 {object} v2.ListResponse[v2.ChangefeedEvent]
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/events [get]
+func (h *OpenAPIV2) listChangefeedEvents(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	// check if the changefeed exists
+	_, err := h.capture.StatusProvider().GetChangeFeedStatus(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	history, err := h.capture.GetEtcdClient().GetChangefeedHistory(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	items := make([]ChangefeedEvent, 0, len(history.Events))
+	for _, event := range history.Events {
+		items = append(items, toAPIChangefeedEvent(event))
+	}
+	c.JSON(http.StatusOK, &ListResponse[ChangefeedEvent]{
+		Total: len(items),
+		Items: items,
+	})
+}
+
+func toAPIChangefeedEvent(event *model.ChangefeedEvent) ChangefeedEvent {
+	res := ChangefeedEvent{
+		Time:     event.Time,
+		Type:     string(event.Type),
+		OldState: string(event.OldState),
+		State:    string(event.State),
+		AdminJob: event.AdminJob,
+		Origin:   string(event.Origin),
+		Error:    toAPIRunningError(event.Error),
+		Message:  event.Message,
+	}
+	for _, change := range event.ConfigChanges {
+		res.ConfigChanges = append(res.ConfigChanges, ChangefeedConfigChange{
+			Path: change.Path,
+			From: change.From,
+			To:   change.To,
+		})
+	}
+	return res
+}
+
+// queryRemoteTableStats queries the replication statistics of tables
+// replicated by the given capture through its HTTP API, the authorization
+// of the original request is passed through.
+func queryRemoteTableStats(
+	ctx context.Context, cp *model.CaptureInfo, changefeedID model.ChangeFeedID,
+	authorization string,
+) ([]TableStats, error) {
+	credential := config.GetGlobalServerConfig().Security
+	scheme := "http"
+	// we should check tls config instead of credential here because
+	// credential will never be nil
+	if tls, _ := credential.ToTLSConfigWithVerify(); tls != nil {
+		scheme = "https"
+	}
+	cli, err := httputil.NewClient(credential)
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
+	defer cli.CloseIdleConnections()
 
+	url := fmt.Sprintf("%s://%s/api/v2/processors/%s/%s/tables",
+		scheme, cp.AdvertiseAddr, changefeedID.ID, cp.ID)
+	var header http.Header
+	if authorization != "" {
+		header = http.Header{"Authorization": []string{authorization}}
+	}
+	content, err := cli.DoRequest(ctx, url, http.MethodGet, header, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp := &ListResponse[TableStats]{}
+	if err := json.Unmarshal(content, resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return resp.Items, nil
+}
+
+func toAPIRunningError(err *model.RunningError) *RunningError {
+	if err == nil {
+		return nil
+	}
+	return &RunningError{
+		Addr:    err.Addr,
+		Code:    err.Code,
+		Message: err.Message,
+	}
+}
+
+func toAPIModel(info *model.ChangeFeedInfo, maskSinkURI bool) *ChangeFeedInfo {
 	sinkURI := info.SinkURI
 	var err error
 	if maskSinkURI {
@@ -407,7 +936,7 @@ func toAPIModel(info *model.ChangeFeedInfo, maskSinkURI bool) *ChangeFeedInfo {
 		Engine:            info.Engine,
 		Config:            ToAPIReplicaConfig(info.Config),
 		State:             info.State,
-		Error:             runningError,
+		Error:             toAPIRunningError(info.Error),
 		SyncPointEnabled:  info.SyncPointEnabled,
 		SyncPointInterval: info.SyncPointInterval,
 		CreatorVersion:    info.CreatorVersion,
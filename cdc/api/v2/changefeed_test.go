@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -218,6 +219,86 @@ func TestCreateChangefeed(t *testing.T) {
 	require.Equal(t, http.StatusCreated, w.Code)
 }
 
+func TestCreateChangefeedDryRun(t *testing.T) {
+	t.Parallel()
+	create := testCase{url: "/api/v2/changefeeds?dry_run=%s", method: "POST"}
+
+	pdClient := &mockPDClient{}
+	helpers := NewMockAPIV2Helpers(gomock.NewController(t))
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	etcdClient := mock_etcd.NewMockCDCEtcdClient(gomock.NewController(t))
+	apiV2 := NewOpenAPIV2ForTest(cp, helpers)
+	router := newRouter(apiV2)
+
+	statusProvider := &mockStatusProvider{}
+	etcdClient.EXPECT().
+		GetEnsureGCServiceID(gomock.Any()).
+		Return(etcd.GcServiceIDForTest()).AnyTimes()
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	cp.EXPECT().GetEtcdClient().Return(etcdClient).AnyTimes()
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	helpers.EXPECT().
+		getPDClient(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pdClient, nil).AnyTimes()
+	helpers.EXPECT().
+		createTiStore(gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
+
+	cfConfig := &ChangefeedConfig{
+		ID:       changeFeedID.ID,
+		SinkURI:  blackholeSink,
+		PDConfig: PDConfig{PDAddrs: []string{"http://127.0.0.1:2379"}},
+	}
+	body, err := json.Marshal(cfConfig)
+	require.Nil(t, err)
+
+	// case 1: invalid dry run flag
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(), create.method,
+		fmt.Sprintf(create.url, "abc"), bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	respErr := model.HTTPError{}
+	err = json.NewDecoder(w.Body).Decode(&respErr)
+	require.Nil(t, err)
+	require.Contains(t, respErr.Code, "ErrAPIInvalidParam")
+
+	// case 2: pre-flight validation failed
+	helpers.EXPECT().
+		preflightChangefeedConfig(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, cerrors.ErrPDEtcdAPIError).Times(1)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), create.method,
+		fmt.Sprintf(create.url, "true"), bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// case 3: success, the changefeed is not created
+	helpers.EXPECT().
+		preflightChangefeedConfig(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&ChangefeedPreflightResult{
+			EligibleTables: []PreflightTable{{Schema: "test", Table: "t1"}},
+			IneligibleTables: []PreflightTable{
+				{Schema: "test", Table: "t2"},
+			},
+			Problems: []string{"some tables are not eligible"},
+		}, nil).Times(1)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), create.method,
+		fmt.Sprintf(create.url, "true"), bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := ChangefeedPreflightResult{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	require.Nil(t, err)
+	require.Len(t, resp.EligibleTables, 1)
+	require.Len(t, resp.IneligibleTables, 1)
+	require.Equal(t, []string{"some tables are not eligible"}, resp.Problems)
+}
+
 func TestUpdateChangefeed(t *testing.T) {
 	t.Parallel()
 	update := testCase{url: "/api/v2/changefeeds/%s", method: "PUT"}
@@ -657,3 +738,405 @@ func TestResumeChangefeed(t *testing.T) {
 	router.ServeHTTP(w, req)
 	require.Equal(t, http.StatusOK, w.Code)
 }
+
+func TestListChangeFeeds(t *testing.T) {
+	t.Parallel()
+
+	list := testCase{url: "/api/v2/changefeeds?state=%s", method: "GET"}
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	statusProvider := &mockStatusProvider{
+		changefeedInfos: map[model.ChangeFeedID]*model.ChangeFeedInfo{
+			model.DefaultChangeFeedID("cf-2"): {State: model.StateNormal},
+			model.DefaultChangeFeedID("cf-1"): {
+				State: model.StateFailed,
+				Error: &model.RunningError{Code: "CDC:ErrGCTTLExceeded"},
+			},
+			model.DefaultChangeFeedID("cf-3"): {State: model.StateFinished},
+		},
+		changefeedStats: map[model.ChangeFeedID]*model.ChangeFeedStatus{
+			model.DefaultChangeFeedID("cf-1"): {CheckpointTs: 1},
+			model.DefaultChangeFeedID("cf-2"): {CheckpointTs: 2},
+			model.DefaultChangeFeedID("cf-3"): {CheckpointTs: 3},
+			// the info of this changefeed is missing
+			model.DefaultChangeFeedID("cf-4"): {CheckpointTs: 4},
+		},
+	}
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	apiV2 := NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{})
+	router := newRouter(apiV2)
+
+	// case 1: list changefeeds in the default states
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(),
+		list.method, fmt.Sprintf(list.url, ""), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := ListResponse[ChangefeedCommonInfo]{}
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	require.Nil(t, err)
+	require.Equal(t, 2, resp.Total)
+	require.Equal(t, "cf-1", resp.Items[0].ID)
+	require.Equal(t, uint64(1), resp.Items[0].CheckpointTSO)
+	require.Equal(t, "CDC:ErrGCTTLExceeded", resp.Items[0].RunningError.Code)
+	require.Equal(t, "cf-2", resp.Items[1].ID)
+	require.Nil(t, resp.Items[1].RunningError)
+
+	// case 2: list all changefeeds
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(),
+		list.method, fmt.Sprintf(list.url, "all"), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp = ListResponse[ChangefeedCommonInfo]{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	require.Nil(t, err)
+	require.Equal(t, 3, resp.Total)
+	require.Equal(t, "cf-3", resp.Items[2].ID)
+
+	// case 3: failed to get changefeed statuses
+	statusProvider.err = cerrors.ErrPDEtcdAPIError.GenWithStackByArgs()
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(),
+		list.method, fmt.Sprintf(list.url, ""), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	respErr := model.HTTPError{}
+	err = json.NewDecoder(w.Body).Decode(&respErr)
+	require.Nil(t, err)
+	require.Contains(t, respErr.Code, "ErrPDEtcdAPIError")
+}
+
+func TestGetChangeFeed(t *testing.T) {
+	t.Parallel()
+
+	get := testCase{url: "/api/v2/changefeeds/%s", method: "GET"}
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	statusProvider := &mockStatusProvider{}
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	apiV2 := NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{})
+	router := newRouter(apiV2)
+
+	// case 1: invalid changefeed id
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(),
+		get.method, fmt.Sprintf(get.url, "@^Invalid"), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	respErr := model.HTTPError{}
+	err := json.NewDecoder(w.Body).Decode(&respErr)
+	require.Nil(t, err)
+	require.Contains(t, respErr.Code, "ErrAPIInvalidParam")
+
+	// case 2: changefeed not exists
+	validID := changeFeedID.ID
+	statusProvider.err = cerrors.ErrChangeFeedNotExists.GenWithStackByArgs(validID)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(),
+		get.method, fmt.Sprintf(get.url, validID), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	respErr = model.HTTPError{}
+	err = json.NewDecoder(w.Body).Decode(&respErr)
+	require.Nil(t, err)
+	require.Contains(t, respErr.Code, "ErrChangeFeedNotExists")
+
+	// case 3: success
+	statusProvider.err = nil
+	statusProvider.changefeedInfo = &model.ChangeFeedInfo{
+		ID:      validID,
+		SinkURI: mysqlSink,
+		State:   model.StateNormal,
+	}
+	statusProvider.changefeedStatus = &model.ChangeFeedStatus{
+		CheckpointTs: 100,
+		ResolvedTs:   200,
+	}
+	statusProvider.taskStatuses = map[model.CaptureID]*model.TaskStatus{
+		"capture-1": {Tables: map[model.TableID]*model.TableReplicaInfo{1: {}}},
+	}
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(),
+		get.method, fmt.Sprintf(get.url, validID), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := ChangefeedDetail{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	require.Nil(t, err)
+	require.Equal(t, validID, resp.ID)
+	require.Equal(t, uint64(100), resp.CheckpointTSO)
+	require.Equal(t, uint64(200), resp.ResolvedTs)
+	require.NotContains(t, resp.SinkURI, "123456")
+	require.Equal(t, []CaptureTaskStatus{
+		{CaptureID: "capture-1", Tables: []int64{1}},
+	}, resp.TaskStatus)
+}
+
+func TestPauseChangefeed(t *testing.T) {
+	t.Parallel()
+
+	pause := testCase{url: "/api/v2/changefeeds/%s/pause", method: "POST"}
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	owner := mock_owner.NewMockOwner(gomock.NewController(t))
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().GetOwner().Return(owner, nil).AnyTimes()
+	statusProvider := &mockStatusProvider{}
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	apiV2 := NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{})
+	router := newRouter(apiV2)
+
+	// case 1: changefeed not exists
+	validID := changeFeedID.ID
+	statusProvider.err = cerrors.ErrChangeFeedNotExists.GenWithStackByArgs(validID)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(),
+		pause.method, fmt.Sprintf(pause.url, validID), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	respErr := model.HTTPError{}
+	err := json.NewDecoder(w.Body).Decode(&respErr)
+	require.Nil(t, err)
+	require.Contains(t, respErr.Code, "ErrChangeFeedNotExists")
+
+	// case 2: success
+	statusProvider.err = nil
+	statusProvider.changefeedStatus = &model.ChangeFeedStatus{}
+	owner.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).
+		Do(func(adminJob model.AdminJob, done chan<- error) {
+			require.EqualValues(t, changeFeedID, adminJob.CfID)
+			require.EqualValues(t, model.AdminStop, adminJob.Type)
+			close(done)
+		}).Times(1)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(),
+		pause.method, fmt.Sprintf(pause.url, validID), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestDeleteChangefeed(t *testing.T) {
+	t.Parallel()
+
+	remove := testCase{url: "/api/v2/changefeeds/%s", method: "DELETE"}
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	owner := mock_owner.NewMockOwner(gomock.NewController(t))
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().GetOwner().Return(owner, nil).AnyTimes()
+	statusProvider := &mockStatusProvider{changefeedStatus: &model.ChangeFeedStatus{}}
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	apiV2 := NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{})
+	router := newRouter(apiV2)
+
+	validID := changeFeedID.ID
+	owner.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).
+		Do(func(adminJob model.AdminJob, done chan<- error) {
+			require.EqualValues(t, changeFeedID, adminJob.CfID)
+			require.EqualValues(t, model.AdminRemove, adminJob.Type)
+			// the changefeed is removed by the owner
+			statusProvider.err = cerrors.ErrChangeFeedNotExists.GenWithStackByArgs(validID)
+			close(done)
+		}).Times(1)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(),
+		remove.method, fmt.Sprintf(remove.url, validID), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestMoveTable(t *testing.T) {
+	t.Parallel()
+
+	move := testCase{url: "/api/v2/changefeeds/%s/tables/move_table", method: "POST"}
+	rebalance := testCase{url: "/api/v2/changefeeds/%s/tables/rebalance_table", method: "POST"}
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	owner := mock_owner.NewMockOwner(gomock.NewController(t))
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().GetOwner().Return(owner, nil).AnyTimes()
+	statusProvider := &mockStatusProvider{changefeedStatus: &model.ChangeFeedStatus{}}
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	apiV2 := NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{})
+	router := newRouter(apiV2)
+	validID := changeFeedID.ID
+
+	// case 1: invalid capture id
+	body, err := json.Marshal(&MoveTableReq{CaptureID: "@^Invalid", TableID: 1})
+	require.Nil(t, err)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(),
+		move.method, fmt.Sprintf(move.url, validID), bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	respErr := model.HTTPError{}
+	err = json.NewDecoder(w.Body).Decode(&respErr)
+	require.Nil(t, err)
+	require.Contains(t, respErr.Code, "ErrAPIInvalidParam")
+
+	// case 2: move table successfully
+	owner.EXPECT().ScheduleTable(changeFeedID, "capture-1", int64(1), gomock.Any()).
+		Do(func(cfID model.ChangeFeedID, toCapture model.CaptureID,
+			tableID model.TableID, done chan<- error,
+		) {
+			close(done)
+		}).Times(1)
+	body, err = json.Marshal(&MoveTableReq{CaptureID: "capture-1", TableID: 1})
+	require.Nil(t, err)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(),
+		move.method, fmt.Sprintf(move.url, validID), bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// case 3: rebalance tables successfully
+	owner.EXPECT().RebalanceTables(changeFeedID, gomock.Any()).
+		Do(func(cfID model.ChangeFeedID, done chan<- error) {
+			close(done)
+		}).Times(1)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(),
+		rebalance.method, fmt.Sprintf(rebalance.url, validID), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestListTableStats(t *testing.T) {
+	t.Parallel()
+
+	list := testCase{url: "/api/v2/changefeeds/%s/tables", method: "GET"}
+
+	// the remote capture serves its table statistics over HTTP.
+	remote := mock_capture.NewMockCapture(gomock.NewController(t))
+	remote.EXPECT().IsReady().Return(true).AnyTimes()
+	remote.EXPECT().Info().Return(model.CaptureInfo{ID: "capture-2"}, nil).AnyTimes()
+	remote.EXPECT().QueryTableStats(gomock.Any(), changeFeedID).Return(
+		[]model.TableStats{{TableID: 2, CaptureID: "capture-2"}}, nil).AnyTimes()
+	server := httptest.NewServer(newRouter(NewOpenAPIV2ForTest(remote, APIV2HelpersImpl{})))
+	defer server.Close()
+
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().Info().Return(model.CaptureInfo{ID: "capture-1"}, nil).AnyTimes()
+	cp.EXPECT().QueryTableStats(gomock.Any(), changeFeedID).Return(
+		[]model.TableStats{
+			{TableID: 3, CaptureID: "capture-1"},
+			{TableID: 1, CaptureID: "capture-1"},
+		}, nil).AnyTimes()
+	statusProvider := &mockStatusProvider{
+		changefeedStatus: &model.ChangeFeedStatus{},
+		captures: []*model.CaptureInfo{
+			{ID: "capture-1"},
+			{ID: "capture-2", AdvertiseAddr: strings.TrimPrefix(server.URL, "http://")},
+		},
+	}
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	apiV2 := NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{})
+	router := newRouter(apiV2)
+
+	// case 1: invalid changefeed id
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(), list.method,
+		fmt.Sprintf(list.url, "@^Invalid"), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	respErr := model.HTTPError{}
+	err := json.NewDecoder(w.Body).Decode(&respErr)
+	require.Nil(t, err)
+	require.Contains(t, respErr.Code, "ErrAPIInvalidParam")
+
+	// case 2: success, tables of all captures are returned in order
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), list.method,
+		fmt.Sprintf(list.url, changeFeedID.ID), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := ListResponse[TableStats]{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	require.Nil(t, err)
+	require.Equal(t, 3, resp.Total)
+	for i, item := range resp.Items {
+		require.Equal(t, int64(i+1), item.TableID)
+	}
+	require.Equal(t, "capture-2", resp.Items[1].CaptureID)
+
+	// case 3: changefeed not exists
+	statusProvider.err = cerrors.ErrChangeFeedNotExists.GenWithStackByArgs(changeFeedID.ID)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), list.method,
+		fmt.Sprintf(list.url, changeFeedID.ID), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	respErr = model.HTTPError{}
+	err = json.NewDecoder(w.Body).Decode(&respErr)
+	require.Nil(t, err)
+	require.Contains(t, respErr.Code, "ErrChangeFeedNotExists")
+}
+
+func TestListChangefeedEvents(t *testing.T) {
+	t.Parallel()
+
+	list := testCase{url: "/api/v2/changefeeds/%s/events", method: "GET"}
+
+	history := &model.ChangefeedHistory{}
+	history.Append(&model.ChangefeedEvent{
+		Type:     model.ChangefeedEventAdminJob,
+		AdminJob: model.AdminStop.String(),
+		Origin:   model.AdminJobOriginCLI,
+	}, &model.ChangefeedEvent{
+		Type:     model.ChangefeedEventStateChanged,
+		OldState: model.StateNormal,
+		State:    model.StateStopped,
+	}, &model.ChangefeedEvent{
+		Type: model.ChangefeedEventConfigUpdated,
+		ConfigChanges: []*model.ChangefeedConfigChange{
+			{Path: "SinkURI", From: "blackhole://", To: "mysql://"},
+		},
+	})
+	etcdClient := mock_etcd.NewMockCDCEtcdClient(gomock.NewController(t))
+	etcdClient.EXPECT().GetChangefeedHistory(gomock.Any(), changeFeedID).
+		Return(history, nil).AnyTimes()
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().GetEtcdClient().Return(etcdClient).AnyTimes()
+	statusProvider := &mockStatusProvider{changefeedStatus: &model.ChangeFeedStatus{}}
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	router := newRouter(NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{}))
+
+	// case 1: invalid changefeed id
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(), list.method,
+		fmt.Sprintf(list.url, "@^Invalid"), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// case 2: success, events are returned in order
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), list.method,
+		fmt.Sprintf(list.url, changeFeedID.ID), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := ListResponse[ChangefeedEvent]{}
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	require.Nil(t, err)
+	require.Equal(t, 3, resp.Total)
+	require.Equal(t, "admin-job", resp.Items[0].Type)
+	require.Equal(t, "cli", resp.Items[0].Origin)
+	require.Equal(t, "stopped", resp.Items[1].State)
+	require.Equal(t, []ChangefeedConfigChange{
+		{Path: "SinkURI", From: "blackhole://", To: "mysql://"},
+	}, resp.Items[2].ConfigChanges)
+
+	// case 3: changefeed not exists
+	statusProvider.err = cerrors.ErrChangeFeedNotExists.GenWithStackByArgs(changeFeedID.ID)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), list.method,
+		fmt.Sprintf(list.url, changeFeedID.ID), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -46,6 +46,26 @@ type TableName struct {
 	IsPartition bool   `json:"is_partition"`
 }
 
+// PreflightTable is a table checked by the changefeed pre-flight validation
+type PreflightTable struct {
+	Schema string `json:"database_name"`
+	Table  string `json:"table_name"`
+	// The target topic and the partition dispatch rule of the table,
+	// only available for MQ sinks.
+	Topic         string `json:"topic,omitempty"`
+	PartitionRule string `json:"partition_rule,omitempty"`
+}
+
+// ChangefeedPreflightResult is the result of the changefeed pre-flight
+// validation, the changefeed is not created by the validation.
+type ChangefeedPreflightResult struct {
+	EligibleTables   []PreflightTable `json:"eligible_tables"`
+	IneligibleTables []PreflightTable `json:"ineligible_tables"`
+	// Problems found in the changefeed config, the changefeed can be
+	// created successfully only if there is no problem.
+	Problems []string `json:"problems"`
+}
+
 // VerifyTableConfig use to verify tables.
 // Only use by Open API v2.
 type VerifyTableConfig struct {
@@ -54,6 +74,57 @@ type VerifyTableConfig struct {
 	StartTs       uint64         `json:"start_ts"`
 }
 
+// DataVerificationConfig is the config of the row-level data verification
+// of a changefeed, the downstream is the MySQL sink of the changefeed.
+type DataVerificationConfig struct {
+	UpstreamTiDB *TiDBConfig `json:"upstream_tidb"`
+	// SyncPointTs is the syncpoint of the changefeed to verify the data at,
+	// the latest data is verified if it's 0.
+	SyncPointTs uint64 `json:"sync_point_ts"`
+	ChunkSize   int    `json:"chunk_size"`
+}
+
+// TiDBConfig is the address and the credential of a TiDB server. Only these
+// fields are accepted, other connection parameters are set by TiCDC.
+type TiDBConfig struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+// DataVerificationStatus is the status of the data verification job of a
+// changefeed.
+type DataVerificationStatus struct {
+	// State is one of running, finished and failed.
+	State     string     `json:"state"`
+	StartTime time.Time  `json:"start_time"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	// Result is only available if the job is finished.
+	Result *DataVerificationResult `json:"result,omitempty"`
+}
+
+// DataVerificationResult is the result of the row-level data verification.
+type DataVerificationResult struct {
+	Consistent bool            `json:"consistent"`
+	Tables     []TableDataDiff `json:"tables"`
+	// FixSQL makes the downstream data the same as the upstream.
+	FixSQL []string `json:"fix_sql,omitempty"`
+}
+
+// TableDataDiff is the data difference of a table
+type TableDataDiff struct {
+	Schema           string `json:"database_name"`
+	Table            string `json:"table_name"`
+	Chunks           int    `json:"chunks"`
+	MismatchedChunks int    `json:"mismatched_chunks"`
+	MissingRows      int    `json:"missing_rows"`
+	ExtraRows        int    `json:"extra_rows"`
+	DifferentRows    int    `json:"different_rows"`
+	Skipped          string `json:"skipped,omitempty"`
+}
+
 func getDefaultVerifyTableConfig() *VerifyTableConfig {
 	return &VerifyTableConfig{
 		ReplicaConfig: GetDefaultReplicaConfig(),
@@ -99,6 +170,7 @@ type ReplicaConfig struct {
 	Filter                *FilterConfig     `json:"filter"`
 	Sink                  *SinkConfig       `json:"sink"`
 	Consistent            *ConsistentConfig `json:"consistent"`
+	Placement             *PlacementConfig  `json:"placement,omitempty"`
 }
 
 // ToInternalReplicaConfig coverts *v2.ReplicaConfig into *config.ReplicaConfig
@@ -154,7 +226,21 @@ func (c *ReplicaConfig) ToInternalReplicaConfig() *config.ReplicaConfig {
 			MaxLogSize:        c.Consistent.MaxLogSize,
 			FlushIntervalInMs: c.Consistent.FlushIntervalInMs,
 			Storage:           c.Consistent.Storage,
+			Compression:       c.Consistent.Compression,
+			EncryptionKeyPath: c.Consistent.EncryptionKeyPath,
+			EncryptionKeyEnv:  c.Consistent.EncryptionKeyEnv,
+		}
+	}
+	if c.Placement != nil {
+		var rules []*config.PlacementRule
+		for _, rule := range c.Placement.Rules {
+			rules = append(rules, &config.PlacementRule{
+				Matcher:  rule.Matcher,
+				Labels:   rule.Labels,
+				SpreadBy: rule.SpreadBy,
+			})
 		}
+		res.Placement = &config.PlacementConfig{Rules: rules}
 	}
 	if c.Sink != nil {
 		var dispatchRules []*config.DispatchRule
@@ -163,6 +249,7 @@ func (c *ReplicaConfig) ToInternalReplicaConfig() *config.ReplicaConfig {
 				Matcher:        rule.Matcher,
 				DispatcherRule: "",
 				PartitionRule:  rule.PartitionRule,
+				Columns:        rule.Columns,
 				TopicRule:      rule.TopicRule,
 			})
 		}
@@ -242,6 +329,7 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			dispatchRules = append(dispatchRules, &DispatchRule{
 				Matcher:       rule.Matcher,
 				PartitionRule: rule.PartitionRule,
+				Columns:       rule.Columns,
 				TopicRule:     rule.TopicRule,
 			})
 		}
@@ -266,7 +354,21 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			MaxLogSize:        cloned.Consistent.MaxLogSize,
 			FlushIntervalInMs: cloned.Consistent.FlushIntervalInMs,
 			Storage:           cloned.Consistent.Storage,
+			Compression:       cloned.Consistent.Compression,
+			EncryptionKeyPath: cloned.Consistent.EncryptionKeyPath,
+			EncryptionKeyEnv:  cloned.Consistent.EncryptionKeyEnv,
+		}
+	}
+	if cloned.Placement != nil {
+		var rules []*PlacementRule
+		for _, rule := range cloned.Placement.Rules {
+			rules = append(rules, &PlacementRule{
+				Matcher:  rule.Matcher,
+				Labels:   rule.Labels,
+				SpreadBy: rule.SpreadBy,
+			})
 		}
+		res.Placement = &PlacementConfig{Rules: rules}
 	}
 	return res
 }
@@ -392,6 +494,7 @@ type SinkConfig struct {
 type DispatchRule struct {
 	Matcher       []string `json:"matcher,omitempty"`
 	PartitionRule string   `json:"partition"`
+	Columns       []string `json:"columns,omitempty"`
 	TopicRule     string   `json:"topic"`
 }
 
@@ -409,6 +512,24 @@ type ConsistentConfig struct {
 	MaxLogSize        int64  `json:"max_log_size"`
 	FlushIntervalInMs int64  `json:"flush_interval"`
 	Storage           string `json:"storage"`
+	Compression       string `json:"compression"`
+	EncryptionKeyPath string `json:"encryption_key_path"`
+	EncryptionKeyEnv  string `json:"encryption_key_env"`
+}
+
+// PlacementConfig represents the placement rules of a changefeed
+// This is a duplicate of config.PlacementConfig
+type PlacementConfig struct {
+	Rules []*PlacementRule `json:"rules"`
+}
+
+// PlacementRule restricts the captures that the matched tables can be
+// replicated on
+// This is a duplicate of config.PlacementRule
+type PlacementRule struct {
+	Matcher  []string          `json:"matcher"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	SpreadBy string            `json:"spread_by,omitempty"`
 }
 
 // EtcdData contains key/value pair of etcd data
@@ -497,3 +618,152 @@ type UpstreamConfig struct {
 	ID uint64 `json:"id"`
 	PDConfig
 }
+
+// ListResponse is the response for all list APIs
+type ListResponse[T any] struct {
+	Total int `json:"total"`
+	Items []T `json:"items"`
+}
+
+// ChangefeedCommonInfo holds some common usage information of a changefeed
+type ChangefeedCommonInfo struct {
+	UpstreamID     uint64          `json:"upstream_id"`
+	Namespace      string          `json:"namespace"`
+	ID             string          `json:"id"`
+	FeedState      model.FeedState `json:"state"`
+	CheckpointTSO  uint64          `json:"checkpoint_tso"`
+	CheckpointTime model.JSONTime  `json:"checkpoint_time"`
+	RunningError   *RunningError   `json:"error"`
+}
+
+// ChangefeedDetail holds detail info of a changefeed
+type ChangefeedDetail struct {
+	UpstreamID     uint64              `json:"upstream_id"`
+	Namespace      string              `json:"namespace"`
+	ID             string              `json:"id"`
+	SinkURI        string              `json:"sink_uri"`
+	CreateTime     model.JSONTime      `json:"create_time"`
+	StartTs        uint64              `json:"start_ts"`
+	ResolvedTs     uint64              `json:"resolved_ts"`
+	TargetTs       uint64              `json:"target_ts"`
+	CheckpointTSO  uint64              `json:"checkpoint_tso"`
+	CheckpointTime model.JSONTime      `json:"checkpoint_time"`
+	Engine         model.SortEngine    `json:"sort_engine,omitempty"`
+	FeedState      model.FeedState     `json:"state"`
+	RunningError   *RunningError       `json:"error"`
+	CreatorVersion string              `json:"creator_version"`
+	TaskStatus     []CaptureTaskStatus `json:"task_status,omitempty"`
+}
+
+// CaptureTaskStatus holds the tables replicated by a capture
+type CaptureTaskStatus struct {
+	CaptureID string `json:"capture_id"`
+	// Table list, containing tables that processor should process
+	Tables []int64 `json:"table_ids"`
+}
+
+// MoveTableReq is the request to move a table to the target capture
+type MoveTableReq struct {
+	CaptureID string `json:"capture_id"`
+	TableID   int64  `json:"table_id"`
+}
+
+// ProcessorCommonInfo holds the common info of a processor
+type ProcessorCommonInfo struct {
+	Namespace    string `json:"namespace"`
+	ChangeFeedID string `json:"changefeed_id"`
+	CaptureID    string `json:"capture_id"`
+}
+
+// ProcessorDetail holds the detail info of a processor
+type ProcessorDetail struct {
+	// The maximum event CommitTs that has been synchronized.
+	CheckPointTs uint64 `json:"checkpoint_ts"`
+	// The event that satisfies CommitTs <= ResolvedTs can be synchronized.
+	ResolvedTs uint64 `json:"resolved_ts"`
+	// all table ids that this processor are replicating
+	Tables []int64 `json:"table_ids"`
+	// The count of events that have been replicated.
+	Count uint64 `json:"count"`
+	// Error code when error happens
+	Error *RunningError `json:"error"`
+}
+
+// TableStats holds the replication statistics of a table
+type TableStats struct {
+	TableID   int64  `json:"table_id"`
+	TableName string `json:"table_name"`
+	CaptureID string `json:"capture_id"`
+	// The state of the table pipeline, such as "Replicating".
+	State        string `json:"state"`
+	CheckpointTs uint64 `json:"checkpoint_ts"`
+	ResolvedTs   uint64 `json:"resolved_ts"`
+	// The lag of checkpoint ts and resolved ts in seconds.
+	CheckpointLag float64 `json:"checkpoint_lag"`
+	ResolvedLag   float64 `json:"resolved_lag"`
+	// The number of events received by the sorter but not yet output.
+	SorterBacklog uint64 `json:"sorter_backlog"`
+	// The throughput of rows emitted to the sink.
+	SinkRowsPerSecond  float64 `json:"sink_rows_per_second"`
+	SinkBytesPerSecond float64 `json:"sink_bytes_per_second"`
+}
+
+// ChangefeedEvent is an event in the history of a changefeed, such as
+// a state change, an admin job, an error or a config update
+type ChangefeedEvent struct {
+	Time time.Time `json:"time"`
+	// The type of the event, one of "state-changed", "admin-job", "error"
+	// and "config-updated".
+	Type     string `json:"type"`
+	OldState string `json:"old_state,omitempty"`
+	State    string `json:"state,omitempty"`
+	AdminJob string `json:"admin_job,omitempty"`
+	// Where the admin job comes from, one of "api", "cli" and "auto".
+	Origin        string                   `json:"origin,omitempty"`
+	Error         *RunningError            `json:"error,omitempty"`
+	ConfigChanges []ChangefeedConfigChange `json:"config_changes,omitempty"`
+	Message       string                   `json:"message,omitempty"`
+}
+
+// ChangefeedConfigChange is a change of a changefeed config item
+type ChangefeedConfigChange struct {
+	Path string      `json:"path"`
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// Capture holds common information of a capture in cdc
+type Capture struct {
+	ID            string `json:"id"`
+	IsOwner       bool   `json:"is_owner"`
+	AdvertiseAddr string `json:"address"`
+	ClusterID     string `json:"cluster_id"`
+	// Labels of the capture, which are used by placement rules.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// DrainCaptureRequest is the request to drain a capture
+type DrainCaptureRequest struct {
+	CaptureID string `json:"capture_id"`
+}
+
+// DrainCaptureResp is the response of draining a capture
+type DrainCaptureResp struct {
+	CurrentTableCount int `json:"current_table_count"`
+}
+
+// ServerStatus holds some common information of a server
+type ServerStatus struct {
+	Version   string         `json:"version"`
+	GitHash   string         `json:"git_hash"`
+	ID        string         `json:"id"`
+	ClusterID string         `json:"cluster_id"`
+	Pid       int            `json:"pid"`
+	IsOwner   bool           `json:"is_owner"`
+	Liveness  model.Liveness `json:"liveness"`
+}
+
+// LogLevelReq is the request to change the log level
+type LogLevelReq struct {
+	Level string `json:"log_level"`
+}
@@ -52,6 +52,20 @@ func TestToAPIReplicaConfig(t *testing.T) {
 		MaxLogSize:        99,
 		FlushIntervalInMs: 10,
 		Storage:           "s3",
+		Compression:       "zstd",
+		EncryptionKeyPath: "/tmp/redo.key",
+	}
+	cfg.Placement = &config.PlacementConfig{
+		Rules: []*config.PlacementRule{
+			{
+				Matcher: []string{"billing.*"},
+				Labels:  map[string]string{"tier": "dedicated"},
+			},
+			{
+				Matcher:  []string{"*.*"},
+				SpreadBy: "zone",
+			},
+		},
 	}
 	cfg.Filter = &config.FilterConfig{
 		Rules: []string{"a", "b", "c"},
@@ -0,0 +1,202 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// getProcessor gets the detailed info of a processor
+// @Summary Get processor detail information
+// @Description get the detail information of a processor
+// @Tags processor
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Param capture_id path string true "capture_id"
+// @Success 200 {object} v2.ProcessorDetail
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/processors/{changefeed_id}/{capture_id} [get]
+func (h *OpenAPIV2) getProcessor(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	captureID := c.Param(apiOpVarCaptureID)
+	if err := model.ValidateChangefeedID(captureID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid capture_id: %s",
+			captureID))
+		return
+	}
+
+	statusProvider := h.capture.StatusProvider()
+	info, err := statusProvider.GetChangeFeedInfo(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if info.State != model.StateNormal {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"changefeed in abnormal state: %s, "+
+				"can't get processors of an abnormal changefeed", string(info.State)))
+		return
+	}
+	// check if this captureID exist
+	procInfos, err := statusProvider.GetProcessors(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	found := false
+	for _, info := range procInfos {
+		if info.CaptureID == captureID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		_ = c.Error(cerror.ErrCaptureNotExist.GenWithStackByArgs(captureID))
+		return
+	}
+
+	statuses, err := statusProvider.GetAllTaskStatuses(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	status, captureExist := statuses[captureID]
+	positions, err := statusProvider.GetTaskPositions(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	position, positionExist := positions[captureID]
+
+	// Note: for the case that no tables are attached to a newly created
+	// changefeed, we just do not report an error.
+	detail := &ProcessorDetail{Tables: make([]int64, 0)}
+	if captureExist && positionExist {
+		detail.CheckPointTs = position.CheckPointTs
+		detail.ResolvedTs = position.ResolvedTs
+		detail.Count = position.Count
+		detail.Error = toAPIRunningError(position.Error)
+		for tableID := range status.Tables {
+			detail.Tables = append(detail.Tables, tableID)
+		}
+	}
+	c.JSON(http.StatusOK, detail)
+}
+
+// listProcessors lists all processors in the TiCDC cluster
+// @Summary List processors
+// @Description list all processors in the TiCDC cluster
+// @Tags processor
+// @Accept json
+// @Produce json
+// @Success 200 {object} v2.ListResponse[v2.ProcessorCommonInfo]
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/processors [get]
+func (h *OpenAPIV2) listProcessors(c *gin.Context) {
+	ctx := c.Request.Context()
+	infos, err := h.capture.StatusProvider().GetProcessors(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	processors := make([]ProcessorCommonInfo, 0, len(infos))
+	for _, info := range infos {
+		processors = append(processors, ProcessorCommonInfo{
+			Namespace:    info.CfID.Namespace,
+			ChangeFeedID: info.CfID.ID,
+			CaptureID:    info.CaptureID,
+		})
+	}
+	c.JSON(http.StatusOK, &ListResponse[ProcessorCommonInfo]{
+		Total: len(processors),
+		Items: processors,
+	})
+}
+
+// getProcessorTableStats gets the replication statistics of tables of a
+// changefeed that are replicated by the capture serving the request.
+// The request is not forwarded to the owner, it is used by the owner to
+// collect table statistics from all captures.
+// @Summary Get table statistics of a processor
+// @Description get the replication statistics of tables replicated by a processor
+// @Tags processor
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Param capture_id path string true "capture_id"
+// @Success 200 {object} v2.ListResponse[v2.TableStats]
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/processors/{changefeed_id}/{capture_id}/tables [get]
+func (h *OpenAPIV2) getProcessorTableStats(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	info, err := h.capture.Info()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	captureID := c.Param(apiOpVarCaptureID)
+	if captureID != info.ID {
+		_ = c.Error(cerror.ErrCaptureNotExist.GenWithStackByArgs(captureID))
+		return
+	}
+
+	stats, err := h.capture.QueryTableStats(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	items := make([]TableStats, 0, len(stats))
+	for _, s := range stats {
+		items = append(items, toAPITableStats(s))
+	}
+	c.JSON(http.StatusOK, &ListResponse[TableStats]{
+		Total: len(items),
+		Items: items,
+	})
+}
+
+func toAPITableStats(s model.TableStats) TableStats {
+	return TableStats{
+		TableID:            s.TableID,
+		TableName:          s.TableName,
+		CaptureID:          s.CaptureID,
+		State:              s.State,
+		CheckpointTs:       s.CheckpointTs,
+		ResolvedTs:         s.ResolvedTs,
+		CheckpointLag:      s.CheckpointLag,
+		ResolvedLag:        s.ResolvedLag,
+		SorterBacklog:      s.SorterBacklog,
+		SinkRowsPerSecond:  s.SinkRowsPerSecond,
+		SinkBytesPerSecond: s.SinkBytesPerSecond,
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProcessor(t *testing.T) {
+	t.Parallel()
+
+	get := testCase{url: "/api/v2/processors/%s/%s", method: "GET"}
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	statusProvider := &mockStatusProvider{
+		changefeedInfo: &model.ChangeFeedInfo{State: model.StateNormal},
+		processors: []*model.ProcInfoSnap{
+			{CfID: changeFeedID, CaptureID: "capture-1"},
+		},
+	}
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	apiV2 := NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{})
+	router := newRouter(apiV2)
+
+	// case 1: invalid capture id
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(), get.method,
+		fmt.Sprintf(get.url, changeFeedID.ID, "@^Invalid"), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	respErr := model.HTTPError{}
+	err := json.NewDecoder(w.Body).Decode(&respErr)
+	require.Nil(t, err)
+	require.Contains(t, respErr.Code, "ErrAPIInvalidParam")
+
+	// case 2: capture not exists
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), get.method,
+		fmt.Sprintf(get.url, changeFeedID.ID, "capture-2"), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	respErr = model.HTTPError{}
+	err = json.NewDecoder(w.Body).Decode(&respErr)
+	require.Nil(t, err)
+	require.Contains(t, respErr.Code, "ErrCaptureNotExist")
+
+	// case 3: no tables are attached to the processor
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), get.method,
+		fmt.Sprintf(get.url, changeFeedID.ID, "capture-1"), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := ProcessorDetail{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	require.Nil(t, err)
+	require.Empty(t, resp.Tables)
+
+	// case 4: success
+	statusProvider.taskStatuses = map[model.CaptureID]*model.TaskStatus{
+		"capture-1": {Tables: map[model.TableID]*model.TableReplicaInfo{1: {}}},
+	}
+	statusProvider.taskPositions = map[model.CaptureID]*model.TaskPosition{
+		"capture-1": {CheckPointTs: 100, ResolvedTs: 200, Count: 10},
+	}
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), get.method,
+		fmt.Sprintf(get.url, changeFeedID.ID, "capture-1"), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp = ProcessorDetail{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	require.Nil(t, err)
+	require.Equal(t, ProcessorDetail{
+		CheckPointTs: 100,
+		ResolvedTs:   200,
+		Count:        10,
+		Tables:       []int64{1},
+	}, resp)
+
+	// case 5: changefeed in abnormal state
+	statusProvider.changefeedInfo = &model.ChangeFeedInfo{State: model.StateStopped}
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), get.method,
+		fmt.Sprintf(get.url, changeFeedID.ID, "capture-1"), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestListProcessors(t *testing.T) {
+	t.Parallel()
+
+	list := testCase{url: "/api/v2/processors", method: "GET"}
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	statusProvider := &mockStatusProvider{
+		processors: []*model.ProcInfoSnap{
+			{CfID: changeFeedID, CaptureID: "capture-1"},
+			{CfID: changeFeedID, CaptureID: "capture-2"},
+		},
+	}
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	apiV2 := NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{})
+	router := newRouter(apiV2)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(),
+		list.method, list.url, nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := ListResponse[ProcessorCommonInfo]{}
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	require.Nil(t, err)
+	require.Equal(t, 2, resp.Total)
+	require.Equal(t, ProcessorCommonInfo{
+		Namespace:    changeFeedID.Namespace,
+		ChangeFeedID: changeFeedID.ID,
+		CaptureID:    "capture-2",
+	}, resp.Items[1])
+}
+
+func TestGetProcessorTableStats(t *testing.T) {
+	t.Parallel()
+
+	get := testCase{url: "/api/v2/processors/%s/%s/tables", method: "GET"}
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().Info().Return(model.CaptureInfo{ID: "capture-1"}, nil).AnyTimes()
+	cp.EXPECT().QueryTableStats(gomock.Any(), changeFeedID).Return(
+		[]model.TableStats{{
+			TableID:           1,
+			CaptureID:         "capture-1",
+			State:             "Replicating",
+			CheckpointTs:      100,
+			SorterBacklog:     10,
+			SinkRowsPerSecond: 20,
+		}}, nil).AnyTimes()
+	apiV2 := NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{})
+	router := newRouter(apiV2)
+
+	// case 1: the capture is not the one serving the request
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(), get.method,
+		fmt.Sprintf(get.url, changeFeedID.ID, "capture-2"), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	respErr := model.HTTPError{}
+	err := json.NewDecoder(w.Body).Decode(&respErr)
+	require.Nil(t, err)
+	require.Contains(t, respErr.Code, "ErrCaptureNotExist")
+
+	// case 2: success, the request is served without the owner
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), get.method,
+		fmt.Sprintf(get.url, changeFeedID.ID, "capture-1"), nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := ListResponse[TableStats]{}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	require.Nil(t, err)
+	require.Equal(t, 1, resp.Total)
+	require.Equal(t, int64(1), resp.Items[0].TableID)
+	require.Equal(t, "Replicating", resp.Items[0].State)
+	require.Equal(t, uint64(10), resp.Items[0].SorterBacklog)
+	require.Equal(t, float64(20), resp.Items[0].SinkRowsPerSecond)
+}
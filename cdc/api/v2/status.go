@@ -0,0 +1,102 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/logutil"
+	"github.com/pingcap/tiflow/pkg/version"
+	"go.uber.org/zap"
+)
+
+// serverStatus gets the status of server(capture)
+// @Summary Get server status
+// @Description get the status of a server(capture)
+// @Tags common
+// @Accept json
+// @Produce json
+// @Success 200 {object} v2.ServerStatus
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/status [get]
+func (h *OpenAPIV2) serverStatus(c *gin.Context) {
+	info, err := h.capture.Info()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	status := &ServerStatus{
+		Version:   version.ReleaseVersion,
+		GitHash:   version.GitHash,
+		Pid:       os.Getpid(),
+		ID:        info.ID,
+		ClusterID: h.capture.GetEtcdClient().GetClusterID(),
+		IsOwner:   h.capture.IsOwner(),
+		Liveness:  h.capture.Liveness(),
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// health checks if the cdc cluster is healthy
+// @Summary Check if CDC cluster is health
+// @Description check if CDC cluster is health
+// @Tags common
+// @Accept json
+// @Produce json
+// @Success 200
+// @Failure 500 {object} model.HTTPError
+// @Router /api/v2/health [get]
+func (h *OpenAPIV2) health(c *gin.Context) {
+	ctx := c.Request.Context()
+	health, err := h.capture.StatusProvider().IsHealthy(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if !health {
+		_ = c.Error(cerror.ErrClusterIsUnhealthy.FastGenByArgs())
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// setLogLevel changes TiCDC log level dynamically.
+// @Summary Change TiCDC log level
+// @Description change TiCDC log level dynamically
+// @Tags common
+// @Accept json
+// @Produce json
+// @Param log_level body v2.LogLevelReq true "log level"
+// @Success 200
+// @Failure 400 {object} model.HTTPError
+// @Router /api/v2/log [post]
+func (h *OpenAPIV2) setLogLevel(c *gin.Context) {
+	req := &LogLevelReq{}
+	if err := c.BindJSON(req); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid log level: %s", err.Error()))
+		return
+	}
+	if err := logutil.SetLogLevel(req.Level); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"fail to change log level: %s", req.Level))
+		return
+	}
+	log.Warn("log level changed", zap.String("level", req.Level))
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,219 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/verification"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	psink "github.com/pingcap/tiflow/pkg/sink"
+	"go.uber.org/zap"
+)
+
+const (
+	// dataVerificationTimeout is the max duration of a data verification job.
+	dataVerificationTimeout = time.Hour
+	// dataVerificationDialTimeout is the dial timeout of the upstream TiDB.
+	dataVerificationDialTimeout = 10 * time.Second
+
+	dataVerificationStateRunning  = "running"
+	dataVerificationStateFinished = "finished"
+	dataVerificationStateFailed   = "failed"
+)
+
+// dataVerificationJobs records the data verification jobs run by the owner,
+// there is at most one running job for a changefeed. The jobs are lost if the
+// owner changes.
+type dataVerificationJobs struct {
+	mu   sync.Mutex
+	jobs map[model.ChangeFeedID]*DataVerificationStatus
+}
+
+func newDataVerificationJobs() *dataVerificationJobs {
+	return &dataVerificationJobs{
+		jobs: make(map[model.ChangeFeedID]*DataVerificationStatus),
+	}
+}
+
+// start records a running job of the changefeed, it returns false if there
+// is a running one already.
+func (j *dataVerificationJobs) start(changefeedID model.ChangeFeedID) (DataVerificationStatus, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if job, ok := j.jobs[changefeedID]; ok && job.State == dataVerificationStateRunning {
+		return DataVerificationStatus{}, false
+	}
+	job := &DataVerificationStatus{
+		State:     dataVerificationStateRunning,
+		StartTime: time.Now(),
+	}
+	j.jobs[changefeedID] = job
+	return *job, true
+}
+
+func (j *dataVerificationJobs) finish(
+	changefeedID model.ChangeFeedID, report *verification.DiffReport, err error,
+) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	job, ok := j.jobs[changefeedID]
+	if !ok {
+		return
+	}
+	now := time.Now()
+	job.EndTime = &now
+	if err != nil {
+		job.State = dataVerificationStateFailed
+		job.Error = err.Error()
+		return
+	}
+	job.State = dataVerificationStateFinished
+	job.Result = toAPIDataVerificationResult(report)
+}
+
+func (j *dataVerificationJobs) get(changefeedID model.ChangeFeedID) (DataVerificationStatus, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	job, ok := j.jobs[changefeedID]
+	if !ok {
+		return DataVerificationStatus{}, false
+	}
+	return *job, true
+}
+
+// verifyChangefeedData starts a job which compares the data between the
+// upstream and the MySQL sink of a changefeed
+// @Summary Verify the data of a changefeed
+// @Description start a row-level data verification job of a changefeed, the status and the report are returned by the get api
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Param verifyConfig body DataVerificationConfig true "data verification config"
+// @Success 202 {object} DataVerificationStatus
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/verify_data [post]
+func (h *OpenAPIV2) verifyChangefeedData(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	cfg := &DataVerificationConfig{}
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+		return
+	}
+	if cfg.UpstreamTiDB == nil || cfg.UpstreamTiDB.Host == "" ||
+		cfg.UpstreamTiDB.Port <= 0 || cfg.UpstreamTiDB.Port > 65535 {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid upstream_tidb, the host and the port are required"))
+		return
+	}
+
+	info, err := h.capture.StatusProvider().GetChangeFeedInfo(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	sinkURI, err := url.Parse(info.SinkURI)
+	if err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrSinkURIInvalid, err))
+		return
+	}
+	if !psink.IsMySQLCompatibleScheme(strings.ToLower(sinkURI.Scheme)) {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"the data of changefeed %s can not be verified, "+
+				"only the MySQL sink is supported", changefeedID.ID))
+		return
+	}
+
+	status, ok := h.dataVerifications.start(changefeedID)
+	if !ok {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"the data verification of changefeed %s is running", changefeedID.ID))
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), dataVerificationTimeout)
+		defer cancel()
+		report, err := h.helpers.verifyChangefeedData(ctx, changefeedID, info, cfg)
+		if err != nil {
+			log.Warn("verify the data of changefeed failed",
+				zap.String("namespace", changefeedID.Namespace),
+				zap.String("changefeed", changefeedID.ID),
+				zap.Error(err))
+		}
+		h.dataVerifications.finish(changefeedID, report, err)
+	}()
+	c.JSON(http.StatusAccepted, &status)
+}
+
+// getDataVerification returns the status of the latest data verification job
+// of a changefeed
+// @Summary Get the data verification of a changefeed
+// @Description get the status of the latest data verification job of a changefeed, the report is included if the job is finished
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Success 200 {object} DataVerificationStatus
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/verify_data [get]
+func (h *OpenAPIV2) getDataVerification(c *gin.Context) {
+	changefeedID := model.DefaultChangeFeedID(c.Param(apiOpVarChangefeedID))
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	status, ok := h.dataVerifications.get(changefeedID)
+	if !ok {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"no data verification of changefeed %s is found", changefeedID.ID))
+		return
+	}
+	c.JSON(http.StatusOK, &status)
+}
+
+func toAPIDataVerificationResult(report *verification.DiffReport) *DataVerificationResult {
+	result := &DataVerificationResult{
+		Consistent: report.Consistent(),
+		FixSQL:     report.FixSQL,
+	}
+	for _, tbl := range report.Tables {
+		result.Tables = append(result.Tables, TableDataDiff{
+			Schema:           tbl.Schema,
+			Table:            tbl.Table,
+			Chunks:           tbl.Chunks,
+			MismatchedChunks: tbl.MismatchedChunks,
+			MissingRows:      tbl.MissingRows,
+			ExtraRows:        tbl.ExtraRows,
+			DifferentRows:    tbl.DifferentRows,
+			Skipped:          tbl.Skipped,
+		})
+	}
+	return result
+}
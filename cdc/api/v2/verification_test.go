@@ -0,0 +1,151 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/verification"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerrors "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyChangefeedData(t *testing.T) {
+	t.Parallel()
+
+	verify := testCase{url: "/api/v2/changefeeds/%s/verify_data", method: "POST"}
+	get := testCase{url: "/api/v2/changefeeds/%s/verify_data", method: "GET"}
+
+	ctrl := gomock.NewController(t)
+	helpers := NewMockAPIV2Helpers(ctrl)
+	cp := mock_capture.NewMockCapture(ctrl)
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	statusProvider := &mockStatusProvider{changefeedInfo: &model.ChangeFeedInfo{
+		SinkURI: blackholeSink,
+		Config:  config.GetDefaultReplicaConfig(),
+	}}
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	router := newRouter(NewOpenAPIV2ForTest(cp, helpers))
+
+	request := func(tc testCase, cfg *DataVerificationConfig) *httptest.ResponseRecorder {
+		var body []byte
+		if cfg != nil {
+			var err error
+			body, err = json.Marshal(cfg)
+			require.Nil(t, err)
+		}
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), tc.method,
+			fmt.Sprintf(tc.url, changeFeedID.ID), bytes.NewReader(body))
+		router.ServeHTTP(w, req)
+		return w
+	}
+	getStatus := func() DataVerificationStatus {
+		w := request(get, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		status := DataVerificationStatus{}
+		require.Nil(t, json.NewDecoder(w.Body).Decode(&status))
+		return status
+	}
+	requireError := func(w *httptest.ResponseRecorder, code string) {
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		respErr := model.HTTPError{}
+		require.Nil(t, json.NewDecoder(w.Body).Decode(&respErr))
+		require.Contains(t, respErr.Code, code)
+	}
+
+	// case 1: no job is started
+	requireError(request(get, nil), "ErrAPIInvalidParam")
+
+	// case 2: the upstream TiDB is required, DSNs are not accepted
+	requireError(request(verify, &DataVerificationConfig{}), "ErrAPIInvalidParam")
+
+	// case 3: the sink of the changefeed is not MySQL compatible
+	cfg := &DataVerificationConfig{
+		UpstreamTiDB: &TiDBConfig{Host: "127.0.0.1", Port: 4000, User: "root"},
+		ChunkSize:    100,
+	}
+	requireError(request(verify, cfg), "ErrAPIInvalidParam")
+
+	// case 4: the job runs asynchronously
+	statusProvider.changefeedInfo.SinkURI = mysqlSink
+	done := make(chan struct{})
+	helpers.EXPECT().
+		verifyChangefeedData(gomock.Any(), changeFeedID, statusProvider.changefeedInfo, cfg).
+		DoAndReturn(func(ctx context.Context, _ model.ChangeFeedID,
+			_ *model.ChangeFeedInfo, _ *DataVerificationConfig,
+		) (*verification.DiffReport, error) {
+			<-done
+			return &verification.DiffReport{
+				Tables: []*verification.TableDiff{{
+					Schema: "test", Table: "t", Chunks: 2,
+					MismatchedChunks: 1, MissingRows: 1,
+				}},
+				FixSQL: []string{"REPLACE INTO `test`.`t` (`id`) VALUES ('1');"},
+			}, nil
+		}).Times(1)
+	w := request(verify, cfg)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Equal(t, dataVerificationStateRunning, getStatus().State)
+	// only one job of a changefeed can be running
+	requireError(request(verify, cfg), "ErrAPIInvalidParam")
+
+	close(done)
+	require.Eventually(t, func() bool {
+		return getStatus().State == dataVerificationStateFinished
+	}, 5*time.Second, 10*time.Millisecond)
+	status := getStatus()
+	require.NotNil(t, status.EndTime)
+	require.False(t, status.Result.Consistent)
+	require.Equal(t, []TableDataDiff{{
+		Schema: "test", Table: "t", Chunks: 2,
+		MismatchedChunks: 1, MissingRows: 1,
+	}}, status.Result.Tables)
+	require.Len(t, status.Result.FixSQL, 1)
+
+	// case 5: the job fails
+	helpers.EXPECT().verifyChangefeedData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, cerrors.ErrMySQLConnectionError).
+		Times(1)
+	w = request(verify, cfg)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Eventually(t, func() bool {
+		return getStatus().State == dataVerificationStateFailed
+	}, 5*time.Second, 10*time.Millisecond)
+	status = getStatus()
+	require.Contains(t, status.Error, "ErrMySQLConnectionError")
+	require.Nil(t, status.Result)
+}
+
+func TestGetUpstreamTiDBDSN(t *testing.T) {
+	t.Parallel()
+
+	dsn := getUpstreamTiDBDSN(&TiDBConfig{
+		Host: "127.0.0.1", Port: 4000, User: "root", Password: "123456",
+	})
+	require.Equal(t, "root:123456@tcp(127.0.0.1:4000)/?timeout=10s&charset=utf8mb4", dsn)
+	require.NotContains(t, dsn, "allowAllFiles")
+}
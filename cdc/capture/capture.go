@@ -61,6 +61,9 @@ type Capture interface {
 	Info() (model.CaptureInfo, error)
 	StatusProvider() owner.StatusProvider
 	WriteDebugInfo(ctx context.Context, w io.Writer)
+	QueryTableStats(
+		ctx context.Context, changefeedID model.ChangeFeedID,
+	) ([]model.TableStats, error)
 
 	GetUpstreamManager() (*upstream.Manager, error)
 	GetEtcdClient() etcd.CDCEtcdClient
@@ -188,6 +191,7 @@ func (c *captureImpl) reset(ctx context.Context) error {
 		ID:            uuid.New().String(),
 		AdvertiseAddr: c.config.AdvertiseAddr,
 		Version:       version.ReleaseVersion,
+		Labels:        c.config.Labels,
 	}
 
 	if c.upstreamManager != nil {
@@ -757,6 +761,39 @@ func (c *captureImpl) WriteDebugInfo(ctx context.Context, w io.Writer) {
 	wait(doneM)
 }
 
+// QueryTableStats returns the replication statistics of tables of
+// the changefeed that are replicated by this capture.
+func (c *captureImpl) QueryTableStats(
+	ctx context.Context, changefeedID model.ChangeFeedID,
+) ([]model.TableStats, error) {
+	done := make(chan error, 1)
+	statsCh := make(chan []model.TableStats, 1)
+	c.captureMu.Lock()
+	if c.processorManager == nil {
+		c.captureMu.Unlock()
+		return nil, cerror.ErrCaptureNotInitialized.GenWithStackByArgs()
+	}
+	c.processorManager.QueryTableStats(ctx, changefeedID, statsCh, done)
+	// Release the lock before waiting, see WriteDebugInfo.
+	c.captureMu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, errors.Trace(ctx.Err())
+	case err := <-done:
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	select {
+	case stats := <-statsCh:
+		return stats, nil
+	default:
+		// The changefeed does not run on this capture.
+		return []model.TableStats{}, nil
+	}
+}
+
 // IsOwner returns whether the capture is an owner
 func (c *captureImpl) IsOwner() bool {
 	c.ownerMu.Lock()
@@ -181,6 +181,21 @@ func (mr *MockCaptureMockRecorder) Liveness() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Liveness", reflect.TypeOf((*MockCapture)(nil).Liveness))
 }
 
+// QueryTableStats mocks base method.
+func (m *MockCapture) QueryTableStats(ctx context.Context, changefeedID model.ChangeFeedID) ([]model.TableStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryTableStats", ctx, changefeedID)
+	ret0, _ := ret[0].([]model.TableStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryTableStats indicates an expected call of QueryTableStats.
+func (mr *MockCaptureMockRecorder) QueryTableStats(ctx, changefeedID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryTableStats", reflect.TypeOf((*MockCapture)(nil).QueryTableStats), ctx, changefeedID)
+}
+
 // Run mocks base method.
 func (m *MockCapture) Run(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -19,11 +19,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tiflow/cdc/api/middleware"
 	"github.com/pingcap/tiflow/cdc/api/owner"
 	"github.com/pingcap/tiflow/cdc/api/status"
 	v1 "github.com/pingcap/tiflow/cdc/api/v1"
 	v2 "github.com/pingcap/tiflow/cdc/api/v2"
 	"github.com/pingcap/tiflow/cdc/capture"
+	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -54,11 +56,14 @@ func RegisterRoutes(
 	// Status API
 	status.RegisterStatusAPIRoutes(router, capture)
 
+	admin := middleware.AuthMiddleware(config.AuthRoleAdmin, config.AuthRoleAdmin)
+
 	// Log API
-	router.POST("/admin/log", gin.WrapF(owner.HandleAdminLogLevel))
+	router.POST("/admin/log", admin, gin.WrapF(owner.HandleAdminLogLevel))
 
 	// pprof debug API
 	pprofGroup := router.Group("/debug/pprof/")
+	pprofGroup.Use(admin)
 	pprofGroup.GET("", gin.WrapF(pprof.Index))
 	pprofGroup.GET("/:any", gin.WrapF(pprof.Index))
 	pprofGroup.GET("/cmdline", gin.WrapF(pprof.Cmdline))
@@ -70,7 +75,7 @@ func RegisterRoutes(
 	// Failpoint API
 	if util.FailpointBuild {
 		// `http.StripPrefix` is needed because `failpoint.HttpHandler` assumes that it handles the prefix `/`.
-		router.Any("/debug/fail/*any", gin.WrapH(http.StripPrefix("/debug/fail", &failpoint.HttpHandler{})))
+		router.Any("/debug/fail/*any", admin, gin.WrapH(http.StripPrefix("/debug/fail", &failpoint.HttpHandler{})))
 	}
 
 	// Promtheus metrics API
@@ -107,6 +107,10 @@ type regionStatefulEvent struct {
 	// finishedCallbackCh is used to mark events that are sent from a give region
 	// worker to this worker(one of the workers in worker pool) are all processed.
 	finishedCallbackCh chan struct{}
+
+	// size is the memory acquired from the memory quota for changeEvent,
+	// it's released after the event is processed.
+	size uint64
 }
 
 var (
@@ -317,6 +321,8 @@ type CDCClient struct {
 	changefeed  model.ChangeFeedID
 
 	regionLimiters *regionEventFeedLimiters
+	// memQuota limits the memory of buffered events, nil means unlimited.
+	memQuota *MemoryQuota
 }
 
 // NewCDCClient creates a CDCClient instance
@@ -328,6 +334,7 @@ func NewCDCClient(
 	pdClock pdutil.Clock,
 	changefeed model.ChangeFeedID,
 	cfg *config.KVClientConfig,
+	memQuota *MemoryQuota,
 ) (c CDCKVClient) {
 	clusterID := pd.GetClusterID(ctx)
 
@@ -340,6 +347,7 @@ func NewCDCClient(
 		pdClock:        pdClock,
 		changefeed:     changefeed,
 		regionLimiters: defaultRegionEventFeedLimiters,
+		memQuota:       memQuota,
 	}
 	return
 }
@@ -1249,6 +1257,7 @@ func (s *eventFeedSession) sendRegionChangeEvents(
 		statefulEvents[i] = make([]*regionStatefulEvent, 0, buffLen)
 	}
 
+	totalSize := uint64(0)
 	for _, event := range events {
 		state, valid := worker.getRegionState(event.RegionId)
 		// Every region's range is locked before sending requests and unlocked after exiting, and the requestID
@@ -1303,13 +1312,22 @@ func (s *eventFeedSession) sendRegionChangeEvents(
 			continue
 		}
 
+		size := uint64(event.Size())
+		totalSize += size
 		slot := worker.inputCalcSlot(event.RegionId)
 		statefulEvents[slot] = append(statefulEvents[slot], &regionStatefulEvent{
 			changeEvent: event,
 			regionID:    event.RegionId,
 			state:       state,
+			size:        size,
 		})
 	}
+	// Blocks the stream until the puller drains enough events if the memory
+	// quota is exceeded, TiKV stops sending events to this stream once the
+	// gRPC flow control window is used up.
+	if err := worker.memQuota.acquire(ctx, totalSize); err != nil {
+		return err
+	}
 	for _, events := range statefulEvents {
 		if len(events) > 0 {
 			err := worker.sendEvents(ctx, events)
@@ -73,7 +73,7 @@ func TestNewClient(t *testing.T) {
 	cli := NewCDCClient(
 		context.Background(), pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	require.NotNil(t, cli)
 }
 
@@ -324,7 +324,7 @@ func TestConnectOfflineTiKV(t *testing.T) {
 	cdcClient := NewCDCClient(
 		context.Background(), pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	// Take care of the eventCh, it's used to output resolvedTs event or kv event
 	// It will stuck the normal routine
 	eventCh := make(chan model.RegionFeedEvent, 50)
@@ -429,7 +429,7 @@ func TestRecvLargeMessageSize(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	wg.Add(1)
 	go func() {
@@ -482,6 +482,109 @@ func TestRecvLargeMessageSize(t *testing.T) {
 	cancel()
 }
 
+func TestMemoryQuota(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	ch2 := make(chan *cdcpb.ChangeDataEvent, 10)
+	srv := newMockChangeDataService(t, ch2)
+	server2, addr := newMockService(ctx, t, srv, wg)
+	defer func() {
+		close(ch2)
+		server2.Stop()
+		wg.Wait()
+	}()
+
+	rpcClient, cluster, pdClient, err := testutils.NewMockTiKV("", mockcopr.NewCoprRPCHandler())
+	require.Nil(t, err)
+	pdClient = &mockPDClient{Client: pdClient, versionGen: defaultVersionGen}
+	defer pdClient.Close() //nolint:errcheck
+	kvStorage, err := tikv.NewTestTiKVStore(rpcClient, pdClient, nil, nil, 0)
+	require.Nil(t, err)
+	defer kvStorage.Close() //nolint:errcheck
+
+	cluster.AddStore(2, addr)
+	cluster.Bootstrap(3, []uint64{2}, []uint64{4}, 4)
+
+	baseAllocatedID := currentRequestID()
+	lockResolver := txnutil.NewLockerResolver(kvStorage,
+		model.DefaultChangeFeedID("changefeed-test"),
+		util.RoleTester)
+	isPullInit := &mockPullerInit{}
+	grpcPool := NewGrpcPoolImpl(ctx, &security.Credential{})
+	defer grpcPool.Close()
+	regionCache := tikv.NewRegionCache(pdClient)
+	defer regionCache.Close()
+	valSize := 1024
+	total := uint64(4 * valSize)
+	quota := NewMemoryQuota(model.DefaultChangeFeedID("changefeed-test"), total)
+	defer quota.Close()
+	cdcClient := NewCDCClient(
+		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
+		model.DefaultChangeFeedID(""),
+		config.GetDefaultServerConfig().KVClient, quota)
+	// The puller drains nothing until all events are sent.
+	eventCh := make(chan model.RegionFeedEvent, 1)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err := cdcClient.EventFeed(ctx,
+			regionspan.ComparableSpan{Start: []byte("a"), End: []byte("b")},
+			1, lockResolver, isPullInit, eventCh)
+		require.Equal(t, context.Canceled, errors.Cause(err))
+	}()
+
+	// new session, new request
+	waitRequestID(t, baseAllocatedID+1)
+	ch2 <- mockInitializedEvent(3 /* regionID */, currentRequestID())
+
+	eventCount := 8
+	for i := 0; i < eventCount; i++ {
+		ch2 <- &cdcpb.ChangeDataEvent{Events: []*cdcpb.Event{
+			{
+				RegionId:  3,
+				RequestId: currentRequestID(),
+				Event: &cdcpb.Event_Entries_{
+					Entries: &cdcpb.Event_Entries{
+						Entries: []*cdcpb.Event_Row{{
+							Type:     cdcpb.Event_COMMITTED,
+							OpType:   cdcpb.Event_Row_PUT,
+							Key:      []byte("a"),
+							Value:    make([]byte, valSize),
+							StartTs:  uint64(i + 1),
+							CommitTs: uint64(i + 2),
+						}},
+					},
+				},
+			},
+		}}
+	}
+
+	// The stream is paused once the quota is used up.
+	require.Eventually(t, func() bool {
+		return quota.Used() > total-uint64(2*valSize)
+	}, 5*time.Second, 10*time.Millisecond)
+	require.LessOrEqual(t, quota.Used(), total)
+
+	// The stream is resumed as the puller drains events.
+	received := 0
+	for received < eventCount {
+		select {
+		case event := <-eventCh:
+			if event.Val != nil {
+				require.Equal(t, valSize, len(event.Val.Value))
+				received++
+			}
+			require.LessOrEqual(t, quota.Used(), total)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "receiving message takes too long")
+		}
+	}
+	require.Eventually(t, func() bool {
+		return quota.Used() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+}
+
 func TestHandleError(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	wg := &sync.WaitGroup{}
@@ -532,7 +635,7 @@ func TestHandleError(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	wg.Add(1)
 	go func() {
@@ -694,7 +797,7 @@ func TestCompatibilityWithSameConn(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	var wg2 sync.WaitGroup
 	wg2.Add(1)
@@ -765,7 +868,7 @@ func TestClusterIDMismatch(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 
 	var wg2 sync.WaitGroup
@@ -837,7 +940,7 @@ func testHandleFeedEvent(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	wg.Add(1)
 	go func() {
@@ -1295,7 +1398,7 @@ func TestStreamSendWithError(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	wg.Add(1)
 	go func() {
@@ -1411,7 +1514,7 @@ func testStreamRecvWithError(t *testing.T, failpointStr string) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	wg.Add(1)
 	go func() {
@@ -1545,7 +1648,7 @@ func TestStreamRecvWithErrorAndResolvedGoBack(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	wg.Add(1)
 	go func() {
@@ -1756,7 +1859,7 @@ func TestIncompatibleTiKV(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	// NOTICE: eventCh may block the main logic of EventFeed
 	eventCh := make(chan model.RegionFeedEvent, 128)
 	wg.Add(1)
@@ -1837,7 +1940,7 @@ func TestNoPendingRegionError(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 
 	wg.Add(1)
@@ -1919,7 +2022,7 @@ func TestDropStaleRequest(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	wg.Add(1)
 	go func() {
@@ -2033,7 +2136,7 @@ func TestResolveLock(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	wg.Add(1)
 	go func() {
@@ -2139,7 +2242,7 @@ func testEventCommitTsFallback(t *testing.T, events []*cdcpb.ChangeDataEvent) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	var clientWg sync.WaitGroup
 	clientWg.Add(1)
@@ -2295,7 +2398,7 @@ func testEventAfterFeedStop(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	wg.Add(1)
 	go func() {
@@ -2479,7 +2582,7 @@ func TestOutOfRegionRangeEvent(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	wg.Add(1)
 	go func() {
@@ -2698,7 +2801,7 @@ func TestResolveLockNoCandidate(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	wg.Add(1)
 	go func() {
@@ -2797,7 +2900,7 @@ func TestFailRegionReentrant(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	wg.Add(1)
 	go func() {
@@ -2882,7 +2985,7 @@ func TestClientV1UnlockRangeReentrant(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	wg.Add(1)
 	go func() {
@@ -2952,7 +3055,7 @@ func testClientErrNoPendingRegion(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	wg.Add(1)
 	go func() {
@@ -3033,7 +3136,7 @@ func testKVClientForceReconnect(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	wg.Add(1)
 	go func() {
@@ -3185,7 +3288,7 @@ func TestConcurrentProcessRangeRequest(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 100)
 	wg.Add(1)
 	go func() {
@@ -3305,7 +3408,7 @@ func TestEvTimeUpdate(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	wg.Add(1)
 	go func() {
@@ -3433,7 +3536,7 @@ func TestRegionWorkerExitWhenIsIdle(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	wg.Add(1)
 	go func() {
@@ -3528,7 +3631,7 @@ func TestPrewriteNotMatchError(t *testing.T) {
 	cdcClient := NewCDCClient(
 		ctx, pdClient, grpcPool, regionCache, pdutil.NewClock4Test(),
 		model.DefaultChangeFeedID(""),
-		config.GetDefaultServerConfig().KVClient)
+		config.GetDefaultServerConfig().KVClient, nil)
 	eventCh := make(chan model.RegionFeedEvent, 50)
 	baseAllocatedID := currentRequestID()
 
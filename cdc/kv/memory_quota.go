@@ -0,0 +1,195 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// MemoryQuota limits the memory of the events buffered in the kv client.
+// It is shared by all region workers of a changefeed. Region workers acquire
+// quota before taking events from gRPC streams and release it once the events
+// are sent to the puller, so the streams stop being read when the puller can
+// not drain the events fast enough.
+type MemoryQuota struct {
+	changefeedID model.ChangeFeedID
+	total        uint64
+
+	mu   sync.Mutex
+	used uint64
+	// notifyCh is closed and replaced when some quota is released and
+	// there are acquirers waiting for it.
+	notifyCh chan struct{}
+	waiting  int
+
+	metricUsed          prometheus.Gauge
+	metricBlockDuration prometheus.Counter
+}
+
+// NewMemoryQuota creates a MemoryQuota. It returns nil if total is 0, which
+// means the memory is unlimited.
+func NewMemoryQuota(changefeedID model.ChangeFeedID, total uint64) *MemoryQuota {
+	if total == 0 {
+		return nil
+	}
+	memoryQuotaGauge.WithLabelValues("total", changefeedID.Namespace, changefeedID.ID).
+		Set(float64(total))
+	return &MemoryQuota{
+		changefeedID: changefeedID,
+		total:        total,
+		notifyCh:     make(chan struct{}),
+		metricUsed: memoryQuotaGauge.
+			WithLabelValues("used", changefeedID.Namespace, changefeedID.ID),
+		metricBlockDuration: memoryQuotaBlockDuration.
+			WithLabelValues(changefeedID.Namespace, changefeedID.ID),
+	}
+}
+
+// Acquire acquires size bytes of memory, it blocks until there is enough
+// quota or the context is canceled. An acquirer is always admitted if no
+// memory is used, so that an event larger than the total quota can't block
+// the kv client forever.
+func (q *MemoryQuota) Acquire(ctx context.Context, size uint64) error {
+	var start time.Time
+	for {
+		q.mu.Lock()
+		if q.used == 0 || q.used+size <= q.total {
+			q.used += size
+			q.metricUsed.Set(float64(q.used))
+			q.mu.Unlock()
+			if !start.IsZero() {
+				q.metricBlockDuration.Add(time.Since(start).Seconds())
+			}
+			return nil
+		}
+		if start.IsZero() {
+			start = time.Now()
+		}
+		q.waiting++
+		notifyCh := q.notifyCh
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			q.mu.Lock()
+			q.waiting--
+			q.mu.Unlock()
+			q.metricBlockDuration.Add(time.Since(start).Seconds())
+			return errors.Trace(ctx.Err())
+		case <-notifyCh:
+		}
+	}
+}
+
+// Release releases size bytes of memory and wakes up the blocked acquirers.
+func (q *MemoryQuota) Release(size uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if size > q.used {
+		log.Panic("memory quota released more than acquired",
+			zap.String("namespace", q.changefeedID.Namespace),
+			zap.String("changefeed", q.changefeedID.ID),
+			zap.Uint64("used", q.used),
+			zap.Uint64("size", size))
+	}
+	q.used -= size
+	q.metricUsed.Set(float64(q.used))
+	if q.waiting > 0 {
+		q.waiting = 0
+		close(q.notifyCh)
+		q.notifyCh = make(chan struct{})
+	}
+}
+
+// Used returns the memory in use.
+func (q *MemoryQuota) Used() uint64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.used
+}
+
+// Close cleans up the metrics of the memory quota.
+func (q *MemoryQuota) Close() {
+	memoryQuotaGauge.DeleteLabelValues("total", q.changefeedID.Namespace, q.changefeedID.ID)
+	memoryQuotaGauge.DeleteLabelValues("used", q.changefeedID.Namespace, q.changefeedID.ID)
+	memoryQuotaBlockDuration.DeleteLabelValues(q.changefeedID.Namespace, q.changefeedID.ID)
+}
+
+// streamMemoryQuota tracks the memory acquired by a gRPC stream from the
+// changefeed level memory quota. Events of the stream may be dropped without
+// being processed after the region worker exits, so all the memory still held
+// by the stream is given back when it's closed.
+type streamMemoryQuota struct {
+	quota *MemoryQuota
+
+	mu     sync.Mutex
+	held   uint64
+	closed bool
+}
+
+func newStreamMemoryQuota(quota *MemoryQuota) *streamMemoryQuota {
+	return &streamMemoryQuota{quota: quota}
+}
+
+func (s *streamMemoryQuota) acquire(ctx context.Context, size uint64) error {
+	if s.quota == nil || size == 0 {
+		return nil
+	}
+	if err := s.quota.Acquire(ctx, size); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		s.quota.Release(size)
+		return nil
+	}
+	s.held += size
+	return nil
+}
+
+func (s *streamMemoryQuota) release(size uint64) {
+	if s.quota == nil || size == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.held -= size
+	s.quota.Release(size)
+}
+
+func (s *streamMemoryQuota) close() {
+	if s.quota == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.quota.Release(s.held)
+	s.held = 0
+}
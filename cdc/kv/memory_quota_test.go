@@ -0,0 +1,111 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryQuotaAcquireAndRelease(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, NewMemoryQuota(model.DefaultChangeFeedID("test"), 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	quota := NewMemoryQuota(model.DefaultChangeFeedID("test-acquire"), 100)
+	defer quota.Close()
+
+	require.Nil(t, quota.Acquire(ctx, 60))
+	require.Nil(t, quota.Acquire(ctx, 40))
+	require.Equal(t, uint64(100), quota.Used())
+
+	// Acquire is blocked until enough quota is released.
+	done := make(chan error, 1)
+	go func() {
+		done <- quota.Acquire(ctx, 50)
+	}()
+	quota.Release(40)
+	select {
+	case <-done:
+		require.FailNow(t, "acquire should be blocked")
+	case <-time.After(100 * time.Millisecond):
+	}
+	quota.Release(20)
+	select {
+	case err := <-done:
+		require.Nil(t, err)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "acquire should not be blocked")
+	}
+	require.Equal(t, uint64(90), quota.Used())
+
+	// Acquire returns once the context is canceled.
+	cctx, ccancel := context.WithCancel(ctx)
+	go func() {
+		done <- quota.Acquire(cctx, 50)
+	}()
+	ccancel()
+	select {
+	case err := <-done:
+		require.Equal(t, context.Canceled, errors.Cause(err))
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "acquire should not be blocked")
+	}
+
+	// An event larger than the total quota is admitted if no memory is used.
+	quota.Release(90)
+	require.Nil(t, quota.Acquire(ctx, 200))
+	require.Equal(t, uint64(200), quota.Used())
+	quota.Release(200)
+}
+
+func TestStreamMemoryQuota(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	quota := NewMemoryQuota(model.DefaultChangeFeedID("test-stream"), 100)
+	defer quota.Close()
+
+	s1 := newStreamMemoryQuota(quota)
+	s2 := newStreamMemoryQuota(quota)
+	require.Nil(t, s1.acquire(ctx, 30))
+	require.Nil(t, s1.acquire(ctx, 20))
+	require.Nil(t, s2.acquire(ctx, 10))
+	s1.release(20)
+	require.Equal(t, uint64(40), quota.Used())
+
+	// The memory held by a closed stream is given back.
+	s1.close()
+	require.Equal(t, uint64(10), quota.Used())
+	s1.release(30)
+	require.Equal(t, uint64(10), quota.Used())
+	require.Nil(t, s1.acquire(ctx, 30))
+	require.Equal(t, uint64(10), quota.Used())
+
+	s2.close()
+	require.Equal(t, uint64(0), quota.Used())
+
+	// Streams without memory quota are unlimited.
+	s3 := newStreamMemoryQuota(nil)
+	require.Nil(t, s3.acquire(ctx, 1000))
+	s3.release(1000)
+	s3.close()
+}
@@ -110,6 +110,21 @@ var (
 			Help:      "region events batch size",
 			Buckets:   prometheus.ExponentialBuckets(1, 2, 20),
 		})
+
+	memoryQuotaGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "kvclient",
+			Name:      "memory_quota",
+			Help:      "memory quota of the events buffered in kv client",
+		}, []string{"type", "namespace", "changefeed"})
+	memoryQuotaBlockDuration = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "kvclient",
+			Name:      "memory_quota_block_duration_seconds",
+			Help:      "The total time kv client is blocked by memory quota",
+		}, []string{"namespace", "changefeed"})
 )
 
 // InitMetrics registers all metrics in the kv package
@@ -126,6 +141,8 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(batchResolvedEventSize)
 	registry.MustRegister(grpcPoolStreamGauge)
 	registry.MustRegister(regionEventsBatchSize)
+	registry.MustRegister(memoryQuotaGauge)
+	registry.MustRegister(memoryQuotaBlockDuration)
 
 	// Register client metrics to registry.
 	registry.MustRegister(grpcMetrics)
@@ -156,6 +156,10 @@ type regionWorker struct {
 
 	storeAddr string
 
+	// memQuota tracks the memory of events received from the gRPC stream but
+	// not yet sent to the puller.
+	memQuota *streamMemoryQuota
+
 	// how many pending input events
 	inputPending int32
 	// get a slot for the given region
@@ -196,6 +200,7 @@ func newRegionWorker(
 		rtsManager:    newRegionTsManager(),
 		rtsUpdateCh:   make(chan *regionTsInfo, 1024),
 		storeAddr:     addr,
+		memQuota:      newStreamMemoryQuota(s.client.memQuota),
 		concurrent:    s.client.config.WorkerConcurrent,
 		metrics:       metrics,
 		inputPending:  0,
@@ -400,6 +405,7 @@ func (w *regionWorker) processEvent(ctx context.Context, event *regionStatefulEv
 		event.finishedCallbackCh <- struct{}{}
 		return nil
 	}
+	defer w.memQuota.release(event.size)
 	var err error
 	event.state.lock.Lock()
 	if event.changeEvent != nil {
@@ -549,11 +555,13 @@ func (w *regionWorker) eventHandler(ctx context.Context) error {
 				if exitEventHandler {
 					return cerror.ErrRegionWorkerExit.GenWithStackByArgs()
 				}
-				if !skipEvent {
-					err = w.processEvent(ctx, event)
-					if err != nil {
-						return err
-					}
+				if skipEvent {
+					w.memQuota.release(event.size)
+					continue
+				}
+				err = w.processEvent(ctx, event)
+				if err != nil {
+					return err
 				}
 			}
 		}
@@ -614,6 +622,8 @@ func (w *regionWorker) run(parentCtx context.Context) error {
 		for _, h := range w.handles {
 			h.Unregister()
 		}
+		// Events left in the input channel are dropped, give back their memory.
+		w.memQuota.close()
 	}()
 	w.parentCtx = parentCtx
 	ctx, cancel := context.WithCancel(parentCtx)
@@ -0,0 +1,147 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// MaxChangefeedHistoryEvents is the max number of events kept in the
+	// history of a changefeed, older events are discarded.
+	MaxChangefeedHistoryEvents = 100
+	// MaxChangefeedHistoryBytes is the max size of the marshaled history.
+	// The whole history is rewritten to etcd on each event, so older events
+	// are discarded if the history is larger than it.
+	MaxChangefeedHistoryBytes = 32 * 1024
+	// maxChangefeedEventMessageBytes is the max size of the messages in an
+	// event, longer messages are truncated.
+	maxChangefeedEventMessageBytes = 1024
+)
+
+// ChangefeedEventType is the type of changefeed event.
+type ChangefeedEventType string
+
+// All changefeed event types
+const (
+	// ChangefeedEventStateChanged means the state of the changefeed is changed.
+	ChangefeedEventStateChanged ChangefeedEventType = "state-changed"
+	// ChangefeedEventAdminJob means an admin job is handled by the owner.
+	ChangefeedEventAdminJob ChangefeedEventType = "admin-job"
+	// ChangefeedEventError means an error is reported by processors.
+	ChangefeedEventError ChangefeedEventType = "error"
+	// ChangefeedEventConfigUpdated means the config of the changefeed is updated.
+	ChangefeedEventConfigUpdated ChangefeedEventType = "config-updated"
+)
+
+// ChangefeedEvent is an event in the history of a changefeed.
+type ChangefeedEvent struct {
+	Time time.Time           `json:"time"`
+	Type ChangefeedEventType `json:"type"`
+
+	// OldState and State are set if the state is changed.
+	OldState FeedState `json:"old-state,omitempty"`
+	State    FeedState `json:"state,omitempty"`
+	// AdminJob and Origin are set if an admin job is handled.
+	AdminJob string         `json:"admin-job,omitempty"`
+	Origin   AdminJobOrigin `json:"origin,omitempty"`
+	// Error is set if an error is reported.
+	Error *RunningError `json:"error,omitempty"`
+	// ConfigChanges is set if the config is updated.
+	ConfigChanges []*ChangefeedConfigChange `json:"config-changes,omitempty"`
+	// Message describes the event.
+	Message string `json:"message,omitempty"`
+}
+
+// ChangefeedConfigChange is a change of a changefeed config item.
+type ChangefeedConfigChange struct {
+	// Path is the dot separated path of the config item, e.g. Config.Sink.Protocol.
+	Path string      `json:"path"`
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// ChangefeedHistory is the bounded event log of a changefeed,
+// events are sorted by time.
+type ChangefeedHistory struct {
+	Events []*ChangefeedEvent `json:"events"`
+}
+
+// Append appends events to the history and discards old events if
+// the history is full, long messages of the events are truncated.
+func (h *ChangefeedHistory) Append(events ...*ChangefeedEvent) {
+	for _, event := range events {
+		h.Events = append(h.Events, truncateChangefeedEvent(event))
+	}
+	start := 0
+	if len(h.Events) > MaxChangefeedHistoryEvents {
+		start = len(h.Events) - MaxChangefeedHistoryEvents
+	}
+	// The size of the history is the sum of the size of its events,
+	// plus the separators and the enclosing object.
+	size := len(`{"events":[]}`)
+	sizes := make([]int, len(h.Events))
+	for i := start; i < len(h.Events); i++ {
+		data, err := json.Marshal(h.Events[i])
+		if err != nil {
+			// The error is returned by Marshal.
+			continue
+		}
+		sizes[i] = len(data) + 1
+		size += sizes[i]
+	}
+	for start < len(h.Events) && size > MaxChangefeedHistoryBytes {
+		size -= sizes[start]
+		start++
+	}
+	if start > 0 {
+		h.Events = append([]*ChangefeedEvent(nil), h.Events[start:]...)
+	}
+}
+
+func truncateChangefeedEvent(event *ChangefeedEvent) *ChangefeedEvent {
+	truncate := func(s string) string {
+		if len(s) <= maxChangefeedEventMessageBytes {
+			return s
+		}
+		return s[:maxChangefeedEventMessageBytes] + "..."
+	}
+	if len(event.Message) > maxChangefeedEventMessageBytes {
+		event.Message = truncate(event.Message)
+	}
+	// The error may be shared with the changefeed status, so it's copied.
+	if event.Error != nil && len(event.Error.Message) > maxChangefeedEventMessageBytes {
+		err := *event.Error
+		err.Message = truncate(err.Message)
+		event.Error = &err
+	}
+	return event
+}
+
+// Marshal returns the json marshal format of a ChangefeedHistory
+func (h *ChangefeedHistory) Marshal() (string, error) {
+	data, err := json.Marshal(h)
+	return string(data), cerror.WrapError(cerror.ErrMarshalFailed, err)
+}
+
+// Unmarshal unmarshals into *ChangefeedHistory from json marshal byte slice
+func (h *ChangefeedHistory) Unmarshal(data []byte) error {
+	err := json.Unmarshal(data, h)
+	return errors.Annotatef(
+		cerror.WrapError(cerror.ErrUnmarshalFailed, err), "Unmarshal data: %v", data)
+}
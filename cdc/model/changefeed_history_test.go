@@ -0,0 +1,97 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangefeedHistoryAppend(t *testing.T) {
+	t.Parallel()
+
+	h := &ChangefeedHistory{}
+	for i := 0; i < MaxChangefeedHistoryEvents; i++ {
+		h.Append(&ChangefeedEvent{Type: ChangefeedEventError, Message: "first"})
+	}
+	require.Len(t, h.Events, MaxChangefeedHistoryEvents)
+
+	// The oldest events are discarded.
+	h.Append(
+		&ChangefeedEvent{Type: ChangefeedEventAdminJob},
+		&ChangefeedEvent{Type: ChangefeedEventStateChanged},
+	)
+	require.Len(t, h.Events, MaxChangefeedHistoryEvents)
+	require.Equal(t, ChangefeedEventError, h.Events[0].Type)
+	require.Equal(t, ChangefeedEventAdminJob, h.Events[MaxChangefeedHistoryEvents-2].Type)
+	require.Equal(t, ChangefeedEventStateChanged, h.Events[MaxChangefeedHistoryEvents-1].Type)
+}
+
+func TestChangefeedHistoryAppendLargeEvents(t *testing.T) {
+	t.Parallel()
+
+	// Long messages are truncated.
+	h := &ChangefeedHistory{}
+	message := strings.Repeat("a", 2*maxChangefeedEventMessageBytes)
+	runningErr := &RunningError{Message: message}
+	h.Append(&ChangefeedEvent{
+		Type:    ChangefeedEventError,
+		Error:   runningErr,
+		Message: message,
+	})
+	require.Len(t, h.Events, 1)
+	require.Len(t, h.Events[0].Message, maxChangefeedEventMessageBytes+3)
+	require.Len(t, h.Events[0].Error.Message, maxChangefeedEventMessageBytes+3)
+	require.Equal(t, message, runningErr.Message)
+
+	// The oldest events are discarded if the history is too large.
+	for i := 0; i < MaxChangefeedHistoryEvents; i++ {
+		h.Append(&ChangefeedEvent{Type: ChangefeedEventError, Message: message})
+	}
+	require.Less(t, len(h.Events), MaxChangefeedHistoryEvents)
+	data, err := h.Marshal()
+	require.Nil(t, err)
+	require.LessOrEqual(t, len(data), MaxChangefeedHistoryBytes)
+	require.Greater(t, len(data), MaxChangefeedHistoryBytes-2*maxChangefeedEventMessageBytes)
+}
+
+func TestChangefeedHistoryMarshal(t *testing.T) {
+	t.Parallel()
+
+	h := &ChangefeedHistory{}
+	h.Append(&ChangefeedEvent{
+		Time:     time.Unix(1, 0).UTC(),
+		Type:     ChangefeedEventStateChanged,
+		OldState: StateNormal,
+		State:    StateStopped,
+	}, &ChangefeedEvent{
+		Time:     time.Unix(2, 0).UTC(),
+		Type:     ChangefeedEventAdminJob,
+		AdminJob: AdminStop.String(),
+		Origin:   AdminJobOriginCLI,
+	})
+	data, err := h.Marshal()
+	require.Nil(t, err)
+	require.Equal(t, `{"events":[`+
+		`{"time":"1970-01-01T00:00:01Z","type":"state-changed","old-state":"normal","state":"stopped"},`+
+		`{"time":"1970-01-01T00:00:02Z","type":"admin-job","admin-job":"stop changefeed","origin":"cli"}]}`,
+		data)
+
+	h2 := &ChangefeedHistory{}
+	require.Nil(t, h2.Unmarshal([]byte(data)))
+	require.Equal(t, h, h2)
+}
@@ -226,3 +226,22 @@ type DrainCaptureRequest struct {
 type DrainCaptureResp struct {
 	CurrentTableCount int `json:"current_table_count"`
 }
+
+// TableStats holds the replication statistics of a table
+type TableStats struct {
+	TableID   int64  `json:"table_id"`
+	TableName string `json:"table_name"`
+	CaptureID string `json:"capture_id"`
+	// The state of the table pipeline, such as "Replicating".
+	State        string `json:"state"`
+	CheckpointTs uint64 `json:"checkpoint_ts"`
+	ResolvedTs   uint64 `json:"resolved_ts"`
+	// The lag of checkpoint ts and resolved ts in seconds.
+	CheckpointLag float64 `json:"checkpoint_lag"`
+	ResolvedLag   float64 `json:"resolved_lag"`
+	// The number of events received by the sorter but not yet output.
+	SorterBacklog uint64 `json:"sorter_backlog"`
+	// The throughput of rows emitted to the sink.
+	SinkRowsPerSecond  float64 `json:"sink_rows_per_second"`
+	SinkBytesPerSecond float64 `json:"sink_bytes_per_second"`
+}
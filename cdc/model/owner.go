@@ -31,8 +31,23 @@ type AdminJob struct {
 	Type                  AdminJobType
 	Error                 *RunningError
 	OverwriteCheckpointTs uint64
+	Origin                AdminJobOrigin
 }
 
+// AdminJobOrigin represents where an admin job comes from.
+type AdminJobOrigin string
+
+// All AdminJob origins
+const (
+	// AdminJobOriginAPI means the job is issued by an open API request.
+	AdminJobOriginAPI AdminJobOrigin = "api"
+	// AdminJobOriginCLI means the job is issued by `cdc cli`.
+	AdminJobOriginCLI AdminJobOrigin = "cli"
+	// AdminJobOriginAuto means the job is issued by the owner itself,
+	// e.g. backoff after errors or reaching the target ts.
+	AdminJobOriginAuto AdminJobOrigin = "auto"
+)
+
 // All AdminJob types
 const (
 	AdminNone AdminJobType = iota
@@ -41,6 +41,8 @@ const (
 	MessageTypeDDL
 	// MessageTypeResolved is resolved type of message key
 	MessageTypeResolved
+	// MessageTypeSyncPoint is syncpoint type of message key
+	MessageTypeSyncPoint
 )
 
 // ColumnFlagType is for encapsulating the flag operations for different flags.
@@ -36,6 +36,7 @@ import (
 	"github.com/pingcap/tiflow/pkg/orchestrator"
 	"github.com/pingcap/tiflow/pkg/txnutil/gc"
 	"github.com/pingcap/tiflow/pkg/upstream"
+	"github.com/pingcap/tiflow/pkg/version"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
@@ -44,7 +45,7 @@ import (
 // newSchedulerV2FromCtx creates a new schedulerV2 from context.
 // This function is factored out to facilitate unit testing.
 func newSchedulerV2FromCtx(
-	ctx cdcContext.Context, startTs uint64,
+	ctx cdcContext.Context, startTs uint64, tableNames scheduler.TableNameGetter,
 ) (ret scheduler.Scheduler, err error) {
 	changeFeedID := ctx.ChangefeedVars().ID
 	messageServer := ctx.GlobalVars().MessageServer
@@ -55,7 +56,8 @@ func newSchedulerV2FromCtx(
 	if cfg.EnableSchedulerV3 {
 		ret, err = scheduler.NewSchedulerV3(
 			ctx, captureID, changeFeedID, startTs,
-			messageServer, messageRouter, ownerRev, cfg.Scheduler)
+			messageServer, messageRouter, ownerRev, cfg.Scheduler,
+			ctx.ChangefeedVars().Info.Config, tableNames)
 	} else {
 		ret, err = scheduler.NewScheduler(
 			ctx, captureID, changeFeedID, startTs,
@@ -64,8 +66,10 @@ func newSchedulerV2FromCtx(
 	return ret, errors.Trace(err)
 }
 
-func newScheduler(ctx cdcContext.Context, startTs uint64) (scheduler.Scheduler, error) {
-	return newSchedulerV2FromCtx(ctx, startTs)
+func newScheduler(
+	ctx cdcContext.Context, startTs uint64, tableNames scheduler.TableNameGetter,
+) (scheduler.Scheduler, error) {
+	return newSchedulerV2FromCtx(ctx, startTs, tableNames)
 }
 
 type changefeed struct {
@@ -127,7 +131,9 @@ type changefeed struct {
 	) (puller.DDLPuller, error)
 
 	newSink      func() DDLSink
-	newScheduler func(ctx cdcContext.Context, startTs uint64) (scheduler.Scheduler, error)
+	newScheduler func(
+		ctx cdcContext.Context, startTs uint64, tableNames scheduler.TableNameGetter,
+	) (scheduler.Scheduler, error)
 }
 
 func newChangefeed(
@@ -163,7 +169,9 @@ func newChangefeed4Test(
 		changefeed model.ChangeFeedID,
 	) (puller.DDLPuller, error),
 	newSink func() DDLSink,
-	newScheduler func(ctx cdcContext.Context, startTs uint64) (scheduler.Scheduler, error),
+	newScheduler func(
+		ctx cdcContext.Context, startTs uint64, tableNames scheduler.TableNameGetter,
+	) (scheduler.Scheduler, error),
 ) *changefeed {
 	c := newChangefeed(id, state, up)
 	c.newDDLPuller = newDDLPuller
@@ -244,6 +252,7 @@ func (c *changefeed) checkStaleCheckpointTs(ctx cdcContext.Context, checkpointTs
 }
 
 func (c *changefeed) tick(ctx cdcContext.Context, captures map[model.CaptureID]*model.CaptureInfo) error {
+	c.feedStateManager.recordHistory = shouldRecordChangefeedHistory(captures)
 	adminJobPending := c.feedStateManager.Tick(c.state)
 	checkpointTs := c.state.Info.GetCheckpointTs(c.state.Status)
 	// check stale checkPointTs must be called before `feedStateManager.ShouldRunning()`
@@ -514,7 +523,7 @@ LOOP:
 		WithLabelValues(c.id.Namespace, c.id.ID)
 
 	// create scheduler
-	c.scheduler, err = c.newScheduler(ctx, checkpointTs)
+	c.scheduler, err = c.newScheduler(ctx, checkpointTs, c.schema.PhysicalTableName)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -685,6 +694,21 @@ func (c *changefeed) preflightCheck(captures map[model.CaptureID]*model.CaptureI
 	return
 }
 
+// shouldRecordChangefeedHistory returns true if all captures can parse the
+// changefeed history keys. Older captures fail to watch the etcd if they
+// find the keys.
+func shouldRecordChangefeedHistory(captures map[model.CaptureID]*model.CaptureInfo) bool {
+	versions := make([]string, 0, len(captures))
+	for _, capture := range captures {
+		versions = append(versions, capture.Version)
+	}
+	clusterVersion, err := version.GetTiCDCClusterVersion(versions)
+	if err != nil {
+		return false
+	}
+	return clusterVersion.ShouldRecordChangefeedHistory()
+}
+
 func (c *changefeed) handleBarrier(ctx cdcContext.Context) (uint64, error) {
 	barrierTp, barrierTs := c.barriers.Min()
 	phyBarrierTs := oracle.ExtractPhysical(barrierTs)
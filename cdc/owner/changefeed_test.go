@@ -215,7 +215,7 @@ func createChangefeed4Test(ctx cdcContext.Context, t *testing.T,
 		},
 		// new scheduler
 		func(
-			ctx cdcContext.Context, startTs uint64,
+			ctx cdcContext.Context, startTs uint64, _ scheduler.TableNameGetter,
 		) (scheduler.Scheduler, error) {
 			return &mockScheduler{}, nil
 		})
@@ -1039,3 +1039,20 @@ func TestBarrierAdvance(t *testing.T) {
 		require.Equal(t, mockDDLPuller.resolvedTs, barrier)
 	}
 }
+
+func TestShouldRecordChangefeedHistory(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, shouldRecordChangefeedHistory(map[model.CaptureID]*model.CaptureInfo{
+		"a": {Version: "6.4.0"},
+		"b": {Version: "6.5.0"},
+	}))
+	require.False(t, shouldRecordChangefeedHistory(map[model.CaptureID]*model.CaptureInfo{
+		"a": {Version: "6.4.0"},
+		"b": {Version: "6.3.0"},
+	}))
+	require.False(t, shouldRecordChangefeedHistory(map[model.CaptureID]*model.CaptureInfo{
+		"a": {Version: "6.4.0"},
+		"b": {Version: "invalid"},
+	}))
+}
@@ -41,7 +41,8 @@ const (
 
 // DDLSink is a wrapper of the `Sink` interface for the owner
 // DDLSink should send `DDLEvent` and `CheckpointTs` to downstream sink,
-// If `SyncPointEnabled`, also send `syncPoint` to downstream.
+// If `SyncPointEnabled`, also send `syncPoint` to downstream, which is only
+// supported by the MySQL sink unless the sink v2 is enabled.
 type DDLSink interface {
 	// run the DDLSink
 	run(ctx cdcContext.Context, id model.ChangeFeedID, info *model.ChangeFeedInfo)
@@ -124,7 +125,9 @@ func ddlSinkInitializer(ctx cdcContext.Context, a *ddlSinkImpl, id model.ChangeF
 		a.sinkV2 = s
 	}
 
-	if !info.SyncPointEnabled {
+	// The syncpoints are written by the sink v2 itself, so that they are
+	// supported by all the sinks instead of only the MySQL sink.
+	if !info.SyncPointEnabled || a.sinkV2 != nil {
 		return nil
 	}
 	syncPointStore, err := mysql.NewSyncpointStore(stdCtx, id, info.SinkURI)
@@ -332,6 +335,12 @@ func (s *ddlSinkImpl) emitSyncPoint(ctx cdcContext.Context, checkpointTs uint64)
 		return nil
 	}
 	s.lastSyncPoint = checkpointTs
+	if s.sinkV2 != nil {
+		s.mu.Lock()
+		tables := s.mu.currentTableNames
+		s.mu.Unlock()
+		return s.sinkV2.WriteSyncPoint(ctx, checkpointTs, tables)
+	}
 	// TODO implement async sink syncPoint
 	return s.syncPointStore.SinkSyncpoint(ctx, ctx.ChangefeedVars().ID, checkpointTs)
 }
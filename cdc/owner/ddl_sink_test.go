@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink"
+	sinkv2 "github.com/pingcap/tiflow/cdc/sinkv2/ddlsink"
 	cdcContext "github.com/pingcap/tiflow/pkg/context"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/retry"
@@ -74,6 +75,38 @@ func newDDLSink4Test() (DDLSink, *mockSink) {
 	return ddlSink, mockSink
 }
 
+type mockSinkV2 struct {
+	sinkv2.DDLEventSink
+	syncPoints []model.Ts
+	tables     []model.TableName
+}
+
+func (m *mockSinkV2) WriteSyncPoint(
+	_ context.Context, ts uint64, tables []model.TableName,
+) error {
+	m.syncPoints = append(m.syncPoints, ts)
+	m.tables = tables
+	return nil
+}
+
+func TestEmitSyncPointToSinkV2(t *testing.T) {
+	t.Parallel()
+
+	ctx := cdcContext.NewBackendContext4Test(true)
+	mSink := &mockSinkV2{}
+	ddlSink := newDDLSink().(*ddlSinkImpl)
+	ddlSink.sinkV2 = mSink
+
+	tables := []model.TableName{{Schema: "test", Table: "t1"}}
+	ddlSink.emitCheckpointTs(100, tables)
+	require.Nil(t, ddlSink.emitSyncPoint(ctx, 100))
+	// The same syncpoint is only written once.
+	require.Nil(t, ddlSink.emitSyncPoint(ctx, 100))
+	require.Nil(t, ddlSink.emitSyncPoint(ctx, 200))
+	require.Equal(t, []model.Ts{100, 200}, mSink.syncPoints)
+	require.Equal(t, tables, mSink.tables)
+}
+
 func TestCheckpoint(t *testing.T) {
 	ddlSink, mSink := newDDLSink4Test()
 	ctx := cdcContext.NewBackendContext4Test(true)
@@ -14,6 +14,8 @@
 package owner
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -22,6 +24,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	cerrors "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
+	"github.com/r3labs/diff"
 	"go.uber.org/zap"
 )
 
@@ -56,6 +59,18 @@ type feedStateManager struct {
 	lastErrorTime   time.Time                   // time of last error for a changefeed
 	backoffInterval time.Duration               // the interval for restarting a changefeed in 'error' state
 	errBackoff      *backoff.ExponentialBackOff // an exponential backoff for restarting a changefeed
+
+	// events are recorded in this tick and appended to the changefeed history
+	// at the end of the tick.
+	events []*model.ChangefeedEvent
+	// patchedState is the last state patched in this tick.
+	patchedState model.FeedState
+	// lastInfo is the changefeed info seen in the last tick, it's used to
+	// find out config updates.
+	lastInfo *model.ChangeFeedInfo
+	// recordHistory is false if there are captures which can not parse the
+	// changefeed history keys, e.g. during a rolling upgrade.
+	recordHistory bool
 }
 
 // newFeedStateManager creates feedStateManager and initialize the exponential backoff
@@ -72,6 +87,7 @@ func newFeedStateManager() *feedStateManager {
 
 	f.resetErrBackoff()
 	f.lastErrorTime = time.Unix(0, 0)
+	f.recordHistory = true
 
 	return f
 }
@@ -94,6 +110,7 @@ func newFeedStateManager4Test(
 
 	f.resetErrBackoff()
 	f.lastErrorTime = time.Unix(0, 0)
+	f.recordHistory = true
 
 	return f
 }
@@ -133,7 +150,9 @@ func (m *feedStateManager) Tick(state *orchestrator.ChangefeedReactorState) (adm
 		} else {
 			m.cleanUpInfos()
 		}
+		m.patchHistory()
 	}()
+	m.recordConfigUpdate()
 	if m.handleAdminJob() {
 		// `handleAdminJob` returns true means that some admin jobs are pending
 		// skip to the next tick until all the admin jobs is handled
@@ -155,6 +174,12 @@ func (m *feedStateManager) Tick(state *orchestrator.ChangefeedReactorState) (adm
 		}
 	}
 	errs := m.errorsReportedByProcessors()
+	for _, err := range errs {
+		m.recordEvent(&model.ChangefeedEvent{
+			Type:  model.ChangefeedEventError,
+			Error: err,
+		})
+	}
 	m.handleError(errs...)
 	return
 }
@@ -174,8 +199,9 @@ func (m *feedStateManager) MarkFinished() {
 		return
 	}
 	m.pushAdminJob(&model.AdminJob{
-		CfID: m.state.ID,
-		Type: model.AdminFinish,
+		CfID:   m.state.ID,
+		Type:   model.AdminFinish,
+		Origin: model.AdminJobOriginAuto,
 	})
 }
 
@@ -210,6 +236,7 @@ func (m *feedStateManager) handleAdminJob() (jobsPending bool) {
 				zap.String("changefeedState", string(m.state.Info.State)), zap.Any("job", job))
 			return
 		}
+		m.recordAdminJob(job, "")
 		m.shouldBeRunning = false
 		jobsPending = true
 		m.patchState(model.StateStopped)
@@ -238,6 +265,10 @@ func (m *feedStateManager) handleAdminJob() (jobsPending bool) {
 		m.state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
 			return nil, true, nil
 		})
+		// remove changefeedHistory
+		m.state.PatchHistory(func(history *model.ChangefeedHistory) (*model.ChangefeedHistory, bool, error) {
+			return nil, history != nil, nil
+		})
 		checkpointTs := m.state.Info.GetCheckpointTs(m.state.Status)
 
 		log.Info("the changefeed is removed",
@@ -254,6 +285,12 @@ func (m *feedStateManager) handleAdminJob() (jobsPending bool) {
 				zap.String("changefeedState", string(m.state.Info.State)), zap.Any("job", job))
 			return
 		}
+		if job.OverwriteCheckpointTs > 0 {
+			m.recordAdminJob(job, fmt.Sprintf(
+				"overwrite checkpoint ts to %d", job.OverwriteCheckpointTs))
+		} else {
+			m.recordAdminJob(job, "")
+		}
 		m.shouldBeRunning = true
 		// when the changefeed is manually resumed, we must reset the backoff
 		m.resetErrBackoff()
@@ -309,6 +346,7 @@ func (m *feedStateManager) handleAdminJob() (jobsPending bool) {
 				zap.String("changefeedState", string(m.state.Info.State)), zap.Any("job", job))
 			return
 		}
+		m.recordAdminJob(job, "")
 		m.shouldBeRunning = false
 		jobsPending = true
 		m.patchState(model.StateFinished)
@@ -347,6 +385,7 @@ func (m *feedStateManager) patchState(feedState model.FeedState) {
 	default:
 		log.Panic("Unreachable")
 	}
+	m.patchedState = feedState
 	m.state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
 		if status == nil {
 			return status, false, nil
@@ -481,9 +520,19 @@ func (m *feedStateManager) handleError(errs ...*model.RunningError) {
 	}
 
 	if time.Since(m.lastErrorTime) < m.backoffInterval {
+		if m.state.Info.State != model.StateError {
+			m.recordAdminJob(&model.AdminJob{
+				Type:   model.AdminStop,
+				Origin: model.AdminJobOriginAuto,
+			}, fmt.Sprintf("backoff %s before restarting", m.backoffInterval))
+		}
 		m.shouldBeRunning = false
 		m.patchState(model.StateError)
 	} else {
+		m.recordAdminJob(&model.AdminJob{
+			Type:   model.AdminResume,
+			Origin: model.AdminJobOriginAuto,
+		}, "restart after backoff")
 		oldBackoffInterval := m.backoffInterval
 		// NextBackOff will never return -1 because the backoff never stops
 		// with `MaxElapsedTime=0`
@@ -498,3 +547,93 @@ func (m *feedStateManager) handleError(errs ...*model.RunningError) {
 			zap.Duration("newInterval", m.backoffInterval))
 	}
 }
+
+func (m *feedStateManager) recordEvent(event *model.ChangefeedEvent) {
+	event.Time = time.Now()
+	m.events = append(m.events, event)
+}
+
+func (m *feedStateManager) recordAdminJob(job *model.AdminJob, message string) {
+	m.recordEvent(&model.ChangefeedEvent{
+		Type:     model.ChangefeedEventAdminJob,
+		AdminJob: job.Type.String(),
+		Origin:   job.Origin,
+		Message:  message,
+	})
+}
+
+// changefeedConfigFields are the fields of model.ChangeFeedInfo
+// that can be updated by users.
+var changefeedConfigFields = map[string]struct{}{
+	"SinkURI":           {},
+	"TargetTs":          {},
+	"Engine":            {},
+	"Config":            {},
+	"SyncPointEnabled":  {},
+	"SyncPointInterval": {},
+}
+
+// recordConfigUpdate records the changes of the changefeed config
+// if the changefeed info is updated since the last tick.
+func (m *feedStateManager) recordConfigUpdate() {
+	lastInfo := m.lastInfo
+	m.lastInfo = m.state.Info
+	if lastInfo == nil || lastInfo == m.state.Info {
+		return
+	}
+	changelog, err := diff.Diff(lastInfo, m.state.Info)
+	if err != nil {
+		log.Warn("failed to diff changefeed info",
+			zap.String("namespace", m.state.ID.Namespace),
+			zap.String("changefeed", m.state.ID.ID),
+			zap.Error(err))
+		return
+	}
+	var changes []*model.ChangefeedConfigChange
+	for _, change := range changelog {
+		if len(change.Path) == 0 {
+			continue
+		}
+		if _, ok := changefeedConfigFields[change.Path[0]]; !ok {
+			continue
+		}
+		changes = append(changes, &model.ChangefeedConfigChange{
+			Path: strings.Join(change.Path, "."),
+			From: change.From,
+			To:   change.To,
+		})
+	}
+	if len(changes) > 0 {
+		m.recordEvent(&model.ChangefeedEvent{
+			Type:          model.ChangefeedEventConfigUpdated,
+			ConfigChanges: changes,
+		})
+	}
+}
+
+// patchHistory appends the events recorded in this tick to the changefeed
+// history.
+func (m *feedStateManager) patchHistory() {
+	if m.patchedState != "" && m.state.Info != nil &&
+		m.state.Info.State != m.patchedState {
+		m.recordEvent(&model.ChangefeedEvent{
+			Type:     model.ChangefeedEventStateChanged,
+			OldState: m.state.Info.State,
+			State:    m.patchedState,
+		})
+	}
+	m.patchedState = ""
+	events := m.events
+	m.events = nil
+	// The history is removed along with the changefeed.
+	if len(events) == 0 || m.shouldBeRemoved || !m.recordHistory {
+		return
+	}
+	m.state.PatchHistory(func(history *model.ChangefeedHistory) (*model.ChangefeedHistory, bool, error) {
+		if history == nil {
+			history = &model.ChangefeedHistory{}
+		}
+		history.Append(events...)
+		return history, true, nil
+	})
+}
@@ -585,3 +585,146 @@ func TestBackoffNeverStops(t *testing.T) {
 		tester.MustApplyPatches()
 	}
 }
+
+func TestChangefeedHistory(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	manager := newFeedStateManager4Test(200, 1600, 0, 2.0)
+	state := orchestrator.NewChangefeedReactorState(etcd.DefaultCDCClusterID,
+		ctx.ChangefeedVars().ID)
+	tester := orchestrator.NewReactorStateTester(t, state, nil)
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		require.Nil(t, info)
+		return &model.ChangeFeedInfo{
+			SinkURI: "123",
+			Config:  &config.ReplicaConfig{},
+			State:   model.StateNormal,
+		}, true, nil
+	})
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		require.Nil(t, status)
+		return &model.ChangeFeedStatus{}, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.Nil(t, state.History)
+
+	// stop the changefeed by cli
+	manager.PushAdminJob(&model.AdminJob{
+		CfID:   ctx.ChangefeedVars().ID,
+		Type:   model.AdminStop,
+		Origin: model.AdminJobOriginCLI,
+	})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	events := state.History.Events
+	require.Len(t, events, 2)
+	require.Equal(t, model.ChangefeedEventAdminJob, events[0].Type)
+	require.Equal(t, model.AdminStop.String(), events[0].AdminJob)
+	require.Equal(t, model.AdminJobOriginCLI, events[0].Origin)
+	require.Equal(t, model.ChangefeedEventStateChanged, events[1].Type)
+	require.Equal(t, model.StateNormal, events[1].OldState)
+	require.Equal(t, model.StateStopped, events[1].State)
+
+	// update the config of the stopped changefeed
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		info.SinkURI = "456"
+		return info, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	events = state.History.Events
+	require.Len(t, events, 3)
+	require.Equal(t, model.ChangefeedEventConfigUpdated, events[2].Type)
+	require.Equal(t, []*model.ChangefeedConfigChange{
+		{Path: "SinkURI", From: "123", To: "456"},
+	}, events[2].ConfigChanges)
+
+	// resume the changefeed by api
+	manager.PushAdminJob(&model.AdminJob{
+		CfID:   ctx.ChangefeedVars().ID,
+		Type:   model.AdminResume,
+		Origin: model.AdminJobOriginAPI,
+	})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	events = state.History.Events
+	require.Len(t, events, 5)
+	require.Equal(t, model.AdminResume.String(), events[3].AdminJob)
+	require.Equal(t, model.AdminJobOriginAPI, events[3].Origin)
+	require.Equal(t, model.StateNormal, events[4].State)
+
+	// the changefeed is stopped and restarted automatically after an error
+	state.PatchTaskPosition(ctx.GlobalVars().CaptureInfo.ID,
+		func(position *model.TaskPosition) (*model.TaskPosition, bool, error) {
+			return &model.TaskPosition{Error: &model.RunningError{
+				Addr:    ctx.GlobalVars().CaptureInfo.AdvertiseAddr,
+				Code:    "[CDC:ErrEtcdSessionDone]",
+				Message: "fake error for test",
+			}}, true, nil
+		})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	events = state.History.Events
+	require.Len(t, events, 8)
+	require.Equal(t, model.ChangefeedEventError, events[5].Type)
+	require.Equal(t, "[CDC:ErrEtcdSessionDone]", events[5].Error.Code)
+	require.Equal(t, model.AdminStop.String(), events[6].AdminJob)
+	require.Equal(t, model.AdminJobOriginAuto, events[6].Origin)
+	require.Equal(t, model.StateError, events[7].State)
+
+	time.Sleep(200 * time.Millisecond)
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	events = state.History.Events
+	require.Len(t, events, 10)
+	require.Equal(t, model.AdminResume.String(), events[8].AdminJob)
+	require.Equal(t, model.AdminJobOriginAuto, events[8].Origin)
+	require.Equal(t, model.StateError, events[9].OldState)
+	require.Equal(t, model.StateNormal, events[9].State)
+
+	// the history is removed along with the changefeed
+	manager.PushAdminJob(&model.AdminJob{
+		CfID: ctx.ChangefeedVars().ID,
+		Type: model.AdminRemove,
+	})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.Nil(t, state.History)
+	require.False(t, state.Exist())
+}
+
+func TestChangefeedHistoryDisabled(t *testing.T) {
+	ctx := cdcContext.NewBackendContext4Test(true)
+	manager := newFeedStateManager4Test(200, 1600, 0, 2.0)
+	manager.recordHistory = false
+	state := orchestrator.NewChangefeedReactorState(etcd.DefaultCDCClusterID,
+		ctx.ChangefeedVars().ID)
+	tester := orchestrator.NewReactorStateTester(t, state, nil)
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		return &model.ChangeFeedInfo{
+			SinkURI: "123",
+			Config:  &config.ReplicaConfig{},
+			State:   model.StateNormal,
+		}, true, nil
+	})
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		return &model.ChangeFeedStatus{}, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+
+	// old captures can not parse the history key, so nothing is written
+	manager.PushAdminJob(&model.AdminJob{
+		CfID:   ctx.ChangefeedVars().ID,
+		Type:   model.AdminStop,
+		Origin: model.AdminJobOriginCLI,
+	})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	require.Equal(t, model.StateStopped, state.Info.State)
+	require.Nil(t, state.History)
+}
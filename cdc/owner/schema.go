@@ -116,6 +116,15 @@ func (s *schemaWrap4Owner) AllTableNames() []model.TableName {
 	return names
 }
 
+// PhysicalTableName returns the name of a table or a partition by its ID.
+func (s *schemaWrap4Owner) PhysicalTableName(tableID model.TableID) (model.TableName, bool) {
+	tableInfo, ok := s.schemaSnapshot.PhysicalTableByID(tableID)
+	if !ok {
+		return model.TableName{}, false
+	}
+	return tableInfo.TableName, true
+}
+
 func (s *schemaWrap4Owner) HandleDDL(job *timodel.Job) error {
 	// We use schemaVersion to check if an already-executed DDL job is processed for a second time.
 	// Unexecuted DDL jobs should have largest schemaVersions
@@ -94,6 +94,24 @@ func TestAllTableNames(t *testing.T) {
 	require.Equal(t, []model.TableName{{Schema: "test", Table: "t1"}}, schema.AllTableNames())
 }
 
+func TestPhysicalTableName(t *testing.T) {
+	helper := entry.NewSchemaTestHelper(t)
+	defer helper.Close()
+	ver, err := helper.Storage().CurrentVersion(oracle.GlobalTxnScope)
+	require.Nil(t, err)
+	schema, err := newSchemaWrap4Owner(helper.Storage(), ver.Ver,
+		config.GetDefaultReplicaConfig(), dummyChangeFeedID)
+	require.Nil(t, err)
+	job := helper.DDL2Job("create table test.t1(id int primary key)")
+	require.Nil(t, schema.HandleDDL(job))
+	name, ok := schema.PhysicalTableName(job.TableID)
+	require.True(t, ok)
+	require.Equal(t, "test", name.Schema)
+	require.Equal(t, "t1", name.Table)
+	_, ok = schema.PhysicalTableName(job.TableID + 1000)
+	require.False(t, ok)
+}
+
 func TestIsIneligibleTableID(t *testing.T) {
 	helper := entry.NewSchemaTestHelper(t)
 	defer helper.Close()
@@ -40,6 +40,9 @@ const (
 	// Query the number of tables in the manager.
 	// command payload is a buffer channel of int, make(chan int, 1).
 	commandTpQueryTableCount
+	// Query the replication statistics of tables of a changefeed.
+	// command payload is a *tableStatsQuery.
+	commandTpQueryTableStats
 	processorLogsWarnDuration = 1 * time.Second
 )
 
@@ -49,10 +52,19 @@ type command struct {
 	done    chan<- error
 }
 
+type tableStatsQuery struct {
+	changefeedID model.ChangeFeedID
+	statsCh      chan []model.TableStats
+}
+
 // Manager is a manager of processor, which maintains the state and behavior of processors
 type Manager interface {
 	orchestrator.Reactor
 	QueryTableCount(ctx context.Context, tableCh chan int, done chan<- error)
+	QueryTableStats(
+		ctx context.Context, changefeedID model.ChangeFeedID,
+		statsCh chan []model.TableStats, done chan<- error,
+	)
 	WriteDebugInfo(ctx context.Context, w io.Writer, done chan<- error)
 	AsyncClose()
 }
@@ -194,6 +206,20 @@ func (m *managerImpl) QueryTableCount(
 	}
 }
 
+// QueryTableStats query the replication statistics of tables of
+// the given changefeed in the manager. Nothing is sent to statsCh if
+// the changefeed does not run on this capture.
+func (m *managerImpl) QueryTableStats(
+	ctx context.Context, changefeedID model.ChangeFeedID,
+	statsCh chan []model.TableStats, done chan<- error,
+) {
+	query := &tableStatsQuery{changefeedID: changefeedID, statsCh: statsCh}
+	err := m.sendCommand(ctx, commandTpQueryTableStats, query, done)
+	if err != nil {
+		log.Warn("send command commandTpQueryTableStats failed", zap.Error(err))
+	}
+}
+
 // WriteDebugInfo write the debug info to Writer
 func (m *managerImpl) WriteDebugInfo(
 	ctx context.Context, w io.Writer, done chan<- error,
@@ -247,6 +273,16 @@ func (m *managerImpl) handleCommand() error {
 		case cmd.payload.(chan int) <- count:
 		default:
 		}
+	case commandTpQueryTableStats:
+		query := cmd.payload.(*tableStatsQuery)
+		p, ok := m.processors[query.changefeedID]
+		if !ok {
+			break
+		}
+		select {
+		case query.statsCh <- p.GetTableStats():
+		default:
+		}
 	default:
 		log.Warn("Unknown command in processor manager", zap.Any("command", cmd))
 	}
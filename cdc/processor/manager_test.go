@@ -313,3 +313,42 @@ func TestQueryTableCount(t *testing.T) {
 		require.FailNow(t, "done must be closed")
 	}
 }
+
+func TestQueryTableStats(t *testing.T) {
+	liveness := model.LivenessCaptureAlive
+	m := NewManager(&model.CaptureInfo{ID: "capture-test"}, nil, &liveness).(*managerImpl)
+	ctx := context.TODO()
+	changefeedID := model.ChangeFeedID{ID: "test"}
+	m.processors[changefeedID] = &processor{
+		captureInfo: &model.CaptureInfo{ID: "capture-test"},
+		upstream:    upstream.NewUpstream4Test(nil),
+		tables: map[model.TableID]tablepipeline.TablePipeline{
+			2: &mockTablePipeline{tableID: 2, state: tablepipeline.TableStateReplicating},
+			1: &mockTablePipeline{tableID: 1, state: tablepipeline.TableStateReplicating},
+		},
+	}
+
+	done := make(chan error, 1)
+	statsCh := make(chan []model.TableStats, 1)
+	m.QueryTableStats(ctx, changefeedID, statsCh, done)
+	err := m.handleCommand()
+	require.Nil(t, err)
+	select {
+	case stats := <-statsCh:
+		require.Len(t, stats, 2)
+		require.Equal(t, int64(1), stats[0].TableID)
+		require.Equal(t, int64(2), stats[1].TableID)
+		require.Equal(t, "capture-test", stats[0].CaptureID)
+		require.Equal(t, "Replicating", stats[0].State)
+		require.Equal(t, uint64(1), stats[0].SorterBacklog)
+	case <-time.After(time.Second):
+		require.FailNow(t, "stats must be sent")
+	}
+
+	// Nothing is sent if the changefeed does not exist.
+	done = make(chan error, 1)
+	m.QueryTableStats(ctx, model.ChangeFeedID{ID: "unknown"}, statsCh, done)
+	err = m.handleCommand()
+	require.Nil(t, err)
+	require.Len(t, statsCh, 0)
+}
@@ -10,6 +10,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	model "github.com/pingcap/tiflow/cdc/model"
 	orchestrator "github.com/pingcap/tiflow/pkg/orchestrator"
 )
 
@@ -60,6 +61,18 @@ func (mr *MockManagerMockRecorder) QueryTableCount(ctx, tableCh, done interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryTableCount", reflect.TypeOf((*MockManager)(nil).QueryTableCount), ctx, tableCh, done)
 }
 
+// QueryTableStats mocks base method.
+func (m *MockManager) QueryTableStats(ctx context.Context, changefeedID model.ChangeFeedID, statsCh chan []model.TableStats, done chan<- error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "QueryTableStats", ctx, changefeedID, statsCh, done)
+}
+
+// QueryTableStats indicates an expected call of QueryTableStats.
+func (mr *MockManagerMockRecorder) QueryTableStats(ctx, changefeedID, statsCh, done interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryTableStats", reflect.TypeOf((*MockManager)(nil).QueryTableStats), ctx, changefeedID, statsCh, done)
+}
+
 // Tick mocks base method.
 func (m *MockManager) Tick(ctx context.Context, state orchestrator.ReactorState) (orchestrator.ReactorState, error) {
 	m.ctrl.T.Helper()
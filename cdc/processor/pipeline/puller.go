@@ -18,6 +18,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/kv"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/puller"
 	"github.com/pingcap/tiflow/pkg/config"
@@ -36,6 +37,9 @@ type pullerNode struct {
 	changefeed model.ChangeFeedID
 	cancel     context.CancelFunc
 	wg         *errgroup.Group
+
+	// memQuota is shared by the kv clients of all tables in the changefeed.
+	memQuota *kv.MemoryQuota
 }
 
 func newPullerNode(
@@ -43,12 +47,14 @@ func newPullerNode(
 	startTs model.Ts,
 	tableName string,
 	changefeed model.ChangeFeedID,
+	memQuota *kv.MemoryQuota,
 ) *pullerNode {
 	return &pullerNode{
 		tableID:    tableID,
 		startTs:    startTs,
 		tableName:  tableName,
 		changefeed: changefeed,
+		memQuota:   memQuota,
 	}
 }
 
@@ -83,6 +89,7 @@ func (n *pullerNode) start(ctx pipeline.NodeContext,
 		n.tableSpan(),
 		kvCfg,
 		n.changefeed,
+		n.memQuota,
 	)
 	n.wg.Go(func() error {
 		ctx.Throw(errors.Trace(plr.Run(ctxC)))
@@ -50,6 +50,8 @@ type sinkNode struct {
 
 	enableOldValue bool
 	splitTxn       bool
+
+	throughput *throughput
 }
 
 func newSinkNode(
@@ -76,6 +78,7 @@ func newSinkNode(
 		redoManager:    redoManager,
 		enableOldValue: enableOldValue,
 		splitTxn:       splitTxn,
+		throughput:     newThroughput(time.Now()),
 	}
 	sn.resolvedTs.Store(model.NewResolvedTs(startTs))
 	sn.checkpointTs.Store(model.NewResolvedTs(startTs))
@@ -226,6 +229,11 @@ func (n *sinkNode) emitRowToSink(ctx context.Context, event *model.PolymorphicEv
 				return err
 			}
 		}
+		bytes := int64(0)
+		for _, row := range rows {
+			bytes += row.ApproximateDataSize
+		}
+		n.throughput.add(len(rows), bytes)
 		if n.sinkV1 != nil {
 			return n.sinkV1.EmitRowChangedEvents(ctx, rows...)
 		}
@@ -71,6 +71,12 @@ type sorterNode struct {
 
 	redoLogEnabled bool
 	changefeed     model.ChangeFeedID
+
+	// The number of row changed events that have been sent to the sorter
+	// and the number of row changed events that have been output by the
+	// sorter, used to calculate the sorter backlog.
+	inputEvents  uint64
+	outputEvents uint64
 }
 
 func newSorterNode(
@@ -241,6 +247,9 @@ func (n *sorterNode) start(
 				if msg == nil || msg.RawKV == nil {
 					log.Panic("unexpected empty msg", zap.Any("msg", msg))
 				}
+				if msg.RawKV.OpType != model.OpTypeResolved {
+					atomic.AddUint64(&n.outputEvents, 1)
+				}
 
 				if msg.CRTs < startTs {
 					// Ignore messages are less than initial checkpoint ts.
@@ -360,6 +369,8 @@ func (n *sorterNode) handleRawEvent(ctx context.Context, event *model.Polymorphi
 			n.state.Store(TableStatePrepared)
 			close(n.preparedCh)
 		}
+	} else if rawKV != nil {
+		atomic.AddUint64(&n.inputEvents, 1)
 	}
 	n.sorter.AddEntry(ctx, event)
 }
@@ -387,3 +398,14 @@ func (n *sorterNode) BarrierTs() model.Ts {
 }
 
 func (n *sorterNode) State() TableState { return n.state.Load() }
+
+// backlog returns the number of row changed events that are sent to
+// the sorter but not yet output.
+func (n *sorterNode) backlog() uint64 {
+	output := atomic.LoadUint64(&n.outputEvents)
+	input := atomic.LoadUint64(&n.inputEvents)
+	if input < output {
+		return 0
+	}
+	return input - output
+}
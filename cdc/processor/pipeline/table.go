@@ -77,6 +77,18 @@ type TableMeta struct {
 	CheckpointTs model.Ts
 	ResolvedTs   model.Ts
 	State        TableState
+	Stats        TableStats
+}
+
+// TableStats is the replication statistics of a table pipeline.
+type TableStats struct {
+	// SorterBacklog is the number of row changed events that have been
+	// received by the sorter but not yet output to the sink.
+	SorterBacklog uint64
+	// SinkRowsPerSecond is the number of rows emitted to the sink per second.
+	SinkRowsPerSecond float64
+	// SinkBytesPerSecond is the bytes of rows emitted to the sink per second.
+	SinkBytesPerSecond float64
 }
 
 // TablePipeline is a pipeline which capture the change log from tikv in a table
@@ -107,6 +119,8 @@ type TablePipeline interface {
 	Wait()
 	// MemoryConsumption return the memory consumption in bytes
 	MemoryConsumption() uint64
+	// Stats returns the replication statistics of this table pipeline
+	Stats() TableStats
 }
 
 // TODO find a better name or avoid using an interface
@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/entry"
+	"github.com/pingcap/tiflow/cdc/kv"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/redo"
 	sinkv1 "github.com/pingcap/tiflow/cdc/sink"
@@ -80,6 +81,7 @@ type tableActor struct {
 	tableID        int64
 	targetTs       model.Ts
 	memoryQuota    uint64
+	kvMemoryQuota  *kv.MemoryQuota
 	replicaInfo    *model.TableReplicaInfo
 	replicaConfig  *serverConfig.ReplicaConfig
 	changefeedVars *cdcContext.ChangefeedVars
@@ -109,6 +111,7 @@ func NewTableActor(cdcCtx cdcContext.Context,
 	sinkV2 sinkv2.TableSink,
 	redoManager redo.LogManager,
 	targetTs model.Ts,
+	kvMemoryQuota *kv.MemoryQuota,
 ) (TablePipeline, error) {
 	config := cdcCtx.ChangefeedVars().Info.Config
 	changefeedVars := cdcCtx.ChangefeedVars()
@@ -132,6 +135,7 @@ func NewTableActor(cdcCtx cdcContext.Context,
 		tableID:       tableID,
 		tableName:     tableName,
 		memoryQuota:   serverConfig.GetGlobalServerConfig().PerTableMemoryQuota,
+		kvMemoryQuota: kvMemoryQuota,
 		upstream:      up,
 		mounter:       mounter,
 		replicaInfo:   replicaInfo,
@@ -305,7 +309,8 @@ func (t *tableActor) start(sdtTableContext context.Context) error {
 		return err
 	}
 
-	pullerNode := newPullerNode(t.tableID, t.replicaInfo.StartTs, t.tableName, t.changefeedVars.ID)
+	pullerNode := newPullerNode(t.tableID, t.replicaInfo.StartTs, t.tableName,
+		t.changefeedVars.ID, t.kvMemoryQuota)
 	pullerActorNodeContext := newContext(sdtTableContext,
 		t.tableName,
 		t.globalVars.TableActorSystem.Router(),
@@ -493,6 +498,16 @@ func (t *tableActor) MemoryConsumption() uint64 {
 	return t.sortNode.flowController.GetConsumption()
 }
 
+// Stats returns the replication statistics of this table pipeline
+func (t *tableActor) Stats() TableStats {
+	rowsPerSecond, bytesPerSecond := t.sinkNode.throughput.rate(time.Now())
+	return TableStats{
+		SorterBacklog:      t.sortNode.backlog(),
+		SinkRowsPerSecond:  rowsPerSecond,
+		SinkBytesPerSecond: bytesPerSecond,
+	}
+}
+
 func (t *tableActor) Start(ts model.Ts) {
 	if atomic.CompareAndSwapInt32(&t.sortNode.started, 0, 1) {
 		t.sortNode.startTsCh <- ts
@@ -398,7 +398,7 @@ func TestNewTableActor(t *testing.T) {
 	tbl, err := NewTableActor(cctx, upstream.NewUpstream4Test(&mockPD{}), nil, 1, "t1",
 		&model.TableReplicaInfo{
 			StartTs: 0,
-		}, mocksink.NewNormalMockSink(), nil, redo.NewDisabledManager(), 10)
+		}, mocksink.NewNormalMockSink(), nil, redo.NewDisabledManager(), 10, nil)
 	require.NotNil(t, tbl)
 	require.Nil(t, err)
 	require.Equal(t, TableStatePreparing, tbl.State())
@@ -414,7 +414,7 @@ func TestNewTableActor(t *testing.T) {
 	tbl, err = NewTableActor(cctx, upstream.NewUpstream4Test(&mockPD{}), nil, 1, "t1",
 		&model.TableReplicaInfo{
 			StartTs: 0,
-		}, mocksink.NewNormalMockSink(), nil, redo.NewDisabledManager(), 10)
+		}, mocksink.NewNormalMockSink(), nil, redo.NewDisabledManager(), 10, nil)
 	require.Nil(t, tbl)
 	require.NotNil(t, err)
 
@@ -0,0 +1,65 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// throughputSampleInterval is the minimal interval between two samples of
+// a throughput, the rate is kept unchanged within the interval.
+const throughputSampleInterval = time.Second
+
+// throughput measures the rows and bytes emitted to the sink.
+// All methods of throughput are thread-safe.
+type throughput struct {
+	rows  uint64
+	bytes uint64
+
+	mu             sync.Mutex
+	lastSampleTime time.Time
+	lastRows       uint64
+	lastBytes      uint64
+	rowsPerSecond  float64
+	bytesPerSecond float64
+}
+
+func newThroughput(now time.Time) *throughput {
+	return &throughput{lastSampleTime: now}
+}
+
+// add records the rows emitted to the sink.
+func (t *throughput) add(rows int, bytes int64) {
+	atomic.AddUint64(&t.rows, uint64(rows))
+	atomic.AddUint64(&t.bytes, uint64(bytes))
+}
+
+// rate returns the rows and bytes emitted per second. It takes a new sample
+// if at least throughputSampleInterval has elapsed since the last one.
+func (t *throughput) rate(now time.Time) (rowsPerSecond, bytesPerSecond float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	elapsed := now.Sub(t.lastSampleTime)
+	if elapsed >= throughputSampleInterval {
+		rows := atomic.LoadUint64(&t.rows)
+		bytes := atomic.LoadUint64(&t.bytes)
+		t.rowsPerSecond = float64(rows-t.lastRows) / elapsed.Seconds()
+		t.bytesPerSecond = float64(bytes-t.lastBytes) / elapsed.Seconds()
+		t.lastRows, t.lastBytes = rows, bytes
+		t.lastSampleTime = now
+	}
+	return t.rowsPerSecond, t.bytesPerSecond
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestThroughputRate(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	tp := newThroughput(start)
+	rows, bytes := tp.rate(start)
+	require.Zero(t, rows)
+	require.Zero(t, bytes)
+
+	tp.add(10, 1000)
+	// The rate is not sampled within the sample interval.
+	rows, bytes = tp.rate(start.Add(throughputSampleInterval / 2))
+	require.Zero(t, rows)
+	require.Zero(t, bytes)
+
+	rows, bytes = tp.rate(start.Add(2 * throughputSampleInterval))
+	require.Equal(t, float64(5), rows)
+	require.Equal(t, float64(500), bytes)
+
+	// The rate drops to zero if nothing is emitted.
+	rows, bytes = tp.rate(start.Add(4 * throughputSampleInterval))
+	require.Zero(t, rows)
+	require.Zero(t, bytes)
+}
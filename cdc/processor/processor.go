@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -71,6 +72,9 @@ type processor struct {
 	sinkV1        sinkv1.Sink
 	sinkV2Factory *factory.SinkFactory
 	redoManager   redo.LogManager
+	// kvMemoryQuota limits the memory of events buffered in the kv clients of
+	// all tables, nil means unlimited.
+	kvMemoryQuota *kv.MemoryQuota
 
 	initialized bool
 	errCh       chan error
@@ -396,6 +400,7 @@ func (p *processor) GetTableMeta(tableID model.TableID) pipeline.TableMeta {
 		CheckpointTs: table.CheckpointTs(),
 		ResolvedTs:   table.ResolvedTs(),
 		State:        table.State(),
+		Stats:        table.Stats(),
 	}
 }
 
@@ -473,6 +478,40 @@ func isProcessorIgnorableError(err error) bool {
 	return false
 }
 
+// GetTableStats returns the replication statistics of all tables
+// in the processor.
+func (p *processor) GetTableStats() []model.TableStats {
+	pdTime, _ := p.upstream.PDClock.CurrentTime()
+	currentTs := oracle.GetPhysical(pdTime)
+	stats := make([]model.TableStats, 0, len(p.tables))
+	for tableID, table := range p.tables {
+		checkpointTs := table.CheckpointTs()
+		resolvedTs := table.ResolvedTs()
+		tableStats := table.Stats()
+		stats = append(stats, model.TableStats{
+			TableID:   tableID,
+			TableName: table.Name(),
+			CaptureID: p.captureInfo.ID,
+			State:     table.State().String(),
+
+			CheckpointTs: checkpointTs,
+			ResolvedTs:   resolvedTs,
+			CheckpointLag: float64(
+				currentTs-oracle.ExtractPhysical(checkpointTs)) / 1e3,
+			ResolvedLag: float64(
+				currentTs-oracle.ExtractPhysical(resolvedTs)) / 1e3,
+
+			SorterBacklog:      tableStats.SorterBacklog,
+			SinkRowsPerSecond:  tableStats.SinkRowsPerSecond,
+			SinkBytesPerSecond: tableStats.SinkBytesPerSecond,
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].TableID < stats[j].TableID
+	})
+	return stats
+}
+
 // Tick implements the `orchestrator.State` interface
 // the `state` parameter is sent by the etcd worker, the `state` must be a snapshot of KVs in etcd
 // The main logic of processor is in this function, including the calculation of many kinds of ts, maintain table pipeline, error handling, etc.
@@ -672,6 +711,7 @@ func (p *processor) lazyInitImpl(ctx cdcContext.Context) error {
 
 	start := time.Now()
 	conf := config.GetGlobalServerConfig()
+	p.kvMemoryQuota = kv.NewMemoryQuota(p.changefeedID, conf.KVClient.MemoryQuota)
 	if !conf.Debug.EnableNewSink {
 		log.Info("Try to create sinkV1")
 		p.sinkV1, err = sinkv1.New(
@@ -957,7 +997,8 @@ func (p *processor) createTablePipelineImpl(
 			s,
 			nil,
 			p.redoManager,
-			p.changefeed.Info.GetTargetTs())
+			p.changefeed.Info.GetTargetTs(),
+			p.kvMemoryQuota)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -974,7 +1015,8 @@ func (p *processor) createTablePipelineImpl(
 			nil,
 			s,
 			p.redoManager,
-			p.changefeed.Info.GetTargetTs())
+			p.changefeed.Info.GetTargetTs(),
+			p.kvMemoryQuota)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -1051,6 +1093,11 @@ func (p *processor) Close() error {
 	p.cancel()
 	p.wg.Wait()
 
+	if p.kvMemoryQuota != nil {
+		p.kvMemoryQuota.Close()
+		p.kvMemoryQuota = nil
+	}
+
 	if p.agent != nil {
 		if err := p.agent.Close(); err != nil {
 			log.Warn("close agent meet error", zap.Error(err))
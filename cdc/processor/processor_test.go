@@ -216,6 +216,10 @@ func (m *mockTablePipeline) MemoryConsumption() uint64 {
 	return 0
 }
 
+func (m *mockTablePipeline) Stats() pipeline.TableStats {
+	return pipeline.TableStats{SorterBacklog: 1}
+}
+
 type mockSchemaStorage struct {
 	// dummy to provide default versions of unimplemented interface methods,
 	// as we only need ResolvedTs() and DoGC() in unit tests.
@@ -72,6 +72,7 @@ func New(ctx context.Context,
 	spans []regionspan.Span,
 	cfg *config.KVClientConfig,
 	changefeed model.ChangeFeedID,
+	memQuota *kv.MemoryQuota,
 ) Puller {
 	tikvStorage, ok := kvStorage.(tikv.Storage)
 	if !ok {
@@ -86,7 +87,7 @@ func New(ctx context.Context,
 	// initialized, the ts should advance to a non-zero value.
 	tsTracker := frontier.NewFrontier(0, comparableSpans...)
 	kvCli := kv.NewCDCKVClient(
-		ctx, pdCli, grpcPool, regionCache, pdClock, changefeed, cfg)
+		ctx, pdCli, grpcPool, regionCache, pdClock, changefeed, cfg, memQuota)
 	p := &pullerImpl{
 		kvCli:        kvCli,
 		kvStorage:    tikvStorage,
@@ -63,6 +63,7 @@ func newMockCDCKVClient(
 	pdClock pdutil.Clock,
 	changefeed model.ChangeFeedID,
 	cfg *config.KVClientConfig,
+	memQuota *kv.MemoryQuota,
 ) kv.CDCKVClient {
 	return &mockCDCKVClient{
 		expectations: make(chan model.RegionFeedEvent, 1024),
@@ -128,7 +129,7 @@ func newPullerForTest(
 	plr := New(
 		ctx, pdCli, grpcPool, regionCache, store, pdutil.NewClock4Test(),
 		checkpointTs, spans, config.GetDefaultServerConfig().KVClient,
-		model.DefaultChangeFeedID("changefeed-id-test"))
+		model.DefaultChangeFeedID("changefeed-id-test"), nil)
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -0,0 +1,288 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// FileHeaderSize is the size of the header of redo log files written by a
+	// Codec. It is a multiple of 8 to keep the records 8 bytes aligned.
+	FileHeaderSize = 16
+	// FileFormatVersion1 is the first version of redo log files with a header.
+	// Redo log files without a header contain plain msgp encoded records.
+	FileFormatVersion1 uint8 = 1
+
+	// lz4BlockMaxSize is the max block size of lz4 frames, redo log records
+	// are usually small, so the default 4MB block size wastes memory.
+	lz4BlockMaxSize = 64 * 1024
+)
+
+// fileHeaderMagic is the magic at the beginning of redo log files with a header.
+// Decoded as the length field of a legacy record, it means a record larger than
+// any redo log file, so it can't be confused with legacy files.
+var fileHeaderMagic = []byte("CDCREDO")
+
+// compression codes stored in the file header.
+var compressionCodes = []string{
+	config.ConsistentCompressionNone,
+	config.ConsistentCompressionLZ4,
+	config.ConsistentCompressionZstd,
+}
+
+// FileHeader is the header of redo log files written by a Codec.
+// layout: magic(7 bytes) | version(1 byte) | compression(1 byte) |
+// encrypted(1 byte) | reserved(6 bytes)
+type FileHeader struct {
+	Version     uint8
+	Compression string
+	Encrypted   bool
+}
+
+// Marshal encodes the header into bytes.
+func (h *FileHeader) Marshal() []byte {
+	buf := make([]byte, FileHeaderSize)
+	copy(buf, fileHeaderMagic)
+	buf[len(fileHeaderMagic)] = h.Version
+	for i, compression := range compressionCodes {
+		if compression == h.Compression {
+			buf[len(fileHeaderMagic)+1] = byte(i)
+		}
+	}
+	if h.Encrypted {
+		buf[len(fileHeaderMagic)+2] = 1
+	}
+	return buf
+}
+
+// ReadFileHeader reads the header of a redo log file. It returns nil if the
+// file is written in the legacy format without a header.
+func ReadFileHeader(br *bufio.Reader) (*FileHeader, error) {
+	magic, err := br.Peek(len(fileHeaderMagic))
+	if err != nil || !bytes.Equal(magic, fileHeaderMagic) {
+		// The file is empty or written without a header.
+		return nil, nil
+	}
+	buf := make([]byte, FileHeaderSize)
+	if _, err := io.ReadFull(br, buf); err != nil {
+		return nil, cerror.WrapError(cerror.ErrRedoFileOp,
+			errors.Annotate(err, "can't read redo log file header"))
+	}
+
+	h := &FileHeader{
+		Version:   buf[len(fileHeaderMagic)],
+		Encrypted: buf[len(fileHeaderMagic)+2] != 0,
+	}
+	if h.Version != FileFormatVersion1 {
+		return nil, cerror.WrapError(cerror.ErrRedoCodecFailed,
+			errors.Errorf("unsupported redo log file version %d", h.Version))
+	}
+	code := int(buf[len(fileHeaderMagic)+1])
+	if code >= len(compressionCodes) {
+		return nil, cerror.WrapError(cerror.ErrRedoCodecFailed,
+			errors.Errorf("unsupported redo log compression code %d", code))
+	}
+	h.Compression = compressionCodes[code]
+	return h, nil
+}
+
+// LoadEncryptionKey loads the hex encoded AES key of redo logs from a local
+// file or an environment variable. It returns nil if neither is specified.
+func LoadEncryptionKey(path, env string) ([]byte, error) {
+	var encoded string
+	switch {
+	case path != "":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrRedoConfigInvalid,
+				errors.Annotatef(err, "can't read redo log encryption key file %s", path))
+		}
+		encoded = string(data)
+	case env != "":
+		var ok bool
+		encoded, ok = os.LookupEnv(env)
+		if !ok {
+			return nil, cerror.WrapError(cerror.ErrRedoConfigInvalid,
+				errors.Errorf("redo log encryption key env %s is not set", env))
+		}
+	default:
+		return nil, nil
+	}
+
+	key, err := hex.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrRedoConfigInvalid,
+			errors.Annotate(err, "redo log encryption key must be hex encoded"))
+	}
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return nil, cerror.WrapError(cerror.ErrRedoConfigInvalid,
+			errors.Errorf("redo log encryption key must be 16, 24 or 32 bytes, got %d", len(key)))
+	}
+	return key, nil
+}
+
+// Codec compresses and encrypts redo log records. Each record is compressed
+// as an independent block, then sealed with AES-GCM and a random nonce.
+// Encode is not thread-safe, Decode is.
+type Codec struct {
+	compression string
+	aead        cipher.AEAD
+
+	lz4Writer   *lz4.Writer
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+}
+
+// NewCodec creates a Codec. It returns nil if neither compression nor
+// encryption is enabled, in that case records are written as is.
+func NewCodec(compression string, key []byte) (*Codec, error) {
+	if compression == "" {
+		compression = config.ConsistentCompressionNone
+	}
+	if compression == config.ConsistentCompressionNone && key == nil {
+		return nil, nil
+	}
+
+	c := &Codec{compression: compression}
+	switch compression {
+	case config.ConsistentCompressionNone:
+	case config.ConsistentCompressionLZ4:
+		c.lz4Writer = lz4.NewWriter(nil)
+		c.lz4Writer.Header.BlockMaxSize = lz4BlockMaxSize
+	case config.ConsistentCompressionZstd:
+		var err error
+		c.zstdEncoder, err = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrRedoCodecFailed, err)
+		}
+		c.zstdDecoder, err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrRedoCodecFailed, err)
+		}
+	default:
+		return nil, cerror.WrapError(cerror.ErrRedoConfigInvalid,
+			errors.Errorf("unsupported redo log compression: %s", compression))
+	}
+
+	if key != nil {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrRedoConfigInvalid, err)
+		}
+		c.aead, err = cipher.NewGCM(block)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrRedoConfigInvalid, err)
+		}
+	}
+	return c, nil
+}
+
+// FileHeader returns the header of redo log files written by the codec.
+func (c *Codec) FileHeader() *FileHeader {
+	return &FileHeader{
+		Version:     FileFormatVersion1,
+		Compression: c.compression,
+		Encrypted:   c.aead != nil,
+	}
+}
+
+// Encode compresses and encrypts a record.
+func (c *Codec) Encode(data []byte) ([]byte, error) {
+	switch c.compression {
+	case config.ConsistentCompressionLZ4:
+		buf := &bytes.Buffer{}
+		c.lz4Writer.Reset(buf)
+		if _, err := c.lz4Writer.Write(data); err != nil {
+			return nil, cerror.WrapError(cerror.ErrRedoCodecFailed, err)
+		}
+		if err := c.lz4Writer.Close(); err != nil {
+			return nil, cerror.WrapError(cerror.ErrRedoCodecFailed, err)
+		}
+		data = buf.Bytes()
+	case config.ConsistentCompressionZstd:
+		data = c.zstdEncoder.EncodeAll(data, nil)
+	}
+
+	if c.aead != nil {
+		nonceSize := c.aead.NonceSize()
+		nonce := make([]byte, nonceSize, nonceSize+len(data)+c.aead.Overhead())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, cerror.WrapError(cerror.ErrRedoCodecFailed, err)
+		}
+		data = c.aead.Seal(nonce, nonce, data, nil)
+	}
+	return data, nil
+}
+
+// Decode decrypts and decompresses a record encoded by Encode.
+func (c *Codec) Decode(data []byte) ([]byte, error) {
+	if c.aead != nil {
+		nonceSize := c.aead.NonceSize()
+		if len(data) < nonceSize {
+			return nil, cerror.WrapError(cerror.ErrRedoCodecFailed,
+				errors.New("encrypted redo log record is too short"))
+		}
+		var err error
+		data, err = c.aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrRedoCodecFailed, err)
+		}
+	}
+
+	switch c.compression {
+	case config.ConsistentCompressionLZ4:
+		decoded, err := io.ReadAll(lz4.NewReader(bytes.NewReader(data)))
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrRedoCodecFailed, err)
+		}
+		data = decoded
+	case config.ConsistentCompressionZstd:
+		decoded, err := c.zstdDecoder.DecodeAll(data, nil)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrRedoCodecFailed, err)
+		}
+		data = decoded
+	}
+	return data, nil
+}
+
+// Close releases the resources of the codec.
+func (c *Codec) Close() {
+	if c == nil {
+		return
+	}
+	if c.zstdEncoder != nil {
+		_ = c.zstdEncoder.Close()
+	}
+	if c.zstdDecoder != nil {
+		c.zstdDecoder.Close()
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodecEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	codec, err := NewCodec("", nil)
+	require.Nil(t, err)
+	require.Nil(t, codec)
+
+	_, err = NewCodec("gzip", nil)
+	require.Regexp(t, ".*unsupported redo log compression.*", err)
+	_, err = NewCodec("lz4", []byte("short"))
+	require.Regexp(t, ".*redo log config invalid.*", err)
+
+	key := bytes.Repeat([]byte{1}, 32)
+	data := bytes.Repeat([]byte("redo log record"), 100)
+	for _, tc := range []struct {
+		compression string
+		key         []byte
+	}{
+		{"lz4", nil},
+		{"zstd", nil},
+		{"none", key},
+		{"lz4", key},
+		{"zstd", key},
+	} {
+		codec, err := NewCodec(tc.compression, tc.key)
+		require.Nil(t, err)
+		encoded, err := codec.Encode(data)
+		require.Nil(t, err)
+		require.NotEqual(t, data, encoded)
+		decoded, err := codec.Decode(encoded)
+		require.Nil(t, err)
+		require.Equal(t, data, decoded)
+
+		header := codec.FileHeader()
+		require.Equal(t, tc.compression, header.Compression)
+		require.Equal(t, tc.key != nil, header.Encrypted)
+		if tc.key != nil {
+			// The record can't be decoded with another key.
+			other, err := NewCodec(tc.compression, bytes.Repeat([]byte{2}, 32))
+			require.Nil(t, err)
+			_, err = other.Decode(encoded)
+			require.Regexp(t, ".*redo log codec failed.*", err)
+			other.Close()
+		}
+		codec.Close()
+	}
+}
+
+func TestReadFileHeader(t *testing.T) {
+	t.Parallel()
+
+	header := &FileHeader{
+		Version:     FileFormatVersion1,
+		Compression: "zstd",
+		Encrypted:   true,
+	}
+	buf := header.Marshal()
+	require.Len(t, buf, FileHeaderSize)
+	br := bufio.NewReader(bytes.NewReader(append(buf, 1, 2, 3)))
+	got, err := ReadFileHeader(br)
+	require.Nil(t, err)
+	require.Equal(t, header, got)
+	// The header is consumed.
+	rest, err := br.Peek(3)
+	require.Nil(t, err)
+	require.Equal(t, []byte{1, 2, 3}, rest)
+
+	// Legacy files and empty files don't have a header.
+	for _, data := range [][]byte{{8, 0, 0, 0, 0, 0, 0, 0}, nil} {
+		br = bufio.NewReader(bytes.NewReader(data))
+		got, err = ReadFileHeader(br)
+		require.Nil(t, err)
+		require.Nil(t, got)
+		_, err = br.Peek(len(data))
+		require.Nil(t, err)
+	}
+
+	buf[len(fileHeaderMagic)] = 2
+	_, err = ReadFileHeader(bufio.NewReader(bytes.NewReader(buf)))
+	require.Regexp(t, ".*unsupported redo log file version 2.*", err)
+}
+
+func TestLoadEncryptionKey(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, 16)
+
+	got, err := LoadEncryptionKey("", "")
+	require.Nil(t, err)
+	require.Nil(t, got)
+
+	path := filepath.Join(t.TempDir(), "redo.key")
+	require.Nil(t, os.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0o600))
+	got, err = LoadEncryptionKey(path, "")
+	require.Nil(t, err)
+	require.Equal(t, key, got)
+
+	t.Setenv("TEST_REDO_ENCRYPTION_KEY", hex.EncodeToString(key[:5]))
+	_, err = LoadEncryptionKey("", "TEST_REDO_ENCRYPTION_KEY")
+	require.Regexp(t, ".*must be 16, 24 or 32 bytes, got 5.*", err)
+	_, err = LoadEncryptionKey("", "TEST_REDO_ENCRYPTION_KEY_NOT_EXIST")
+	require.Regexp(t, ".*is not set.*", err)
+}
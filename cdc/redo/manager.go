@@ -253,6 +253,7 @@ func NewManager(ctx context.Context, cfg *config.ConsistentConfig, opts *Manager
 			MaxLogSize:        cfg.MaxLogSize,
 			FlushIntervalInMs: cfg.FlushIntervalInMs,
 			S3Storage:         m.storageType == consistentStorageS3,
+			Compression:       cfg.Compression,
 
 			EmitMeta:      m.opts.EmitMeta,
 			EmitRowEvents: m.opts.EmitRowEvents,
@@ -261,6 +262,11 @@ func NewManager(ctx context.Context, cfg *config.ConsistentConfig, opts *Manager
 		if writerCfg.S3Storage {
 			writerCfg.S3URI = *uri
 		}
+		writerCfg.EncryptionKey, err = common.LoadEncryptionKey(
+			cfg.EncryptionKeyPath, cfg.EncryptionKeyEnv)
+		if err != nil {
+			return nil, err
+		}
 		writer, err := writer.NewLogWriter(ctx, writerCfg)
 		if err != nil {
 			return nil, err
@@ -63,6 +63,8 @@ type readerConfig struct {
 	s3Storage  bool
 	s3URI      url.URL
 	workerNums int
+	// encryptionKey is used to decrypt the encrypted log files.
+	encryptionKey []byte
 }
 
 type reader struct {
@@ -71,6 +73,8 @@ type reader struct {
 	br       *bufio.Reader
 	fileName string
 	closer   io.Closer
+	// codec decodes the records if the file is written with a file header.
+	codec *common.Codec
 	// lastValidOff file offset following the last valid decoded record
 	lastValidOff int64
 }
@@ -95,25 +99,64 @@ func newReader(ctx context.Context, cfg *readerConfig) ([]fileReader, error) {
 		cfg.workerNums = defaultWorkerNum
 	}
 
-	rr, err := openSelectedFiles(ctx, cfg.dir, cfg.fileType, cfg.startTs, cfg.workerNums)
+	rr, err := openSelectedFiles(ctx, cfg.dir, cfg.fileType, cfg.startTs,
+		cfg.workerNums, cfg.encryptionKey)
 	if err != nil {
 		return nil, err
 	}
 
 	readers := []fileReader{}
 	for i := range rr {
-		readers = append(readers,
-			&reader{
-				cfg:      cfg,
-				br:       bufio.NewReader(rr[i]),
-				fileName: rr[i].(*os.File).Name(),
-				closer:   rr[i],
-			})
+		r, err := newFileReader(rr[i].(*os.File), cfg.encryptionKey)
+		if err != nil {
+			for j := i + 1; j < len(rr); j++ {
+				rr[j].Close()
+			}
+			for _, r := range readers {
+				r.Close()
+			}
+			return nil, err
+		}
+		r.cfg = cfg
+		readers = append(readers, r)
 	}
 
 	return readers, nil
 }
 
+// newFileReader creates a reader of the log file, the file is closed if
+// it fails to read the file header.
+func newFileReader(file *os.File, encryptionKey []byte) (*reader, error) {
+	r := &reader{
+		br:       bufio.NewReader(file),
+		fileName: file.Name(),
+		closer:   file,
+	}
+	header, err := common.ReadFileHeader(r.br)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	// log files without a header are written in the legacy format.
+	if header != nil {
+		if header.Encrypted && encryptionKey == nil {
+			file.Close()
+			return nil, cerror.WrapError(cerror.ErrRedoConfigInvalid,
+				errors.Errorf("redo log file %s is encrypted, but no encryption key is provided", file.Name()))
+		}
+		if !header.Encrypted {
+			encryptionKey = nil
+		}
+		r.codec, err = common.NewCodec(header.Compression, encryptionKey)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		r.lastValidOff = common.FileHeaderSize
+	}
+	return r, nil
+}
+
 func selectDownLoadFile(ctx context.Context, s3storage storage.ExternalStorage, fixedType string) ([]string, error) {
 	files := []string{}
 	err := s3storage.WalkDir(ctx, &storage.WalkOption{}, func(path string, size int64) error {
@@ -163,7 +206,9 @@ func downLoadToLocal(ctx context.Context, dir string, s3storage storage.External
 	return eg.Wait()
 }
 
-func openSelectedFiles(ctx context.Context, dir, fixedType string, startTs uint64, workerNum int) ([]io.ReadCloser, error) {
+func openSelectedFiles(
+	ctx context.Context, dir, fixedType string, startTs uint64, workerNum int, encryptionKey []byte,
+) ([]io.ReadCloser, error) {
 	files, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrRedoFileOp, errors.Annotatef(err, "can't read log file directory: %s", dir))
@@ -211,7 +256,7 @@ func openSelectedFiles(ctx context.Context, dir, fixedType string, startTs uint6
 		}
 	}
 
-	sortFiles, err := createSortedFiles(ctx, dir, unSortedFile, workerNum)
+	sortFiles, err := createSortedFiles(ctx, dir, unSortedFile, workerNum, encryptionKey)
 	if err != nil {
 		return nil, err
 	}
@@ -223,11 +268,10 @@ func openReadFile(name string) (*os.File, error) {
 	return os.OpenFile(name, os.O_RDONLY, common.DefaultFileMode)
 }
 
-func readFile(file *os.File) (logHeap, error) {
-	r := &reader{
-		br:       bufio.NewReader(file),
-		fileName: file.Name(),
-		closer:   file,
+func readFile(file *os.File, encryptionKey []byte) (logHeap, error) {
+	r, err := newFileReader(file, encryptionKey)
+	if err != nil {
+		return nil, err
 	}
 	defer r.Close()
 
@@ -247,11 +291,13 @@ func readFile(file *os.File) (logHeap, error) {
 	return h, nil
 }
 
-// writFile if not safely closed, the sorted file will end up with .sort.tmp as the file name suffix
-func writFile(ctx context.Context, dir, name string, h logHeap) error {
+// writFile if not safely closed, the sorted file will end up with .sort.tmp as the file name suffix,
+// the sorted file is encrypted with the same key as the log file, so decrypted logs never land on disk.
+func writFile(ctx context.Context, dir, name string, h logHeap, encryptionKey []byte) error {
 	cfg := &writer.FileWriterConfig{
-		Dir:        dir,
-		MaxLogSize: math.MaxInt32,
+		Dir:           dir,
+		MaxLogSize:    math.MaxInt32,
+		EncryptionKey: encryptionKey,
 	}
 	w, err := writer.NewWriter(ctx, cfg, writer.WithLogFileName(func() string { return name }))
 	if err != nil {
@@ -273,7 +319,9 @@ func writFile(ctx context.Context, dir, name string, h logHeap) error {
 	return w.Close()
 }
 
-func createSortedFiles(ctx context.Context, dir string, names []string, workerNum int) ([]io.ReadCloser, error) {
+func createSortedFiles(
+	ctx context.Context, dir string, names []string, workerNum int, encryptionKey []byte,
+) ([]io.ReadCloser, error) {
 	logFiles := []io.ReadCloser{}
 	errCh := make(chan error)
 	retCh := make(chan io.ReadCloser)
@@ -292,7 +340,7 @@ func createSortedFiles(ctx context.Context, dir string, names []string, workerNu
 		}
 
 		for i := 0; i < len(nn); i++ {
-			go createSortedFile(ctx, dir, nn[i], errCh, retCh)
+			go createSortedFile(ctx, dir, nn[i], encryptionKey, errCh, retCh)
 		}
 		for i := 0; i < len(nn); i++ {
 			select {
@@ -312,7 +360,10 @@ func createSortedFiles(ctx context.Context, dir string, names []string, workerNu
 	return logFiles, nil
 }
 
-func createSortedFile(ctx context.Context, dir string, name string, errCh chan error, retCh chan io.ReadCloser) {
+func createSortedFile(
+	ctx context.Context, dir string, name string, encryptionKey []byte,
+	errCh chan error, retCh chan io.ReadCloser,
+) {
 	path := filepath.Join(dir, name)
 	file, err := openReadFile(path)
 	if err != nil {
@@ -320,7 +371,7 @@ func createSortedFile(ctx context.Context, dir string, name string, errCh chan e
 		return
 	}
 
-	h, err := readFile(file)
+	h, err := readFile(file, encryptionKey)
 	if err != nil {
 		errCh <- err
 		return
@@ -333,7 +384,7 @@ func createSortedFile(ctx context.Context, dir string, name string, errCh chan e
 	}
 
 	sortFileName := name + common.SortLogEXT
-	err = writFile(ctx, dir, sortFileName, h)
+	err = writFile(ctx, dir, sortFileName, h, encryptionKey)
 	if err != nil {
 		errCh <- err
 		return
@@ -392,7 +443,18 @@ func (r *reader) Read(redoLog *model.RedoLog) error {
 		return cerror.WrapError(cerror.ErrRedoFileOp, err)
 	}
 
-	_, err = redoLog.UnmarshalMsg(data[:recBytes])
+	record := data[:recBytes]
+	if r.codec != nil {
+		record, err = r.codec.Decode(record)
+		if err != nil {
+			if r.isTornEntry(data) {
+				return io.EOF
+			}
+			return err
+		}
+	}
+
+	_, err = redoLog.UnmarshalMsg(record)
 	if err != nil {
 		if r.isTornEntry(data) {
 			// just return io.EOF, since if torn write it is the last redoLog entry
@@ -465,6 +527,7 @@ func (r *reader) Close() error {
 	if r == nil || r.closer == nil {
 		return nil
 	}
+	r.codec.Close()
 
 	return cerror.WrapError(cerror.ErrRedoFileOp, r.closer.Close())
 }
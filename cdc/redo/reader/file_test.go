@@ -93,6 +93,79 @@ func TestReaderRead(t *testing.T) {
 	time.Sleep(1001 * time.Millisecond)
 }
 
+func TestReaderReadWithCodec(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+	writeLogs := func(dir string, compression string, key []byte, commitTs ...uint64) {
+		cfg := &writer.FileWriterConfig{
+			MaxLogSize:    100000,
+			Dir:           dir,
+			ChangeFeedID:  model.DefaultChangeFeedID("test-cf"),
+			CaptureID:     "cp",
+			FileType:      common.DefaultRowLogFileType,
+			Compression:   compression,
+			EncryptionKey: key,
+		}
+		w, err := writer.NewWriter(ctx, cfg)
+		require.Nil(t, err)
+		w.AdvanceTs(commitTs[0])
+		for _, ts := range commitTs {
+			log := &model.RedoLog{
+				RedoRow: &model.RedoRowChangedEvent{Row: &model.RowChangedEvent{CommitTs: ts}},
+			}
+			data, err := log.MarshalMsg(nil)
+			require.Nil(t, err)
+			_, err = w.Write(data)
+			require.Nil(t, err)
+		}
+		require.Nil(t, w.Close())
+	}
+
+	for _, compression := range []string{"none", "lz4", "zstd"} {
+		dir := t.TempDir()
+		// A legacy file and a file with the header can be read together.
+		writeLogs(dir, "", nil, 5, 3)
+		writeLogs(dir, compression, key, 20, 10, 15)
+
+		// The encrypted file can't be read without the key.
+		_, err := newReader(ctx, &readerConfig{
+			dir:      dir,
+			startTs:  1,
+			endTs:    30,
+			fileType: common.DefaultRowLogFileType,
+		})
+		require.Regexp(t, ".*no encryption key is provided.*", err)
+
+		r, err := newReader(ctx, &readerConfig{
+			dir:           dir,
+			startTs:       1,
+			endTs:         30,
+			fileType:      common.DefaultRowLogFileType,
+			encryptionKey: key,
+		})
+		require.Nil(t, err)
+		require.Len(t, r, 2)
+		var commitTs []uint64
+		for _, rr := range r {
+			for {
+				log := &model.RedoLog{}
+				err := rr.Read(log)
+				if err == io.EOF {
+					break
+				}
+				require.Nil(t, err)
+				commitTs = append(commitTs, log.RedoRow.Row.CommitTs)
+			}
+			require.Nil(t, rr.Close())
+		}
+		require.ElementsMatch(t, []uint64{3, 5, 10, 15, 20}, commitTs)
+	}
+}
+
 func TestReaderOpenSelectedFiles(t *testing.T) {
 	dir := t.TempDir()
 
@@ -210,7 +283,7 @@ func TestReaderOpenSelectedFiles(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		ret, err := openSelectedFiles(ctx, tt.args.dir, tt.args.fixedName, tt.args.startTs, 100)
+		ret, err := openSelectedFiles(ctx, tt.args.dir, tt.args.fixedName, tt.args.startTs, 100, nil)
 		if tt.wantErr == "" {
 			require.Nil(t, err, tt.name)
 			require.Equal(t, len(tt.wantRet), len(ret), tt.name)
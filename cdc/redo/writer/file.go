@@ -83,6 +83,10 @@ type FileWriterConfig struct {
 	MaxLogSize int64
 	S3Storage  bool
 	S3URI      url.URL
+	// Compression and EncryptionKey configure the codec of records, a file
+	// header is written at the beginning of each file if any of them is set.
+	Compression   string
+	EncryptionKey []byte
 }
 
 // Option define the writerOptions
@@ -133,6 +137,7 @@ type Writer struct {
 	sync.RWMutex
 	uuidGenerator uuid.Generator
 	allocator     *fsutil.FileAllocator
+	codec         *common.Codec
 
 	metricFsyncDuration    prometheus.Observer
 	metricFlushAllDuration prometheus.Observer
@@ -158,6 +163,11 @@ func NewWriter(ctx context.Context, cfg *FileWriterConfig, opts ...Option) (*Wri
 		}
 	}
 
+	codec, err := common.NewCodec(cfg.Compression, cfg.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
 	op := &writerOptions{}
 	for _, opt := range opts {
 		opt(op)
@@ -168,6 +178,7 @@ func NewWriter(ctx context.Context, cfg *FileWriterConfig, opts ...Option) (*Wri
 		op:        op,
 		uint64buf: make([]byte, 8),
 		storage:   s3storage,
+		codec:     codec,
 
 		metricFsyncDuration: common.RedoFsyncDurationHistogram.
 			WithLabelValues(cfg.ChangeFeedID.Namespace, cfg.ChangeFeedID.ID),
@@ -186,7 +197,7 @@ func NewWriter(ctx context.Context, cfg *FileWriterConfig, opts ...Option) (*Wri
 		return nil, cerror.WrapError(cerror.ErrRedoFileOp, errors.New("invalid redo dir path"))
 	}
 
-	err := os.MkdirAll(cfg.Dir, common.DefaultDirMode)
+	err = os.MkdirAll(cfg.Dir, common.DefaultDirMode)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrRedoFileOp,
 			errors.Annotatef(err, "can't make dir: %s for redo writing", cfg.Dir))
@@ -209,6 +220,13 @@ func (w *Writer) Write(rawData []byte) (int, error) {
 	w.Lock()
 	defer w.Unlock()
 
+	if w.codec != nil {
+		var err error
+		if rawData, err = w.codec.Encode(rawData); err != nil {
+			return 0, err
+		}
+	}
+
 	writeLen := int64(len(rawData))
 	if writeLen > w.cfg.MaxLogSize {
 		return 0, cerror.ErrFileSizeExceed.GenWithStackByArgs(writeLen, w.cfg.MaxLogSize)
@@ -289,6 +307,7 @@ func (w *Writer) Close() error {
 	if !w.IsRunning() {
 		return nil
 	}
+	defer w.codec.Close()
 
 	common.RedoFlushAllDurationHistogram.
 		DeleteLabelValues(w.cfg.ChangeFeedID.Namespace, w.cfg.ChangeFeedID.ID)
@@ -433,6 +452,15 @@ func (w *Writer) openNew() error {
 	if err != nil {
 		return err
 	}
+	if w.codec != nil {
+		// the header tells the reader how to decode the records of the file.
+		n, err := w.bw.Write(w.codec.FileHeader().Marshal())
+		if err != nil {
+			return cerror.WrapError(cerror.ErrRedoFileOp, err)
+		}
+		w.metricWriteBytes.Add(float64(n))
+		w.size += int64(n)
+	}
 	return nil
 }
 
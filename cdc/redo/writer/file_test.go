@@ -14,8 +14,11 @@
 package writer
 
 import (
+	"bufio"
 	"context"
+	"encoding/binary"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -476,3 +479,62 @@ func TestRotateFileWithoutFileAllocator(t *testing.T) {
 
 	w.Close()
 }
+
+func TestWriterWriteWithCodec(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	key := []byte("0123456789abcdef")
+	w, err := NewWriter(ctx, &FileWriterConfig{
+		Dir:           dir,
+		CaptureID:     "cp",
+		ChangeFeedID:  model.DefaultChangeFeedID("test-codec"),
+		FileType:      common.DefaultRowLogFileType,
+		Compression:   "zstd",
+		EncryptionKey: key,
+	})
+	require.Nil(t, err)
+
+	// Each rotated file starts with the file header.
+	w.AdvanceTs(10)
+	_, err = w.Write([]byte("record1"))
+	require.Nil(t, err)
+	require.Nil(t, w.rotate())
+	w.AdvanceTs(20)
+	_, err = w.Write([]byte("record2"))
+	require.Nil(t, err)
+	require.Nil(t, w.Close())
+
+	codec, err := common.NewCodec("zstd", key)
+	require.Nil(t, err)
+	defer codec.Close()
+	files, err := os.ReadDir(dir)
+	require.Nil(t, err)
+	require.Len(t, files, 2)
+	var records []string
+	for _, file := range files {
+		f, err := os.Open(filepath.Join(dir, file.Name()))
+		require.Nil(t, err)
+		br := bufio.NewReader(f)
+		header, err := common.ReadFileHeader(br)
+		require.Nil(t, err)
+		require.Equal(t, codec.FileHeader(), header)
+
+		lenField := make([]byte, 8)
+		_, err = io.ReadFull(br, lenField)
+		require.Nil(t, err)
+		// the record size is stored in the lower 56 bits of the length field.
+		size := binary.LittleEndian.Uint64(lenField) & ^(uint64(0xff) << 56)
+		data := make([]byte, size)
+		_, err = io.ReadFull(br, data)
+		require.Nil(t, err)
+		record, err := codec.Decode(data)
+		require.Nil(t, err)
+		records = append(records, string(record))
+		require.Nil(t, f.Close())
+	}
+	require.ElementsMatch(t, []string{"record1", "record2"}, records)
+}
@@ -82,6 +82,9 @@ type LogWriterConfig struct {
 	S3Storage         bool
 	// S3URI should be like S3URI="s3://logbucket/test-changefeed?endpoint=http://$S3_ENDPOINT/"
 	S3URI url.URL
+	// Compression and EncryptionKey configure the codec of redo log records.
+	Compression   string
+	EncryptionKey []byte
 
 	EmitMeta      bool
 	EmitRowEvents bool
@@ -120,6 +123,9 @@ func NewLogWriter(
 			MaxLogSize:   cfg.MaxLogSize,
 			S3Storage:    cfg.S3Storage,
 			S3URI:        cfg.S3URI,
+
+			Compression:   cfg.Compression,
+			EncryptionKey: cfg.EncryptionKey,
 		}
 		if logWriter.rowWriter, err = NewWriter(ctx, writerCfg, opts...); err != nil {
 			return
@@ -136,6 +142,9 @@ func NewLogWriter(
 			MaxLogSize:   cfg.MaxLogSize,
 			S3Storage:    cfg.S3Storage,
 			S3URI:        cfg.S3URI,
+
+			Compression:   cfg.Compression,
+			EncryptionKey: cfg.EncryptionKey,
 		}
 		if logWriter.ddlWriter, err = NewWriter(ctx, writerCfg, opts...); err != nil {
 			return
@@ -25,6 +25,10 @@ const (
 	CheckpointCannotProceed = model.Ts(0)
 )
 
+// TableNameGetter returns the name of a table by its physical table ID.
+// The second returned value is false if the table is not found.
+type TableNameGetter func(tableID model.TableID) (model.TableName, bool)
+
 // Scheduler is an interface for scheduling tables.
 // Since in our design, we do not record checkpoints per table,
 // how we calculate the global watermarks (checkpoint-ts and resolved-ts)
@@ -81,6 +81,10 @@ func (t *table) getTableStatus() schedulepb.TableStatus {
 			CheckpointTs: meta.CheckpointTs,
 			ResolvedTs:   meta.ResolvedTs,
 		},
+		Stats: schedulepb.Stats{
+			EventRate:     uint64(meta.Stats.SinkRowsPerSecond),
+			SorterBacklog: meta.Stats.SorterBacklog,
+		},
 	}
 }
 
@@ -68,13 +68,19 @@ func NewCoordinator(
 	messageRouter p2p.MessageRouter,
 	ownerRevision int64,
 	cfg *config.SchedulerConfig,
+	replicaConfig *config.ReplicaConfig,
+	tableNames internal.TableNameGetter,
 ) (internal.Scheduler, error) {
+	placement, err := scheduler.NewPlacement(replicaConfig, tableNames)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	trans, err := transport.NewTransport(
 		ctx, changefeedID, transport.SchedulerRole, messageServer, messageRouter)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	coord := newCoordinator(captureID, changefeedID, ownerRevision, cfg)
+	coord := newCoordinator(captureID, changefeedID, ownerRevision, cfg, placement)
 	coord.trans = trans
 	return coord, nil
 }
@@ -84,6 +90,7 @@ func newCoordinator(
 	changefeedID model.ChangeFeedID,
 	ownerRevision int64,
 	cfg *config.SchedulerConfig,
+	placement *scheduler.Placement,
 ) *coordinator {
 	revision := schedulepb.OwnerRevision{Revision: ownerRevision}
 
@@ -95,7 +102,7 @@ func newCoordinator(
 			cfg.MaxTaskConcurrency, changefeedID),
 		captureM: member.NewCaptureManager(
 			captureID, changefeedID, revision, cfg.HeartbeatTick),
-		schedulerM:   scheduler.NewSchedulerManager(changefeedID, cfg),
+		schedulerM:   scheduler.NewSchedulerManager(changefeedID, cfg, placement),
 		changefeedID: changefeedID,
 	}
 }
@@ -24,7 +24,9 @@ import (
 	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
 	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
 	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/schedulepb"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/scheduler"
 	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/transport"
+	"github.com/pingcap/tiflow/pkg/config"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -183,3 +185,109 @@ func BenchmarkCoordinatorHeartbeatResponse(b *testing.B) {
 		return name, coord, currentTables, captures
 	})
 }
+
+// BenchmarkCoordinatorBalanceByTraffic balances a synthetic skewed workload,
+// where all the hot tables are replicated by the first capture. It reports
+// the ratio of the cost of the hottest capture to the average cost after
+// a round of balancing.
+func BenchmarkCoordinatorBalanceByTraffic(b *testing.B) {
+	log.SetLevel(zapcore.DPanicLevel)
+	ctx := context.Background()
+	const captureCount = 8
+	size := 131072 // 2^17
+	for total := captureCount; total <= size; total *= 2 {
+		b.Run(fmt.Sprintf("BalanceByTraffic %d", total), func(b *testing.B) {
+			imbalance := float64(0)
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				coord, currentTables, captures := newSkewedTrafficCoordinator(
+					b, total, captureCount)
+				b.StartTimer()
+				if _, _, err := coord.poll(ctx, 0, currentTables, captures); err != nil {
+					b.Fatal(err)
+				}
+				b.StopTimer()
+				imbalance = trafficImbalance(coord, captureCount)
+			}
+			b.ReportMetric(imbalance, "imbalance")
+		})
+	}
+}
+
+func newSkewedTrafficCoordinator(
+	b *testing.B, total int, captureCount int,
+) (*coordinator, []model.TableID, map[model.CaptureID]*model.CaptureInfo) {
+	cfg := &config.SchedulerConfig{
+		HeartbeatTick: math.MaxInt,
+		// Allow moving all tables in a round.
+		MaxTaskConcurrency:        total,
+		AddTableBatchSize:         50,
+		BalanceByTraffic:          true,
+		TrafficImbalanceThreshold: 0.2,
+	}
+	captures := map[model.CaptureID]*model.CaptureInfo{}
+	// Disable heartbeat.
+	captureM := member.NewCaptureManager(
+		"", model.ChangeFeedID{}, schedulepb.OwnerRevision{}, cfg.HeartbeatTick)
+	captureM.SetInitializedForTests(true)
+	for i := 0; i < captureCount; i++ {
+		captures[fmt.Sprint(i)] = &model.CaptureInfo{}
+		captureM.Captures[fmt.Sprint(i)] = &member.CaptureStatus{
+			State: member.CaptureStateInitialized,
+		}
+	}
+	replicationM := replication.NewReplicationManager(
+		cfg.MaxTaskConcurrency, model.ChangeFeedID{})
+	currentTables := make([]model.TableID, 0, total)
+	for i := 0; i < total; i++ {
+		tableID := int64(10000 + i)
+		currentTables = append(currentTables, tableID)
+		captureID := fmt.Sprint(i % captureCount)
+		stats := schedulepb.Stats{EventRate: 10}
+		if captureID == "0" {
+			stats.EventRate = 1000
+		}
+		rep, err := replication.NewReplicationSet(
+			tableID, 0, map[string]*schedulepb.TableStatus{
+				captureID: {
+					TableID: tableID,
+					State:   schedulepb.TableStateReplicating,
+					Stats:   stats,
+				},
+			}, model.ChangeFeedID{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		replicationM.SetReplicationSetForTests(rep)
+	}
+	coord := &coordinator{
+		trans:        transport.NewMockTrans(),
+		replicationM: replicationM,
+		captureM:     captureM,
+		schedulerM:   scheduler.NewSchedulerManager(model.ChangeFeedID{}, cfg, nil),
+	}
+	return coord, currentTables, captures
+}
+
+// trafficImbalance returns the ratio of the cost of the hottest capture to
+// the average cost, the moving tables are counted on their destinations.
+func trafficImbalance(coord *coordinator, captureCount int) float64 {
+	costs := make(map[model.CaptureID]float64, captureCount)
+	total := float64(0)
+	for _, rep := range coord.replicationM.ReplicationSets() {
+		captureID := rep.Primary
+		for id, role := range rep.Captures {
+			if role == replication.RoleSecondary {
+				captureID = id
+			}
+		}
+		cost := float64(1 + rep.Stats.EventRate)
+		costs[captureID] += cost
+		total += cost
+	}
+	hottest := float64(0)
+	for _, cost := range costs {
+		hottest = math.Max(hottest, cost)
+	}
+	return hottest / (total / float64(captureCount))
+}
@@ -121,7 +121,7 @@ func TestCoordinatorHeartbeat(t *testing.T) {
 		HeartbeatTick:      math.MaxInt,
 		MaxTaskConcurrency: 1,
 		AddTableBatchSize:  50,
-	})
+	}, nil)
 	trans := transport.NewMockTrans()
 	coord.trans = trans
 
@@ -178,7 +178,7 @@ func TestCoordinatorAddCapture(t *testing.T) {
 	coord := newCoordinator("a", model.ChangeFeedID{}, 1, &config.SchedulerConfig{
 		HeartbeatTick:      math.MaxInt,
 		MaxTaskConcurrency: 1,
-	})
+	}, nil)
 	trans := transport.NewMockTrans()
 	coord.trans = trans
 
@@ -235,7 +235,7 @@ func TestCoordinatorRemoveCapture(t *testing.T) {
 		HeartbeatTick:      math.MaxInt,
 		MaxTaskConcurrency: 1,
 		AddTableBatchSize:  50,
-	})
+	}, nil)
 	trans := transport.NewMockTrans()
 	coord.trans = trans
 
@@ -313,7 +313,7 @@ func TestCoordinatorDrainCapture(t *testing.T) {
 	require.Equal(t, 1, count)
 
 	coord.schedulerM = scheduler.NewSchedulerManager(
-		model.ChangeFeedID{}, config.NewDefaultSchedulerConfig())
+		model.ChangeFeedID{}, config.NewDefaultSchedulerConfig(), nil)
 	count, err = coord.DrainCapture("b")
 	require.NoError(t, err)
 	require.Equal(t, 1, count)
@@ -325,7 +325,7 @@ func TestCoordinatorAdvanceCheckpoint(t *testing.T) {
 	coord := newCoordinator("a", model.ChangeFeedID{}, 1, &config.SchedulerConfig{
 		HeartbeatTick:      math.MaxInt,
 		MaxTaskConcurrency: 1,
-	})
+	}, nil)
 	trans := transport.NewMockTrans()
 	coord.trans = trans
 
@@ -31,7 +31,7 @@ func TestInfoProvider(t *testing.T) {
 	coord := newCoordinator("a", model.ChangeFeedID{}, 1, &config.SchedulerConfig{
 		HeartbeatTick:      math.MaxInt,
 		MaxTaskConcurrency: 1,
-	})
+	}, nil)
 	coord.captureM.Captures = map[model.CaptureID]*member.CaptureStatus{
 		"a": {Tables: []schedulepb.TableStatus{{
 			TableID:    1,
@@ -67,7 +67,7 @@ func TestInfoProviderIsInitialized(t *testing.T) {
 	coord := newCoordinator("a", model.ChangeFeedID{}, 1, &config.SchedulerConfig{
 		HeartbeatTick:      math.MaxInt,
 		MaxTaskConcurrency: 1,
-	})
+	}, nil)
 	var ip internal.InfoProvider = coord
 
 	// Has not initialized yet.
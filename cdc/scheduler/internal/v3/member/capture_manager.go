@@ -61,6 +61,8 @@ type CaptureStatus struct {
 	ID       model.CaptureID
 	Addr     string
 	IsOwner  bool
+	// Labels of the capture, see config.ServerConfig.Labels.
+	Labels map[string]string
 }
 
 func newCaptureStatus(
@@ -220,8 +222,10 @@ func (c *CaptureManager) HandleAliveCaptureUpdate(
 	for id, info := range aliveCaptures {
 		if _, ok := c.Captures[id]; !ok {
 			// A new capture.
-			c.Captures[id] = newCaptureStatus(
+			status := newCaptureStatus(
 				c.OwnerRev, id, info.AdvertiseAddr, c.ownerID == id)
+			status.Labels = info.Labels
+			c.Captures[id] = status
 			log.Info("schedulerv3: find a new capture",
 				zap.String("captureAddr", info.AdvertiseAddr),
 				zap.String("capture", id))
@@ -135,6 +135,8 @@ type ReplicationSet struct { //nolint:revive
 	//     CaptureRolePrimary.
 	Captures   map[model.CaptureID]Role
 	Checkpoint schedulepb.Checkpoint
+	// Stats is the latest statistics of the table reported by the primary.
+	Stats schedulepb.Stats
 }
 
 // NewReplicationSet returns a new replication set.
@@ -180,6 +182,7 @@ func NewReplicationSet(
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
+			r.Stats = table.Stats
 		case schedulepb.TableStatePreparing:
 			// Recognize secondary if it's table is in preparing state.
 			err := r.setCapture(captureID, RoleSecondary)
@@ -702,6 +705,7 @@ func (r *ReplicationSet) pollOnReplicating(
 	case schedulepb.TableStateReplicating:
 		if r.Primary == captureID {
 			r.updateCheckpoint(input.Checkpoint)
+			r.Stats = input.Stats
 			return nil, false, nil
 		}
 		return nil, false, r.multiplePrimaryError(
@@ -1181,6 +1181,41 @@ func TestReplicationSetMarshalJSON(t *testing.T) {
 	require.Contains(t, string(b), "Replicating", string(b))
 }
 
+func TestReplicationSetUpdateStats(t *testing.T) {
+	t.Parallel()
+
+	tableID := model.TableID(1)
+	stats := schedulepb.Stats{EventRate: 100, SorterBacklog: 10}
+	r, err := NewReplicationSet(tableID, 0, map[model.CaptureID]*schedulepb.TableStatus{
+		"1": {
+			TableID: tableID,
+			State:   schedulepb.TableStateReplicating,
+			Stats:   stats,
+		},
+	}, model.ChangeFeedID{})
+	require.Nil(t, err)
+	require.Equal(t, stats, r.Stats)
+
+	// Only the stats reported by the primary are recorded.
+	stats = schedulepb.Stats{EventRate: 200, SorterBacklog: 20}
+	msgs, err := r.handleTableStatus("1", &schedulepb.TableStatus{
+		TableID: tableID,
+		State:   schedulepb.TableStateReplicating,
+		Stats:   stats,
+	})
+	require.Nil(t, err)
+	require.Len(t, msgs, 0)
+	require.Equal(t, stats, r.Stats)
+
+	_, err = r.handleTableStatus("2", &schedulepb.TableStatus{
+		TableID: tableID,
+		State:   schedulepb.TableStateAbsent,
+		Stats:   schedulepb.Stats{EventRate: 300},
+	})
+	require.Nil(t, err)
+	require.Equal(t, stats, r.Stats)
+}
+
 func TestReplicationSetMoveTableSameDestCapture(t *testing.T) {
 	t.Parallel()
 
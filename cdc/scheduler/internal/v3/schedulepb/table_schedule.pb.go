@@ -589,17 +589,70 @@ func (m *Heartbeat) GetIsStopping() bool {
 	return false
 }
 
+type Stats struct {
+	EventRate     uint64 `protobuf:"varint,1,opt,name=event_rate,json=eventRate,proto3" json:"event_rate,omitempty"`
+	SorterBacklog uint64 `protobuf:"varint,2,opt,name=sorter_backlog,json=sorterBacklog,proto3" json:"sorter_backlog,omitempty"`
+}
+
+func (m *Stats) Reset()         { *m = Stats{} }
+func (m *Stats) String() string { return proto.CompactTextString(m) }
+func (*Stats) ProtoMessage()    {}
+func (*Stats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ab4bb9c6b16cfa4d, []int{8}
+}
+func (m *Stats) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Stats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Stats.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Stats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Stats.Merge(m, src)
+}
+func (m *Stats) XXX_Size() int {
+	return m.Size()
+}
+func (m *Stats) XXX_DiscardUnknown() {
+	xxx_messageInfo_Stats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Stats proto.InternalMessageInfo
+
+func (m *Stats) GetEventRate() uint64 {
+	if m != nil {
+		return m.EventRate
+	}
+	return 0
+}
+
+func (m *Stats) GetSorterBacklog() uint64 {
+	if m != nil {
+		return m.SorterBacklog
+	}
+	return 0
+}
+
 type TableStatus struct {
 	TableID    github_com_pingcap_tiflow_cdc_model.TableID `protobuf:"varint,1,opt,name=table_id,json=tableId,proto3,casttype=github.com/pingcap/tiflow/cdc/model.TableID" json:"table_id,omitempty"`
 	State      TableState                                  `protobuf:"varint,2,opt,name=state,proto3,enum=pingcap.tiflow.cdc.schedulepb.TableState" json:"state,omitempty"`
 	Checkpoint Checkpoint                                  `protobuf:"bytes,3,opt,name=checkpoint,proto3" json:"checkpoint"`
+	Stats      Stats                                       `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats"`
 }
 
 func (m *TableStatus) Reset()         { *m = TableStatus{} }
 func (m *TableStatus) String() string { return proto.CompactTextString(m) }
 func (*TableStatus) ProtoMessage()    {}
 func (*TableStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab4bb9c6b16cfa4d, []int{8}
+	return fileDescriptor_ab4bb9c6b16cfa4d, []int{9}
 }
 func (m *TableStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -649,6 +702,13 @@ func (m *TableStatus) GetCheckpoint() Checkpoint {
 	return Checkpoint{}
 }
 
+func (m *TableStatus) GetStats() Stats {
+	if m != nil {
+		return m.Stats
+	}
+	return Stats{}
+}
+
 type HeartbeatResponse struct {
 	Tables   []TableStatus                                `protobuf:"bytes,1,rep,name=tables,proto3" json:"tables"`
 	Liveness github_com_pingcap_tiflow_cdc_model.Liveness `protobuf:"varint,2,opt,name=liveness,proto3,casttype=github.com/pingcap/tiflow/cdc/model.Liveness" json:"liveness,omitempty"`
@@ -658,7 +718,7 @@ func (m *HeartbeatResponse) Reset()         { *m = HeartbeatResponse{} }
 func (m *HeartbeatResponse) String() string { return proto.CompactTextString(m) }
 func (*HeartbeatResponse) ProtoMessage()    {}
 func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab4bb9c6b16cfa4d, []int{9}
+	return fileDescriptor_ab4bb9c6b16cfa4d, []int{10}
 }
 func (m *HeartbeatResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -709,7 +769,7 @@ func (m *OwnerRevision) Reset()         { *m = OwnerRevision{} }
 func (m *OwnerRevision) String() string { return proto.CompactTextString(m) }
 func (*OwnerRevision) ProtoMessage()    {}
 func (*OwnerRevision) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab4bb9c6b16cfa4d, []int{10}
+	return fileDescriptor_ab4bb9c6b16cfa4d, []int{11}
 }
 func (m *OwnerRevision) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -753,7 +813,7 @@ func (m *ProcessorEpoch) Reset()         { *m = ProcessorEpoch{} }
 func (m *ProcessorEpoch) String() string { return proto.CompactTextString(m) }
 func (*ProcessorEpoch) ProtoMessage()    {}
 func (*ProcessorEpoch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab4bb9c6b16cfa4d, []int{11}
+	return fileDescriptor_ab4bb9c6b16cfa4d, []int{12}
 }
 func (m *ProcessorEpoch) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -804,7 +864,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab4bb9c6b16cfa4d, []int{12}
+	return fileDescriptor_ab4bb9c6b16cfa4d, []int{13}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -900,7 +960,7 @@ func (m *Message_Header) Reset()         { *m = Message_Header{} }
 func (m *Message_Header) String() string { return proto.CompactTextString(m) }
 func (*Message_Header) ProtoMessage()    {}
 func (*Message_Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab4bb9c6b16cfa4d, []int{12, 0}
+	return fileDescriptor_ab4bb9c6b16cfa4d, []int{13, 0}
 }
 func (m *Message_Header) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -961,6 +1021,7 @@ func init() {
 	proto.RegisterType((*RemoveTableResponse)(nil), "pingcap.tiflow.cdc.schedulepb.RemoveTableResponse")
 	proto.RegisterType((*DispatchTableResponse)(nil), "pingcap.tiflow.cdc.schedulepb.DispatchTableResponse")
 	proto.RegisterType((*Heartbeat)(nil), "pingcap.tiflow.cdc.schedulepb.Heartbeat")
+	proto.RegisterType((*Stats)(nil), "pingcap.tiflow.cdc.schedulepb.Stats")
 	proto.RegisterType((*TableStatus)(nil), "pingcap.tiflow.cdc.schedulepb.TableStatus")
 	proto.RegisterType((*HeartbeatResponse)(nil), "pingcap.tiflow.cdc.schedulepb.HeartbeatResponse")
 	proto.RegisterType((*OwnerRevision)(nil), "pingcap.tiflow.cdc.schedulepb.OwnerRevision")
@@ -972,79 +1033,80 @@ func init() {
 func init() { proto.RegisterFile("table_schedule.proto", fileDescriptor_ab4bb9c6b16cfa4d) }
 
 var fileDescriptor_ab4bb9c6b16cfa4d = []byte{
-	// 1138 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x57, 0x4b, 0x6f, 0xdb, 0xc6,
-	0x13, 0x17, 0x25, 0x59, 0x8f, 0x91, 0xe3, 0x30, 0x1b, 0x39, 0xd6, 0x9f, 0xff, 0x56, 0x62, 0x89,
-	0xc2, 0x70, 0x95, 0x44, 0x4a, 0x9c, 0x1e, 0x8a, 0xf4, 0x50, 0x58, 0x89, 0x0b, 0x07, 0x8d, 0xeb,
-	0x80, 0x76, 0xfa, 0x42, 0x01, 0x81, 0x22, 0x37, 0x12, 0x61, 0x89, 0xcb, 0x72, 0x69, 0x1b, 0x3e,
-	0xf6, 0xd2, 0x83, 0x4e, 0x3d, 0xf5, 0xa6, 0x73, 0x81, 0xf4, 0x8b, 0xf8, 0xd0, 0x83, 0x8f, 0x05,
-	0x5a, 0x08, 0xad, 0xfd, 0x1d, 0x7a, 0x70, 0x2f, 0x05, 0x77, 0x97, 0xa4, 0x24, 0x2b, 0x95, 0xdc,
-	0x17, 0x7a, 0xdb, 0xdd, 0x99, 0xf9, 0xcd, 0xeb, 0x37, 0x43, 0x10, 0x8a, 0xbe, 0xd1, 0xea, 0xe2,
-	0x26, 0x35, 0x3b, 0xd8, 0x3a, 0xe8, 0xe2, 0x9a, 0xeb, 0x11, 0x9f, 0xa0, 0xd7, 0x5d, 0xdb, 0x69,
-	0x9b, 0x86, 0x5b, 0xf3, 0xed, 0x17, 0x5d, 0x72, 0x54, 0x33, 0x2d, 0xb3, 0x16, 0xaa, 0xb8, 0x2d,
-	0xa5, 0xd8, 0x26, 0x6d, 0xc2, 0x34, 0xeb, 0xc1, 0x89, 0x1b, 0x69, 0x2f, 0x25, 0x80, 0x47, 0x1d,
-	0x6c, 0xee, 0xbb, 0xc4, 0x76, 0x7c, 0xb4, 0x03, 0xd7, 0xcc, 0xe8, 0xd6, 0xf4, 0x69, 0x49, 0x52,
-	0xa5, 0xb5, 0x74, 0xa3, 0x7a, 0x31, 0xac, 0xac, 0xb6, 0x6d, 0xbf, 0x73, 0xd0, 0xaa, 0x99, 0xa4,
-	0x57, 0x17, 0x9e, 0xea, 0xdc, 0x53, 0xdd, 0xb4, 0xcc, 0x7a, 0x8f, 0x58, 0xb8, 0x5b, 0xdb, 0xa3,
-	0xfa, 0x62, 0x0c, 0xb0, 0x47, 0xd1, 0x07, 0x50, 0xf0, 0x30, 0x25, 0xdd, 0x43, 0x6c, 0x05, 0x70,
-	0xc9, 0x2b, 0xc3, 0x41, 0x68, 0xbe, 0x47, 0xb5, 0x9f, 0x24, 0xb8, 0xbe, 0x61, 0x59, 0x7b, 0x41,
-	0xf6, 0x3a, 0xfe, 0xe2, 0x00, 0x53, 0x1f, 0x3d, 0x87, 0x1c, 0xaf, 0x86, 0x6d, 0xb1, 0x60, 0x53,
-	0x8d, 0x87, 0x67, 0xc3, 0x4a, 0x96, 0xe9, 0x3c, 0x79, 0x7c, 0x31, 0xac, 0xdc, 0x9e, 0xcb, 0x11,
-	0x57, 0xd7, 0xb3, 0x0c, 0xeb, 0x89, 0x85, 0xde, 0x80, 0x45, 0x9b, 0x36, 0x29, 0x36, 0x89, 0x63,
-	0x19, 0xde, 0x31, 0x0b, 0x3c, 0xa7, 0x17, 0x6c, 0xba, 0x1b, 0x3e, 0xa1, 0x1d, 0x80, 0x38, 0xd5,
-	0x52, 0x4a, 0x95, 0xd6, 0x0a, 0xeb, 0x6f, 0xd5, 0xfe, 0xb0, 0x09, 0xb5, 0xb8, 0xd4, 0x8d, 0xf4,
-	0xc9, 0xb0, 0x92, 0xd0, 0x47, 0x20, 0xb4, 0x7d, 0x40, 0x3a, 0xee, 0x91, 0x43, 0xfc, 0x2f, 0x24,
-	0xa8, 0x9d, 0x48, 0x50, 0x7c, 0x6c, 0x53, 0xd7, 0xf0, 0xcd, 0xce, 0x98, 0xbf, 0x6d, 0xc8, 0x1b,
-	0x96, 0xd5, 0x64, 0x7a, 0xcc, 0x61, 0x61, 0xbd, 0x36, 0x23, 0xab, 0x89, 0x9e, 0x6c, 0x25, 0xf4,
-	0x9c, 0x21, 0x9e, 0xd0, 0x47, 0xb0, 0xe8, 0xb1, 0xa4, 0x04, 0x62, 0x92, 0x21, 0xde, 0x9f, 0x81,
-	0x78, 0xb9, 0x0e, 0x5b, 0x09, 0xbd, 0xe0, 0xc5, 0xaf, 0x8d, 0x3c, 0x64, 0x3d, 0x2e, 0xd1, 0xbe,
-	0x95, 0x40, 0x8e, 0x43, 0xa0, 0x2e, 0x71, 0x28, 0x46, 0x0d, 0xc8, 0x50, 0xdf, 0xf0, 0x0f, 0xa8,
-	0xc8, 0xa1, 0x3a, 0xc3, 0x23, 0xb3, 0xde, 0x65, 0x16, 0xba, 0xb0, 0x9c, 0xe8, 0x70, 0xf2, 0xaf,
-	0x77, 0xf8, 0xa5, 0x04, 0x37, 0xc7, 0x52, 0xfb, 0x2f, 0x07, 0xfb, 0xbd, 0x04, 0xcb, 0x13, 0x0c,
-	0x11, 0xe1, 0x7e, 0x78, 0x99, 0x22, 0xf5, 0xb9, 0x29, 0xc2, 0x31, 0xc6, 0x38, 0xf2, 0xf1, 0x54,
-	0x8e, 0xac, 0x5f, 0x85, 0x23, 0x11, 0xea, 0x18, 0x49, 0x00, 0x72, 0x9e, 0x10, 0x69, 0x5f, 0x49,
-	0x90, 0xdf, 0xc2, 0x86, 0xe7, 0xb7, 0xb0, 0xe1, 0xa3, 0x4f, 0x20, 0x1f, 0x4e, 0x55, 0x50, 0xf4,
-	0xd4, 0x5a, 0xaa, 0xf1, 0xee, 0xd9, 0xb0, 0x92, 0x13, 0x73, 0x42, 0xaf, 0x3a, 0x57, 0x39, 0x31,
-	0x57, 0x14, 0x55, 0xa0, 0x10, 0x6c, 0x0e, 0x9f, 0xb8, 0x81, 0x91, 0x58, 0x1c, 0x60, 0xd3, 0x5d,
-	0xf1, 0xa2, 0xfd, 0x2a, 0x41, 0x61, 0xa4, 0x81, 0xff, 0xd4, 0x06, 0x7b, 0x0f, 0x16, 0x02, 0x66,
-	0xf0, 0x6a, 0x2e, 0xcd, 0xa4, 0x42, 0x14, 0x11, 0xd6, 0xb9, 0xdd, 0xdf, 0xbf, 0xdf, 0xbe, 0x93,
-	0xe0, 0x46, 0xd4, 0x81, 0x88, 0x4c, 0x5b, 0x90, 0x61, 0x21, 0xf3, 0x36, 0x5c, 0x89, 0xfb, 0xc2,
-	0x87, 0xb0, 0x47, 0x4f, 0x21, 0xd7, 0xb5, 0x0f, 0xb1, 0x83, 0x29, 0xff, 0xd0, 0x2c, 0x34, 0xee,
-	0x5d, 0x0c, 0x2b, 0x77, 0xe6, 0xa9, 0xde, 0x53, 0x61, 0xa7, 0x47, 0x08, 0xda, 0x6d, 0xb8, 0xb6,
-	0x73, 0xe4, 0x60, 0x4f, 0xc7, 0x87, 0x36, 0xb5, 0x89, 0x83, 0x94, 0x80, 0x4c, 0xfc, 0xcc, 0xfb,
-	0xa4, 0x47, 0x77, 0x6d, 0x15, 0x96, 0x9e, 0x79, 0xc4, 0xc4, 0x94, 0x12, 0x6f, 0xd3, 0x25, 0x66,
-	0x07, 0x15, 0x61, 0x01, 0x07, 0x07, 0xa6, 0x9a, 0xd7, 0xf9, 0x45, 0xfb, 0x32, 0x0b, 0xd9, 0x6d,
-	0x4c, 0xa9, 0xd1, 0xc6, 0x68, 0x13, 0x32, 0x1d, 0x6c, 0x58, 0xd8, 0x13, 0x23, 0x74, 0x77, 0x46,
-	0xe2, 0xc2, 0xae, 0xb6, 0xc5, 0x8c, 0x74, 0x61, 0x8c, 0x36, 0x21, 0xd7, 0xa3, 0xed, 0xa6, 0x7f,
-	0xec, 0x86, 0xad, 0xae, 0xce, 0x07, 0xb4, 0x77, 0xec, 0x62, 0x3d, 0xdb, 0xa3, 0xed, 0xe0, 0x80,
-	0x36, 0x21, 0xfd, 0xc2, 0x23, 0x3d, 0xd6, 0xe7, 0x7c, 0xe3, 0xfe, 0xc5, 0xb0, 0x72, 0x77, 0x9e,
-	0xc2, 0x3d, 0x32, 0x5c, 0xff, 0xc0, 0x0b, 0x88, 0xc7, 0xcc, 0xd1, 0x06, 0x24, 0x7d, 0x52, 0x4a,
-	0xff, 0x59, 0x90, 0xa4, 0x4f, 0x90, 0x0d, 0xb7, 0x2c, 0xb1, 0x76, 0xf8, 0x3e, 0x68, 0x8a, 0x45,
-	0x5f, 0x5a, 0x60, 0x75, 0x7a, 0x30, 0x23, 0xbd, 0x69, 0x5f, 0x35, 0xbd, 0x68, 0x4d, 0xfb, 0xd6,
-	0x75, 0x61, 0xe5, 0x92, 0x2b, 0x4e, 0xcb, 0x52, 0x86, 0xf9, 0x7a, 0xfb, 0x6a, 0xbe, 0xb8, 0xad,
-	0xbe, 0x6c, 0x4d, 0x5d, 0x9b, 0xef, 0x43, 0xbe, 0x13, 0xd2, 0xbf, 0x94, 0x65, 0xf8, 0x6b, 0x33,
-	0xf0, 0xe3, 0x71, 0x89, 0x4d, 0x51, 0x13, 0x50, 0x74, 0x89, 0x03, 0xce, 0x31, 0xc0, 0x7b, 0x73,
-	0x03, 0x86, 0xc1, 0xde, 0xe8, 0x4c, 0x3e, 0x29, 0x3f, 0x4a, 0x90, 0xe1, 0x2c, 0x43, 0x25, 0xc8,
-	0x1e, 0x62, 0x2f, 0xe2, 0x7c, 0x5e, 0x0f, 0xaf, 0xe8, 0x53, 0x58, 0x22, 0xc1, 0x7c, 0x34, 0xa3,
-	0xa1, 0xe0, 0x6b, 0xfb, 0xce, 0x8c, 0x08, 0xc6, 0x86, 0x4a, 0x4c, 0xf0, 0x35, 0x32, 0x36, 0x69,
-	0x9f, 0xc3, 0x75, 0x37, 0x9c, 0xa6, 0x26, 0x9f, 0xa2, 0xd4, 0x5c, 0x23, 0x32, 0x3e, 0x83, 0x02,
-	0x7c, 0xc9, 0x1d, 0x7b, 0xad, 0x7e, 0x93, 0x04, 0x88, 0xb7, 0x1d, 0xd2, 0x20, 0xfb, 0xdc, 0xd9,
-	0x77, 0xc8, 0x91, 0x23, 0x27, 0x94, 0xe5, 0xfe, 0x40, 0xbd, 0x11, 0x0b, 0x85, 0x00, 0xa9, 0x90,
-	0xd9, 0x68, 0x51, 0xec, 0xf8, 0xb2, 0xa4, 0x14, 0xfb, 0x03, 0x55, 0x8e, 0x55, 0xf8, 0x3b, 0x5a,
-	0x85, 0xfc, 0x33, 0x0f, 0xbb, 0x86, 0x67, 0x3b, 0x6d, 0x39, 0xa9, 0xac, 0xf4, 0x07, 0xea, 0xcd,
-	0x58, 0x29, 0x12, 0xa1, 0x37, 0x21, 0xc7, 0x2f, 0xd8, 0x92, 0x53, 0xca, 0xad, 0xfe, 0x40, 0x45,
-	0x93, 0x6a, 0xd8, 0x42, 0x55, 0x28, 0xe8, 0xd8, 0xed, 0xda, 0xa6, 0xe1, 0x07, 0x78, 0x69, 0xe5,
-	0x7f, 0xfd, 0x81, 0xba, 0x3c, 0xb2, 0xa2, 0x63, 0x61, 0x80, 0x18, 0x7e, 0x5a, 0xe4, 0x85, 0x49,
-	0xc4, 0x50, 0x12, 0x64, 0xc9, 0xce, 0xd8, 0x92, 0x33, 0x93, 0x59, 0x0a, 0x41, 0xf5, 0x37, 0x09,
-	0x0a, 0x23, 0xbb, 0x01, 0x95, 0x01, 0xb6, 0x69, 0x3b, 0x2e, 0xce, 0x52, 0x7f, 0xa0, 0x8e, 0xbc,
-	0xa0, 0x77, 0x60, 0x65, 0x9b, 0xb6, 0xa7, 0x8d, 0x9b, 0x2c, 0x29, 0xff, 0xef, 0x0f, 0xd4, 0x57,
-	0x89, 0xd1, 0x43, 0x28, 0x5d, 0x16, 0x71, 0xf2, 0xc9, 0x49, 0xe5, 0xb5, 0xfe, 0x40, 0x7d, 0xa5,
-	0x1c, 0x69, 0xb0, 0xb8, 0x4d, 0xdb, 0x11, 0x8f, 0xe5, 0x94, 0x22, 0xf7, 0x07, 0xea, 0xd8, 0x1b,
-	0x5a, 0x87, 0xe2, 0xe8, 0x3d, 0xc2, 0x4e, 0x2b, 0xa5, 0xfe, 0x40, 0x9d, 0x2a, 0x6b, 0xac, 0x9d,
-	0xfe, 0x52, 0x4e, 0x9c, 0x9c, 0x95, 0xa5, 0xd3, 0xb3, 0xb2, 0xf4, 0xf3, 0x59, 0x59, 0xfa, 0xfa,
-	0xbc, 0x9c, 0x38, 0x3d, 0x2f, 0x27, 0x7e, 0x38, 0x2f, 0x27, 0x3e, 0x83, 0x98, 0x65, 0xad, 0x0c,
-	0xfb, 0x75, 0x7a, 0xf0, 0x7b, 0x00, 0x00, 0x00, 0xff, 0xff, 0xaa, 0xc5, 0x86, 0xb3, 0x87, 0x0d,
-	0x00, 0x00,
+	// 1167 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcd, 0x57, 0xcd, 0x6f, 0x1b, 0x45,
+	0x14, 0xcf, 0x3a, 0xfe, 0x7c, 0x4e, 0x5c, 0x67, 0xea, 0x34, 0xc6, 0xd0, 0x24, 0xac, 0x4a, 0x14,
+	0xdc, 0xd6, 0x6e, 0x53, 0x0e, 0xa8, 0x1c, 0x20, 0x6e, 0x8b, 0x52, 0xd1, 0xd0, 0x6a, 0x9a, 0xf2,
+	0x25, 0x24, 0x6b, 0xbd, 0x3b, 0xb5, 0x57, 0x71, 0x3c, 0xcb, 0xce, 0x3a, 0x55, 0x8f, 0x5c, 0x38,
+	0x70, 0xe2, 0xc4, 0x8d, 0x33, 0x12, 0xfc, 0x03, 0xfc, 0x09, 0x39, 0x70, 0xc8, 0x11, 0x09, 0x14,
+	0x41, 0xf9, 0x2f, 0xe0, 0xc2, 0xdb, 0x99, 0xf1, 0xae, 0xed, 0xb8, 0xd8, 0xe1, 0x4b, 0x1c, 0x2c,
+	0xed, 0xbc, 0x8f, 0xdf, 0xfb, 0x98, 0xdf, 0x7b, 0x23, 0x43, 0x29, 0xb0, 0x5a, 0x5d, 0xd6, 0x14,
+	0x76, 0x87, 0x39, 0xfd, 0x2e, 0xab, 0x79, 0x3e, 0x0f, 0x38, 0xb9, 0xe8, 0xb9, 0xbd, 0xb6, 0x6d,
+	0x79, 0xb5, 0xc0, 0x7d, 0xdc, 0xe5, 0x4f, 0x6a, 0xb6, 0x63, 0xd7, 0x06, 0x26, 0x5e, 0xab, 0x52,
+	0x6a, 0xf3, 0x36, 0x97, 0x96, 0xf5, 0xf0, 0x4b, 0x39, 0x99, 0xdf, 0x18, 0x00, 0xb7, 0x3a, 0xcc,
+	0xde, 0xf7, 0xb8, 0xdb, 0x0b, 0xc8, 0x7d, 0x58, 0xb4, 0xa3, 0x53, 0x33, 0x10, 0x65, 0x63, 0xdd,
+	0xd8, 0x4c, 0x36, 0xaa, 0xbf, 0x9d, 0xac, 0x6d, 0xb4, 0xdd, 0xa0, 0xd3, 0x6f, 0xd5, 0x6c, 0x7e,
+	0x50, 0xd7, 0x91, 0xea, 0x2a, 0x52, 0x1d, 0x23, 0xd5, 0x0f, 0xb8, 0xc3, 0xba, 0xb5, 0x3d, 0x41,
+	0x17, 0x62, 0x80, 0x3d, 0x41, 0xde, 0x81, 0xbc, 0xcf, 0x04, 0xef, 0x1e, 0x32, 0x27, 0x84, 0x4b,
+	0x9c, 0x19, 0x0e, 0x06, 0xee, 0x7b, 0xc2, 0xfc, 0xc9, 0x80, 0x73, 0xdb, 0x8e, 0xb3, 0x17, 0x56,
+	0x4f, 0xd9, 0x27, 0x7d, 0x26, 0x02, 0xf2, 0x08, 0xb2, 0xaa, 0x1b, 0xae, 0x23, 0x93, 0x9d, 0x6f,
+	0xdc, 0x7c, 0x76, 0xb2, 0x96, 0x91, 0x36, 0x77, 0x6f, 0x63, 0xa0, 0xcb, 0x33, 0x05, 0x52, 0xe6,
+	0x34, 0x23, 0xb1, 0xee, 0x3a, 0xe4, 0x65, 0x58, 0x70, 0x45, 0x53, 0x30, 0x9b, 0xf7, 0x1c, 0xcb,
+	0x7f, 0x2a, 0x13, 0xcf, 0xd2, 0xbc, 0x2b, 0x1e, 0x0e, 0x44, 0xd8, 0x2b, 0x88, 0x4b, 0x2d, 0xcf,
+	0xa3, 0x41, 0x7e, 0xeb, 0xd5, 0xda, 0x9f, 0x5e, 0x42, 0x2d, 0x6e, 0x75, 0x23, 0x79, 0x74, 0xb2,
+	0x36, 0x47, 0x87, 0x20, 0xcc, 0x7d, 0x20, 0x94, 0x1d, 0xf0, 0x43, 0xf6, 0x1f, 0x14, 0x68, 0x1e,
+	0x19, 0x50, 0xba, 0xed, 0x0a, 0xcf, 0x0a, 0xec, 0xce, 0x48, 0xbc, 0x5d, 0xc8, 0x59, 0x0e, 0x5e,
+	0x56, 0x28, 0x93, 0x01, 0xf3, 0x5b, 0xb5, 0x29, 0x55, 0x8d, 0xdd, 0xc9, 0xce, 0x1c, 0xcd, 0x5a,
+	0x5a, 0x44, 0xde, 0x83, 0x05, 0x5f, 0x16, 0xa5, 0x11, 0x13, 0x12, 0xf1, 0xfa, 0x14, 0xc4, 0xd3,
+	0x7d, 0x40, 0xd0, 0xbc, 0x1f, 0x4b, 0x1b, 0x39, 0xc8, 0xf8, 0x4a, 0x63, 0x7e, 0x6d, 0x40, 0x31,
+	0x4e, 0x41, 0x78, 0xbc, 0x27, 0x18, 0x69, 0x40, 0x5a, 0x04, 0x56, 0xd0, 0x17, 0xba, 0x86, 0xea,
+	0x94, 0x88, 0xd2, 0xfb, 0xa1, 0xf4, 0xa0, 0xda, 0x73, 0xec, 0x86, 0x13, 0x7f, 0xff, 0x86, 0x71,
+	0xda, 0xce, 0x8f, 0x94, 0xf6, 0x7f, 0x4e, 0xf6, 0x7b, 0x03, 0x96, 0xc7, 0x18, 0xa2, 0xd3, 0x7d,
+	0xf7, 0x34, 0x45, 0xea, 0x33, 0x53, 0x44, 0x61, 0x8c, 0x70, 0xe4, 0xfd, 0x89, 0x1c, 0xd9, 0x3a,
+	0x0b, 0x47, 0x22, 0xd4, 0x11, 0x92, 0x00, 0x64, 0x7d, 0xad, 0x32, 0x3f, 0x33, 0x20, 0xb7, 0xc3,
+	0x2c, 0x3f, 0x68, 0x31, 0x2b, 0x20, 0x1f, 0x40, 0x6e, 0x30, 0x55, 0x61, 0xd3, 0xe7, 0x71, 0xac,
+	0xde, 0xc0, 0xb1, 0xca, 0xea, 0x39, 0x11, 0x67, 0x9d, 0xab, 0xac, 0x9e, 0x2b, 0x41, 0xd6, 0x20,
+	0x1f, 0x6e, 0x8e, 0x80, 0x7b, 0xa1, 0x93, 0x5e, 0x1c, 0x80, 0x8b, 0x43, 0x4b, 0xcc, 0x5d, 0x48,
+	0x85, 0x57, 0x27, 0xc8, 0x45, 0x00, 0x76, 0xc8, 0x70, 0xcf, 0xfa, 0x56, 0xa0, 0xfa, 0x98, 0xa4,
+	0x39, 0x29, 0xa1, 0x28, 0x20, 0xaf, 0x40, 0x41, 0x70, 0x3f, 0x60, 0x7e, 0xb3, 0x65, 0xd9, 0xfb,
+	0x5d, 0xae, 0xb0, 0x92, 0x74, 0x51, 0x49, 0x1b, 0x4a, 0x68, 0x7e, 0x97, 0x80, 0xfc, 0x10, 0x1f,
+	0xfe, 0xad, 0x85, 0xf8, 0x26, 0xa4, 0x42, 0xa2, 0xa9, 0xcb, 0x29, 0x4c, 0x65, 0x56, 0x94, 0x11,
+	0xa3, 0xca, 0xef, 0x1f, 0x5f, 0x97, 0xe4, 0x2d, 0x95, 0x91, 0x28, 0x27, 0x25, 0xd6, 0xa5, 0x29,
+	0x58, 0xb2, 0xe7, 0x1a, 0x46, 0x39, 0x9a, 0xdf, 0x1a, 0xb0, 0x14, 0x51, 0x22, 0x62, 0xf7, 0x0e,
+	0xa4, 0x65, 0xd1, 0x8a, 0x17, 0x67, 0x1a, 0x46, 0x0d, 0xaf, 0xfd, 0xc9, 0x3d, 0xc8, 0x76, 0x5d,
+	0xbc, 0x4f, 0x26, 0xd4, 0xcb, 0x97, 0x6a, 0x5c, 0xc3, 0xfe, 0x5f, 0x99, 0xa5, 0xff, 0xf7, 0xb4,
+	0x1f, 0x8d, 0x10, 0xcc, 0xcb, 0xb0, 0x78, 0xff, 0x49, 0x8f, 0xf9, 0x94, 0x1d, 0xba, 0xc2, 0xe5,
+	0x3d, 0x52, 0x09, 0xd9, 0xad, 0xbe, 0xd5, 0x4d, 0xd3, 0xe8, 0x6c, 0x6e, 0x40, 0xe1, 0x81, 0xcf,
+	0x6d, 0xf4, 0xe3, 0xfe, 0x1d, 0x8f, 0xdb, 0x1d, 0x52, 0x82, 0x14, 0x0b, 0x3f, 0xa4, 0x69, 0x8e,
+	0xaa, 0x83, 0xf9, 0x69, 0x06, 0x32, 0xbb, 0x68, 0x65, 0xb5, 0x19, 0xb9, 0x03, 0xe9, 0x0e, 0xb3,
+	0x1c, 0xe6, 0xeb, 0x99, 0xbe, 0x3a, 0xa5, 0x70, 0xed, 0x57, 0xdb, 0x91, 0x4e, 0x54, 0x3b, 0x23,
+	0x4c, 0xf6, 0x40, 0xb4, 0x9b, 0xc1, 0x53, 0x6f, 0x40, 0x96, 0xea, 0x6c, 0x40, 0x7b, 0xe8, 0x41,
+	0x33, 0xe8, 0x1b, 0x7e, 0x20, 0x4c, 0xf2, 0xb1, 0xcf, 0x0f, 0x24, 0x53, 0x72, 0x8d, 0xeb, 0xd8,
+	0xb8, 0xab, 0xb3, 0x34, 0xee, 0x96, 0xe5, 0x05, 0x7d, 0x3f, 0xa4, 0xae, 0x74, 0x27, 0xdb, 0x90,
+	0x08, 0xb8, 0xa4, 0xc8, 0x5f, 0x02, 0x41, 0x67, 0xe2, 0xc2, 0x05, 0x47, 0xef, 0x41, 0xb5, 0xa0,
+	0x9a, 0xfa, 0xe5, 0x29, 0xa7, 0x64, 0x9f, 0x6e, 0x4c, 0x29, 0x6f, 0xd2, 0x33, 0x4b, 0x4b, 0xce,
+	0xa4, 0xc7, 0xb7, 0x0b, 0x2b, 0xa7, 0x42, 0x29, 0x5a, 0x96, 0xd3, 0x32, 0xd6, 0x6b, 0x67, 0x8b,
+	0xa5, 0x7c, 0xe9, 0xb2, 0x33, 0x71, 0x8f, 0xbf, 0x0d, 0xb9, 0xce, 0x80, 0xfe, 0xe5, 0x8c, 0xc4,
+	0xdf, 0x9c, 0x82, 0x1f, 0x8f, 0x4b, 0xec, 0x4a, 0x9a, 0x40, 0xa2, 0x43, 0x9c, 0x70, 0x56, 0x02,
+	0x5e, 0x9b, 0x19, 0x70, 0x90, 0xec, 0x52, 0x67, 0x5c, 0x54, 0xf9, 0xd1, 0x80, 0xb4, 0x62, 0x19,
+	0x29, 0x43, 0xe6, 0x90, 0xf9, 0x11, 0xe7, 0x73, 0x74, 0x70, 0x24, 0x1f, 0x42, 0x81, 0x87, 0xf3,
+	0xd1, 0x8c, 0x86, 0x42, 0xbd, 0x23, 0x57, 0xa6, 0x64, 0x30, 0x32, 0x54, 0x7a, 0x82, 0x17, 0xf9,
+	0xc8, 0xa4, 0x7d, 0x0c, 0xe7, 0xbc, 0xc1, 0x34, 0x35, 0xd5, 0x14, 0xcd, 0xcf, 0x34, 0x22, 0xa3,
+	0x33, 0xa8, 0xc1, 0x0b, 0xde, 0x88, 0xb4, 0xfa, 0x65, 0x02, 0x20, 0xde, 0x97, 0xc4, 0x84, 0xcc,
+	0xa3, 0xde, 0x7e, 0x0f, 0x33, 0x28, 0xce, 0x55, 0x96, 0x3f, 0xff, 0x6a, 0x7d, 0x29, 0x56, 0x6a,
+	0x05, 0x59, 0x87, 0xf4, 0x76, 0x4b, 0xe0, 0x4b, 0x51, 0x34, 0x2a, 0x25, 0x34, 0x29, 0xc6, 0x26,
+	0x4a, 0x4e, 0x36, 0x20, 0xf7, 0xc0, 0x67, 0x9e, 0xe5, 0x63, 0x7e, 0xc5, 0x44, 0x65, 0x05, 0x8d,
+	0xce, 0xc7, 0x46, 0x91, 0x8a, 0x5c, 0x82, 0xac, 0x3a, 0x30, 0xa7, 0x38, 0x5f, 0xb9, 0x80, 0x66,
+	0x64, 0xdc, 0x8c, 0x39, 0xa4, 0x0a, 0x79, 0xca, 0xbc, 0xae, 0x6b, 0x5b, 0x41, 0x88, 0x97, 0xac,
+	0xbc, 0x80, 0x86, 0xcb, 0x43, 0x4b, 0x3e, 0x56, 0x86, 0x88, 0x83, 0xb7, 0xae, 0x98, 0x1a, 0x47,
+	0x1c, 0x68, 0xc2, 0x2a, 0xe5, 0x37, 0x86, 0x4d, 0x8f, 0x57, 0xa9, 0x15, 0xd5, 0xdf, 0x0d, 0xc8,
+	0x0f, 0xed, 0x06, 0xb2, 0x0a, 0xb0, 0x2b, 0xda, 0x71, 0x73, 0x0a, 0xe8, 0x36, 0x24, 0x21, 0xaf,
+	0xc3, 0x0a, 0x9e, 0x26, 0x8d, 0x1b, 0xb6, 0xe9, 0x45, 0x34, 0x7e, 0x9e, 0x9a, 0xdc, 0x84, 0xf2,
+	0x69, 0x95, 0x22, 0x1f, 0x36, 0xef, 0x25, 0x74, 0x7d, 0xae, 0x1e, 0x2b, 0x59, 0x40, 0x5d, 0xc4,
+	0x63, 0xec, 0x62, 0x11, 0xed, 0x47, 0x64, 0x64, 0x0b, 0x4a, 0xc3, 0xe7, 0x08, 0x3b, 0x59, 0x29,
+	0xa3, 0xed, 0x44, 0x5d, 0x63, 0xf3, 0xf8, 0x97, 0xd5, 0xb9, 0xa3, 0x67, 0xab, 0xc6, 0x31, 0xfe,
+	0x7e, 0xc6, 0xdf, 0x17, 0xbf, 0xae, 0xce, 0x1d, 0xe3, 0xef, 0x07, 0xfc, 0x7d, 0x04, 0x31, 0xcb,
+	0x5a, 0x69, 0xf9, 0x5f, 0xee, 0xc6, 0x1f, 0xe4, 0xe8, 0x89, 0x2b, 0x18, 0x0e, 0x00, 0x00,
 }
 
 func (m *Checkpoint) Marshal() (dAtA []byte, err error) {
@@ -1446,6 +1508,39 @@ func (m *Heartbeat) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *Stats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Stats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Stats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SorterBacklog != 0 {
+		i = encodeVarintTableSchedule(dAtA, i, uint64(m.SorterBacklog))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.EventRate != 0 {
+		i = encodeVarintTableSchedule(dAtA, i, uint64(m.EventRate))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *TableStatus) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1466,6 +1561,16 @@ func (m *TableStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	{
+		size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTableSchedule(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
 	{
 		size, err := m.Checkpoint.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -1917,6 +2022,21 @@ func (m *Heartbeat) Size() (n int) {
 	return n
 }
 
+func (m *Stats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.EventRate != 0 {
+		n += 1 + sovTableSchedule(uint64(m.EventRate))
+	}
+	if m.SorterBacklog != 0 {
+		n += 1 + sovTableSchedule(uint64(m.SorterBacklog))
+	}
+	return n
+}
+
 func (m *TableStatus) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1931,6 +2051,8 @@ func (m *TableStatus) Size() (n int) {
 	}
 	l = m.Checkpoint.Size()
 	n += 1 + l + sovTableSchedule(uint64(l))
+	l = m.Stats.Size()
+	n += 1 + l + sovTableSchedule(uint64(l))
 	return n
 }
 
@@ -2943,6 +3065,94 @@ func (m *Heartbeat) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *Stats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTableSchedule
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Stats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Stats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventRate", wireType)
+			}
+			m.EventRate = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTableSchedule
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EventRate |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SorterBacklog", wireType)
+			}
+			m.SorterBacklog = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTableSchedule
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SorterBacklog |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTableSchedule(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTableSchedule
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *TableStatus) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -3043,6 +3253,39 @@ func (m *TableStatus) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTableSchedule
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTableSchedule
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTableSchedule
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTableSchedule(dAtA[iNdEx:])
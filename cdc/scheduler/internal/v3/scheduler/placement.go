@@ -0,0 +1,351 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"math"
+	"sort"
+
+	tfilter "github.com/pingcap/tidb/util/table-filter"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// Placement restricts the captures that tables can be replicated on,
+// according to the placement rules of a changefeed.
+// A nil Placement places no restriction.
+type Placement struct {
+	rules      []*placementRule
+	tableNames internal.TableNameGetter
+}
+
+type placementRule struct {
+	filter   tfilter.Filter
+	labels   map[string]string
+	spreadBy string
+}
+
+// NewPlacement returns a Placement, or nil if the changefeed has no
+// placement rule.
+func NewPlacement(
+	cfg *config.ReplicaConfig, tableNames internal.TableNameGetter,
+) (*Placement, error) {
+	if cfg == nil || cfg.Placement == nil || len(cfg.Placement.Rules) == 0 {
+		return nil, nil
+	}
+	if err := cfg.Placement.ValidateAndAdjust(); err != nil {
+		return nil, err
+	}
+	p := &Placement{
+		rules:      make([]*placementRule, 0, len(cfg.Placement.Rules)),
+		tableNames: tableNames,
+	}
+	for _, rule := range cfg.Placement.Rules {
+		f, err := tfilter.Parse(rule.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrPlacementRuleInvalid, err, rule.Matcher)
+		}
+		if !cfg.CaseSensitive {
+			f = tfilter.CaseInsensitive(f)
+		}
+		p.rules = append(p.rules, &placementRule{
+			filter:   f,
+			labels:   rule.Labels,
+			spreadBy: rule.SpreadBy,
+		})
+	}
+	return p, nil
+}
+
+// ruleOf returns the first rule that matches the table, or nil if there is
+// no such rule.
+func (p *Placement) ruleOf(tableID model.TableID) *placementRule {
+	name, ok := p.tableNames(tableID)
+	if !ok {
+		return nil
+	}
+	for _, rule := range p.rules {
+		if rule.filter.MatchTable(name.Schema, name.Table) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// accept returns true if the capture satisfies the rule.
+func (r *placementRule) accept(capture *member.CaptureStatus) bool {
+	for key, value := range r.labels {
+		if capture.Labels[key] != value {
+			return false
+		}
+	}
+	if r.spreadBy != "" {
+		if _, ok := capture.Labels[r.spreadBy]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// placementView is a snapshot of how tables are placed, it is built for
+// a round of scheduling. A nil placementView places no restriction.
+type placementView struct {
+	placement *Placement
+	captures  map[model.CaptureID]*member.CaptureStatus
+	// rules caches the rule of tables, a nil value means no rule.
+	rules map[model.TableID]*placementRule
+	// spread counts the tables of each spread rule by the label value of
+	// the captures they are replicated on.
+	spread map[*placementRule]map[string]int
+}
+
+func (p *Placement) newView(
+	captures map[model.CaptureID]*member.CaptureStatus,
+	replications map[model.TableID]*replication.ReplicationSet,
+) *placementView {
+	if p == nil {
+		return nil
+	}
+	v := &placementView{
+		placement: p,
+		captures:  captures,
+		rules:     make(map[model.TableID]*placementRule),
+		spread:    make(map[*placementRule]map[string]int),
+	}
+	for _, rule := range p.rules {
+		if rule.spreadBy == "" {
+			continue
+		}
+		counts := make(map[string]int)
+		for _, capture := range captures {
+			if rule.accept(capture) {
+				counts[capture.Labels[rule.spreadBy]] = 0
+			}
+		}
+		v.spread[rule] = counts
+	}
+	for tableID, rep := range replications {
+		if rep.Primary == "" {
+			continue
+		}
+		v.move(tableID, "", rep.Primary)
+	}
+	return v
+}
+
+func (v *placementView) ruleOf(tableID model.TableID) *placementRule {
+	if v == nil {
+		return nil
+	}
+	rule, ok := v.rules[tableID]
+	if !ok {
+		rule = v.placement.ruleOf(tableID)
+		v.rules[tableID] = rule
+	}
+	return rule
+}
+
+// allowed returns true if the table can be replicated on the capture.
+func (v *placementView) allowed(
+	tableID model.TableID, captureID model.CaptureID,
+) bool {
+	rule := v.ruleOf(tableID)
+	if rule == nil {
+		return true
+	}
+	capture, ok := v.captures[captureID]
+	return ok && rule.accept(capture)
+}
+
+// canMove returns true if the table can be moved from the source capture
+// to the target capture, without violating its rule or making the tables
+// of its spread rule more uneven. Tables that are not replicated yet or
+// are misplaced can only be moved to the label values with the fewest
+// tables of the spread rule.
+// The source is empty if the table is not replicated yet.
+func (v *placementView) canMove(
+	tableID model.TableID, source, target model.CaptureID,
+) bool {
+	if !v.allowed(tableID, target) {
+		return false
+	}
+	rule := v.ruleOf(tableID)
+	if rule == nil || rule.spreadBy == "" {
+		return true
+	}
+	to := v.captures[target].Labels[rule.spreadBy]
+	if source == "" || !v.allowed(tableID, source) {
+		return v.spread[rule][to] == v.minSpread(rule)
+	}
+	from := v.captures[source].Labels[rule.spreadBy]
+	return from == to || v.spread[rule][to] < v.spread[rule][from]
+}
+
+func (v *placementView) minSpread(rule *placementRule) int {
+	minCount := math.MaxInt64
+	for _, count := range v.spread[rule] {
+		if count < minCount {
+			minCount = count
+		}
+	}
+	return minCount
+}
+
+// move records that the table is moved from the source capture to the
+// target capture. The source is empty if the table is not replicated yet.
+func (v *placementView) move(
+	tableID model.TableID, source, target model.CaptureID,
+) {
+	rule := v.ruleOf(tableID)
+	if rule == nil || rule.spreadBy == "" {
+		return
+	}
+	if source != "" && v.allowed(tableID, source) {
+		v.spread[rule][v.captures[source].Labels[rule.spreadBy]]--
+	}
+	if v.allowed(tableID, target) {
+		v.spread[rule][v.captures[target].Labels[rule.spreadBy]]++
+	}
+}
+
+// spreadOf returns the number of tables of the table's spread rule that
+// are replicated by captures with the same label value as the capture.
+func (v *placementView) spreadOf(
+	tableID model.TableID, captureID model.CaptureID,
+) int {
+	rule := v.ruleOf(tableID)
+	if rule == nil || rule.spreadBy == "" {
+		return 0
+	}
+	capture, ok := v.captures[captureID]
+	if !ok {
+		return 0
+	}
+	return v.spread[rule][capture.Labels[rule.spreadBy]]
+}
+
+// pickTarget returns the capture with the minimum workload in workloads
+// that the table can be moved to from the source capture. Ties are broken
+// by the spread of the table's spread rule, and then by capture ID.
+// The source is empty if the table is not replicated yet.
+func (v *placementView) pickTarget(
+	tableID model.TableID, source model.CaptureID,
+	workloads map[model.CaptureID]int,
+) (model.CaptureID, bool) {
+	target := ""
+	minWorkload, minSpread := math.MaxInt64, math.MaxInt64
+	for captureID, workload := range workloads {
+		if !v.canMove(tableID, source, captureID) {
+			continue
+		}
+		spread := v.spreadOf(tableID, captureID)
+		if workload < minWorkload ||
+			(workload == minWorkload && spread < minSpread) ||
+			(workload == minWorkload && spread == minSpread && captureID < target) {
+			target = captureID
+			minWorkload, minSpread = workload, spread
+		}
+	}
+	return target, target != ""
+}
+
+// newPlacementMoveTables moves tables that are replicated on captures not
+// satisfying their rules, and moves tables of spread rules to even them
+// out across label values.
+func newPlacementMoveTables(
+	view *placementView,
+	captures map[model.CaptureID]*member.CaptureStatus,
+	replications map[model.TableID]*replication.ReplicationSet,
+	maxTaskLimit int,
+) []replication.MoveTable {
+	if view == nil {
+		return nil
+	}
+	workloads := make(map[model.CaptureID]int, len(captures))
+	for captureID := range captures {
+		workloads[captureID] = 0
+	}
+	tables := make([]model.TableID, 0, len(replications))
+	for tableID, rep := range replications {
+		if rep.State != replication.ReplicationSetStateReplicating {
+			continue
+		}
+		if _, ok := workloads[rep.Primary]; !ok {
+			continue
+		}
+		workloads[rep.Primary]++
+		tables = append(tables, tableID)
+	}
+	// Sort tables so that the result is deterministic.
+	sort.Slice(tables, func(i, j int) bool { return tables[i] < tables[j] })
+
+	moved := make(map[model.TableID]struct{})
+	moveTables := make([]replication.MoveTable, 0)
+	moveTo := func(tableID model.TableID, source, target model.CaptureID) {
+		moveTables = append(moveTables, replication.MoveTable{
+			TableID:     tableID,
+			DestCapture: target,
+		})
+		moved[tableID] = struct{}{}
+		view.move(tableID, source, target)
+		workloads[source]--
+		workloads[target]++
+	}
+	// Move misplaced tables first, since they violate the rules.
+	for _, tableID := range tables {
+		if len(moveTables) >= maxTaskLimit {
+			return moveTables
+		}
+		source := replications[tableID].Primary
+		if view.allowed(tableID, source) {
+			continue
+		}
+		if target, ok := view.pickTarget(tableID, source, workloads); ok {
+			moveTo(tableID, source, target)
+		}
+	}
+	for _, tableID := range tables {
+		if len(moveTables) >= maxTaskLimit {
+			return moveTables
+		}
+		if _, ok := moved[tableID]; ok {
+			continue
+		}
+		rule := view.ruleOf(tableID)
+		if rule == nil || rule.spreadBy == "" {
+			continue
+		}
+		source := replications[tableID].Primary
+		// Only move the table if the spread is uneven, i.e. there are
+		// label values that have at least 2 fewer tables.
+		spread := view.spreadOf(tableID, source)
+		if spread-view.minSpread(rule) <= 1 {
+			continue
+		}
+		candidates := make(map[model.CaptureID]int)
+		for captureID, workload := range workloads {
+			if view.spreadOf(tableID, captureID) < spread {
+				candidates[captureID] = workload
+			}
+		}
+		if target, ok := view.pickTarget(tableID, source, candidates); ok {
+			moveTo(tableID, source, target)
+		}
+	}
+	return moveTables
+}
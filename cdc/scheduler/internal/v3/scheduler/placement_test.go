@@ -0,0 +1,215 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/schedulepb"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPlacement(
+	t *testing.T, names map[model.TableID]string, rules ...*config.PlacementRule,
+) *Placement {
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Placement = &config.PlacementConfig{Rules: rules}
+	p, err := NewPlacement(cfg, func(tableID model.TableID) (model.TableName, bool) {
+		name, ok := names[tableID]
+		if !ok {
+			return model.TableName{}, false
+		}
+		return model.TableName{Schema: "billing", Table: name}, true
+	})
+	require.Nil(t, err)
+	return p
+}
+
+func TestNewPlacement(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultReplicaConfig()
+	p, err := NewPlacement(cfg, nil)
+	require.Nil(t, err)
+	require.Nil(t, p)
+
+	cfg.Placement = &config.PlacementConfig{Rules: []*config.PlacementRule{
+		{Matcher: []string{"billing.["}, Labels: map[string]string{"tier": "dedicated"}},
+	}}
+	_, err = NewPlacement(cfg, nil)
+	require.True(t, cerror.ErrPlacementRuleInvalid.Equal(err))
+
+	// The first matched rule takes effect.
+	p = newTestPlacement(t, map[model.TableID]string{1: "t1", 2: "t2"},
+		&config.PlacementRule{
+			Matcher: []string{"billing.t1"}, Labels: map[string]string{"tier": "dedicated"},
+		},
+		&config.PlacementRule{Matcher: []string{"billing.*"}, SpreadBy: "zone"})
+	require.Equal(t, p.rules[0], p.ruleOf(1))
+	require.Equal(t, p.rules[1], p.ruleOf(2))
+	require.Nil(t, p.ruleOf(3))
+
+	require.True(t, p.rules[0].accept(&member.CaptureStatus{
+		Labels: map[string]string{"tier": "dedicated", "zone": "z1"},
+	}))
+	require.False(t, p.rules[0].accept(&member.CaptureStatus{}))
+	require.True(t, p.rules[1].accept(&member.CaptureStatus{
+		Labels: map[string]string{"zone": "z1"},
+	}))
+	require.False(t, p.rules[1].accept(&member.CaptureStatus{
+		Labels: map[string]string{"tier": "dedicated"},
+	}))
+}
+
+func TestPlacementBasicScheduler(t *testing.T) {
+	t.Parallel()
+
+	p := newTestPlacement(t, map[model.TableID]string{1: "t1", 2: "t2", 3: "t3"},
+		&config.PlacementRule{
+			Matcher: []string{"billing.t1"}, Labels: map[string]string{"tier": "dedicated"},
+		})
+	b := newBasicScheduler(10, model.ChangeFeedID{}, p)
+	captures := map[model.CaptureID]*member.CaptureStatus{
+		"a": {}, "b": {Labels: map[string]string{"tier": "dedicated"}}, "c": {},
+	}
+	tasks := b.Schedule(0, []model.TableID{1, 2, 3}, captures,
+		map[model.TableID]*replication.ReplicationSet{})
+	require.Len(t, tasks, 1)
+	require.Len(t, tasks[0].BurstBalance.AddTables, 3)
+	for _, add := range tasks[0].BurstBalance.AddTables {
+		if add.TableID == 1 {
+			require.Equal(t, "b", add.CaptureID)
+		}
+	}
+
+	// The table is added even if no capture satisfies its rule.
+	delete(captures, "b")
+	tasks = b.Schedule(0, []model.TableID{1}, captures,
+		map[model.TableID]*replication.ReplicationSet{})
+	require.Len(t, tasks, 1)
+	require.Len(t, tasks[0].BurstBalance.AddTables, 1)
+}
+
+func TestPlacementBalanceScheduler(t *testing.T) {
+	t.Parallel()
+
+	p := newTestPlacement(t, map[model.TableID]string{1: "t1", 2: "t2", 3: "t3", 4: "t4"},
+		&config.PlacementRule{
+			Matcher: []string{"billing.t1"}, Labels: map[string]string{"tier": "dedicated"},
+		},
+		&config.PlacementRule{Matcher: []string{"billing.*"}, SpreadBy: "zone"})
+	sched := newBalanceScheduler(0, 10, p)
+	sched.random = nil
+
+	// Table 1 is moved to the dedicated capture, and the other tables are
+	// spread across zones.
+	captures := map[model.CaptureID]*member.CaptureStatus{
+		"a": {Labels: map[string]string{"zone": "z1"}},
+		"b": {Labels: map[string]string{"zone": "z1"}},
+		"c": {Labels: map[string]string{"zone": "z2"}},
+		"d": {Labels: map[string]string{"tier": "dedicated"}},
+	}
+	replications := map[model.TableID]*replication.ReplicationSet{
+		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		2: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		3: {State: replication.ReplicationSetStateReplicating, Primary: "b"},
+		4: {State: replication.ReplicationSetStateReplicating, Primary: "b"},
+	}
+	tasks := sched.Schedule(0, []model.TableID{1, 2, 3, 4}, captures, replications)
+	require.Len(t, tasks, 2)
+	require.EqualValues(t, &replication.MoveTable{TableID: 1, DestCapture: "d"},
+		tasks[0].MoveTable)
+	require.EqualValues(t, &replication.MoveTable{TableID: 2, DestCapture: "c"},
+		tasks[1].MoveTable)
+
+	// The tables are balanced among captures satisfying their rules.
+	replications[1].Primary = "d"
+	replications[2].Primary = "c"
+	tasks = sched.Schedule(0, []model.TableID{1, 2, 3, 4}, captures, replications)
+	require.Len(t, tasks, 1)
+	require.Equal(t, "a", tasks[0].MoveTable.DestCapture)
+	replications[tasks[0].MoveTable.TableID].Primary = "a"
+	tasks = sched.Schedule(0, []model.TableID{1, 2, 3, 4}, captures, replications)
+	require.Len(t, tasks, 0)
+}
+
+func TestPlacementDrainCaptureScheduler(t *testing.T) {
+	t.Parallel()
+
+	p := newTestPlacement(t, map[model.TableID]string{1: "t1", 2: "t2"},
+		&config.PlacementRule{
+			Matcher: []string{"billing.t1"}, Labels: map[string]string{"tier": "dedicated"},
+		})
+	sched := newDrainCaptureScheduler(10, model.ChangeFeedID{}, p)
+	captures := map[model.CaptureID]*member.CaptureStatus{
+		"a": {Labels: map[string]string{"tier": "dedicated"}},
+		"b": {},
+		"c": {Labels: map[string]string{"tier": "dedicated"}},
+	}
+	replications := map[model.TableID]*replication.ReplicationSet{
+		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
+		2: {State: replication.ReplicationSetStateReplicating, Primary: "c"},
+	}
+	require.True(t, sched.setTarget("a"))
+	tasks := sched.Schedule(0, []model.TableID{1, 2}, captures, replications)
+	require.Len(t, tasks, 1)
+	require.EqualValues(t, &replication.MoveTable{TableID: 1, DestCapture: "c"},
+		tasks[0].MoveTable)
+
+	// Draining takes precedence over placement rules.
+	captures["c"].Labels = nil
+	tasks = sched.Schedule(0, []model.TableID{1, 2}, captures, replications)
+	require.Len(t, tasks, 1)
+	require.EqualValues(t, &replication.MoveTable{TableID: 1, DestCapture: "b"},
+		tasks[0].MoveTable)
+}
+
+func TestPlacementTrafficBalance(t *testing.T) {
+	t.Parallel()
+
+	p := newTestPlacement(t, map[model.TableID]string{1: "t1", 2: "t2", 3: "t3"},
+		&config.PlacementRule{
+			Matcher: []string{"billing.t1"}, Labels: map[string]string{"tier": "dedicated"},
+		})
+	captures := map[model.CaptureID]*member.CaptureStatus{
+		"a": {Labels: map[string]string{"tier": "dedicated"}}, "b": {},
+	}
+	replications := map[model.TableID]*replication.ReplicationSet{
+		1: {
+			State: replication.ReplicationSetStateReplicating, Primary: "a",
+			Stats: schedulepb.Stats{EventRate: 100},
+		},
+		2: {
+			State: replication.ReplicationSetStateReplicating, Primary: "a",
+			Stats: schedulepb.Stats{EventRate: 40},
+		},
+		3: {
+			State: replication.ReplicationSetStateReplicating, Primary: "a",
+			Stats: schedulepb.Stats{EventRate: 40},
+		},
+	}
+	// Table 1 is the best choice, but it can only be placed on capture "a".
+	moves := newTrafficBalanceMoveTables(captures, replications, 0.2, 10, nil)
+	require.Equal(t, []replication.MoveTable{{TableID: 1, DestCapture: "b"}}, moves)
+	view := p.newView(captures, replications)
+	moves = newTrafficBalanceMoveTables(captures, replications, 0.2, 10, view)
+	require.Equal(t, []replication.MoveTable{
+		{TableID: 2, DestCapture: "b"}, {TableID: 3, DestCapture: "b"},
+	}, moves)
+}
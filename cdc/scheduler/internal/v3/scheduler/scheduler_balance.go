@@ -39,13 +39,17 @@ type balanceScheduler struct {
 	forceBalance bool
 
 	maxTaskConcurrency int
+	placement          *Placement
 }
 
-func newBalanceScheduler(interval time.Duration, concurrency int) *balanceScheduler {
+func newBalanceScheduler(
+	interval time.Duration, concurrency int, placement *Placement,
+) *balanceScheduler {
 	return &balanceScheduler{
 		random:               rand.New(rand.NewSource(time.Now().UnixNano())),
 		checkBalanceInterval: interval,
 		maxTaskConcurrency:   concurrency,
+		placement:            placement,
 	}
 }
 
@@ -75,8 +79,22 @@ func (b *balanceScheduler) Schedule(
 		}
 	}
 
+	// Tables violating placement rules are moved before balancing.
+	view := b.placement.newView(captures, replications)
+	moves := newPlacementMoveTables(
+		view, captures, replications, b.maxTaskConcurrency)
+	if len(moves) != 0 {
+		tasks := make([]*replication.ScheduleTask, 0, len(moves))
+		for i := 0; i < len(moves); i++ {
+			// No need for accept callback here.
+			tasks = append(tasks, &replication.ScheduleTask{MoveTable: &moves[i]})
+		}
+		b.forceBalance = true
+		return tasks
+	}
+
 	tasks := buildBalanceMoveTables(
-		b.random, currentTables, captures, replications, b.maxTaskConcurrency)
+		b.random, currentTables, captures, replications, b.maxTaskConcurrency, view)
 	b.forceBalance = len(tasks) != 0
 	return tasks
 }
@@ -87,6 +105,7 @@ func buildBalanceMoveTables(
 	captures map[model.CaptureID]*member.CaptureStatus,
 	replications map[model.TableID]*replication.ReplicationSet,
 	maxTaskConcurrency int,
+	view *placementView,
 ) []*replication.ScheduleTask {
 	captureTables := make(map[model.CaptureID][]model.TableID)
 	for _, tableID := range currentTables {
@@ -100,7 +119,7 @@ func buildBalanceMoveTables(
 	}
 
 	moves := newBalanceMoveTables(
-		random, captures, replications, maxTaskConcurrency, model.ChangeFeedID{})
+		random, captures, replications, maxTaskConcurrency, view, model.ChangeFeedID{})
 	tasks := make([]*replication.ScheduleTask, 0, len(moves))
 	for i := 0; i < len(moves); i++ {
 		// No need for accept callback here.
@@ -26,7 +26,7 @@ import (
 func TestSchedulerBalanceCaptureOnline(t *testing.T) {
 	t.Parallel()
 
-	sched := newBalanceScheduler(time.Duration(0), 3)
+	sched := newBalanceScheduler(time.Duration(0), 3, nil)
 	sched.random = nil
 
 	// New capture "b" online
@@ -74,7 +74,7 @@ func TestSchedulerBalanceCaptureOnline(t *testing.T) {
 func TestSchedulerBalanceTaskLimit(t *testing.T) {
 	t.Parallel()
 
-	sched := newBalanceScheduler(time.Duration(0), 2)
+	sched := newBalanceScheduler(time.Duration(0), 2, nil)
 	sched.random = nil
 
 	// New capture "b" online
@@ -89,7 +89,7 @@ func TestSchedulerBalanceTaskLimit(t *testing.T) {
 	tasks := sched.Schedule(0, currentTables, captures, replications)
 	require.Len(t, tasks, 2)
 
-	sched = newBalanceScheduler(time.Duration(0), 1)
+	sched = newBalanceScheduler(time.Duration(0), 1, nil)
 	tasks = sched.Schedule(0, currentTables, captures, replications)
 	require.Len(t, tasks, 1)
 }
@@ -0,0 +1,226 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"math"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/schedulepb"
+	"go.uber.org/zap"
+)
+
+const (
+	// baseTableCost is the cost of an idle table, so that idle tables are
+	// still balanced by count.
+	baseTableCost = 1
+	// sorterBacklogDrainSeconds is the expected time for draining the sorter
+	// backlog of a table, it converts the backlog to an event rate.
+	sorterBacklogDrainSeconds = 60
+)
+
+var _ scheduler = &trafficBalanceScheduler{}
+
+// The scheduler for balancing tables among all captures by the cost of
+// replicating tables, which is estimated by the table statistics reported
+// by captures.
+type trafficBalanceScheduler struct {
+	lastRebalanceTime    time.Time
+	checkBalanceInterval time.Duration
+	// imbalanceThreshold is the ratio by which the cost of a capture can
+	// exceed the average cost before tables are moved away from it.
+	imbalanceThreshold float64
+
+	changefeedID       model.ChangeFeedID
+	maxTaskConcurrency int
+	placement          *Placement
+}
+
+func newTrafficBalanceScheduler(
+	interval time.Duration, threshold float64, concurrency int,
+	changefeed model.ChangeFeedID, placement *Placement,
+) *trafficBalanceScheduler {
+	return &trafficBalanceScheduler{
+		checkBalanceInterval: interval,
+		imbalanceThreshold:   threshold,
+		changefeedID:         changefeed,
+		maxTaskConcurrency:   concurrency,
+		placement:            placement,
+	}
+}
+
+func (b *trafficBalanceScheduler) Name() string {
+	return "traffic-balance-scheduler"
+}
+
+func (b *trafficBalanceScheduler) Schedule(
+	_ model.Ts,
+	currentTables []model.TableID,
+	captures map[model.CaptureID]*member.CaptureStatus,
+	replications map[model.TableID]*replication.ReplicationSet,
+) []*replication.ScheduleTask {
+	// Unlike the balance scheduler, it always waits for `checkBalanceInterval`
+	// after producing tasks, so that the statistics of moved tables are
+	// reported by their new captures before the next round.
+	now := time.Now()
+	if now.Sub(b.lastRebalanceTime) < b.checkBalanceInterval {
+		// skip balance.
+		return nil
+	}
+	b.lastRebalanceTime = now
+
+	for _, capture := range captures {
+		if capture.State == member.CaptureStateStopping {
+			log.Debug("schedulerv3: capture is stopping, premature to balance table",
+				zap.String("namespace", b.changefeedID.Namespace),
+				zap.String("changefeed", b.changefeedID.ID))
+			return nil
+		}
+	}
+	// The statistics of tables being moved are inaccurate,
+	// only balance when all tables are replicating.
+	for _, tableID := range currentTables {
+		rep, ok := replications[tableID]
+		if !ok || rep.State != replication.ReplicationSetStateReplicating {
+			log.Debug("schedulerv3: not all table replicating, premature to balance table",
+				zap.String("namespace", b.changefeedID.Namespace),
+				zap.String("changefeed", b.changefeedID.ID))
+			return nil
+		}
+	}
+
+	// Tables violating placement rules are moved before balancing.
+	view := b.placement.newView(captures, replications)
+	moves := newPlacementMoveTables(
+		view, captures, replications, b.maxTaskConcurrency)
+	if len(moves) == 0 {
+		moves = newTrafficBalanceMoveTables(
+			captures, replications, b.imbalanceThreshold, b.maxTaskConcurrency, view)
+	}
+	tasks := make([]*replication.ScheduleTask, 0, len(moves))
+	for i := 0; i < len(moves); i++ {
+		// No need for accept callback here.
+		tasks = append(tasks, &replication.ScheduleTask{MoveTable: &moves[i]})
+	}
+	return tasks
+}
+
+// tableCost estimates the cost of replicating a table, it is the number of
+// events the table is expected to process per second.
+func tableCost(stats schedulepb.Stats) float64 {
+	return baseTableCost + float64(stats.EventRate) +
+		float64(stats.SorterBacklog)/sorterBacklogDrainSeconds
+}
+
+func newTrafficBalanceMoveTables(
+	captures map[model.CaptureID]*member.CaptureStatus,
+	replications map[model.TableID]*replication.ReplicationSet,
+	imbalanceThreshold float64,
+	maxTaskLimit int,
+	view *placementView,
+) []replication.MoveTable {
+	if len(captures) == 0 {
+		return nil
+	}
+
+	totalCost := float64(0)
+	captureCosts := make(map[model.CaptureID]float64, len(captures))
+	captureTables := make(map[model.CaptureID][]model.TableID, len(captures))
+	tableCosts := make(map[model.TableID]float64, len(replications))
+	for captureID := range captures {
+		captureCosts[captureID] = 0
+	}
+	for tableID, rep := range replications {
+		if rep.State != replication.ReplicationSetStateReplicating {
+			continue
+		}
+		if _, ok := captureCosts[rep.Primary]; !ok {
+			continue
+		}
+		cost := tableCost(rep.Stats)
+		tableCosts[tableID] = cost
+		captureCosts[rep.Primary] += cost
+		captureTables[rep.Primary] = append(captureTables[rep.Primary], tableID)
+		totalCost += cost
+	}
+	// Hysteresis, captures whose cost does not exceed the upper limit are
+	// considered balanced.
+	upperLimit := totalCost / float64(len(captures)) * (1 + imbalanceThreshold)
+
+	moved := make(map[model.TableID]struct{})
+	moveTables := make([]replication.MoveTable, 0)
+	for len(moveTables) < maxTaskLimit {
+		// Find the hottest and the coldest captures, ties are broken by
+		// capture ID so that the result is deterministic.
+		var source, target model.CaptureID
+		for captureID, cost := range captureCosts {
+			if source == "" || cost > captureCosts[source] ||
+				(cost == captureCosts[source] && captureID < source) {
+				source = captureID
+			}
+			if target == "" || cost < captureCosts[target] ||
+				(cost == captureCosts[target] && captureID < target) {
+				target = captureID
+			}
+		}
+		if captureCosts[source] <= upperLimit {
+			break
+		}
+
+		// Moving a table whose cost is closest to half of the gap reduces
+		// the gap the most. Tables whose cost is not less than the gap are
+		// skipped, since moving them makes the target hotter than the source.
+		// Tables that can not be placed on the target are skipped too.
+		gap := captureCosts[source] - captureCosts[target]
+		victim, found := model.TableID(0), false
+		for _, tableID := range captureTables[source] {
+			if _, ok := moved[tableID]; ok {
+				continue
+			}
+			if !view.canMove(tableID, source, target) {
+				continue
+			}
+			cost := tableCosts[tableID]
+			if cost >= gap {
+				continue
+			}
+			if !found {
+				victim, found = tableID, true
+				continue
+			}
+			diff := math.Abs(cost - gap/2)
+			victimDiff := math.Abs(tableCosts[victim] - gap/2)
+			if diff < victimDiff || (diff == victimDiff && tableID < victim) {
+				victim = tableID
+			}
+		}
+		if !found {
+			break
+		}
+
+		moveTables = append(moveTables, replication.MoveTable{
+			TableID:     victim,
+			DestCapture: target,
+		})
+		moved[victim] = struct{}{}
+		view.move(victim, source, target)
+		captureCosts[source] -= tableCosts[victim]
+		captureCosts[target] += tableCosts[victim]
+	}
+	return moveTables
+}
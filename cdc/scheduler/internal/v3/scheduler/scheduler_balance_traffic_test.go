@@ -0,0 +1,196 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/member"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/replication"
+	"github.com/pingcap/tiflow/cdc/scheduler/internal/v3/schedulepb"
+	"github.com/stretchr/testify/require"
+)
+
+func newReplicatingSet(
+	captureID model.CaptureID, eventRate uint64,
+) *replication.ReplicationSet {
+	return &replication.ReplicationSet{
+		State:   replication.ReplicationSetStateReplicating,
+		Primary: captureID,
+		Stats:   schedulepb.Stats{EventRate: eventRate},
+	}
+}
+
+func TestSchedulerTrafficBalance(t *testing.T) {
+	t.Parallel()
+
+	sched := newTrafficBalanceScheduler(
+		time.Duration(0), 0.2, 3, model.ChangeFeedID{}, nil)
+
+	// Capture "a" has the same number of tables as "b", but all hot tables.
+	captures := map[model.CaptureID]*member.CaptureStatus{"a": {}, "b": {}}
+	currentTables := []model.TableID{1, 2, 3, 4}
+	replications := map[model.TableID]*replication.ReplicationSet{
+		1: newReplicatingSet("a", 1000),
+		2: newReplicatingSet("a", 1000),
+		3: newReplicatingSet("b", 10),
+		4: newReplicatingSet("b", 10),
+	}
+	tasks := sched.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 1)
+	require.Equal(t, model.TableID(1), tasks[0].MoveTable.TableID)
+	require.Equal(t, "b", tasks[0].MoveTable.DestCapture)
+
+	// The traffic is balanced within the threshold.
+	replications[1] = newReplicatingSet("b", 1000)
+	tasks = sched.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 0)
+
+	// Do not balance if a capture is stopping.
+	replications[1] = newReplicatingSet("a", 1000)
+	captures["a"].State = member.CaptureStateStopping
+	tasks = sched.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 0)
+	captures["a"].State = member.CaptureStateInitialized
+
+	// Do not balance if some tables are not replicating.
+	replications[3].State = replication.ReplicationSetStatePrepare
+	tasks = sched.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 0)
+	replications[3].State = replication.ReplicationSetStateReplicating
+
+	// Do not balance if it has not passed the check balance interval.
+	sched.checkBalanceInterval = time.Hour
+	sched.lastRebalanceTime = time.Time{}
+	tasks = sched.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 1)
+	tasks = sched.Schedule(0, currentTables, captures, replications)
+	require.Len(t, tasks, 0)
+}
+
+func TestSchedulerTrafficBalanceHotTable(t *testing.T) {
+	t.Parallel()
+
+	// A single hot table can not be split, moving it makes the target
+	// capture hotter than the source capture.
+	captures := map[model.CaptureID]*member.CaptureStatus{"a": {}, "b": {}}
+	replications := map[model.TableID]*replication.ReplicationSet{
+		1: newReplicatingSet("a", 10000),
+		2: newReplicatingSet("b", 10),
+		3: newReplicatingSet("b", 10),
+	}
+	moves := newTrafficBalanceMoveTables(captures, replications, 0.2, 10, nil)
+	require.Len(t, moves, 0)
+
+	// Idle tables are balanced by count.
+	captures = map[model.CaptureID]*member.CaptureStatus{"a": {}, "b": {}}
+	replications = map[model.TableID]*replication.ReplicationSet{
+		1: newReplicatingSet("a", 0),
+		2: newReplicatingSet("a", 0),
+		3: newReplicatingSet("a", 0),
+		4: newReplicatingSet("a", 0),
+	}
+	moves = newTrafficBalanceMoveTables(captures, replications, 0.2, 10, nil)
+	require.Len(t, moves, 2)
+}
+
+func TestSchedulerTrafficBalanceTaskLimit(t *testing.T) {
+	t.Parallel()
+
+	captures := map[model.CaptureID]*member.CaptureStatus{"a": {}, "b": {}}
+	replications := map[model.TableID]*replication.ReplicationSet{}
+	for i := 1; i <= 8; i++ {
+		replications[model.TableID(i)] = newReplicatingSet("a", 100)
+	}
+	moves := newTrafficBalanceMoveTables(captures, replications, 0.2, 2, nil)
+	require.Len(t, moves, 2)
+	moves = newTrafficBalanceMoveTables(captures, replications, 0.2, 10, nil)
+	require.Len(t, moves, 4)
+	for _, move := range moves {
+		require.Equal(t, "b", move.DestCapture)
+	}
+}
+
+// newSkewedReplications returns replication sets that all tables are replicated
+// by the first capture, and the event rates of tables follow a Zipf distribution.
+func newSkewedReplications(
+	tableCount, captureCount int,
+) (map[model.CaptureID]*member.CaptureStatus, map[model.TableID]*replication.ReplicationSet) {
+	random := rand.New(rand.NewSource(0))
+	zipf := rand.NewZipf(random, 1.1, 1, 100000)
+	captures := make(map[model.CaptureID]*member.CaptureStatus, captureCount)
+	for i := 0; i < captureCount; i++ {
+		captures[fmt.Sprint(i)] = &member.CaptureStatus{}
+	}
+	replications := make(map[model.TableID]*replication.ReplicationSet, tableCount)
+	for i := 0; i < tableCount; i++ {
+		replications[model.TableID(i)] = newReplicatingSet("0", zipf.Uint64())
+	}
+	return captures, replications
+}
+
+func TestSchedulerTrafficBalanceSkewedWorkload(t *testing.T) {
+	t.Parallel()
+
+	const threshold = 0.2
+	captures, replications := newSkewedReplications(1000, 4)
+	for round := 0; ; round++ {
+		require.Less(t, round, 1000, "traffic balance does not converge")
+		moves := newTrafficBalanceMoveTables(captures, replications, threshold, 10, nil)
+		if len(moves) == 0 {
+			break
+		}
+		for _, move := range moves {
+			replications[move.TableID].Primary = move.DestCapture
+		}
+	}
+
+	totalCost := float64(0)
+	captureCosts := make(map[model.CaptureID]float64)
+	maxTableCost := float64(0)
+	for _, rep := range replications {
+		cost := tableCost(rep.Stats)
+		totalCost += cost
+		captureCosts[rep.Primary] += cost
+		if cost > maxTableCost {
+			maxTableCost = cost
+		}
+	}
+	avgCost := totalCost / float64(len(captures))
+	for captureID, cost := range captureCosts {
+		// A capture can only exceed the upper limit because of a single
+		// table that is too hot to be moved.
+		if cost > avgCost*(1+threshold) {
+			require.Greater(t, maxTableCost, avgCost*threshold, captureID)
+		}
+	}
+}
+
+func BenchmarkTrafficBalance(b *testing.B) {
+	size := 131072 // 2^17
+	for total := 1024; total <= size; total *= 4 {
+		captures, replications := newSkewedReplications(total, 8)
+		b.ResetTimer()
+		b.Run(fmt.Sprintf("TrafficBalance %d", total), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				newTrafficBalanceMoveTables(captures, replications, 0.2, 10, nil)
+			}
+		})
+		b.StopTimer()
+	}
+}
@@ -39,13 +39,17 @@ type basicScheduler struct {
 	lastRebalanceTime    time.Time
 	checkBalanceInterval time.Duration
 	changefeedID         model.ChangeFeedID
+	placement            *Placement
 }
 
-func newBasicScheduler(batchSize int, changefeed model.ChangeFeedID) *basicScheduler {
+func newBasicScheduler(
+	batchSize int, changefeed model.ChangeFeedID, placement *Placement,
+) *basicScheduler {
 	return &basicScheduler{
 		batchSize:    batchSize,
 		random:       rand.New(rand.NewSource(time.Now().UnixNano())),
 		changefeedID: changefeed,
+		placement:    placement,
 	}
 }
 
@@ -111,8 +115,9 @@ func (b *basicScheduler) Schedule(
 			zap.String("changefeed", b.changefeedID.ID),
 			zap.Strings("captureIDs", captureIDs),
 			zap.Int64s("tableIDs", newTables))
-		tasks = append(
-			tasks, newBurstAddTables(checkpointTs, newTables, captureIDs))
+		view := b.placement.newView(captures, replications)
+		tasks = append(tasks, newBurstAddTables(
+			checkpointTs, newTables, captureIDs, view, replications, b.changefeedID))
 	}
 
 	// Build remove table tasks.
@@ -147,17 +152,55 @@ func (b *basicScheduler) Schedule(
 }
 
 // newBurstAddTables add each new table to captures in a round-robin way.
+// Tables matching placement rules are added to captures satisfying the rules.
 func newBurstAddTables(
 	checkpointTs model.Ts, newTables []model.TableID, captureIDs []model.CaptureID,
+	view *placementView, replications map[model.TableID]*replication.ReplicationSet,
+	changefeedID model.ChangeFeedID,
 ) *replication.ScheduleTask {
+	var workloads map[model.CaptureID]int
+	if view != nil {
+		workloads = make(map[model.CaptureID]int, len(captureIDs))
+		for _, captureID := range captureIDs {
+			workloads[captureID] = 0
+		}
+		for _, rep := range replications {
+			if _, ok := workloads[rep.Primary]; ok {
+				workloads[rep.Primary]++
+			}
+		}
+	}
+
 	idx := 0
 	tables := make([]replication.AddTable, 0, len(newTables))
 	for _, tableID := range newTables {
+		if view.ruleOf(tableID) != nil {
+			if target, ok := view.pickTarget(tableID, "", workloads); ok {
+				tables = append(tables, replication.AddTable{
+					TableID:      tableID,
+					CaptureID:    target,
+					CheckpointTs: checkpointTs,
+				})
+				workloads[target]++
+				view.move(tableID, "", target)
+				continue
+			}
+			// Do not block the changefeed, the table is moved by the balance
+			// scheduler once there is a capture satisfying its rule.
+			log.Warn("schedulerv3: cannot find a capture satisfying "+
+				"the placement rule when add new table, ignore the rule",
+				zap.String("namespace", changefeedID.Namespace),
+				zap.String("changefeed", changefeedID.ID),
+				zap.Int64("tableID", tableID))
+		}
 		tables = append(tables, replication.AddTable{
 			TableID:      tableID,
 			CaptureID:    captureIDs[idx],
 			CheckpointTs: checkpointTs,
 		})
+		if workloads != nil {
+			workloads[captureIDs[idx]]++
+		}
 		idx++
 		if idx >= len(captureIDs) {
 			idx = 0
@@ -14,7 +14,6 @@
 package scheduler
 
 import (
-	"math"
 	"sync"
 
 	"github.com/pingcap/log"
@@ -35,15 +34,17 @@ type drainCaptureScheduler struct {
 
 	changefeedID       model.ChangeFeedID
 	maxTaskConcurrency int
+	placement          *Placement
 }
 
 func newDrainCaptureScheduler(
-	concurrency int, changefeed model.ChangeFeedID,
+	concurrency int, changefeed model.ChangeFeedID, placement *Placement,
 ) *drainCaptureScheduler {
 	return &drainCaptureScheduler{
 		target:             captureIDNotDraining,
 		maxTaskConcurrency: concurrency,
 		changefeedID:       changefeed,
+		placement:          placement,
 	}
 }
 
@@ -167,18 +168,24 @@ func (d *drainCaptureScheduler) Schedule(
 	}
 
 	// For each victim table, find the target for it
+	view := d.placement.newView(captures, replications)
 	result := make([]*replication.ScheduleTask, 0, maxTaskConcurrency)
 	for _, tableID := range victimTables {
-		target := ""
-		minWorkload := math.MaxInt64
-		for captureID, workload := range captureWorkload {
-			if workload < minWorkload {
-				minWorkload = workload
-				target = captureID
-			}
+		target, ok := view.pickTarget(tableID, d.target, captureWorkload)
+		if !ok {
+			// Draining takes precedence over placement rules, the table is
+			// moved back by the balance scheduler once there is a capture
+			// satisfying its rule.
+			log.Warn("schedulerv3: drain capture cannot find a capture "+
+				"satisfying the placement rule, ignore the rule",
+				zap.String("namespace", d.changefeedID.Namespace),
+				zap.String("changefeed", d.changefeedID.ID),
+				zap.Int64("tableID", tableID))
+			var noPlacement *placementView
+			target, ok = noPlacement.pickTarget(tableID, d.target, captureWorkload)
 		}
 
-		if minWorkload == math.MaxInt64 {
+		if !ok {
 			log.Panic("schedulerv3: drain capture meet unexpected min workload",
 				zap.String("namespace", d.changefeedID.Namespace),
 				zap.String("changefeed", d.changefeedID.ID),
@@ -195,6 +202,7 @@ func (d *drainCaptureScheduler) Schedule(
 
 		// Increase target workload to make sure tables are evenly distributed.
 		captureWorkload[target]++
+		view.move(tableID, d.target, target)
 	}
 
 	return result
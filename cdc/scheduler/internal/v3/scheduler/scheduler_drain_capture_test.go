@@ -25,7 +25,7 @@ import (
 func TestDrainCapture(t *testing.T) {
 	t.Parallel()
 
-	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{})
+	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{}, nil)
 	require.Equal(t, "drain-capture-scheduler", scheduler.Name())
 
 	var checkpointTs model.Ts
@@ -120,7 +120,7 @@ func TestDrainCapture(t *testing.T) {
 	require.Equal(t, "a", scheduler.target)
 	require.Len(t, tasks, 3)
 
-	scheduler = newDrainCaptureScheduler(1, model.ChangeFeedID{})
+	scheduler = newDrainCaptureScheduler(1, model.ChangeFeedID{}, nil)
 	require.True(t, scheduler.setTarget("a"))
 	tasks = scheduler.Schedule(checkpointTs, currentTables, captures, replications)
 	require.Equal(t, "a", scheduler.target)
@@ -134,7 +134,7 @@ func TestDrainStoppingCapture(t *testing.T) {
 	captures := make(map[model.CaptureID]*member.CaptureStatus)
 	currentTables := make([]model.TableID, 0)
 	replications := make(map[model.TableID]*replication.ReplicationSet)
-	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{})
+	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{}, nil)
 
 	tasks := scheduler.Schedule(checkpointTs, currentTables, captures, replications)
 	require.Empty(t, tasks)
@@ -165,7 +165,7 @@ func TestDrainSkipOwner(t *testing.T) {
 		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
 		2: {State: replication.ReplicationSetStateReplicating, Primary: "b"},
 	}
-	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{})
+	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{}, nil)
 	tasks := scheduler.Schedule(checkpointTs, currentTables, captures, replications)
 	require.Len(t, tasks, 0)
 	require.EqualValues(t, captureIDNotDraining, scheduler.getTarget())
@@ -184,7 +184,7 @@ func TestDrainImbalanceCluster(t *testing.T) {
 		1: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
 		2: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
 	}
-	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{})
+	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{}, nil)
 	scheduler.setTarget("a")
 	tasks := scheduler.Schedule(checkpointTs, currentTables, captures, replications)
 	require.Len(t, tasks, 2)
@@ -207,7 +207,7 @@ func TestDrainEvenlyDistributedTables(t *testing.T) {
 		3: {State: replication.ReplicationSetStateReplicating, Primary: "a"},
 		6: {State: replication.ReplicationSetStateReplicating, Primary: "b"},
 	}
-	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{})
+	scheduler := newDrainCaptureScheduler(10, model.ChangeFeedID{}, nil)
 	scheduler.setTarget("a")
 	tasks := scheduler.Schedule(checkpointTs, currentTables, captures, replications)
 	require.Len(t, tasks, 3)
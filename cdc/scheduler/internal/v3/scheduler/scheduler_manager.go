@@ -37,6 +37,7 @@ type Manager struct { //nolint:revive
 // NewSchedulerManager returns a new scheduler manager.
 func NewSchedulerManager(
 	changefeedID model.ChangeFeedID, cfg *config.SchedulerConfig,
+	placement *Placement,
 ) *Manager {
 	sm := &Manager{
 		maxTaskConcurrency: cfg.MaxTaskConcurrency,
@@ -49,13 +50,19 @@ func NewSchedulerManager(
 	}
 
 	sm.schedulers[schedulerPriorityBasic] = newBasicScheduler(
-		cfg.AddTableBatchSize, changefeedID)
+		cfg.AddTableBatchSize, changefeedID, placement)
 	sm.schedulers[schedulerPriorityDrainCapture] = newDrainCaptureScheduler(
-		cfg.MaxTaskConcurrency, changefeedID)
-	sm.schedulers[schedulerPriorityBalance] = newBalanceScheduler(
-		time.Duration(cfg.CheckBalanceInterval), cfg.MaxTaskConcurrency)
+		cfg.MaxTaskConcurrency, changefeedID, placement)
+	if cfg.BalanceByTraffic {
+		sm.schedulers[schedulerPriorityBalance] = newTrafficBalanceScheduler(
+			time.Duration(cfg.CheckBalanceInterval), cfg.TrafficImbalanceThreshold,
+			cfg.MaxTaskConcurrency, changefeedID, placement)
+	} else {
+		sm.schedulers[schedulerPriorityBalance] = newBalanceScheduler(
+			time.Duration(cfg.CheckBalanceInterval), cfg.MaxTaskConcurrency, placement)
+	}
 	sm.schedulers[schedulerPriorityMoveTable] = newMoveTableScheduler(changefeedID)
-	sm.schedulers[schedulerPriorityRebalance] = newRebalanceScheduler(changefeedID)
+	sm.schedulers[schedulerPriorityRebalance] = newRebalanceScheduler(changefeedID, placement)
 
 	return sm
 }
@@ -27,13 +27,19 @@ func TestNewSchedulerManager(t *testing.T) {
 	t.Parallel()
 
 	m := NewSchedulerManager(model.DefaultChangeFeedID("test-changefeed"),
-		config.NewDefaultSchedulerConfig())
+		config.NewDefaultSchedulerConfig(), nil)
 	require.NotNil(t, m)
 	require.NotNil(t, m.schedulers[schedulerPriorityBasic])
 	require.NotNil(t, m.schedulers[schedulerPriorityBalance])
 	require.NotNil(t, m.schedulers[schedulerPriorityMoveTable])
 	require.NotNil(t, m.schedulers[schedulerPriorityRebalance])
 	require.NotNil(t, m.schedulers[schedulerPriorityDrainCapture])
+	require.IsType(t, &balanceScheduler{}, m.schedulers[schedulerPriorityBalance])
+
+	cfg := config.NewDefaultSchedulerConfig()
+	cfg.BalanceByTraffic = true
+	m = NewSchedulerManager(model.DefaultChangeFeedID("test-changefeed"), cfg, nil)
+	require.IsType(t, &trafficBalanceScheduler{}, m.schedulers[schedulerPriorityBalance])
 }
 
 func TestSchedulerManagerScheduler(t *testing.T) {
@@ -41,7 +47,7 @@ func TestSchedulerManagerScheduler(t *testing.T) {
 
 	cfg := config.NewDefaultSchedulerConfig()
 	cfg.MaxTaskConcurrency = 1
-	m := NewSchedulerManager(model.DefaultChangeFeedID("test-changefeed"), cfg)
+	m := NewSchedulerManager(model.DefaultChangeFeedID("test-changefeed"), cfg, nil)
 
 	captures := map[model.CaptureID]*member.CaptureStatus{
 		"a": {State: member.CaptureStateInitialized},
@@ -34,13 +34,17 @@ type rebalanceScheduler struct {
 	random    *rand.Rand
 
 	changefeedID model.ChangeFeedID
+	placement    *Placement
 }
 
-func newRebalanceScheduler(changefeed model.ChangeFeedID) *rebalanceScheduler {
+func newRebalanceScheduler(
+	changefeed model.ChangeFeedID, placement *Placement,
+) *rebalanceScheduler {
 	return &rebalanceScheduler{
 		rebalance:    0,
 		random:       rand.New(rand.NewSource(time.Now().UnixNano())),
 		changefeedID: changefeed,
+		placement:    placement,
 	}
 }
 
@@ -89,7 +93,9 @@ func (r *rebalanceScheduler) Schedule(
 	}
 
 	unlimited := math.MaxInt
-	tasks := newBalanceMoveTables(r.random, captures, replications, unlimited, r.changefeedID)
+	view := r.placement.newView(captures, replications)
+	tasks := newBalanceMoveTables(
+		r.random, captures, replications, unlimited, view, r.changefeedID)
 	if len(tasks) == 0 {
 		return nil
 	}
@@ -110,6 +116,7 @@ func newBalanceMoveTables(
 	captures map[model.CaptureID]*member.CaptureStatus,
 	replications map[model.TableID]*replication.ReplicationSet,
 	maxTaskLimit int,
+	view *placementView,
 	changefeedID model.ChangeFeedID,
 ) []replication.MoveTable {
 	tablesPerCapture := make(map[model.CaptureID]*tableSet)
@@ -128,7 +135,8 @@ func newBalanceMoveTables(
 	upperLimitPerCapture := int(math.Ceil(float64(len(replications)) / float64(len(captures))))
 
 	victims := make([]model.TableID, 0)
-	for _, ts := range tablesPerCapture {
+	victimSources := make(map[model.TableID]model.CaptureID)
+	for captureID, ts := range tablesPerCapture {
 		tables := ts.keys()
 		if random != nil {
 			// Complexity note: Shuffle has O(n), where `n` is the number of tables.
@@ -158,6 +166,7 @@ func newBalanceMoveTables(
 				break
 			}
 			victims = append(victims, table)
+			victimSources[table] = captureID
 			ts.remove(table)
 			tableNum2Remove--
 		}
@@ -172,32 +181,33 @@ func newBalanceMoveTables(
 	}
 	// for each victim table, find the target for it
 	moveTables := make([]replication.MoveTable, 0, len(victims))
-	for idx, tableID := range victims {
-		target := ""
-		minWorkload := math.MaxInt64
-
-		for captureID, workload := range captureWorkload {
-			if workload < minWorkload {
-				minWorkload = workload
-				target = captureID
-			}
+	for _, tableID := range victims {
+		if len(moveTables) >= maxTaskLimit {
+			// We have reached the task limit.
+			break
 		}
-
-		if minWorkload == math.MaxInt64 {
+		source := victimSources[tableID]
+		target, ok := view.pickTarget(tableID, source, captureWorkload)
+		if !ok && view == nil {
 			log.Panic("schedulerv3: rebalance meet unexpected min workload "+
 				"when try to the the target capture",
 				zap.String("namespace", changefeedID.Namespace),
 				zap.String("changefeed", changefeedID.ID))
 		}
-		if idx >= maxTaskLimit {
-			// We have reached the task limit.
-			break
+		if !ok || target == source ||
+			tablesPerCapture[target].size() >= upperLimitPerCapture {
+			// There is no capture satisfying the placement rule of the
+			// table, keep it on the source capture.
+			tablesPerCapture[source].add(tableID)
+			captureWorkload[source] = randomizeWorkload(random, tablesPerCapture[source].size())
+			continue
 		}
 
 		moveTables = append(moveTables, replication.MoveTable{
 			TableID:     tableID,
 			DestCapture: target,
 		})
+		view.move(tableID, source, target)
 		tablesPerCapture[target].add(tableID)
 		captureWorkload[target] = randomizeWorkload(random, tablesPerCapture[target].size())
 	}
@@ -52,7 +52,7 @@ func TestSchedulerRebalance(t *testing.T) {
 		4: {State: replication.ReplicationSetStateAbsent},
 	}
 
-	scheduler := newRebalanceScheduler(model.ChangeFeedID{})
+	scheduler := newRebalanceScheduler(model.ChangeFeedID{}, nil)
 	require.Equal(t, "rebalance-scheduler", scheduler.Name())
 	// rebalance is not triggered
 	tasks := scheduler.Schedule(checkpointTs, currentTables, captures, replications)
@@ -55,6 +55,9 @@ type Query internal.Query
 // Note that Agent is not thread-safe
 type Agent internal.Agent
 
+// TableNameGetter returns the name of a table by its physical table ID.
+type TableNameGetter internal.TableNameGetter
+
 // CheckpointCannotProceed is a placeholder indicating that the
 // Owner should not advance the global checkpoint TS just yet.
 const CheckpointCannotProceed = internal.CheckpointCannotProceed
@@ -116,10 +119,13 @@ func NewSchedulerV3(
 	messageRouter p2p.MessageRouter,
 	ownerRevision int64,
 	cfg *config.SchedulerConfig,
+	replicaConfig *config.ReplicaConfig,
+	tableNames TableNameGetter,
 ) (Scheduler, error) {
 	return v3.NewCoordinator(
 		ctx, captureID, changeFeedID, checkpointTs,
-		messageServer, messageRouter, ownerRevision, cfg)
+		messageServer, messageRouter, ownerRevision, cfg,
+		replicaConfig, internal.TableNameGetter(tableNames))
 }
 
 // InitMetrics registers all metrics used in scheduler
@@ -47,6 +47,7 @@ type BatchEncoder struct {
 	maxMessageBytes    int
 
 	enableTiDBExtension        bool
+	enableWatermark            bool
 	decimalHandlingMode        string
 	bigintUnsignedHandlingMode string
 }
@@ -76,7 +77,9 @@ func (a *BatchEncoder) AppendRowChangedEvent(
 	)
 	topic = sanitizeTopic(topic)
 
-	if !e.IsDelete() {
+	// The value of the delete event is nil, unless the TiDB extension is
+	// enabled, in which case the old values and the commit ts are sent.
+	if !e.IsDelete() || a.enableTiDBExtension {
 		res, err := a.avroEncode(ctx, e, topic, false)
 		if err != nil {
 			log.Error("AppendRowChangedEvent: avro encoding failed", zap.Error(err))
@@ -130,9 +133,46 @@ func (a *BatchEncoder) AppendRowChangedEvent(
 	return nil
 }
 
-// EncodeCheckpointEvent is no-op for now
+// EncodeCheckpointEvent encodes the checkpoint ts as a watermark event if the
+// watermark is enabled, otherwise it is no-op.
+// The watermark event is not in the confluent avro wire format, it is only
+// used by the consumers aware of it, such as the kafka-consumer.
 func (a *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error) {
-	return nil, nil
+	if !a.enableTiDBExtension || !a.enableWatermark {
+		return nil, nil
+	}
+
+	value, err := encodeTsEvent(checkpointByte, ts)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewResolvedMsg(config.ProtocolAvro, nil, value, ts), nil
+}
+
+// EncodeSyncPointEvent encodes the syncpoint ts in the same format as the
+// watermark event, so it's also only sent if the watermark is enabled.
+func (a *BatchEncoder) EncodeSyncPointEvent(ts uint64) (*common.Message, error) {
+	if !a.enableTiDBExtension || !a.enableWatermark {
+		return nil, nil
+	}
+
+	value, err := encodeTsEvent(syncPointByte, ts)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewSyncPointMsg(config.ProtocolAvro, nil, value, ts), nil
+}
+
+func encodeTsEvent(tp uint8, ts uint64) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	data := []interface{}{tp, ts}
+	for _, v := range data {
+		err := binary.Write(buf, binary.BigEndian, v)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrAvroToEnvelopeError, err)
+		}
+	}
+	return buf.Bytes(), nil
 }
 
 // EncodeDDLEvent is no-op now
@@ -150,6 +190,7 @@ func (a *BatchEncoder) Build() (messages []*common.Message) {
 const (
 	insertOperation = "c"
 	updateOperation = "u"
+	deleteOperation = "d"
 )
 
 func (a *BatchEncoder) avroEncode(
@@ -178,6 +219,9 @@ func (a *BatchEncoder) avroEncode(
 			operation = insertOperation
 		} else if e.IsUpdate() {
 			operation = updateOperation
+		} else if e.IsDelete() {
+			cols = e.PreColumns
+			operation = deleteOperation
 		} else {
 			log.Error("unknown operation", zap.Any("rowChangedEvent", e))
 			return nil, cerror.ErrAvroEncodeFailed.GenWithStack("unknown operation")
@@ -382,6 +426,10 @@ func rowToAvroSchema(
 	}
 
 	for i, col := range columnInfo {
+		// The column may be nil if it is not selected.
+		if col == nil {
+			continue
+		}
 		avroType, err := columnToAvroSchema(
 			col,
 			colInfos[i].Ft,
@@ -778,7 +826,15 @@ func columnToAvroData(
 	}
 }
 
-const magicByte = uint8(0)
+const (
+	magicByte = uint8(0)
+	// checkpointByte is the first byte of the watermark events,
+	// which is followed by the checkpoint ts in big endian.
+	checkpointByte = uint8(2)
+	// syncPointByte is the first byte of the syncpoint events,
+	// which is followed by the syncpoint ts in big endian.
+	syncPointByte = uint8(3)
+)
 
 // confluent avro wire format, confluent avro is not same as apache avro
 // https://rmoff.net/2020/07/03/why-json-isnt-the-same-as-json-schema-in-kafka-connect-converters \
@@ -846,6 +902,7 @@ func (b *batchEncoderBuilder) Build() codec.EventBatchEncoder {
 	encoder.resultBuf = make([]*common.Message, 0, 4096)
 	encoder.maxMessageBytes = b.config.MaxMessageBytes
 	encoder.enableTiDBExtension = b.config.EnableTiDBExtension
+	encoder.enableWatermark = b.config.AvroEnableWatermark
 	encoder.decimalHandlingMode = b.config.AvroDecimalHandlingMode
 	encoder.bigintUnsignedHandlingMode = b.config.AvroBigintUnsignedHandlingMode
 
@@ -0,0 +1,418 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// batchDecoder decodes the avro messages into the original events.
+// Each message contains only one event, and the value of the delete event
+// is nil unless the TiDB extension is enabled.
+type batchDecoder struct {
+	ctx                 context.Context
+	key                 []byte
+	value               []byte
+	enableTiDBExtension bool
+	schemaManager       *schemaManager
+}
+
+// HasNext implements the EventBatchDecoder interface
+func (d *batchDecoder) HasNext() (model.MessageType, bool, error) {
+	if len(d.key) == 0 && len(d.value) == 0 {
+		return model.MessageTypeUnknown, false, nil
+	}
+	if len(d.value) > 0 && d.value[0] == checkpointByte {
+		return model.MessageTypeResolved, true, nil
+	}
+	if len(d.value) > 0 && d.value[0] == syncPointByte {
+		return model.MessageTypeSyncPoint, true, nil
+	}
+	return model.MessageTypeRow, true, nil
+}
+
+// NextResolvedEvent implements the EventBatchDecoder interface
+func (d *batchDecoder) NextResolvedEvent() (uint64, error) {
+	if len(d.value) != 9 || d.value[0] != checkpointByte {
+		return 0, cerror.ErrAvroDecodeFailed.GenWithStack(
+			"not found resolved event message")
+	}
+	ts := binary.BigEndian.Uint64(d.value[1:])
+	d.key, d.value = nil, nil
+	return ts, nil
+}
+
+// NextSyncPointEvent implements the EventBatchDecoder interface
+func (d *batchDecoder) NextSyncPointEvent() (uint64, error) {
+	if len(d.value) != 9 || d.value[0] != syncPointByte {
+		return 0, cerror.ErrAvroDecodeFailed.GenWithStack(
+			"not found syncpoint event message")
+	}
+	ts := binary.BigEndian.Uint64(d.value[1:])
+	d.key, d.value = nil, nil
+	return ts, nil
+}
+
+// NextRowChangedEvent implements the EventBatchDecoder interface
+func (d *batchDecoder) NextRowChangedEvent() (*model.RowChangedEvent, error) {
+	if len(d.key) == 0 && len(d.value) == 0 {
+		return nil, cerror.ErrAvroDecodeFailed.GenWithStack(
+			"not found row changed event message")
+	}
+
+	var (
+		keyNative map[string]interface{}
+		keySchema *avroSchemaTop
+		err       error
+	)
+	if len(d.key) > 0 {
+		keyNative, keySchema, err = d.decodeEnvelope(d.key)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	// The value of the delete event is nil if the TiDB extension is not enabled,
+	// the handle key columns in the key are used as the old values.
+	if len(d.value) == 0 {
+		event := new(model.RowChangedEvent)
+		event.Table = keySchema.tableName()
+		event.PreColumns, err = nativeToColumns(keyNative, keySchema)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		event.WithHandlePrimaryFlag(handleKeyNames(keySchema))
+		d.key = nil
+		return event, nil
+	}
+
+	native, schema, err := d.decodeEnvelope(d.value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	columns, err := nativeToColumns(native, schema)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	event := new(model.RowChangedEvent)
+	event.Table = schema.tableName()
+	operation := insertOperation
+	if d.enableTiDBExtension {
+		op, ok := native[tidbOp].(string)
+		if !ok {
+			return nil, cerror.ErrAvroDecodeFailed.GenWithStack(
+				"%s not found, is the TiDB extension enabled", tidbOp)
+		}
+		operation = op
+		commitTs, ok := native[tidbCommitTs].(int64)
+		if !ok {
+			return nil, cerror.ErrAvroDecodeFailed.GenWithStack(
+				"%s not found, is the TiDB extension enabled", tidbCommitTs)
+		}
+		event.CommitTs = uint64(commitTs)
+	}
+	// The old values of the update event are not sent,
+	// so the update event is decoded as an insert one.
+	if operation == deleteOperation {
+		event.PreColumns = columns
+	} else {
+		event.Columns = columns
+	}
+	if keySchema != nil {
+		event.WithHandlePrimaryFlag(handleKeyNames(keySchema))
+	}
+
+	d.key, d.value = nil, nil
+	return event, nil
+}
+
+// NextDDLEvent implements the EventBatchDecoder interface.
+// The avro protocol doesn't send DDL events.
+func (d *batchDecoder) NextDDLEvent() (*model.DDLEvent, error) {
+	return nil, cerror.ErrAvroDecodeFailed.GenWithStack(
+		"avro protocol doesn't support DDL events")
+}
+
+// decodeEnvelope decodes the data in the confluent avro wire format,
+// the schema is fetched from the Registry by the ID in the envelope.
+func (d *batchDecoder) decodeEnvelope(
+	data []byte,
+) (map[string]interface{}, *avroSchemaTop, error) {
+	if len(data) < 5 || data[0] != magicByte {
+		return nil, nil, cerror.ErrAvroDecodeFailed.GenWithStack(
+			"invalid avro message, the magic byte or the schema ID is missing")
+	}
+	registryID := int(int32(binary.BigEndian.Uint32(data[1:5])))
+	avroCodec, err := d.schemaManager.LookupByID(d.ctx, registryID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	native, _, err := avroCodec.NativeFromBinary(data[5:])
+	if err != nil {
+		return nil, nil, cerror.WrapError(cerror.ErrAvroDecodeFailed, err)
+	}
+	result, ok := native.(map[string]interface{})
+	if !ok {
+		return nil, nil, cerror.ErrAvroDecodeFailed.GenWithStack(
+			"the avro data is not a record")
+	}
+
+	schema := new(avroSchemaTop)
+	if err := json.Unmarshal([]byte(avroCodec.Schema()), schema); err != nil {
+		return nil, nil, cerror.WrapError(cerror.ErrAvroDecodeFailed, err)
+	}
+	return result, schema, nil
+}
+
+// tableName extracts the table name from the schema, which is generated by
+// `rowToAvroSchema`, the schema name is the last part of the namespace.
+// Note: the names are sanitized by the encoder, so they may be different
+// from the upstream ones if they contain characters not permitted by avro.
+func (s *avroSchemaTop) tableName() *model.TableName {
+	if s == nil {
+		return nil
+	}
+	schema := s.Namespace
+	if i := strings.LastIndex(schema, "."); i >= 0 {
+		schema = schema[i+1:]
+	}
+	return &model.TableName{
+		Schema: schema,
+		Table:  s.Name,
+	}
+}
+
+func handleKeyNames(keySchema *avroSchemaTop) map[string]struct{} {
+	names := make(map[string]struct{}, len(keySchema.Fields))
+	for _, field := range keySchema.Fields {
+		if name, ok := field["name"].(string); ok {
+			names[name] = struct{}{}
+		}
+	}
+	return names
+}
+
+// nativeToColumns converts the decoded avro record to columns,
+// the columns are in the same order as the fields of the schema.
+func nativeToColumns(
+	native map[string]interface{}, schema *avroSchemaTop,
+) ([]*model.Column, error) {
+	columns := make([]*model.Column, 0, len(schema.Fields))
+	for _, field := range schema.Fields {
+		name, ok := field["name"].(string)
+		if !ok {
+			return nil, cerror.ErrAvroDecodeFailed.GenWithStack(
+				"field name not found in schema %s.%s", schema.Namespace, schema.Name)
+		}
+		if name == tidbOp || name == tidbCommitTs || name == tidbPhysicalTime {
+			continue
+		}
+		fieldSchema, nullable := getFieldSchema(field["type"])
+		if fieldSchema == nil {
+			return nil, cerror.ErrAvroDecodeFailed.GenWithStack(
+				"invalid schema of field %s", name)
+		}
+		col, err := avroDataToColumn(name, native[name], fieldSchema)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if nullable {
+			col.Flag.SetIsNullable()
+		}
+		columns = append(columns, col)
+	}
+	return columns, nil
+}
+
+// getFieldSchema returns the schema of the non-null type of the field,
+// and whether the field is nullable.
+func getFieldSchema(tp interface{}) (map[string]interface{}, bool) {
+	switch v := tp.(type) {
+	case map[string]interface{}:
+		return v, false
+	case []interface{}:
+		for _, t := range v {
+			if s, ok := t.(map[string]interface{}); ok {
+				return s, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// avroDataToColumn converts the avro data to a column, it is the reverse
+// of `columnToAvroData`, the mysql type is restored by the `tidb_type`
+// parameter of the field, so the types of the same TiDB type share one
+// mysql type, such as TINYINT and INT.
+func avroDataToColumn(
+	name string, data interface{}, fieldSchema map[string]interface{},
+) (*model.Column, error) {
+	params, _ := fieldSchema["connect.parameters"].(map[string]interface{})
+	tt, _ := params[tidbType].(string)
+
+	col := &model.Column{Name: name}
+	switch tt {
+	case "INT", "INT UNSIGNED":
+		col.Type = mysql.TypeLong
+	case "BIGINT", "BIGINT UNSIGNED":
+		col.Type = mysql.TypeLonglong
+	case "FLOAT":
+		col.Type = mysql.TypeFloat
+	case "DOUBLE":
+		col.Type = mysql.TypeDouble
+	case "BIT":
+		col.Type = mysql.TypeBit
+	case "DECIMAL":
+		col.Type = mysql.TypeNewDecimal
+	case "TEXT":
+		col.Type = mysql.TypeVarchar
+	case "BLOB":
+		col.Type = mysql.TypeBlob
+		col.Flag.SetIsBinary()
+	case "ENUM":
+		col.Type = mysql.TypeEnum
+	case "SET":
+		col.Type = mysql.TypeSet
+	case "JSON":
+		col.Type = mysql.TypeJSON
+	case "DATE":
+		col.Type = mysql.TypeDate
+	case "DATETIME":
+		col.Type = mysql.TypeDatetime
+	case "TIMESTAMP":
+		col.Type = mysql.TypeTimestamp
+	case "TIME":
+		col.Type = mysql.TypeDuration
+	case "YEAR":
+		col.Type = mysql.TypeYear
+	default:
+		log.Error("unknown tidb type", zap.String("name", name), zap.String("tidbType", tt))
+		return nil, cerror.ErrAvroDecodeFailed.GenWithStack(
+			"unknown tidb type %s of column %s", tt, name)
+	}
+	if strings.HasSuffix(tt, " UNSIGNED") {
+		col.Flag.SetIsUnsigned()
+	}
+
+	// The nullable data is a union, which is a map with only one entry.
+	if union, ok := data.(map[string]interface{}); ok {
+		for _, v := range union {
+			data = v
+		}
+	}
+	if data == nil {
+		return col, nil
+	}
+
+	var err error
+	switch v := data.(type) {
+	case int32:
+		if col.Flag.IsUnsigned() {
+			col.Value = uint64(v)
+		} else {
+			col.Value = int64(v)
+		}
+	case int64:
+		if col.Flag.IsUnsigned() {
+			col.Value = uint64(v)
+		} else {
+			col.Value = v
+		}
+	case float64:
+		col.Value = v
+	case *big.Rat:
+		scale := 0
+		if s, ok := fieldSchema["scale"].(float64); ok {
+			scale = int(s)
+		}
+		col.Value = v.FloatString(scale)
+	case []byte:
+		if col.Type == mysql.TypeBit {
+			col.Value = bitsToUint64(v)
+		} else {
+			col.Value = v
+		}
+	case string:
+		col.Value = v
+		// BIGINT UNSIGNED is encoded as string in the string handling mode.
+		if col.Type == mysql.TypeLonglong && col.Flag.IsUnsigned() {
+			col.Value, err = strconv.ParseUint(v, 10, 64)
+		}
+	default:
+		err = errors.Errorf("unexpected data type %T", data)
+	}
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrAvroDecodeFailed, err)
+	}
+	return col, nil
+}
+
+// bitsToUint64 converts the big endian bytes of the BIT column to uint64.
+func bitsToUint64(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
+
+// BatchDecoderBuilder builds the avro decoders, which share the
+// schema manager to avoid fetching the same schema repeatedly.
+type BatchDecoderBuilder struct {
+	ctx                 context.Context
+	enableTiDBExtension bool
+	schemaManager       *schemaManager
+}
+
+// NewBatchDecoderBuilder creates a BatchDecoderBuilder with the Registry.
+func NewBatchDecoderBuilder(
+	ctx context.Context, registryURL string, enableTiDBExtension bool,
+) (*BatchDecoderBuilder, error) {
+	// The schemas are looked up by ID, so the subject suffix is not used.
+	schemaManager, err := NewAvroSchemaManager(ctx, nil, registryURL, "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &BatchDecoderBuilder{
+		ctx:                 ctx,
+		enableTiDBExtension: enableTiDBExtension,
+		schemaManager:       schemaManager,
+	}, nil
+}
+
+// Build creates a decoder for the key and value of a kafka message.
+func (b *BatchDecoderBuilder) Build(key, value []byte) codec.EventBatchDecoder {
+	return &batchDecoder{
+		ctx:                 b.ctx,
+		key:                 key,
+		value:               value,
+		enableTiDBExtension: b.enableTiDBExtension,
+		schemaManager:       b.schemaManager,
+	}
+}
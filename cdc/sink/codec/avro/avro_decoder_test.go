@@ -0,0 +1,295 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSchemaRegistry starts a schema registry stand-in, which supports
+// registering schemas and looking up schemas by ID.
+func newTestSchemaRegistry(t *testing.T) *httptest.Server {
+	var (
+		mu      sync.Mutex
+		schemas []string
+	)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/":
+			_, _ = w.Write([]byte("{}"))
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/subjects/"):
+			var req registerRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			id := 0
+			for i, schema := range schemas {
+				if schema == req.Schema {
+					id = i + 1
+				}
+			}
+			if id == 0 {
+				schemas = append(schemas, req.Schema)
+				id = len(schemas)
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(&registerResponse{ID: id}))
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/schemas/ids/"):
+			id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/schemas/ids/"))
+			if err != nil || id <= 0 || id > len(schemas) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			resp := lookupResponse{RegistryID: id, Schema: schemas[id-1]}
+			require.NoError(t, json.NewEncoder(w).Encode(&resp))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	return httptest.NewServer(http.HandlerFunc(handler))
+}
+
+func newTestEncoder(
+	ctx context.Context, t *testing.T, registryURL string,
+) *BatchEncoder {
+	keyManager, err := NewAvroSchemaManager(ctx, nil, registryURL, keySchemaSuffix)
+	require.NoError(t, err)
+	valueManager, err := NewAvroSchemaManager(ctx, nil, registryURL, valueSchemaSuffix)
+	require.NoError(t, err)
+
+	return &BatchEncoder{
+		namespace:                  model.DefaultNamespace,
+		keySchemaManager:           keyManager,
+		valueSchemaManager:         valueManager,
+		resultBuf:                  make([]*common.Message, 0, 4096),
+		maxMessageBytes:            math.MaxInt,
+		enableTiDBExtension:        true,
+		enableWatermark:            true,
+		decimalHandlingMode:        common.DecimalHandlingModePrecise,
+		bigintUnsignedHandlingMode: common.BigintUnsignedHandlingModeLong,
+	}
+}
+
+func TestAvroDecodeRowChangedEvent(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	registry := newTestSchemaRegistry(t)
+	defer registry.Close()
+	encoder := newTestEncoder(ctx, t, registry.URL)
+	builder, err := NewBatchDecoderBuilder(ctx, registry.URL, true)
+	require.NoError(t, err)
+
+	decimalFt := types.NewFieldType(mysql.TypeNewDecimal)
+	decimalFt.SetFlen(10)
+	decimalFt.SetDecimal(3)
+	bitFt := types.NewFieldType(mysql.TypeBit)
+	bitFt.SetFlen(10)
+
+	colInfos := []rowcodec.ColInfo{
+		{ID: 1, IsPKHandle: true, Ft: types.NewFieldType(mysql.TypeLong)},
+		{ID: 2, Ft: setFlag(types.NewFieldType(mysql.TypeTiny), uint(model.UnsignedFlag))},
+		{ID: 3, Ft: setFlag(types.NewFieldType(mysql.TypeLonglong), uint(model.UnsignedFlag))},
+		{ID: 4, Ft: decimalFt},
+		{ID: 5, Ft: types.NewFieldType(mysql.TypeVarchar)},
+		{ID: 6, Ft: setBinChsClnFlag(types.NewFieldType(mysql.TypeBlob))},
+		{ID: 7, Ft: bitFt},
+		{ID: 8, Ft: setElems(types.NewFieldType(mysql.TypeEnum), []string{"a", "b"})},
+		{ID: 9, Ft: types.NewFieldType(mysql.TypeDatetime)},
+		{ID: 10, Ft: types.NewFieldType(mysql.TypeYear)},
+		{ID: 11, Ft: types.NewFieldType(mysql.TypeDouble)},
+	}
+	newColumns := func(name string) []*model.Column {
+		return []*model.Column{
+			{
+				Name: "id", Type: mysql.TypeLong, Value: int64(1),
+				Flag: model.HandleKeyFlag | model.PrimaryKeyFlag,
+			},
+			{Name: "tiny", Type: mysql.TypeTiny, Value: uint64(255), Flag: model.UnsignedFlag},
+			{
+				Name: "bigint", Type: mysql.TypeLonglong, Value: uint64(math.MaxUint64),
+				Flag: model.UnsignedFlag,
+			},
+			{Name: "decimal", Type: mysql.TypeNewDecimal, Value: "1234567.891"},
+			{Name: "name", Type: mysql.TypeVarchar, Value: []byte(name)},
+			{Name: "blob", Type: mysql.TypeBlob, Value: []byte{0x1, 0x2}, Flag: model.BinaryFlag},
+			{Name: "bit", Type: mysql.TypeBit, Value: uint64(683)},
+			{Name: "enum", Type: mysql.TypeEnum, Value: uint64(2)},
+			{Name: "datetime", Type: mysql.TypeDatetime, Value: "2022-09-01 10:35:12"},
+			{Name: "year", Type: mysql.TypeYear, Value: int64(2022)},
+			{Name: "double", Type: mysql.TypeDouble, Value: nil, Flag: model.NullableFlag},
+		}
+	}
+	expectedColumns := func(name string) []*model.Column {
+		return []*model.Column{
+			{
+				Name: "id", Type: mysql.TypeLong, Value: int64(1),
+				Flag: model.HandleKeyFlag | model.PrimaryKeyFlag,
+			},
+			{Name: "tiny", Type: mysql.TypeLong, Value: uint64(255), Flag: model.UnsignedFlag},
+			{
+				Name: "bigint", Type: mysql.TypeLonglong, Value: uint64(math.MaxUint64),
+				Flag: model.UnsignedFlag,
+			},
+			{Name: "decimal", Type: mysql.TypeNewDecimal, Value: "1234567.891"},
+			{Name: "name", Type: mysql.TypeVarchar, Value: name},
+			{Name: "blob", Type: mysql.TypeBlob, Value: []byte{0x1, 0x2}, Flag: model.BinaryFlag},
+			{Name: "bit", Type: mysql.TypeBit, Value: uint64(683)},
+			{Name: "enum", Type: mysql.TypeEnum, Value: "b"},
+			{Name: "datetime", Type: mysql.TypeDatetime, Value: "2022-09-01 10:35:12"},
+			{Name: "year", Type: mysql.TypeYear, Value: int64(2022)},
+			{Name: "double", Type: mysql.TypeDouble, Value: nil, Flag: model.NullableFlag},
+		}
+	}
+
+	table := &model.TableName{Schema: "test", Table: "t1"}
+	events := []*model.RowChangedEvent{
+		{
+			CommitTs: 100, Table: table, ColInfos: colInfos,
+			Columns: newColumns("insert"),
+		},
+		{
+			CommitTs: 101, Table: table, ColInfos: colInfos,
+			Columns: newColumns("update"), PreColumns: newColumns("insert"),
+		},
+		{
+			CommitTs: 102, Table: table, ColInfos: colInfos,
+			PreColumns: newColumns("update"),
+		},
+	}
+	expected := []*model.RowChangedEvent{
+		{CommitTs: 100, Table: table, Columns: expectedColumns("insert")},
+		// The old values of the update event are not sent.
+		{CommitTs: 101, Table: table, Columns: expectedColumns("update")},
+		{CommitTs: 102, Table: table, PreColumns: expectedColumns("update")},
+	}
+
+	for i, event := range events {
+		err := encoder.AppendRowChangedEvent(ctx, "avro-test", event, nil)
+		require.NoError(t, err)
+		msgs := encoder.Build()
+		require.Len(t, msgs, 1)
+
+		decoder := builder.Build(msgs[0].Key, msgs[0].Value)
+		tp, hasNext, err := decoder.HasNext()
+		require.NoError(t, err)
+		require.True(t, hasNext)
+		require.Equal(t, model.MessageTypeRow, tp)
+		decoded, err := decoder.NextRowChangedEvent()
+		require.NoError(t, err)
+		require.Equal(t, expected[i], decoded, fmt.Sprintf("event %d", i))
+
+		_, hasNext, err = decoder.HasNext()
+		require.NoError(t, err)
+		require.False(t, hasNext)
+	}
+
+	// Without the TiDB extension, the delete event only contains the handle key.
+	encoder.enableTiDBExtension = false
+	err = encoder.AppendRowChangedEvent(ctx, "avro-test", events[2], nil)
+	require.NoError(t, err)
+	msgs := encoder.Build()
+	require.Len(t, msgs, 1)
+	require.Nil(t, msgs[0].Value)
+
+	builder.enableTiDBExtension = false
+	decoder := builder.Build(msgs[0].Key, msgs[0].Value)
+	tp, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeRow, tp)
+	decoded, err := decoder.NextRowChangedEvent()
+	require.NoError(t, err)
+	require.Equal(t, &model.RowChangedEvent{
+		Table:      table,
+		PreColumns: expectedColumns("update")[:1],
+	}, decoded)
+}
+
+func TestAvroDecodeResolvedEvent(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	registry := newTestSchemaRegistry(t)
+	defer registry.Close()
+	encoder := newTestEncoder(ctx, t, registry.URL)
+	builder, err := NewBatchDecoderBuilder(ctx, registry.URL, true)
+	require.NoError(t, err)
+
+	msg, err := encoder.EncodeCheckpointEvent(417318403368288260)
+	require.NoError(t, err)
+	require.NotNil(t, msg)
+
+	decoder := builder.Build(msg.Key, msg.Value)
+	tp, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeResolved, tp)
+	ts, err := decoder.NextResolvedEvent()
+	require.NoError(t, err)
+	require.Equal(t, uint64(417318403368288260), ts)
+
+	// The watermark is not sent if it is not enabled.
+	encoder.enableWatermark = false
+	msg, err = encoder.EncodeCheckpointEvent(417318403368288260)
+	require.NoError(t, err)
+	require.Nil(t, msg)
+}
+
+func TestAvroDecodeSyncPointEvent(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	registry := newTestSchemaRegistry(t)
+	defer registry.Close()
+	encoder := newTestEncoder(ctx, t, registry.URL)
+	builder, err := NewBatchDecoderBuilder(ctx, registry.URL, true)
+	require.NoError(t, err)
+
+	msg, err := encoder.EncodeSyncPointEvent(417318403368288260)
+	require.NoError(t, err)
+	require.Equal(t, model.MessageTypeSyncPoint, msg.Type)
+
+	decoder := builder.Build(msg.Key, msg.Value)
+	tp, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeSyncPoint, tp)
+	_, err = decoder.NextResolvedEvent()
+	require.Error(t, err)
+	ts, err := decoder.NextSyncPointEvent()
+	require.NoError(t, err)
+	require.Equal(t, uint64(417318403368288260), ts)
+
+	// The syncpoint is not sent if the watermark is not enabled.
+	encoder.enableWatermark = false
+	msg, err = encoder.EncodeSyncPointEvent(417318403368288260)
+	require.NoError(t, err)
+	require.Nil(t, msg)
+}
@@ -20,6 +20,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -45,6 +46,9 @@ type schemaManager struct {
 
 	cacheRWLock sync.RWMutex
 	cache       map[string]*schemaCacheEntry
+	// idCache caches the codecs by the Registry designated ID,
+	// it is only used by the decoder.
+	idCache map[int]*goavro.Codec
 }
 
 type schemaCacheEntry struct {
@@ -105,6 +109,7 @@ func NewAvroSchemaManager(
 	return &schemaManager{
 		registryURL:   registryURL,
 		cache:         make(map[string]*schemaCacheEntry, 1),
+		idCache:       make(map[int]*goavro.Codec, 1),
 		subjectSuffix: subjectSuffix,
 	}, nil
 }
@@ -295,6 +300,87 @@ func (m *schemaManager) Lookup(
 	return cacheEntry.codec, cacheEntry.registryID, nil
 }
 
+// LookupByID looks up the schema by the Registry designated ID.
+// A registered schema is never changed, so it is cached once fetched.
+func (m *schemaManager) LookupByID(
+	ctx context.Context,
+	registryID int,
+) (*goavro.Codec, error) {
+	m.cacheRWLock.RLock()
+	if codec, exists := m.idCache[registryID]; exists {
+		m.cacheRWLock.RUnlock()
+		return codec, nil
+	}
+	m.cacheRWLock.RUnlock()
+
+	uri := m.registryURL + "/schemas/ids/" + strconv.Itoa(registryID)
+	log.Debug("Querying for schema by ID", zap.String("uri", uri))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
+	if err != nil {
+		log.Error("Error constructing request for Registry lookup", zap.Error(err))
+		return nil, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	req.Header.Add(
+		"Accept",
+		"application/vnd.schemaregistry.v1+json, application/vnd.schemaregistry+json, "+
+			"application/json",
+	)
+
+	resp, err := httpRetry(ctx, m.credential, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.Error("Failed to parse result from Registry", zap.Error(err))
+		return nil, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+
+	if resp.StatusCode == 404 {
+		log.Warn("Specified schema not found in Registry",
+			zap.Int("registryID", registryID))
+		return nil, cerror.ErrAvroSchemaAPIError.GenWithStack(
+			"Schema %d not found in Registry", registryID,
+		)
+	}
+
+	if resp.StatusCode != 200 {
+		log.Error("Failed to query schema from the Registry, HTTP error",
+			zap.Int("status", resp.StatusCode),
+			zap.String("uri", uri),
+			zap.ByteString("responseBody", body))
+		return nil, cerror.ErrAvroSchemaAPIError.GenWithStack(
+			"Failed to query schema from the Registry, HTTP error",
+		)
+	}
+
+	var jsonResp lookupResponse
+	err = json.Unmarshal(body, &jsonResp)
+	if err != nil {
+		log.Error("Failed to parse result from Registry", zap.Error(err))
+		return nil, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+
+	codec, err := goavro.NewCodec(jsonResp.Schema)
+	if err != nil {
+		log.Error("Creating Avro codec failed", zap.Error(err))
+		return nil, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+
+	m.cacheRWLock.Lock()
+	m.idCache[registryID] = codec
+	m.cacheRWLock.Unlock()
+
+	log.Info("Avro schema lookup by ID successful",
+		zap.Int("registryID", registryID),
+		zap.String("schema", codec.Schema()))
+
+	return codec, nil
+}
+
 // SchemaGenerator represents a function that returns an Avro schema in JSON.
 // Used for lazy evaluation
 type SchemaGenerator func() (string, error)
@@ -20,6 +20,8 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/codec/canal"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 	"github.com/pingcap/tiflow/cdc/sink/codec/craft"
+	"github.com/pingcap/tiflow/cdc/sink/codec/csv"
+	"github.com/pingcap/tiflow/cdc/sink/codec/debezium"
 	"github.com/pingcap/tiflow/cdc/sink/codec/maxwell"
 	"github.com/pingcap/tiflow/cdc/sink/codec/open"
 	"github.com/pingcap/tiflow/pkg/config"
@@ -30,17 +32,21 @@ import (
 func NewEventBatchEncoderBuilder(ctx context.Context, c *common.Config) (codec.EncoderBuilder, error) {
 	switch c.Protocol {
 	case config.ProtocolDefault, config.ProtocolOpen:
-		return open.NewBatchEncoderBuilder(c), nil
+		return open.NewBatchEncoderBuilder(ctx, c)
 	case config.ProtocolCanal:
 		return canal.NewBatchEncoderBuilder(), nil
 	case config.ProtocolAvro:
 		return avro.NewBatchEncoderBuilder(ctx, c)
 	case config.ProtocolMaxwell:
-		return maxwell.NewBatchEncoderBuilder(), nil
+		return maxwell.NewBatchEncoderBuilder(c), nil
 	case config.ProtocolCanalJSON:
-		return canal.NewJSONBatchEncoderBuilder(c), nil
+		return canal.NewJSONBatchEncoderBuilder(ctx, c)
 	case config.ProtocolCraft:
 		return craft.NewBatchEncoderBuilder(c), nil
+	case config.ProtocolCsv:
+		return csv.NewBatchEncoderBuilder(c), nil
+	case config.ProtocolDebezium:
+		return debezium.NewBatchEncoderBuilder(c), nil
 	default:
 		return nil, cerror.ErrSinkUnknownProtocol.GenWithStackByArgs(c.Protocol)
 	}
@@ -43,6 +43,13 @@ func (d *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error)
 	return nil, nil
 }
 
+// EncodeSyncPointEvent implements the EventBatchEncoder interface
+func (d *BatchEncoder) EncodeSyncPointEvent(ts uint64) (*common.Message, error) {
+	// For canal now, there is no such a corresponding type to the syncpoint,
+	// the event is ignored as the resolved event.
+	return nil, nil
+}
+
 // AppendRowChangedEvent implements the EventBatchEncoder interface
 func (d *BatchEncoder) AppendRowChangedEvent(
 	_ context.Context,
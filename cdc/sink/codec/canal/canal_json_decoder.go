@@ -14,11 +14,14 @@
 package canal
 
 import (
+	"context"
 	"encoding/json"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/claimcheck"
 	cerrors "github.com/pingcap/tiflow/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -69,6 +72,15 @@ func (b *batchDecoder) NextRowChangedEvent() (*model.RowChangedEvent, error) {
 		return nil, cerrors.ErrCanalDecodeFailed.
 			GenWithStack("not found row changed event message")
 	}
+	if withExtension, ok := b.msg.(*canalJSONMessageWithTiDBExtension); ok &&
+		withExtension.Extensions.ClaimCheckLocation != "" {
+		result, err := b.decodeClaimCheckRow(withExtension.Extensions.ClaimCheckLocation)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		b.msg = nil
+		return result, nil
+	}
 	result, err := canalJSONMessage2RowChange(b.msg)
 	if err != nil {
 		return nil, err
@@ -77,6 +89,25 @@ func (b *batchDecoder) NextRowChangedEvent() (*model.RowChangedEvent, error) {
 	return result, nil
 }
 
+// decodeClaimCheckRow reads the original message from the claim-check
+// storage, and decodes the row from it.
+func (b *batchDecoder) decodeClaimCheckRow(location string) (*model.RowChangedEvent, error) {
+	msg, err := claimcheck.ReadMessage(context.Background(), location)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	decoder := NewBatchDecoder(msg.Value, b.enableTiDBExtension)
+	tp, hasNext, err := decoder.HasNext()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !hasNext || tp != model.MessageTypeRow {
+		return nil, cerrors.ErrCanalDecodeFailed.GenWithStack(
+			"not found row changed event message in the claim-check message %s", location)
+	}
+	return decoder.NextRowChangedEvent()
+}
+
 // NextDDLEvent implements the EventBatchDecoder interface
 // `HasNext` should be called before this.
 func (b *batchDecoder) NextDDLEvent() (*model.DDLEvent, error) {
@@ -108,3 +139,20 @@ func (b *batchDecoder) NextResolvedEvent() (uint64, error) {
 	b.msg = nil
 	return withExtensionEvent.Extensions.WatermarkTs, nil
 }
+
+// NextSyncPointEvent implements the EventBatchDecoder interface
+// `HasNext` should be called before this.
+func (b *batchDecoder) NextSyncPointEvent() (uint64, error) {
+	if b.msg == nil || b.msg.messageType() != model.MessageTypeSyncPoint {
+		return 0, cerrors.ErrCanalDecodeFailed.
+			GenWithStack("not found syncpoint event message")
+	}
+
+	withExtensionEvent, ok := b.msg.(*canalJSONMessageWithTiDBExtension)
+	if !ok {
+		return 0, cerrors.ErrCanalDecodeFailed.
+			GenWithStack("MessageTypeSyncPoint tidb extension not found")
+	}
+	b.msg = nil
+	return withExtensionEvent.Extensions.SyncPointTs, nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/claimcheck"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerrors "github.com/pingcap/tiflow/pkg/errors"
@@ -35,8 +36,14 @@ type JSONBatchEncoder struct {
 	messageBuf  []canalJSONMessageInterface
 	callbackBuf []func()
 	// When it is true, canal-json would generate TiDB extension information
-	// which, at the moment, only includes `tidbWaterMarkType` and `_tidb` fields.
+	// which, at the moment, only includes `tidbWaterMarkType`, `tidbSyncPointType`
+	// and `_tidb` fields.
 	enableTiDBExtension bool
+
+	maxMessageBytes int
+	// claimCheck is used to send the large messages to the external
+	// storage, it is nil if the claim-check is disabled.
+	claimCheck *claimcheck.ClaimCheck
 }
 
 // newJSONBatchEncoder creates a new JSONBatchEncoder
@@ -193,9 +200,32 @@ func (c *JSONBatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, er
 	return common.NewResolvedMsg(config.ProtocolCanalJSON, nil, value, ts), nil
 }
 
+// EncodeSyncPointEvent implements the EventBatchEncoder interface
+func (c *JSONBatchEncoder) EncodeSyncPointEvent(ts uint64) (*common.Message, error) {
+	if !c.enableTiDBExtension {
+		return nil, nil
+	}
+
+	msg := &canalJSONMessageWithTiDBExtension{
+		canalJSONMessage: &canalJSONMessage{
+			ID:            0,
+			IsDDL:         false,
+			EventType:     tidbSyncPointType,
+			ExecutionTime: convertToCanalTs(ts),
+			BuildTime:     time.Now().UnixNano() / int64(time.Millisecond), // converts to milliseconds
+		},
+		Extensions: &tidbExtension{SyncPointTs: ts},
+	}
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return nil, cerrors.WrapError(cerrors.ErrCanalEncodeFailed, err)
+	}
+	return common.NewSyncPointMsg(config.ProtocolCanalJSON, nil, value, ts), nil
+}
+
 // AppendRowChangedEvent implements the interface EventJSONBatchEncoder
 func (c *JSONBatchEncoder) AppendRowChangedEvent(
-	_ context.Context,
+	ctx context.Context,
 	_ string,
 	e *model.RowChangedEvent,
 	callback func(),
@@ -204,6 +234,12 @@ func (c *JSONBatchEncoder) AppendRowChangedEvent(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if c.claimCheck != nil {
+		message, err = c.claimCheckIfTooLarge(ctx, e, message)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
 	c.messageBuf = append(c.messageBuf, message)
 	if callback != nil {
 		c.callbackBuf = append(c.callbackBuf, callback)
@@ -211,6 +247,36 @@ func (c *JSONBatchEncoder) AppendRowChangedEvent(
 	return nil
 }
 
+// claimCheckIfTooLarge writes the message into the claim-check storage if it
+// is larger than `max-message-bytes`, and returns the message which only
+// contains the handle key and the location of the original message.
+func (c *JSONBatchEncoder) claimCheckIfTooLarge(
+	ctx context.Context, e *model.RowChangedEvent, message canalJSONMessageInterface,
+) (canalJSONMessageInterface, error) {
+	value, err := json.Marshal(message)
+	if err != nil {
+		return nil, cerrors.WrapError(cerrors.ErrCanalEncodeFailed, err)
+	}
+	length := len(value) + common.MaxRecordOverhead
+	if length <= c.maxMessageBytes {
+		return message, nil
+	}
+
+	log.Warn("Single message too large, send it to the claim-check storage",
+		zap.Int("max-message-size", c.maxMessageBytes), zap.Int("length", length), zap.Any("table", e.Table))
+	location, err := c.claimCheck.WriteMessage(ctx, nil, value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	reference, err := c.newJSONMessageForDML(claimcheck.HandleKeyOnly(e))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The claim-check requires the TiDB extension, which is checked by the config.
+	reference.(*canalJSONMessageWithTiDBExtension).Extensions.ClaimCheckLocation = location
+	return reference, nil
+}
+
 // EncodeDDLEvent encodes DDL events
 func (c *JSONBatchEncoder) EncodeDDLEvent(e *model.DDLEvent) (*common.Message, error) {
 	message := c.newJSONMessageForDDL(e)
@@ -248,18 +314,34 @@ func (c *JSONBatchEncoder) Build() []*common.Message {
 }
 
 type jsonBatchEncoderBuilder struct {
-	config *common.Config
+	config     *common.Config
+	claimCheck *claimcheck.ClaimCheck
 }
 
 // NewJSONBatchEncoderBuilder creates a canal-json batchEncoderBuilder.
-func NewJSONBatchEncoderBuilder(config *common.Config) codec.EncoderBuilder {
-	return &jsonBatchEncoderBuilder{config: config}
+func NewJSONBatchEncoderBuilder(
+	ctx context.Context, config *common.Config,
+) (codec.EncoderBuilder, error) {
+	var (
+		claimCheck *claimcheck.ClaimCheck
+		err        error
+	)
+	if config.EnableClaimCheck() {
+		claimCheck, err = claimcheck.New(ctx, config.ClaimCheckStorageURI,
+			config.ClaimCheckRetention)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &jsonBatchEncoderBuilder{config: config, claimCheck: claimCheck}, nil
 }
 
 // Build a `JSONBatchEncoder`
 func (b *jsonBatchEncoderBuilder) Build() codec.EventBatchEncoder {
 	encoder := newJSONBatchEncoder()
 	encoder.(*JSONBatchEncoder).enableTiDBExtension = b.config.EnableTiDBExtension
+	encoder.(*JSONBatchEncoder).maxMessageBytes = b.config.MaxMessageBytes
+	encoder.(*JSONBatchEncoder).claimCheck = b.claimCheck
 
 	return encoder
 }
@@ -14,8 +14,10 @@
 package canal
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/pingcap/tidb/parser/mysql"
@@ -216,6 +218,37 @@ func TestEncodeCheckpointEvent(t *testing.T) {
 	}
 }
 
+func TestEncodeSyncPointEvent(t *testing.T) {
+	t.Parallel()
+	var syncPointTs uint64 = 2333
+	encoder := &JSONBatchEncoder{builder: newCanalEntryBuilder()}
+	msg, err := encoder.EncodeSyncPointEvent(syncPointTs)
+	require.Nil(t, err)
+	require.Nil(t, msg)
+
+	encoder.enableTiDBExtension = true
+	msg, err = encoder.EncodeSyncPointEvent(syncPointTs)
+	require.Nil(t, err)
+	require.Equal(t, model.MessageTypeSyncPoint, msg.Type)
+	require.Contains(t, string(msg.Value), `"type":"TIDB_SYNCPOINT"`)
+	require.Contains(t, string(msg.Value), `"_tidb":{"syncPointTs":2333}`)
+
+	decoder := NewBatchDecoder(msg.Value, true)
+	ty, hasNext, err := decoder.HasNext()
+	require.Nil(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeSyncPoint, ty)
+	_, err = decoder.NextResolvedEvent()
+	require.NotNil(t, err)
+	consumed, err := decoder.NextSyncPointEvent()
+	require.Nil(t, err)
+	require.Equal(t, syncPointTs, consumed)
+
+	_, hasNext, err = decoder.HasNext()
+	require.Nil(t, err)
+	require.False(t, hasNext)
+}
+
 func TestCheckpointEventValueMarshal(t *testing.T) {
 	t.Parallel()
 	var watermark uint64 = 1024
@@ -378,3 +411,62 @@ func TestCanalJSONAppendRowChangedEventWithCallback(t *testing.T) {
 	msgs[4].Callback()
 	require.Equal(t, 15, count, "expected one callback be called")
 }
+
+func TestCanalJSONClaimCheck(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg := common.NewConfig(config.ProtocolCanalJSON).WithMaxMessageBytes(1024)
+	cfg.EnableTiDBExtension = true
+	cfg.LargeMessageHandleOption = common.LargeMessageHandleOptionClaimCheck
+	cfg.ClaimCheckStorageURI = "file://" + t.TempDir()
+	builder, err := NewJSONBatchEncoderBuilder(ctx, cfg)
+	require.NoError(t, err)
+	encoder := builder.Build()
+
+	small := &model.RowChangedEvent{
+		CommitTs: 1,
+		Table:    &model.TableName{Schema: "a", Table: "b"},
+		Columns: []*model.Column{
+			{
+				Name: "id", Type: mysql.TypeLong, Value: int64(1),
+				Flag: model.HandleKeyFlag | model.PrimaryKeyFlag,
+			},
+			{Name: "col1", Type: mysql.TypeVarchar, Value: []byte("aa")},
+		},
+	}
+	large := &model.RowChangedEvent{
+		CommitTs: 2,
+		Table:    small.Table,
+		Columns: []*model.Column{
+			small.Columns[0],
+			{Name: "col1", Type: mysql.TypeVarchar, Value: []byte(strings.Repeat("a", 2048))},
+		},
+	}
+	require.NoError(t, encoder.AppendRowChangedEvent(ctx, "", small, nil))
+	require.NoError(t, encoder.AppendRowChangedEvent(ctx, "", large, nil))
+	messages := encoder.Build()
+	require.Len(t, messages, 2)
+
+	for i, expected := range []*model.RowChangedEvent{small, large} {
+		require.LessOrEqual(t, messages[i].Length(), 1024)
+		claimChecked := bytes.Contains(messages[i].Value, []byte("claimCheckLocation"))
+		require.Equal(t, expected == large, claimChecked)
+
+		decoder := NewBatchDecoder(messages[i].Value, true)
+		tp, hasNext, err := decoder.HasNext()
+		require.NoError(t, err)
+		require.True(t, hasNext)
+		require.Equal(t, model.MessageTypeRow, tp)
+		row, err := decoder.NextRowChangedEvent()
+		require.NoError(t, err)
+		require.Equal(t, expected.CommitTs, row.CommitTs)
+		require.Len(t, row.Columns, 2)
+		for _, col := range row.Columns {
+			if col.Name == "col1" {
+				require.Equal(t, string(expected.Columns[1].Value.([]byte)), col.Value)
+			}
+		}
+	}
+}
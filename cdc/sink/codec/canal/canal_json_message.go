@@ -25,7 +25,10 @@ import (
 	canal "github.com/pingcap/tiflow/proto/canal"
 )
 
-const tidbWaterMarkType = "TIDB_WATERMARK"
+const (
+	tidbWaterMarkType = "TIDB_WATERMARK"
+	tidbSyncPointType = "TIDB_SYNCPOINT"
+)
 
 // The TiCDC Canal-JSON implementation extend the official format with a TiDB extension field.
 // canalJSONMessageInterface is used to support this without affect the original format.
@@ -122,6 +125,10 @@ func (c *canalJSONMessage) messageType() model.MessageType {
 		return model.MessageTypeResolved
 	}
 
+	if c.EventType == tidbSyncPointType {
+		return model.MessageTypeSyncPoint
+	}
+
 	return model.MessageTypeRow
 }
 
@@ -140,6 +147,10 @@ func (c *canalJSONMessage) pkNameSet() map[string]struct{} {
 type tidbExtension struct {
 	CommitTs    uint64 `json:"commitTs,omitempty"`
 	WatermarkTs uint64 `json:"watermarkTs,omitempty"`
+	SyncPointTs uint64 `json:"syncPointTs,omitempty"`
+	// ClaimCheckLocation is the location of the original message in the
+	// external storage, the message itself only contains the handle key.
+	ClaimCheckLocation string `json:"claimCheckLocation,omitempty"`
 }
 
 type canalJSONMessageWithTiDBExtension struct {
@@ -0,0 +1,226 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package claimcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"go.uber.org/zap"
+)
+
+// cleanInterval is the interval between two rounds of deleting
+// the expired messages from the external storage.
+const cleanInterval = 10 * time.Minute
+
+// storages caches the external storages of the locations read by
+// ReadMessage, so each storage is created only once.
+var storages = struct {
+	sync.Mutex
+	m map[string]storage.ExternalStorage
+}{m: make(map[string]storage.ExternalStorage)}
+
+// Message is the message stored in the external storage, the key and the
+// value are the same as what would be sent to the MQ without claim-check.
+type Message struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// ClaimCheck writes the messages which are too large to be sent to the MQ
+// into the external storage. The messages sent to the MQ only carry the
+// locations of them, and the decoders read the original messages back.
+// The messages are deleted after the retention, the file names start with
+// the write time of the messages, so no extra metadata is needed.
+type ClaimCheck struct {
+	storage storage.ExternalStorage
+	// location is the storage URI without the query parameters,
+	// which may contain the credentials of the storage.
+	location string
+	// retention is how long the messages are kept in the external storage.
+	retention time.Duration
+}
+
+// New creates a ClaimCheck from the storage URI. The expired messages
+// are deleted in the background until the context is done.
+func New(
+	ctx context.Context, storageURI string, retention time.Duration,
+) (*ClaimCheck, error) {
+	uri, err := url.Parse(storageURI)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrClaimCheckFailed, err)
+	}
+	s, err := cloudstorage.GetExternalStorage(ctx, uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	location := *uri
+	location.RawQuery = ""
+	c := &ClaimCheck{
+		storage:   s,
+		location:  strings.TrimSuffix(location.String(), "/"),
+		retention: retention,
+	}
+	go c.runCleaner(ctx)
+	return c, nil
+}
+
+// WriteMessage writes the message into the external storage,
+// and returns the location of it.
+func (c *ClaimCheck) WriteMessage(ctx context.Context, key, value []byte) (string, error) {
+	data, err := json.Marshal(&Message{Key: key, Value: value})
+	if err != nil {
+		return "", cerror.WrapError(cerror.ErrClaimCheckFailed, err)
+	}
+	fileName := fmt.Sprintf("%d-%s.json", time.Now().Unix(), uuid.NewString())
+	if err := c.storage.WriteFile(ctx, fileName, data); err != nil {
+		return "", cerror.WrapError(cerror.ErrClaimCheckFailed, err)
+	}
+	return c.location + "/" + fileName, nil
+}
+
+// runCleaner deletes the expired messages every clean interval, so the
+// writers are never blocked by walking the storage. The failure is only
+// logged, and the messages are deleted in the next round.
+func (c *ClaimCheck) runCleaner(ctx context.Context) {
+	ticker := time.NewTicker(cleanInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := c.cleanExpired(ctx, now); err != nil {
+				log.Warn("failed to clean the expired claim-check messages",
+					zap.String("location", c.location), zap.Error(err))
+			}
+		}
+	}
+}
+
+// cleanExpired deletes the messages written before `now - retention`.
+func (c *ClaimCheck) cleanExpired(ctx context.Context, now time.Time) error {
+	deadline := now.Add(-c.retention).Unix()
+	var expired []string
+	err := c.storage.WalkDir(ctx, &storage.WalkOption{}, func(path string, _ int64) error {
+		idx := strings.Index(path, "-")
+		if idx < 0 || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+		writeTime, err := strconv.ParseInt(path[:idx], 10, 64)
+		// The file isn't written by the claim-check.
+		if err != nil {
+			return nil
+		}
+		if writeTime < deadline {
+			expired = append(expired, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return cerror.WrapError(cerror.ErrClaimCheckFailed, err)
+	}
+	for _, path := range expired {
+		if err := c.storage.DeleteFile(ctx, path); err != nil {
+			return cerror.WrapError(cerror.ErrClaimCheckFailed, err)
+		}
+	}
+	if len(expired) > 0 {
+		log.Info("expired claim-check messages are deleted",
+			zap.String("location", c.location), zap.Int("count", len(expired)))
+	}
+	return nil
+}
+
+// ReadMessage reads the message from the location returned by WriteMessage.
+// The credentials of the storage are not contained in the location, so they
+// must be provided by the environment, e.g. `AWS_ACCESS_KEY_ID` for s3.
+func ReadMessage(ctx context.Context, location string) (*Message, error) {
+	idx := strings.LastIndex(location, "/")
+	if idx < 0 {
+		return nil, cerror.ErrClaimCheckFailed.GenWithStack(
+			"invalid claim-check location %s", location)
+	}
+	s, err := getStorage(ctx, location[:idx])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data, err := s.ReadFile(ctx, location[idx+1:])
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrClaimCheckFailed, err)
+	}
+
+	msg := new(Message)
+	if err := json.Unmarshal(data, msg); err != nil {
+		return nil, cerror.WrapError(cerror.ErrClaimCheckFailed, err)
+	}
+	return msg, nil
+}
+
+// getStorage returns the cached external storage of the storage URI,
+// and creates it if it's not cached.
+func getStorage(ctx context.Context, storageURI string) (storage.ExternalStorage, error) {
+	storages.Lock()
+	defer storages.Unlock()
+	if s, ok := storages.m[storageURI]; ok {
+		return s, nil
+	}
+	uri, err := url.Parse(storageURI)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrClaimCheckFailed, err)
+	}
+	s, err := cloudstorage.GetExternalStorage(ctx, uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storages.m[storageURI] = s
+	return s, nil
+}
+
+// HandleKeyOnly returns a copy of the event which only contains the handle
+// key columns, the other columns are set to nil, which means they are
+// filtered out, so the encoders skip them.
+func HandleKeyOnly(e *model.RowChangedEvent) *model.RowChangedEvent {
+	filter := func(columns []*model.Column) []*model.Column {
+		if columns == nil {
+			return nil
+		}
+		result := make([]*model.Column, len(columns))
+		for i, col := range columns {
+			if col != nil && col.Flag.IsHandleKey() {
+				result[i] = col
+			}
+		}
+		return result
+	}
+
+	result := *e
+	result.Columns = filter(e.Columns)
+	result.PreColumns = filter(e.PreColumns)
+	return &result
+}
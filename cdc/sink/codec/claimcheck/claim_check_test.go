@@ -0,0 +1,119 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package claimcheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteReadMessage(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir := t.TempDir()
+	claimCheck, err := New(ctx, "file://"+dir+"?a=b", time.Hour)
+	require.NoError(t, err)
+
+	location, err := claimCheck.WriteMessage(ctx, []byte("key"), []byte("value"))
+	require.NoError(t, err)
+	// The query parameters are not in the location.
+	require.True(t, strings.HasPrefix(location, "file://"+dir+"/"))
+	require.True(t, strings.HasSuffix(location, ".json"))
+
+	msg, err := ReadMessage(ctx, location)
+	require.NoError(t, err)
+	require.Equal(t, &Message{Key: []byte("key"), Value: []byte("value")}, msg)
+
+	_, err = ReadMessage(ctx, "file://"+dir+"/not-exist.json")
+	require.True(t, cerror.ErrClaimCheckFailed.Equal(err))
+
+	// The storage of the location is created once and cached.
+	storages.Lock()
+	cached := storages.m["file://"+dir]
+	storages.Unlock()
+	require.NotNil(t, cached)
+	s, err := getStorage(ctx, "file://"+dir)
+	require.NoError(t, err)
+	require.Equal(t, cached, s)
+}
+
+func TestCleanExpired(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	claimCheck, err := New(ctx, "file://"+t.TempDir(), time.Hour)
+	require.NoError(t, err)
+
+	location, err := claimCheck.WriteMessage(ctx, nil, []byte("value"))
+	require.NoError(t, err)
+	fileName := location[strings.LastIndex(location, "/")+1:]
+	now := time.Now()
+	expired := fmt.Sprintf("%d-expired.json", now.Add(-2*time.Hour).Unix())
+	require.NoError(t, claimCheck.storage.WriteFile(ctx, expired, []byte("{}")))
+	// The files not written by the claim-check are never deleted.
+	require.NoError(t, claimCheck.storage.WriteFile(ctx, "other.json", []byte("{}")))
+
+	exists := func(name string) bool {
+		ok, err := claimCheck.storage.FileExists(ctx, name)
+		require.NoError(t, err)
+		return ok
+	}
+
+	require.NoError(t, claimCheck.cleanExpired(ctx, now))
+	require.False(t, exists(expired))
+	require.True(t, exists(fileName))
+	require.True(t, exists("other.json"))
+
+	require.NoError(t, claimCheck.cleanExpired(ctx, now.Add(2*time.Hour)))
+	require.False(t, exists(fileName))
+	require.True(t, exists("other.json"))
+}
+
+func TestHandleKeyOnly(t *testing.T) {
+	t.Parallel()
+
+	e := &model.RowChangedEvent{
+		CommitTs: 1,
+		Table:    &model.TableName{Schema: "test", Table: "t"},
+		Columns: []*model.Column{
+			{Name: "id", Value: 1, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag},
+			{Name: "a", Value: "b"},
+			nil,
+		},
+		PreColumns: []*model.Column{
+			{Name: "id", Value: 1, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag},
+			{Name: "a", Value: "a"},
+			nil,
+		},
+	}
+	result := HandleKeyOnly(e)
+	require.Equal(t, e.CommitTs, result.CommitTs)
+	require.Equal(t, []*model.Column{e.Columns[0], nil, nil}, result.Columns)
+	require.Equal(t, []*model.Column{e.PreColumns[0], nil, nil}, result.PreColumns)
+	// The original event is not modified.
+	require.Equal(t, "b", e.Columns[1].Value)
+
+	e.PreColumns = nil
+	require.Nil(t, HandleKeyOnly(e).PreColumns)
+}
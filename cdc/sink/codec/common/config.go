@@ -16,6 +16,8 @@ package common
 import (
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tiflow/pkg/config"
@@ -25,6 +27,16 @@ import (
 // defaultMaxBatchSize sets the default value for max-batch-size
 const defaultMaxBatchSize int = 16
 
+const (
+	defaultCSVDelimiter  = ","
+	defaultCSVQuote      = "\""
+	defaultCSVNullString = "\\N"
+)
+
+// defaultClaimCheckRetention is the same as the default retention of kafka
+// topics, so the stored messages outlive the messages referencing them.
+const defaultClaimCheckRetention = 7 * 24 * time.Hour
+
 // Config use to create the encoder
 type Config struct {
 	Protocol config.Protocol
@@ -33,13 +45,37 @@ type Config struct {
 	MaxMessageBytes int
 	MaxBatchSize    int
 
-	// canal-json only
+	// canal-json, avro and maxwell only
 	EnableTiDBExtension bool
 
 	// avro only
 	AvroSchemaRegistry             string
 	AvroDecimalHandlingMode        string
 	AvroBigintUnsignedHandlingMode string
+	// AvroEnableWatermark makes the avro encoder send the checkpoint ts as
+	// watermark events, it requires the TiDB extension to be enabled.
+	AvroEnableWatermark bool
+
+	// debezium only
+	// DebeziumDisableSchema makes the debezium encoder omit the schema
+	// section, which is the same as `schemas.enable=false` of the JsonConverter.
+	DebeziumDisableSchema bool
+
+	// LargeMessageHandleOption is the way to handle the row changed events
+	// whose encoded messages are larger than `max-message-bytes`, only
+	// `open-protocol` and `canal-json` support `claim-check` at the moment.
+	LargeMessageHandleOption string
+	// ClaimCheckStorageURI is the URI of the external storage, which stores
+	// the large messages if the `claim-check` option is used.
+	ClaimCheckStorageURI string
+	// ClaimCheckRetention is how long the large messages are kept
+	// in the external storage before they are deleted.
+	ClaimCheckRetention time.Duration
+
+	// csv only
+	CSVDelimiter  string
+	CSVQuote      string
+	CSVNullString string
 }
 
 // NewConfig return a Config for codec
@@ -54,6 +90,17 @@ func NewConfig(protocol config.Protocol) *Config {
 		AvroSchemaRegistry:             "",
 		AvroDecimalHandlingMode:        "precise",
 		AvroBigintUnsignedHandlingMode: "long",
+		AvroEnableWatermark:            false,
+
+		DebeziumDisableSchema: false,
+
+		LargeMessageHandleOption: LargeMessageHandleOptionNone,
+		ClaimCheckStorageURI:     "",
+		ClaimCheckRetention:      defaultClaimCheckRetention,
+
+		CSVDelimiter:  defaultCSVDelimiter,
+		CSVQuote:      defaultCSVQuote,
+		CSVNullString: defaultCSVNullString,
 	}
 }
 
@@ -64,6 +111,14 @@ const (
 	codecOPTAvroDecimalHandlingMode        = "avro-decimal-handling-mode"
 	codecOPTAvroBigintUnsignedHandlingMode = "avro-bigint-unsigned-handling-mode"
 	codecOPTAvroSchemaRegistry             = "schema-registry"
+	codecOPTAvroEnableWatermark            = "avro-enable-watermark"
+	codecOPTDebeziumDisableSchema          = "debezium-disable-schema"
+	codecOPTLargeMessageHandleOption       = "large-message-handle-option"
+	codecOPTClaimCheckStorageURI           = "claim-check-storage-uri"
+	codecOPTClaimCheckRetention            = "claim-check-retention"
+	codecOPTCSVDelimiter                   = "csv-delimiter"
+	codecOPTCSVQuote                       = "csv-quote"
+	codecOPTCSVNullString                  = "csv-null"
 )
 
 const (
@@ -75,6 +130,10 @@ const (
 	BigintUnsignedHandlingModeString = "string"
 	// BigintUnsignedHandlingModeLong is the long mode for unsigned bigint handling
 	BigintUnsignedHandlingModeLong = "long"
+	// LargeMessageHandleOptionNone fails the changefeed if the message is too large
+	LargeMessageHandleOptionNone = "none"
+	// LargeMessageHandleOptionClaimCheck sends the large message to the external storage
+	LargeMessageHandleOptionClaimCheck = "claim-check"
 )
 
 // Apply fill the Config
@@ -112,6 +171,50 @@ func (c *Config) Apply(sinkURI *url.URL, config *config.ReplicaConfig) error {
 		c.AvroBigintUnsignedHandlingMode = s
 	}
 
+	if s := params.Get(codecOPTAvroEnableWatermark); s != "" {
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		c.AvroEnableWatermark = b
+	}
+
+	if s := params.Get(codecOPTDebeziumDisableSchema); s != "" {
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		c.DebeziumDisableSchema = b
+	}
+
+	if s := params.Get(codecOPTLargeMessageHandleOption); s != "" {
+		c.LargeMessageHandleOption = s
+	}
+
+	if s := params.Get(codecOPTClaimCheckStorageURI); s != "" {
+		c.ClaimCheckStorageURI = s
+	}
+
+	if s := params.Get(codecOPTClaimCheckRetention); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		c.ClaimCheckRetention = d
+	}
+
+	if params.Has(codecOPTCSVDelimiter) {
+		c.CSVDelimiter = params.Get(codecOPTCSVDelimiter)
+	}
+
+	if params.Has(codecOPTCSVQuote) {
+		c.CSVQuote = params.Get(codecOPTCSVQuote)
+	}
+
+	if params.Has(codecOPTCSVNullString) {
+		c.CSVNullString = params.Get(codecOPTCSVNullString)
+	}
+
 	if config.Sink != nil && config.Sink.SchemaRegistry != "" {
 		c.AvroSchemaRegistry = config.Sink.SchemaRegistry
 	}
@@ -125,12 +228,42 @@ func (c *Config) WithMaxMessageBytes(bytes int) *Config {
 	return c
 }
 
+// EnableClaimCheck returns true if the large messages are sent to the external storage.
+func (c *Config) EnableClaimCheck() bool {
+	return c.LargeMessageHandleOption == LargeMessageHandleOptionClaimCheck
+}
+
+// ValidateSyncPoint returns an error if the encoder doesn't send the
+// syncpoint events, which are required if the syncpoint is enabled.
+func (c *Config) ValidateSyncPoint() error {
+	switch c.Protocol {
+	case config.ProtocolOpen, config.ProtocolCraft, config.ProtocolDebezium:
+		return nil
+	case config.ProtocolCanalJSON:
+		if !c.EnableTiDBExtension {
+			return cerror.ErrSyncPointNotSupported.GenWithStackByArgs(
+				c.Protocol, "enable-tidb-extension must be true")
+		}
+		return nil
+	case config.ProtocolAvro:
+		if !c.EnableTiDBExtension || !c.AvroEnableWatermark {
+			return cerror.ErrSyncPointNotSupported.GenWithStackByArgs(
+				c.Protocol, "enable-tidb-extension and avro-enable-watermark must be true")
+		}
+		return nil
+	default:
+		return cerror.ErrSyncPointNotSupported.GenWithStackByArgs(
+			c.Protocol, "the protocol has no syncpoint event")
+	}
+}
+
 // Validate the Config
 func (c *Config) Validate() error {
 	if c.EnableTiDBExtension &&
-		!(c.Protocol == config.ProtocolCanalJSON || c.Protocol == config.ProtocolAvro) {
+		!(c.Protocol == config.ProtocolCanalJSON || c.Protocol == config.ProtocolAvro ||
+			c.Protocol == config.ProtocolMaxwell) {
 		return cerror.ErrCodecInvalidConfig.GenWithStack(
-			`enable-tidb-extension only supports canal-json/avro protocol`,
+			`enable-tidb-extension only supports canal-json/avro/maxwell protocol`,
 		)
 	}
 
@@ -161,6 +294,77 @@ func (c *Config) Validate() error {
 				BigintUnsignedHandlingModeString,
 			)
 		}
+
+		if c.AvroEnableWatermark && !c.EnableTiDBExtension {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`%s requires parameter "%s"`,
+				codecOPTAvroEnableWatermark,
+				codecOPTEnableTiDBExtension,
+			)
+		}
+	}
+
+	if c.LargeMessageHandleOption != LargeMessageHandleOptionNone &&
+		c.LargeMessageHandleOption != LargeMessageHandleOptionClaimCheck {
+		return cerror.ErrCodecInvalidConfig.GenWithStack(
+			`%s value could only be "%s" or "%s"`,
+			codecOPTLargeMessageHandleOption,
+			LargeMessageHandleOptionNone,
+			LargeMessageHandleOptionClaimCheck,
+		)
+	}
+
+	if c.EnableClaimCheck() {
+		if c.Protocol != config.ProtocolOpen && c.Protocol != config.ProtocolDefault &&
+			c.Protocol != config.ProtocolCanalJSON {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`%s only supports open-protocol/canal-json protocol`,
+				LargeMessageHandleOptionClaimCheck,
+			)
+		}
+
+		// The claim-check location is carried by the TiDB extension of canal-json.
+		if c.Protocol == config.ProtocolCanalJSON && !c.EnableTiDBExtension {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`%s requires parameter "%s"`,
+				LargeMessageHandleOptionClaimCheck,
+				codecOPTEnableTiDBExtension,
+			)
+		}
+
+		if c.ClaimCheckStorageURI == "" {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`%s requires parameter "%s"`,
+				LargeMessageHandleOptionClaimCheck,
+				codecOPTClaimCheckStorageURI,
+			)
+		}
+
+		if c.ClaimCheckRetention <= 0 {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`invalid %s %s`, codecOPTClaimCheckRetention, c.ClaimCheckRetention,
+			)
+		}
+	}
+
+	if c.Protocol == config.ProtocolCsv {
+		if len(c.CSVDelimiter) == 0 {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`%s must not be empty`, codecOPTCSVDelimiter,
+			)
+		}
+
+		if len(c.CSVQuote) > 1 {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`%s could only be a single character or empty`, codecOPTCSVQuote,
+			)
+		}
+
+		if len(c.CSVQuote) > 0 && strings.Contains(c.CSVDelimiter, c.CSVQuote) {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`%s must not contain %s`, codecOPTCSVDelimiter, codecOPTCSVQuote,
+			)
+		}
 	}
 
 	if c.MaxMessageBytes <= 0 {
@@ -16,6 +16,7 @@ package common
 import (
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/stretchr/testify/require"
@@ -31,7 +32,15 @@ func TestNewConfig(t *testing.T) {
 	require.Equal(t, false, c.EnableTiDBExtension)
 	require.Equal(t, "precise", c.AvroDecimalHandlingMode)
 	require.Equal(t, "long", c.AvroBigintUnsignedHandlingMode)
+	require.Equal(t, false, c.AvroEnableWatermark)
+	require.Equal(t, false, c.DebeziumDisableSchema)
+	require.Equal(t, "none", c.LargeMessageHandleOption)
+	require.Equal(t, "", c.ClaimCheckStorageURI)
+	require.Equal(t, 7*24*time.Hour, c.ClaimCheckRetention)
 	require.Equal(t, "", c.AvroSchemaRegistry)
+	require.Equal(t, ",", c.CSVDelimiter)
+	require.Equal(t, "\"", c.CSVQuote)
+	require.Equal(t, "\\N", c.CSVNullString)
 }
 
 func TestConfigApplyValidate(t *testing.T) {
@@ -81,7 +90,7 @@ func TestConfigApplyValidate(t *testing.T) {
 	require.True(t, c.EnableTiDBExtension)
 
 	err = c.Validate()
-	require.ErrorContains(t, err, "enable-tidb-extension only supports canal-json/avro protocol")
+	require.ErrorContains(t, err, "enable-tidb-extension only supports canal-json/avro/maxwell protocol")
 
 	// avro
 	uri = "kafka://127.0.0.1:9092/abc?protocol=avro"
@@ -169,6 +178,33 @@ func TestConfigApplyValidate(t *testing.T) {
 		`bigint-unsigned-handling-mode value could only be "long" or "string"`,
 	)
 
+	// avro-enable-watermark
+	c = NewConfig(config.ProtocolAvro)
+	uri = "kafka://127.0.0.1:9092/abc?protocol=avro&avro-enable-watermark=true"
+	sinkURI, err = url.Parse(uri)
+	require.NoError(t, err)
+
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	require.True(t, c.AvroEnableWatermark)
+
+	err = c.Validate()
+	require.ErrorContains(
+		t,
+		err,
+		`avro-enable-watermark requires parameter "enable-tidb-extension"`,
+	)
+
+	uri = "kafka://127.0.0.1:9092/abc?protocol=avro&avro-enable-watermark=true" +
+		"&enable-tidb-extension=true"
+	sinkURI, err = url.Parse(uri)
+	require.NoError(t, err)
+
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	err = c.Validate()
+	require.NoError(t, err)
+
 	// Illegal max-message-bytes.
 	uri = "kafka://127.0.0.1:9092/abc?kafka-version=2.6.0&max-message-bytes=a"
 	sinkURI, err = url.Parse(uri)
@@ -204,4 +240,126 @@ func TestConfigApplyValidate(t *testing.T) {
 
 	err = c.Validate()
 	require.ErrorContains(t, err, "invalid max-batch-size -1")
+
+	// debezium-disable-schema
+	uri = "kafka://127.0.0.1:9092/abc?protocol=debezium&debezium-disable-schema=true"
+	sinkURI, err = url.Parse(uri)
+	require.NoError(t, err)
+
+	c = NewConfig(config.ProtocolDebezium)
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	require.True(t, c.DebeziumDisableSchema)
+	require.NoError(t, c.Validate())
+}
+
+func TestConfigApplyValidateClaimCheck(t *testing.T) {
+	t.Parallel()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	testCases := []struct {
+		uri         string
+		expectedErr string
+	}{
+		{
+			uri: "kafka://127.0.0.1:9092/abc?protocol=open-protocol" +
+				"&large-message-handle-option=claim-check" +
+				"&claim-check-storage-uri=file%3A%2F%2F%2Ftmp%2Fclaim-check",
+		},
+		{
+			uri: "kafka://127.0.0.1:9092/abc?protocol=canal-json&enable-tidb-extension=true" +
+				"&large-message-handle-option=claim-check" +
+				"&claim-check-storage-uri=s3%3A%2F%2Fbucket%2Fprefix",
+		},
+		{
+			uri:         "kafka://127.0.0.1:9092/abc?protocol=open-protocol&large-message-handle-option=abc",
+			expectedErr: `large-message-handle-option value could only be "none" or "claim-check"`,
+		},
+		{
+			uri: "kafka://127.0.0.1:9092/abc?protocol=open-protocol" +
+				"&large-message-handle-option=claim-check",
+			expectedErr: `claim-check requires parameter "claim-check-storage-uri"`,
+		},
+		{
+			uri: "kafka://127.0.0.1:9092/abc?protocol=canal-json" +
+				"&large-message-handle-option=claim-check" +
+				"&claim-check-storage-uri=s3%3A%2F%2Fbucket%2Fprefix",
+			expectedErr: `claim-check requires parameter "enable-tidb-extension"`,
+		},
+		{
+			uri: "kafka://127.0.0.1:9092/abc?protocol=open-protocol" +
+				"&large-message-handle-option=claim-check" +
+				"&claim-check-storage-uri=s3%3A%2F%2Fbucket%2Fprefix" +
+				"&claim-check-retention=0s",
+			expectedErr: "invalid claim-check-retention 0s",
+		},
+		{
+			uri: "kafka://127.0.0.1:9092/abc?protocol=maxwell" +
+				"&large-message-handle-option=claim-check" +
+				"&claim-check-storage-uri=s3%3A%2F%2Fbucket%2Fprefix",
+			expectedErr: "claim-check only supports open-protocol/canal-json protocol",
+		},
+		{
+			uri: "kafka://127.0.0.1:9092/abc?protocol=craft" +
+				"&large-message-handle-option=claim-check" +
+				"&claim-check-storage-uri=s3%3A%2F%2Fbucket%2Fprefix",
+			expectedErr: "claim-check only supports open-protocol/canal-json protocol",
+		},
+		{
+			uri: "kafka://127.0.0.1:9092/abc?protocol=debezium" +
+				"&large-message-handle-option=claim-check" +
+				"&claim-check-storage-uri=s3%3A%2F%2Fbucket%2Fprefix",
+			expectedErr: "claim-check only supports open-protocol/canal-json protocol",
+		},
+	}
+
+	for _, tc := range testCases {
+		sinkURI, err := url.Parse(tc.uri)
+		require.NoError(t, err)
+		var p config.Protocol
+		require.NoError(t, p.FromString(sinkURI.Query().Get("protocol")))
+
+		c := NewConfig(p)
+		require.NoError(t, c.Apply(sinkURI, replicaConfig))
+		err = c.Validate()
+		if tc.expectedErr == "" {
+			require.NoError(t, err)
+			require.True(t, c.EnableClaimCheck())
+		} else {
+			require.ErrorContains(t, err, tc.expectedErr)
+		}
+	}
+}
+
+func TestConfigApplyValidateCSV(t *testing.T) {
+	t.Parallel()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	uri := "file:///tmp/cdc?protocol=csv&csv-delimiter=%7C&csv-quote=&csv-null=NULL"
+	sinkURI, err := url.Parse(uri)
+	require.NoError(t, err)
+
+	c := NewConfig(config.ProtocolCsv)
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	require.Equal(t, "|", c.CSVDelimiter)
+	require.Equal(t, "", c.CSVQuote)
+	require.Equal(t, "NULL", c.CSVNullString)
+	require.NoError(t, c.Validate())
+
+	uri = "file:///tmp/cdc?protocol=csv&csv-quote=ab"
+	sinkURI, err = url.Parse(uri)
+	require.NoError(t, err)
+	c = NewConfig(config.ProtocolCsv)
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	require.ErrorContains(t, c.Validate(), "csv-quote could only be a single character or empty")
+
+	uri = "file:///tmp/cdc?protocol=csv&csv-delimiter=%22"
+	sinkURI, err = url.Parse(uri)
+	require.NoError(t, err)
+	c = NewConfig(config.ProtocolCsv)
+	err = c.Apply(sinkURI, replicaConfig)
+	require.NoError(t, err)
+	require.ErrorContains(t, c.Validate(), "csv-delimiter must not contain csv-quote")
 }
@@ -51,6 +51,20 @@ func (b *batchDecoder) NextResolvedEvent() (uint64, error) {
 	return ts, nil
 }
 
+// NextSyncPointEvent implements the EventBatchDecoder interface
+func (b *batchDecoder) NextSyncPointEvent() (uint64, error) {
+	ty, hasNext, err := b.HasNext()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if !hasNext || ty != model.MessageTypeSyncPoint {
+		return 0, cerror.ErrCraftCodecInvalidData.GenWithStack("not found syncpoint event message")
+	}
+	ts := b.headers.GetTs(b.index)
+	b.index++
+	return ts, nil
+}
+
 // NextRowChangedEvent implements the EventBatchDecoder interface
 func (b *batchDecoder) NextRowChangedEvent() (*model.RowChangedEvent, error) {
 	ty, hasNext, err := b.HasNext()
@@ -116,8 +130,8 @@ func (b *batchDecoder) NextDDLEvent() (*model.DDLEvent, error) {
 	return event, nil
 }
 
-// newBatchDecoder creates a new batchDecoder.
-func newBatchDecoder(bits []byte) (codec.EventBatchDecoder, error) {
+// NewBatchDecoder creates a new craft batchDecoder.
+func NewBatchDecoder(bits []byte) (codec.EventBatchDecoder, error) {
 	return NewBatchDecoderWithAllocator(bits, NewSliceAllocator(64))
 }
 
@@ -42,6 +42,13 @@ func (e *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error)
 		NewResolvedEventEncoder(e.allocator, ts).Encode(), ts), nil
 }
 
+// EncodeSyncPointEvent implements the EventBatchEncoder interface
+func (e *BatchEncoder) EncodeSyncPointEvent(ts uint64) (*common.Message, error) {
+	return common.NewSyncPointMsg(
+		config.ProtocolCraft, nil,
+		NewSyncPointEventEncoder(e.allocator, ts).Encode(), ts), nil
+}
+
 // AppendRowChangedEvent implements the EventBatchEncoder interface
 func (e *BatchEncoder) AppendRowChangedEvent(
 	_ context.Context,
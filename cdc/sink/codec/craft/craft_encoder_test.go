@@ -76,7 +76,7 @@ func TestCraftMaxBatchSize(t *testing.T) {
 	messages := encoder.Build()
 	sum := 0
 	for _, msg := range messages {
-		decoder, err := newBatchDecoder(msg.Value)
+		decoder, err := NewBatchDecoder(msg.Value)
 		require.Nil(t, err)
 		count := 0
 		for {
@@ -207,7 +207,28 @@ func testBatchCodec(
 func TestDefaultCraftBatchCodec(t *testing.T) {
 	cfg := common.NewConfig(config.ProtocolCraft).WithMaxMessageBytes(8192)
 	cfg.MaxBatchSize = 64
-	testBatchCodec(t, NewBatchEncoderBuilder(cfg), newBatchDecoder)
+	testBatchCodec(t, NewBatchEncoderBuilder(cfg), NewBatchDecoder)
+}
+
+func TestCraftSyncPointEvent(t *testing.T) {
+	t.Parallel()
+	encoder := NewBatchEncoder()
+	msg, err := encoder.EncodeSyncPointEvent(424316592563683329)
+	require.Nil(t, err)
+	require.Equal(t, model.MessageTypeSyncPoint, msg.Type)
+
+	decoder, err := NewBatchDecoder(msg.Value)
+	require.Nil(t, err)
+	tp, hasNext, err := decoder.HasNext()
+	require.Nil(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeSyncPoint, tp)
+	ts, err := decoder.NextSyncPointEvent()
+	require.Nil(t, err)
+	require.Equal(t, uint64(424316592563683329), ts)
+	_, hasNext, err = decoder.HasNext()
+	require.Nil(t, err)
+	require.False(t, hasNext)
 }
 
 func TestCraftAppendRowChangedEventWithCallback(t *testing.T) {
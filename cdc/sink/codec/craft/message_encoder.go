@@ -308,6 +308,18 @@ func NewResolvedEventEncoder(allocator *SliceAllocator, ts uint64) *MessageEncod
 	}).encodeBodySize()
 }
 
+// NewSyncPointEventEncoder creates a new encoder with given allocator and timestamp
+func NewSyncPointEventEncoder(allocator *SliceAllocator, ts uint64) *MessageEncoder {
+	return NewMessageEncoder(allocator).encodeHeaders(&Headers{
+		ts:        allocator.oneUint64Slice(ts),
+		ty:        allocator.oneUint64Slice(uint64(model.MessageTypeSyncPoint)),
+		partition: oneNullInt64Slice,
+		schema:    oneNullStringSlice,
+		table:     oneNullStringSlice,
+		count:     1,
+	}).encodeBodySize()
+}
+
 // NewDDLEventEncoder creates a new encoder with given allocator and timestamp
 func NewDDLEventEncoder(allocator *SliceAllocator, ev *model.DDLEvent) *MessageEncoder {
 	ty := uint64(ev.Type)
@@ -0,0 +1,102 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csv
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+)
+
+// BatchEncoder encodes each row changed event into a csv row.
+// DDL and checkpoint events can not be represented by csv,
+// so they are ignored by the encoder.
+type BatchEncoder struct {
+	messageBuf []*common.Message
+	config     *common.Config
+}
+
+// EncodeCheckpointEvent implements the EventBatchEncoder interface
+func (b *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error) {
+	// Csv does not support checkpoint event.
+	return nil, nil
+}
+
+// EncodeSyncPointEvent implements the EventBatchEncoder interface
+func (b *BatchEncoder) EncodeSyncPointEvent(ts uint64) (*common.Message, error) {
+	// Csv does not support syncpoint event.
+	return nil, nil
+}
+
+// AppendRowChangedEvent implements the EventBatchEncoder interface
+func (b *BatchEncoder) AppendRowChangedEvent(
+	_ context.Context,
+	_ string,
+	e *model.RowChangedEvent,
+	callback func(),
+) error {
+	msg, err := rowChangedEvent2CSVMsg(b.config, e)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	m := common.NewMsg(config.ProtocolCsv, nil, msg.encode(), e.CommitTs,
+		model.MessageTypeRow, &e.Table.Schema, &e.Table.Table)
+	m.IncRowsCount()
+	m.Callback = callback
+	b.messageBuf = append(b.messageBuf, m)
+	return nil
+}
+
+// EncodeDDLEvent implements the EventBatchEncoder interface
+func (b *BatchEncoder) EncodeDDLEvent(e *model.DDLEvent) (*common.Message, error) {
+	// Csv does not support DDL event.
+	return nil, nil
+}
+
+// Build implements the EventBatchEncoder interface
+func (b *BatchEncoder) Build() (messages []*common.Message) {
+	if len(b.messageBuf) == 0 {
+		return nil
+	}
+	ret := b.messageBuf
+	b.messageBuf = make([]*common.Message, 0)
+	return ret
+}
+
+// newBatchEncoder creates a new csv BatchEncoder.
+func newBatchEncoder(config *common.Config) codec.EventBatchEncoder {
+	return &BatchEncoder{
+		config:     config,
+		messageBuf: make([]*common.Message, 0),
+	}
+}
+
+type batchEncoderBuilder struct {
+	config *common.Config
+}
+
+// NewBatchEncoderBuilder creates a csv batchEncoderBuilder.
+func NewBatchEncoderBuilder(config *common.Config) codec.EncoderBuilder {
+	return &batchEncoderBuilder{config: config}
+}
+
+// Build a csv BatchEncoder
+func (b *batchEncoderBuilder) Build() codec.EventBatchEncoder {
+	return newBatchEncoder(b.config)
+}
@@ -0,0 +1,112 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVBatchEncoder(t *testing.T) {
+	t.Parallel()
+
+	cfg := common.NewConfig(config.ProtocolCsv)
+	encoder := NewBatchEncoderBuilder(cfg).Build()
+
+	table := &model.TableName{Schema: "test", Table: "t1"}
+	insert := &model.RowChangedEvent{
+		CommitTs: 417318403368288260,
+		Table:    table,
+		Columns: []*model.Column{
+			{Name: "id", Type: mysql.TypeLong, Value: int64(1)},
+			{Name: "name", Type: mysql.TypeVarchar, Value: []byte("a\"b")},
+			{Name: "data", Type: mysql.TypeBlob, Flag: model.BinaryFlag, Value: []byte("abc")},
+			{Name: "score", Type: mysql.TypeDouble, Value: 1.5},
+			{Name: "comment", Type: mysql.TypeVarchar, Value: nil},
+		},
+	}
+	del := &model.RowChangedEvent{
+		CommitTs: 417318403368288261,
+		Table:    table,
+		PreColumns: []*model.Column{
+			{Name: "id", Type: mysql.TypeLong, Value: int64(2)},
+		},
+	}
+	update := &model.RowChangedEvent{
+		CommitTs: 417318403368288262,
+		Table:    table,
+		PreColumns: []*model.Column{
+			{Name: "id", Type: mysql.TypeLong, Value: int64(3)},
+		},
+		Columns: []*model.Column{
+			{Name: "id", Type: mysql.TypeLong, Value: int64(4)},
+		},
+	}
+
+	callbackCnt := 0
+	for _, e := range []*model.RowChangedEvent{insert, del, update} {
+		err := encoder.AppendRowChangedEvent(context.Background(), "", e, func() {
+			callbackCnt++
+		})
+		require.Nil(t, err)
+	}
+
+	messages := encoder.Build()
+	require.Len(t, messages, 3)
+	require.Equal(t, `"I","t1","test",417318403368288260,1,"a""b","YWJj",1.5,\N`,
+		string(messages[0].Value))
+	require.Equal(t, `"D","t1","test",417318403368288261,2`, string(messages[1].Value))
+	require.Equal(t, `"U","t1","test",417318403368288262,4`, string(messages[2].Value))
+	for _, m := range messages {
+		require.Equal(t, 1, m.GetRowsCount())
+		require.Equal(t, config.ProtocolCsv, m.Protocol)
+		m.Callback()
+	}
+	require.Equal(t, 3, callbackCnt)
+	require.Nil(t, encoder.Build())
+
+	// DDL and checkpoint events are not supported by csv.
+	msg, err := encoder.EncodeCheckpointEvent(1)
+	require.Nil(t, err)
+	require.Nil(t, msg)
+	msg, err = encoder.EncodeDDLEvent(&model.DDLEvent{})
+	require.Nil(t, err)
+	require.Nil(t, msg)
+}
+
+func TestCSVFormatWithoutQuote(t *testing.T) {
+	t.Parallel()
+
+	cfg := common.NewConfig(config.ProtocolCsv)
+	cfg.CSVQuote = ""
+	cfg.CSVDelimiter = "|"
+	cfg.CSVNullString = "NULL"
+
+	msg, err := rowChangedEvent2CSVMsg(cfg, &model.RowChangedEvent{
+		CommitTs: 1,
+		Table:    &model.TableName{Schema: "test", Table: "t1"},
+		Columns: []*model.Column{
+			{Name: "a", Type: mysql.TypeVarchar, Value: "x|y\nz"},
+			{Name: "b", Type: mysql.TypeVarchar, Value: nil},
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "I|t1|test|1|x\\|y\\\nz|NULL", string(msg.encode()))
+}
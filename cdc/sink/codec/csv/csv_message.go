@@ -0,0 +1,198 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csv
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// operation specifies the operation type of a csv row.
+type operation int
+
+const (
+	operationInsert operation = iota
+	operationDelete
+	operationUpdate
+)
+
+func (o operation) String() string {
+	switch o {
+	case operationInsert:
+		return "I"
+	case operationDelete:
+		return "D"
+	case operationUpdate:
+		return "U"
+	default:
+		return "unknown"
+	}
+}
+
+// csvMessage is a row of the csv file.
+// The layout of a row is:
+// | operation | table name | schema name | commit ts | column values... |
+type csvMessage struct {
+	// config hold the csv configuration items.
+	config *common.Config
+	// opType denotes the specific operation type.
+	opType     operation
+	tableName  string
+	schemaName string
+	commitTs   uint64
+	columns    []any
+}
+
+func newCSVMessage(config *common.Config) *csvMessage {
+	return &csvMessage{
+		config: config,
+	}
+}
+
+// encode returns a byte slice composed of the columns as follows:
+// Col1: The operation-type indicator: I, D, U.
+// Col2: Table name, the name of the source table.
+// Col3: Schema name, the name of the source schema.
+// Col4: Commit TS, the commit-ts of the source txn.
+// Col5-n: one or more columns that represent the data to be changed.
+func (c *csvMessage) encode() []byte {
+	strBuilder := new(strings.Builder)
+	c.formatValue(c.opType.String(), strBuilder)
+	c.formatValue(c.tableName, strBuilder)
+	c.formatValue(c.schemaName, strBuilder)
+	c.formatValue(c.commitTs, strBuilder)
+	for _, col := range c.columns {
+		c.formatValue(col, strBuilder)
+	}
+	return []byte(strBuilder.String())
+}
+
+func (c *csvMessage) formatWithQuotes(value string, strBuilder *strings.Builder) {
+	quote := c.config.CSVQuote
+	strBuilder.WriteString(quote)
+	// replace any quote in csv column with two quotes.
+	strBuilder.WriteString(strings.ReplaceAll(value, quote, quote+quote))
+	strBuilder.WriteString(quote)
+}
+
+func (c *csvMessage) formatWithoutQuotes(value string, strBuilder *strings.Builder) {
+	delimiter := c.config.CSVDelimiter
+	// escape the delimiter and line breaks, because there is no quote
+	// to surround the value.
+	for _, ch := range value {
+		switch {
+		case strings.HasPrefix(delimiter, string(ch)), ch == '\\', ch == '\n', ch == '\r':
+			strBuilder.WriteByte('\\')
+		}
+		strBuilder.WriteRune(ch)
+	}
+}
+
+func (c *csvMessage) formatValue(value any, strBuilder *strings.Builder) {
+	if strBuilder.Len() > 0 {
+		strBuilder.WriteString(c.config.CSVDelimiter)
+	}
+
+	switch v := value.(type) {
+	case nil:
+		strBuilder.WriteString(c.config.CSVNullString)
+	case uint64, int64:
+		strBuilder.WriteString(fmt.Sprintf("%d", v))
+	case float32:
+		strBuilder.WriteString(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	case float64:
+		strBuilder.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+	case string:
+		if len(c.config.CSVQuote) > 0 {
+			c.formatWithQuotes(v, strBuilder)
+		} else {
+			c.formatWithoutQuotes(v, strBuilder)
+		}
+	default:
+		strBuilder.WriteString(fmt.Sprintf("%v", v))
+	}
+}
+
+// fromColValToCsvVal converts a column value to the value which can be
+// formatted into the csv row.
+func fromColValToCsvVal(col *model.Column) (any, error) {
+	if col.Value == nil {
+		return nil, nil
+	}
+
+	switch col.Type {
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		var b []byte
+		switch v := col.Value.(type) {
+		case []byte:
+			b = v
+		case string:
+			b = []byte(v)
+		default:
+			return nil, cerror.ErrCSVEncodeFailed.GenWithStack(
+				"unexpected value type %T of column %s", col.Value, col.Name)
+		}
+		if col.Flag.IsBinary() {
+			return base64.StdEncoding.EncodeToString(b), nil
+		}
+		return string(b), nil
+	default:
+		return col.Value, nil
+	}
+}
+
+// rowChangedEvent2CSVMsg converts a RowChangedEvent to a csv message.
+// For delete events the values before the change are written,
+// otherwise the values after the change are written.
+func rowChangedEvent2CSVMsg(config *common.Config, e *model.RowChangedEvent) (*csvMessage, error) {
+	csvMsg := newCSVMessage(config)
+	csvMsg.tableName = e.Table.Table
+	csvMsg.schemaName = e.Table.Schema
+	csvMsg.commitTs = e.CommitTs
+
+	var cols []*model.Column
+	switch {
+	case e.IsDelete():
+		csvMsg.opType = operationDelete
+		cols = e.PreColumns
+	case e.IsUpdate():
+		csvMsg.opType = operationUpdate
+		cols = e.Columns
+	default:
+		csvMsg.opType = operationInsert
+		cols = e.Columns
+	}
+
+	csvMsg.columns = make([]any, 0, len(cols))
+	for _, col := range cols {
+		// The column may be nil if it is filtered out.
+		if col == nil {
+			continue
+		}
+		v, err := fromColValToCsvVal(col)
+		if err != nil {
+			return nil, err
+		}
+		csvMsg.columns = append(csvMsg.columns, v)
+	}
+	return csvMsg, nil
+}
@@ -0,0 +1,397 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// batchDecoder decodes the debezium messages into the original events.
+// The column types are restored from the schema, if the schema is not
+// sent, the column values are decoded as strings or nil.
+type batchDecoder struct {
+	keyPayload json.RawMessage
+
+	nextType    model.MessageType
+	nextSchema  *schemaField
+	nextPayload json.RawMessage
+}
+
+// NewBatchDecoder creates a new debezium batchDecoder.
+func NewBatchDecoder(key, value []byte) (codec.EventBatchDecoder, error) {
+	decoder := &batchDecoder{}
+	// The tombstone messages have no value.
+	if len(value) == 0 {
+		return decoder, nil
+	}
+
+	if len(key) > 0 {
+		_, keyPayload, err := splitEnvelope(key)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		decoder.keyPayload = keyPayload
+	}
+
+	schema, payload, err := splitEnvelope(value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	header := struct {
+		Op        string  `json:"op"`
+		DDL       *string `json:"ddl"`
+		SyncPoint bool    `json:"syncpoint"`
+	}{}
+	if err := json.Unmarshal(payload, &header); err != nil {
+		return nil, cerror.WrapError(cerror.ErrDebeziumDecodeFailed, err)
+	}
+	switch {
+	case header.Op != "":
+		decoder.nextType = model.MessageTypeRow
+	case header.DDL != nil:
+		decoder.nextType = model.MessageTypeDDL
+	case header.SyncPoint:
+		decoder.nextType = model.MessageTypeSyncPoint
+	default:
+		decoder.nextType = model.MessageTypeResolved
+	}
+	decoder.nextSchema = schema
+	decoder.nextPayload = payload
+	return decoder, nil
+}
+
+// splitEnvelope returns the schema and the payload of the message, the
+// schema is nil if the message only contains the payload.
+func splitEnvelope(data []byte) (*schemaField, json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, nil, cerror.WrapError(cerror.ErrDebeziumDecodeFailed, err)
+	}
+	payload, ok := fields["payload"]
+	if !ok {
+		return nil, data, nil
+	}
+	var schema *schemaField
+	if raw, ok := fields["schema"]; ok {
+		if err := json.Unmarshal(raw, &schema); err != nil {
+			return nil, nil, cerror.WrapError(cerror.ErrDebeziumDecodeFailed, err)
+		}
+	}
+	return schema, payload, nil
+}
+
+// HasNext implements the EventBatchDecoder interface
+func (b *batchDecoder) HasNext() (model.MessageType, bool, error) {
+	if b.nextPayload == nil {
+		return model.MessageTypeUnknown, false, nil
+	}
+	return b.nextType, true, nil
+}
+
+// NextResolvedEvent implements the EventBatchDecoder interface
+func (b *batchDecoder) NextResolvedEvent() (uint64, error) {
+	if b.nextPayload == nil || b.nextType != model.MessageTypeResolved {
+		return 0, cerror.ErrDebeziumDecodeFailed.GenWithStack(
+			"not found resolved event message")
+	}
+	payload := new(heartbeatPayload)
+	if err := unmarshalUseNumber(b.nextPayload, payload); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if payload.Source == nil {
+		return 0, cerror.ErrDebeziumDecodeFailed.GenWithStack(
+			"the source is missing in the heartbeat message")
+	}
+	b.nextPayload = nil
+	return payload.Source.CommitTs, nil
+}
+
+// NextSyncPointEvent implements the EventBatchDecoder interface
+func (b *batchDecoder) NextSyncPointEvent() (uint64, error) {
+	if b.nextPayload == nil || b.nextType != model.MessageTypeSyncPoint {
+		return 0, cerror.ErrDebeziumDecodeFailed.GenWithStack(
+			"not found syncpoint event message")
+	}
+	payload := new(syncPointPayload)
+	if err := unmarshalUseNumber(b.nextPayload, payload); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if payload.Source == nil {
+		return 0, cerror.ErrDebeziumDecodeFailed.GenWithStack(
+			"the source is missing in the syncpoint message")
+	}
+	b.nextPayload = nil
+	return payload.Source.CommitTs, nil
+}
+
+// NextRowChangedEvent implements the EventBatchDecoder interface
+func (b *batchDecoder) NextRowChangedEvent() (*model.RowChangedEvent, error) {
+	if b.nextPayload == nil || b.nextType != model.MessageTypeRow {
+		return nil, cerror.ErrDebeziumDecodeFailed.GenWithStack(
+			"not found row changed event message")
+	}
+	payload := new(rowPayload)
+	if err := unmarshalUseNumber(b.nextPayload, payload); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if payload.Source == nil {
+		return nil, cerror.ErrDebeziumDecodeFailed.GenWithStack(
+			"the source is missing in the row message")
+	}
+
+	e := &model.RowChangedEvent{
+		CommitTs: payload.Source.CommitTs,
+		Table: &model.TableName{
+			Schema: payload.Source.DB,
+			Table:  payload.Source.Table,
+		},
+	}
+	var err error
+	switch payload.Op {
+	case opCreate:
+		e.Columns, err = dataToColumns(payload.After, b.valueFields("after"))
+	case opUpdate:
+		e.Columns, err = dataToColumns(payload.After, b.valueFields("after"))
+		if err == nil {
+			e.PreColumns, err = dataToColumns(payload.Before, b.valueFields("before"))
+		}
+	case opDelete:
+		e.PreColumns, err = dataToColumns(payload.Before, b.valueFields("before"))
+	default:
+		err = cerror.ErrDebeziumDecodeFailed.GenWithStack(
+			"unknown operation %s", payload.Op)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	names, err := b.handleKeyNames()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	e.WithHandlePrimaryFlag(names)
+	b.nextPayload = nil
+	return e, nil
+}
+
+// NextDDLEvent implements the EventBatchDecoder interface
+func (b *batchDecoder) NextDDLEvent() (*model.DDLEvent, error) {
+	if b.nextPayload == nil || b.nextType != model.MessageTypeDDL {
+		return nil, cerror.ErrDebeziumDecodeFailed.GenWithStack(
+			"not found ddl event message")
+	}
+	payload := new(ddlPayload)
+	if err := unmarshalUseNumber(b.nextPayload, payload); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if payload.Source == nil {
+		return nil, cerror.ErrDebeziumDecodeFailed.GenWithStack(
+			"the source is missing in the ddl message")
+	}
+
+	e := &model.DDLEvent{
+		CommitTs: payload.Source.CommitTs,
+		Query:    payload.DDL,
+		Type:     timodel.ActionNone,
+		TableInfo: &model.SimpleTableInfo{
+			Schema: payload.DatabaseName,
+			Table:  payload.Source.Table,
+		},
+	}
+	if len(payload.TableChanges) > 0 {
+		change := payload.TableChanges[0]
+		e.Type = tableChangeToDDL(change.Type)
+		if change.Table != nil {
+			columns := change.Table.Columns
+			sort.Slice(columns, func(i, j int) bool {
+				return columns[i].Position < columns[j].Position
+			})
+			for _, col := range columns {
+				e.TableInfo.ColumnInfo = append(e.TableInfo.ColumnInfo, &model.ColumnInfo{
+					Name: col.Name,
+					Type: types.StrToType(strings.ToLower(col.TypeName)),
+				})
+			}
+		}
+	}
+	b.nextPayload = nil
+	return e, nil
+}
+
+// valueFields returns the schema of the columns in the given field of
+// the envelope, it returns nil if the schema is not sent.
+func (b *batchDecoder) valueFields(name string) []*schemaField {
+	if b.nextSchema == nil {
+		return nil
+	}
+	for _, field := range b.nextSchema.Fields {
+		if field.Field == name {
+			return field.Fields
+		}
+	}
+	return nil
+}
+
+func (b *batchDecoder) handleKeyNames() (map[string]struct{}, error) {
+	if b.keyPayload == nil {
+		return nil, nil
+	}
+	columns := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(b.keyPayload, &columns); err != nil {
+		return nil, cerror.WrapError(cerror.ErrDebeziumDecodeFailed, err)
+	}
+	names := make(map[string]struct{}, len(columns))
+	for name := range columns {
+		names[name] = struct{}{}
+	}
+	return names, nil
+}
+
+func unmarshalUseNumber(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return cerror.WrapError(cerror.ErrDebeziumDecodeFailed, decoder.Decode(v))
+}
+
+// dataToColumns converts the data of the debezium message to columns. The
+// columns are in the order of the schema, and if the schema is not sent,
+// they are sorted by the names and the numbers are kept as strings.
+func dataToColumns(
+	data map[string]interface{}, fields []*schemaField,
+) ([]*model.Column, error) {
+	if data == nil {
+		return nil, nil
+	}
+	columns := make([]*model.Column, 0, len(data))
+	if fields == nil {
+		for name, value := range data {
+			col := &model.Column{Name: name}
+			if number, ok := value.(json.Number); ok {
+				col.Value = number.String()
+			} else {
+				col.Value = value
+			}
+			columns = append(columns, col)
+		}
+		sort.Slice(columns, func(i, j int) bool {
+			return columns[i].Name < columns[j].Name
+		})
+		return columns, nil
+	}
+
+	for _, field := range fields {
+		value, ok := data[field.Field]
+		if !ok {
+			continue
+		}
+		tp, flag := parseSourceColumnType(field.Parameters[sourceColumnTypeKey])
+		if field.Optional {
+			flag.SetIsNullable()
+		}
+		col := &model.Column{Name: field.Field, Type: tp, Flag: flag}
+		var err error
+		col.Value, err = decodeColumnValue(value, field.Type, col)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		columns = append(columns, col)
+	}
+	return columns, nil
+}
+
+// decodeColumnValue is the reverse of columnValue, the value is converted
+// to the type used by the column of the given type.
+func decodeColumnValue(
+	value interface{}, fieldType string, col *model.Column,
+) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	var (
+		result interface{}
+		err    error
+	)
+	switch fieldType {
+	case "int8", "int16", "int32", "int64":
+		number, ok := value.(json.Number)
+		if !ok {
+			break
+		}
+		switch {
+		case col.Flag.IsUnsigned(), col.Type == mysql.TypeBit,
+			col.Type == mysql.TypeEnum, col.Type == mysql.TypeSet:
+			result, err = strconv.ParseUint(number.String(), 10, 64)
+		default:
+			result, err = number.Int64()
+		}
+	case "float32":
+		number, ok := value.(json.Number)
+		if !ok {
+			break
+		}
+		var f float64
+		f, err = strconv.ParseFloat(number.String(), 32)
+		result = float32(f)
+	case "float64":
+		number, ok := value.(json.Number)
+		if !ok {
+			break
+		}
+		result, err = number.Float64()
+	case "bytes":
+		s, ok := value.(string)
+		if !ok {
+			break
+		}
+		result, err = base64.StdEncoding.DecodeString(s)
+	default:
+		result = value
+	}
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrDebeziumDecodeFailed, err)
+	}
+	if result == nil {
+		return nil, cerror.ErrDebeziumDecodeFailed.GenWithStack(
+			"unexpected value %v of column %s with type %s",
+			value, col.Name, fieldType)
+	}
+	return result, nil
+}
+
+// tableChangeToDDL returns the DDL type of the table change, only the types
+// which can be restored exactly are handled, the others are `ActionNone`.
+func tableChangeToDDL(tp string) timodel.ActionType {
+	switch tp {
+	case tableChangeCreate:
+		return timodel.ActionCreateTable
+	case tableChangeDrop:
+		return timodel.ActionDropTable
+	default:
+		return timodel.ActionNone
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebeziumDecodeRowChangedEvents(t *testing.T) {
+	t.Parallel()
+
+	newColumns := func(name string) []*model.Column {
+		return []*model.Column{
+			{
+				Name: "id", Type: mysql.TypeLong, Value: int64(1),
+				Flag: model.HandleKeyFlag | model.PrimaryKeyFlag,
+			},
+			{Name: "tiny", Type: mysql.TypeTiny, Value: uint64(255), Flag: model.UnsignedFlag},
+			{
+				Name: "bigint", Type: mysql.TypeLonglong, Value: uint64(math.MaxUint64),
+				Flag: model.UnsignedFlag,
+			},
+			{Name: "float", Type: mysql.TypeFloat, Value: float32(1.5)},
+			{Name: "double", Type: mysql.TypeDouble, Value: nil, Flag: model.NullableFlag},
+			{Name: "decimal", Type: mysql.TypeNewDecimal, Value: "1234567.891"},
+			{Name: "name", Type: mysql.TypeVarchar, Value: []byte(name)},
+			{Name: "blob", Type: mysql.TypeBlob, Value: []byte{0x1, 0x2}, Flag: model.BinaryFlag},
+			{Name: "bit", Type: mysql.TypeBit, Value: uint64(683)},
+			{Name: "enum", Type: mysql.TypeEnum, Value: uint64(2)},
+			{Name: "datetime", Type: mysql.TypeDatetime, Value: "2022-09-01 10:35:12"},
+			{Name: "year", Type: mysql.TypeYear, Value: int64(2022)},
+		}
+	}
+	expectedColumns := func(name string) []*model.Column {
+		columns := newColumns(name)
+		columns[6].Value = name
+		return columns
+	}
+
+	table := &model.TableName{Schema: "test", Table: "t1"}
+	events := []*model.RowChangedEvent{
+		{CommitTs: 424316552636792833, Table: table, Columns: newColumns("a")},
+		{
+			CommitTs: 424316552636792834, Table: table,
+			Columns: newColumns("b"), PreColumns: newColumns("a"),
+		},
+		{CommitTs: 424316552636792835, Table: table, PreColumns: newColumns("b")},
+	}
+	expected := []*model.RowChangedEvent{
+		{CommitTs: 424316552636792833, Table: table, Columns: expectedColumns("a")},
+		{
+			CommitTs: 424316552636792834, Table: table,
+			Columns: expectedColumns("b"), PreColumns: expectedColumns("a"),
+		},
+		{CommitTs: 424316552636792835, Table: table, PreColumns: expectedColumns("b")},
+	}
+
+	encoder := newBatchEncoder(false)
+	for _, event := range events {
+		err := encoder.AppendRowChangedEvent(context.Background(), "", event, nil)
+		require.NoError(t, err)
+	}
+	messages := encoder.Build()
+	require.Len(t, messages, len(events))
+
+	for i, msg := range messages {
+		decoder, err := NewBatchDecoder(msg.Key, msg.Value)
+		require.NoError(t, err)
+		tp, hasNext, err := decoder.HasNext()
+		require.NoError(t, err)
+		require.True(t, hasNext)
+		require.Equal(t, model.MessageTypeRow, tp)
+		row, err := decoder.NextRowChangedEvent()
+		require.NoError(t, err)
+		require.Equal(t, expected[i], row)
+
+		_, hasNext, err = decoder.HasNext()
+		require.NoError(t, err)
+		require.False(t, hasNext)
+	}
+
+	// Without the schema, the values are decoded as strings.
+	encoder = newBatchEncoder(true)
+	err := encoder.AppendRowChangedEvent(context.Background(), "", events[0], nil)
+	require.NoError(t, err)
+	messages = encoder.Build()
+	require.Len(t, messages, 1)
+
+	decoder, err := NewBatchDecoder(messages[0].Key, messages[0].Value)
+	require.NoError(t, err)
+	_, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	row, err := decoder.NextRowChangedEvent()
+	require.NoError(t, err)
+	require.Equal(t, events[0].CommitTs, row.CommitTs)
+	require.Len(t, row.Columns, len(events[0].Columns))
+	for _, col := range row.Columns {
+		switch col.Name {
+		case "id":
+			require.Equal(t, "1", col.Value)
+			require.True(t, col.Flag.IsHandleKey())
+		case "bigint":
+			require.Equal(t, "18446744073709551615", col.Value)
+		case "name":
+			require.Equal(t, "a", col.Value)
+		case "double":
+			require.Nil(t, col.Value)
+		}
+	}
+}
+
+func TestDebeziumDecodeDDLEvent(t *testing.T) {
+	t.Parallel()
+
+	ddl := &model.DDLEvent{
+		CommitTs: 424316552636792833,
+		TableInfo: &model.SimpleTableInfo{
+			Schema: "test", Table: "t1",
+			ColumnInfo: []*model.ColumnInfo{
+				{Name: "id", Type: mysql.TypeLong},
+				{Name: "name", Type: mysql.TypeVarchar},
+			},
+		},
+		Query: "create table t1(id int primary key, name varchar(255))",
+		Type:  timodel.ActionCreateTable,
+	}
+	for _, disableSchema := range []bool{false, true} {
+		encoder := newBatchEncoder(disableSchema)
+		msg, err := encoder.EncodeDDLEvent(ddl)
+		require.NoError(t, err)
+
+		decoder, err := NewBatchDecoder(msg.Key, msg.Value)
+		require.NoError(t, err)
+		tp, hasNext, err := decoder.HasNext()
+		require.NoError(t, err)
+		require.True(t, hasNext)
+		require.Equal(t, model.MessageTypeDDL, tp)
+		decoded, err := decoder.NextDDLEvent()
+		require.NoError(t, err)
+		require.Equal(t, ddl, decoded)
+
+		_, hasNext, err = decoder.HasNext()
+		require.NoError(t, err)
+		require.False(t, hasNext)
+	}
+
+	// The schema level DDLs don't have table changes.
+	ddl = &model.DDLEvent{
+		CommitTs:  424316552636792834,
+		TableInfo: &model.SimpleTableInfo{Schema: "test"},
+		Query:     "drop database test",
+		Type:      timodel.ActionDropSchema,
+	}
+	encoder := newBatchEncoder(false)
+	msg, err := encoder.EncodeDDLEvent(ddl)
+	require.NoError(t, err)
+	decoder, err := NewBatchDecoder(msg.Key, msg.Value)
+	require.NoError(t, err)
+	decoded, err := decoder.NextDDLEvent()
+	require.NoError(t, err)
+	require.Equal(t, timodel.ActionNone, decoded.Type)
+	require.Equal(t, ddl.Query, decoded.Query)
+	require.Equal(t, ddl.TableInfo, decoded.TableInfo)
+}
+
+func TestDebeziumDecodeResolvedEvent(t *testing.T) {
+	t.Parallel()
+
+	encoder := newBatchEncoder(false)
+	msg, err := encoder.EncodeCheckpointEvent(424316552636792833)
+	require.NoError(t, err)
+	require.Equal(t, model.MessageTypeResolved, msg.Type)
+
+	decoder, err := NewBatchDecoder(msg.Key, msg.Value)
+	require.NoError(t, err)
+	tp, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeResolved, tp)
+	ts, err := decoder.NextResolvedEvent()
+	require.NoError(t, err)
+	require.Equal(t, uint64(424316552636792833), ts)
+
+	_, err = decoder.NextRowChangedEvent()
+	require.Error(t, err)
+}
+
+func TestDebeziumDecodeSyncPointEvent(t *testing.T) {
+	t.Parallel()
+
+	for _, disableSchema := range []bool{false, true} {
+		encoder := newBatchEncoder(disableSchema)
+		msg, err := encoder.EncodeSyncPointEvent(424316552636792833)
+		require.NoError(t, err)
+		require.Equal(t, model.MessageTypeSyncPoint, msg.Type)
+
+		decoder, err := NewBatchDecoder(msg.Key, msg.Value)
+		require.NoError(t, err)
+		tp, hasNext, err := decoder.HasNext()
+		require.NoError(t, err)
+		require.True(t, hasNext)
+		require.Equal(t, model.MessageTypeSyncPoint, tp)
+		_, err = decoder.NextResolvedEvent()
+		require.Error(t, err)
+		ts, err := decoder.NextSyncPointEvent()
+		require.NoError(t, err)
+		require.Equal(t, uint64(424316552636792833), ts)
+
+		_, hasNext, err = decoder.HasNext()
+		require.NoError(t, err)
+		require.False(t, hasNext)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// BatchEncoder encodes the events into the debezium format, which is the
+// format of the kafka connect JsonConverter. Each row is sent as a message.
+type BatchEncoder struct {
+	messages []*common.Message
+	// disableSchema omits the schema and only sends the payload.
+	disableSchema bool
+	// maxMessageBytes is the max size of a row message.
+	maxMessageBytes int
+}
+
+// newBatchEncoder creates a new debezium BatchEncoder.
+func newBatchEncoder(disableSchema bool) codec.EventBatchEncoder {
+	return &BatchEncoder{
+		messages:        make([]*common.Message, 0),
+		disableSchema:   disableSchema,
+		maxMessageBytes: config.DefaultMaxMessageBytes,
+	}
+}
+
+// EncodeCheckpointEvent implements the EventBatchEncoder interface.
+// The checkpoint ts is sent as a heartbeat message.
+func (d *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error) {
+	payload := &heartbeatPayload{
+		Source: newSource(ts, "", ""),
+		TsMs:   time.Now().UnixMilli(),
+	}
+	value, err := d.encode(heartbeatSchema(), payload)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewResolvedMsg(config.ProtocolDebezium, nil, value, ts), nil
+}
+
+// EncodeSyncPointEvent implements the EventBatchEncoder interface.
+// The syncpoint ts is sent in the same envelope as the heartbeat message,
+// which is marked by the `syncpoint` field.
+func (d *BatchEncoder) EncodeSyncPointEvent(ts uint64) (*common.Message, error) {
+	payload := &syncPointPayload{
+		Source:    newSource(ts, "", ""),
+		TsMs:      time.Now().UnixMilli(),
+		SyncPoint: true,
+	}
+	value, err := d.encode(syncPointSchema(), payload)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewSyncPointMsg(config.ProtocolDebezium, nil, value, ts), nil
+}
+
+// AppendRowChangedEvent implements the EventBatchEncoder interface
+func (d *BatchEncoder) AppendRowChangedEvent(
+	_ context.Context,
+	_ string,
+	e *model.RowChangedEvent,
+	callback func(),
+) error {
+	var key []byte
+	keySchema, keyPayload := handleKeyToKey(e)
+	if keyPayload != nil {
+		var err error
+		key, err = d.encode(keySchema, keyPayload)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	value, err := d.encode(rowChangeToSchema(e),
+		rowChangeToPayload(e, time.Now().UnixMilli()))
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	m := common.NewMsg(config.ProtocolDebezium, key, value,
+		e.CommitTs, model.MessageTypeRow, &e.Table.Schema, &e.Table.Table)
+	// Each row is sent as a message, so the row can't be sent
+	// if the message is larger than max-message-bytes.
+	if m.Length() > d.maxMessageBytes {
+		log.Warn("Single message too large",
+			zap.Int("max-message-size", d.maxMessageBytes),
+			zap.Int("length", m.Length()), zap.Any("table", e.Table))
+		return cerror.ErrMessageTooLarge.GenWithStackByArgs()
+	}
+	m.IncRowsCount()
+	m.Callback = callback
+	d.messages = append(d.messages, m)
+	return nil
+}
+
+// EncodeDDLEvent implements the EventBatchEncoder interface
+func (d *BatchEncoder) EncodeDDLEvent(e *model.DDLEvent) (*common.Message, error) {
+	key, err := d.encode(ddlKeySchema(), map[string]interface{}{
+		"databaseName": e.TableInfo.Schema,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	value, err := d.encode(ddlSchema(), ddlEventToPayload(e, time.Now().UnixMilli()))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewDDLMsg(config.ProtocolDebezium, key, value, e), nil
+}
+
+// Build implements the EventBatchEncoder interface
+func (d *BatchEncoder) Build() []*common.Message {
+	if len(d.messages) == 0 {
+		return nil
+	}
+	ret := d.messages
+	d.messages = make([]*common.Message, 0)
+	return ret
+}
+
+func (d *BatchEncoder) encode(schema *schemaField, payload interface{}) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if d.disableSchema {
+		data, err = json.Marshal(payload)
+	} else {
+		data, err = json.Marshal(&message{Schema: schema, Payload: payload})
+	}
+	return data, cerror.WrapError(cerror.ErrDebeziumEncodeFailed, err)
+}
+
+type batchEncoderBuilder struct {
+	config *common.Config
+}
+
+// NewBatchEncoderBuilder creates a debezium batchEncoderBuilder.
+func NewBatchEncoderBuilder(config *common.Config) codec.EncoderBuilder {
+	return &batchEncoderBuilder{config: config}
+}
+
+// Build a `BatchEncoder`
+func (b *batchEncoderBuilder) Build() codec.EventBatchEncoder {
+	encoder := newBatchEncoder(b.config.DebeziumDisableSchema)
+	encoder.(*BatchEncoder).maxMessageBytes = b.config.MaxMessageBytes
+	return encoder
+}
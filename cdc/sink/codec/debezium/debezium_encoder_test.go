@@ -0,0 +1,170 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebeziumAppendRowChangedEvent(t *testing.T) {
+	t.Parallel()
+
+	e := &model.RowChangedEvent{
+		CommitTs: 424316552636792833,
+		Table:    &model.TableName{Schema: "test", Table: "t1"},
+		Columns: []*model.Column{
+			{
+				Name: "id", Type: mysql.TypeLong, Value: int64(1),
+				Flag: model.HandleKeyFlag | model.PrimaryKeyFlag,
+			},
+			{Name: "name", Type: mysql.TypeVarchar, Value: []byte("a")},
+			nil,
+		},
+	}
+
+	builder := NewBatchEncoderBuilder(common.NewConfig(config.ProtocolDebezium))
+	encoder := builder.Build()
+	called := false
+	err := encoder.AppendRowChangedEvent(context.Background(), "", e, func() { called = true })
+	require.NoError(t, err)
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.Equal(t, 1, messages[0].GetRowsCount())
+	require.Equal(t, e.CommitTs, messages[0].Ts)
+	messages[0].Callback()
+	require.True(t, called)
+	require.Nil(t, encoder.Build())
+
+	key := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(messages[0].Key, &key))
+	require.Equal(t, map[string]interface{}{"id": float64(1)}, key["payload"])
+	require.Equal(t, "tidb.test.t1.Key", key["schema"].(map[string]interface{})["name"])
+
+	value := new(message)
+	require.NoError(t, json.Unmarshal(messages[0].Value, value))
+	require.Equal(t, "tidb.test.t1.Envelope", value.Schema.Name)
+	require.Len(t, value.Schema.Fields, 5)
+	after := value.Schema.Fields[1]
+	require.Equal(t, "after", after.Field)
+	// The filtered column is not in the schema.
+	require.Equal(t, []*schemaField{
+		{
+			Type: "int32", Field: "id",
+			Parameters: map[string]string{sourceColumnTypeKey: "INT"},
+		},
+		{
+			Type: "string", Field: "name",
+			Parameters: map[string]string{sourceColumnTypeKey: "VARCHAR"},
+		},
+	}, after.Fields)
+
+	payload := value.Payload.(map[string]interface{})
+	require.Nil(t, payload["before"])
+	require.Equal(t, map[string]interface{}{"id": float64(1), "name": "a"}, payload["after"])
+	require.Equal(t, opCreate, payload["op"])
+	source := payload["source"].(map[string]interface{})
+	require.Equal(t, connectorName, source["connector"])
+	require.Equal(t, "test", source["db"])
+	require.Equal(t, "t1", source["table"])
+	require.Equal(t, float64(e.CommitTs), source["commit_ts"])
+
+	// Only the payload is sent if the schema is disabled.
+	c := common.NewConfig(config.ProtocolDebezium)
+	c.DebeziumDisableSchema = true
+	encoder = NewBatchEncoderBuilder(c).Build()
+	err = encoder.AppendRowChangedEvent(context.Background(), "", e, nil)
+	require.NoError(t, err)
+	messages = encoder.Build()
+	require.Len(t, messages, 1)
+	require.JSONEq(t, `{"id":1}`, string(messages[0].Key))
+	bare := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(messages[0].Value, &bare))
+	require.NotContains(t, bare, "schema")
+	require.Equal(t, opCreate, bare["op"])
+}
+
+func TestDebeziumMaxMessageBytes(t *testing.T) {
+	t.Parallel()
+
+	e := &model.RowChangedEvent{
+		CommitTs: 424316552636792833,
+		Table:    &model.TableName{Schema: "test", Table: "t1"},
+		Columns: []*model.Column{
+			{
+				Name: "id", Type: mysql.TypeLong, Value: int64(1),
+				Flag: model.HandleKeyFlag | model.PrimaryKeyFlag,
+			},
+			{Name: "name", Type: mysql.TypeVarchar, Value: []byte(strings.Repeat("a", 1024))},
+		},
+	}
+
+	c := common.NewConfig(config.ProtocolDebezium).WithMaxMessageBytes(1024)
+	encoder := NewBatchEncoderBuilder(c).Build()
+	err := encoder.AppendRowChangedEvent(context.Background(), "", e, nil)
+	require.True(t, cerror.ErrMessageTooLarge.Equal(err))
+	require.Nil(t, encoder.Build())
+
+	encoder = NewBatchEncoderBuilder(common.NewConfig(config.ProtocolDebezium)).Build()
+	err = encoder.AppendRowChangedEvent(context.Background(), "", e, nil)
+	require.NoError(t, err)
+	require.Len(t, encoder.Build(), 1)
+}
+
+func TestDebeziumEncodeDDLEvent(t *testing.T) {
+	t.Parallel()
+
+	e := &model.DDLEvent{
+		CommitTs: 424316552636792833,
+		TableInfo: &model.SimpleTableInfo{
+			Schema: "test", Table: "t1",
+			ColumnInfo: []*model.ColumnInfo{{Name: "id", Type: mysql.TypeLong}},
+		},
+		Query: "alter table t1 add column a int",
+	}
+	encoder := newBatchEncoder(false)
+	msg, err := encoder.EncodeDDLEvent(e)
+	require.NoError(t, err)
+	require.Equal(t, model.MessageTypeDDL, msg.Type)
+	require.JSONEq(t, `{"databaseName":"test"}`, string(mustPayload(t, msg.Key)))
+
+	payload := new(ddlPayload)
+	require.NoError(t, json.Unmarshal(mustPayload(t, msg.Value), payload))
+	require.Equal(t, "test", payload.DatabaseName)
+	require.Equal(t, e.Query, payload.DDL)
+	require.Equal(t, []*tableChange{{
+		Type: tableChangeAlter,
+		ID:   `"test"."t1"`,
+		Table: &table{Columns: []*tableColumn{
+			{Name: "id", TypeName: "INT", Position: 1},
+		}},
+	}}, payload.TableChanges)
+}
+
+func mustPayload(t *testing.T, data []byte) json.RawMessage {
+	envelope := struct {
+		Payload json.RawMessage `json:"payload"`
+	}{}
+	require.NoError(t, json.Unmarshal(data, &envelope))
+	return envelope.Payload
+}
@@ -0,0 +1,434 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"fmt"
+	"strings"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/version"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+const (
+	connectorName = "TiCDC"
+	// serverName is the logical name of the upstream cluster, it is used
+	// as the `source.name` and the prefix of the schema names.
+	serverName = "tidb"
+	// sourceColumnTypeKey is the schema parameter which carries the original
+	// column type, the same as the `column.propagate.source.type` option of
+	// the debezium MySQL connector.
+	sourceColumnTypeKey = "__debezium.source.column.type"
+
+	opCreate = "c"
+	opUpdate = "u"
+	opDelete = "d"
+
+	tableChangeCreate = "CREATE"
+	tableChangeAlter  = "ALTER"
+	tableChangeDrop   = "DROP"
+)
+
+// schemaField is the schema of the kafka connect JsonConverter.
+type schemaField struct {
+	Type       string            `json:"type"`
+	Optional   bool              `json:"optional"`
+	Name       string            `json:"name,omitempty"`
+	Field      string            `json:"field,omitempty"`
+	Fields     []*schemaField    `json:"fields,omitempty"`
+	Items      *schemaField      `json:"items,omitempty"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// message is the envelope of the kafka connect JsonConverter, the schema
+// is omitted if it is disabled, and only the payload is sent in that case.
+type message struct {
+	Schema  *schemaField `json:"schema"`
+	Payload interface{}  `json:"payload"`
+}
+
+type source struct {
+	Version   string `json:"version"`
+	Connector string `json:"connector"`
+	Name      string `json:"name"`
+	TsMs      int64  `json:"ts_ms"`
+	Snapshot  string `json:"snapshot"`
+	DB        string `json:"db"`
+	Table     string `json:"table,omitempty"`
+	// CommitTs is the TSO of the transaction, which is specific to TiDB.
+	CommitTs uint64 `json:"commit_ts"`
+}
+
+// rowPayload is the payload of the data change events.
+type rowPayload struct {
+	Before map[string]interface{} `json:"before"`
+	After  map[string]interface{} `json:"after"`
+	Source *source                `json:"source"`
+	Op     string                 `json:"op"`
+	TsMs   int64                  `json:"ts_ms"`
+}
+
+// ddlPayload is the payload of the schema change events.
+type ddlPayload struct {
+	Source       *source        `json:"source"`
+	TsMs         int64          `json:"ts_ms"`
+	DatabaseName string         `json:"databaseName"`
+	DDL          string         `json:"ddl"`
+	TableChanges []*tableChange `json:"tableChanges"`
+}
+
+type tableChange struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	Table *table `json:"table"`
+}
+
+type table struct {
+	Columns []*tableColumn `json:"columns"`
+}
+
+type tableColumn struct {
+	Name     string `json:"name"`
+	TypeName string `json:"typeName"`
+	Position int    `json:"position"`
+}
+
+// heartbeatPayload is the payload of the checkpoint events, the
+// `source.commit_ts` is the checkpoint ts.
+type heartbeatPayload struct {
+	Source *source `json:"source"`
+	TsMs   int64   `json:"ts_ms"`
+}
+
+// syncPointPayload is the payload of the syncpoint events, it's the same as
+// the heartbeat except the `syncpoint` flag, the `source.commit_ts` is the
+// syncpoint ts.
+type syncPointPayload struct {
+	Source    *source `json:"source"`
+	TsMs      int64   `json:"ts_ms"`
+	SyncPoint bool    `json:"syncpoint"`
+}
+
+func newSource(commitTs uint64, schema, table string) *source {
+	return &source{
+		Version:   version.ReleaseVersion,
+		Connector: connectorName,
+		Name:      serverName,
+		TsMs:      oracle.ExtractPhysical(commitTs),
+		Snapshot:  "false",
+		DB:        schema,
+		Table:     table,
+		CommitTs:  commitTs,
+	}
+}
+
+func schemaNamePrefix(schema, table string) string {
+	return fmt.Sprintf("%s.%s.%s", serverName, schema, table)
+}
+
+func rowChangeToPayload(e *model.RowChangedEvent, tsMs int64) *rowPayload {
+	payload := &rowPayload{
+		Source: newSource(e.CommitTs, e.Table.Schema, e.Table.Table),
+		TsMs:   tsMs,
+	}
+	switch {
+	case e.IsDelete():
+		payload.Op = opDelete
+		payload.Before = columnsToData(e.PreColumns)
+	case e.IsUpdate():
+		payload.Op = opUpdate
+		payload.Before = columnsToData(e.PreColumns)
+		payload.After = columnsToData(e.Columns)
+	default:
+		payload.Op = opCreate
+		payload.After = columnsToData(e.Columns)
+	}
+	return payload
+}
+
+func columnsToData(columns []*model.Column) map[string]interface{} {
+	data := make(map[string]interface{}, len(columns))
+	for _, col := range columns {
+		if col == nil {
+			continue
+		}
+		data[col.Name] = columnValue(col)
+	}
+	return data
+}
+
+// columnValue returns the value of the column in the debezium message. The
+// binary values are encoded in base64 by the json encoder, which is the
+// same as the `bytes` schema type, the others are kept as they are.
+func columnValue(col *model.Column) interface{} {
+	if b, ok := col.Value.([]byte); ok && !col.Flag.IsBinary() {
+		return string(b)
+	}
+	return col.Value
+}
+
+// rowChangeToSchema returns the schema of the data change event, the schema
+// of the columns is built from the non-trivial columns of the event.
+func rowChangeToSchema(e *model.RowChangedEvent) *schemaField {
+	columns := e.Columns
+	if e.IsDelete() {
+		columns = e.PreColumns
+	}
+	fields := make([]*schemaField, 0, len(columns))
+	for _, col := range columns {
+		if col == nil {
+			continue
+		}
+		fields = append(fields, columnSchema(col.Name, col.Type, col.Flag))
+	}
+
+	prefix := schemaNamePrefix(e.Table.Schema, e.Table.Table)
+	return &schemaField{
+		Type: "struct",
+		Name: prefix + ".Envelope",
+		Fields: []*schemaField{
+			{
+				Type: "struct", Optional: true, Name: prefix + ".Value",
+				Field: "before", Fields: fields,
+			},
+			{
+				Type: "struct", Optional: true, Name: prefix + ".Value",
+				Field: "after", Fields: fields,
+			},
+			sourceSchema(),
+			{Type: "string", Field: "op"},
+			{Type: "int64", Optional: true, Field: "ts_ms"},
+		},
+	}
+}
+
+// handleKeyToKey returns the schema and the payload of the message key,
+// which contains the handle key columns. It returns nil if there is no
+// handle key column in the event, e.g. the tables without primary keys.
+func handleKeyToKey(e *model.RowChangedEvent) (*schemaField, map[string]interface{}) {
+	columns := e.HandleKeyColumns()
+	if len(columns) == 0 {
+		return nil, nil
+	}
+	fields := make([]*schemaField, 0, len(columns))
+	for _, col := range columns {
+		fields = append(fields, columnSchema(col.Name, col.Type, col.Flag))
+	}
+	schema := &schemaField{
+		Type:   "struct",
+		Name:   schemaNamePrefix(e.Table.Schema, e.Table.Table) + ".Key",
+		Fields: fields,
+	}
+	return schema, columnsToData(columns)
+}
+
+func columnSchema(name string, tp byte, flag model.ColumnFlagType) *schemaField {
+	return &schemaField{
+		Type:     fieldType(tp, flag),
+		Optional: flag.IsNullable(),
+		Field:    name,
+		Parameters: map[string]string{
+			sourceColumnTypeKey: sourceColumnType(tp, flag),
+		},
+	}
+}
+
+// fieldType returns the schema type of the column, the temporal types, the
+// decimal and the json type are kept as strings, as what canal-json does.
+func fieldType(tp byte, flag model.ColumnFlagType) string {
+	switch tp {
+	case mysql.TypeTiny:
+		return "int16"
+	case mysql.TypeShort:
+		if flag.IsUnsigned() {
+			return "int32"
+		}
+		return "int16"
+	case mysql.TypeInt24, mysql.TypeYear:
+		return "int32"
+	case mysql.TypeLong:
+		if flag.IsUnsigned() {
+			return "int64"
+		}
+		return "int32"
+	case mysql.TypeLonglong, mysql.TypeBit, mysql.TypeEnum, mysql.TypeSet:
+		return "int64"
+	case mysql.TypeFloat:
+		return "float32"
+	case mysql.TypeDouble:
+		return "float64"
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		if flag.IsBinary() {
+			return "bytes"
+		}
+		return "string"
+	default:
+		return "string"
+	}
+}
+
+func sourceColumnType(tp byte, flag model.ColumnFlagType) string {
+	charset := ""
+	if flag.IsBinary() {
+		charset = "binary"
+	}
+	typeName := strings.ToUpper(types.TypeToStr(tp, charset))
+	if flag.IsUnsigned() {
+		typeName += " UNSIGNED"
+	}
+	return typeName
+}
+
+// parseSourceColumnType is the reverse of sourceColumnType.
+func parseSourceColumnType(typeName string) (byte, model.ColumnFlagType) {
+	var flag model.ColumnFlagType
+	typeName = strings.ToLower(typeName)
+	if strings.HasSuffix(typeName, " unsigned") {
+		flag.SetIsUnsigned()
+		typeName = strings.TrimSuffix(typeName, " unsigned")
+	}
+	if strings.Contains(typeName, "blob") || strings.Contains(typeName, "binary") {
+		flag.SetIsBinary()
+	}
+	return types.StrToType(typeName), flag
+}
+
+func sourceSchema() *schemaField {
+	return &schemaField{
+		Type:  "struct",
+		Name:  "io.debezium.connector.mysql.Source",
+		Field: "source",
+		Fields: []*schemaField{
+			{Type: "string", Field: "version"},
+			{Type: "string", Field: "connector"},
+			{Type: "string", Field: "name"},
+			{Type: "int64", Field: "ts_ms"},
+			{Type: "string", Optional: true, Field: "snapshot"},
+			{Type: "string", Field: "db"},
+			{Type: "string", Optional: true, Field: "table"},
+			{Type: "int64", Field: "commit_ts"},
+		},
+	}
+}
+
+func ddlEventToPayload(e *model.DDLEvent, tsMs int64) *ddlPayload {
+	payload := &ddlPayload{
+		Source:       newSource(e.CommitTs, e.TableInfo.Schema, e.TableInfo.Table),
+		TsMs:         tsMs,
+		DatabaseName: e.TableInfo.Schema,
+		DDL:          e.Query,
+		TableChanges: make([]*tableChange, 0, 1),
+	}
+	// The schema level DDLs don't change any table.
+	if e.TableInfo.Table == "" {
+		return payload
+	}
+
+	change := &tableChange{
+		Type:  tableChangeAlter,
+		ID:    fmt.Sprintf("\"%s\".\"%s\"", e.TableInfo.Schema, e.TableInfo.Table),
+		Table: &table{Columns: make([]*tableColumn, 0, len(e.TableInfo.ColumnInfo))},
+	}
+	switch e.Type {
+	case timodel.ActionCreateTable:
+		change.Type = tableChangeCreate
+	case timodel.ActionDropTable:
+		change.Type = tableChangeDrop
+	}
+	for i, col := range e.TableInfo.ColumnInfo {
+		change.Table.Columns = append(change.Table.Columns, &tableColumn{
+			Name:     col.Name,
+			TypeName: strings.ToUpper(types.TypeStr(col.Type)),
+			Position: i + 1,
+		})
+	}
+	payload.TableChanges = append(payload.TableChanges, change)
+	return payload
+}
+
+func ddlKeySchema() *schemaField {
+	return &schemaField{
+		Type: "struct",
+		Name: "io.debezium.connector.mysql.SchemaChangeKey",
+		Fields: []*schemaField{
+			{Type: "string", Field: "databaseName"},
+		},
+	}
+}
+
+func ddlSchema() *schemaField {
+	columnSchema := &schemaField{
+		Type: "struct",
+		Name: "io.debezium.connector.schema.Column",
+		Fields: []*schemaField{
+			{Type: "string", Field: "name"},
+			{Type: "string", Field: "typeName"},
+			{Type: "int32", Field: "position"},
+		},
+	}
+	changeSchema := &schemaField{
+		Type: "struct",
+		Name: "io.debezium.connector.schema.Change",
+		Fields: []*schemaField{
+			{Type: "string", Field: "type"},
+			{Type: "string", Field: "id"},
+			{
+				Type: "struct", Optional: true,
+				Name: "io.debezium.connector.schema.Table", Field: "table",
+				Fields: []*schemaField{
+					{Type: "array", Field: "columns", Items: columnSchema},
+				},
+			},
+		},
+	}
+	return &schemaField{
+		Type: "struct",
+		Name: "io.debezium.connector.mysql.SchemaChangeValue",
+		Fields: []*schemaField{
+			sourceSchema(),
+			{Type: "int64", Optional: true, Field: "ts_ms"},
+			{Type: "string", Optional: true, Field: "databaseName"},
+			{Type: "string", Optional: true, Field: "ddl"},
+			{Type: "array", Field: "tableChanges", Items: changeSchema},
+		},
+	}
+}
+
+func heartbeatSchema() *schemaField {
+	return &schemaField{
+		Type: "struct",
+		Name: "io.debezium.connector.common.Heartbeat",
+		Fields: []*schemaField{
+			sourceSchema(),
+			{Type: "int64", Field: "ts_ms"},
+		},
+	}
+}
+
+func syncPointSchema() *schemaField {
+	return &schemaField{
+		Type: "struct",
+		Name: serverName + ".SyncPoint",
+		Fields: []*schemaField{
+			sourceSchema(),
+			{Type: "int64", Field: "ts_ms"},
+			{Type: "boolean", Field: "syncpoint"},
+		},
+	}
+}
@@ -25,6 +25,8 @@ type EventBatchDecoder interface {
 	HasNext() (model.MessageType, bool, error)
 	// NextResolvedEvent returns the next resolved event if exists
 	NextResolvedEvent() (uint64, error)
+	// NextSyncPointEvent returns the ts of the next syncpoint event if exists
+	NextSyncPointEvent() (uint64, error)
 	// NextRowChangedEvent returns the next row changed event if exists
 	NextRowChangedEvent() (*model.RowChangedEvent, error)
 	// NextDDLEvent returns the next DDL event if exists
@@ -30,6 +30,11 @@ type EventBatchEncoder interface {
 	// EncodeCheckpointEvent appends a checkpoint event into the batch.
 	// This event will be broadcast to all partitions to signal a global checkpoint.
 	EncodeCheckpointEvent(ts uint64) (*common.Message, error)
+	// EncodeSyncPointEvent encodes a syncpoint event, it will be broadcast to
+	// all partitions to signal that all the events before ts have been sent,
+	// so the consumers can take a consistent snapshot at ts.
+	// It returns nil if the protocol doesn't support the syncpoint event.
+	EncodeSyncPointEvent(ts uint64) (*common.Message, error)
 	// AppendRowChangedEvent appends the calling context, a row changed event and the dispatch
 	// topic into the batch
 	AppendRowChangedEvent(context.Context, string, *model.RowChangedEvent, func()) error
@@ -28,6 +28,9 @@ type MessageKey struct {
 	RowID     int64             `json:"rid,omitempty"`
 	Partition *int64            `json:"ptn,omitempty"`
 	Type      model.MessageType `json:"t"`
+	// ClaimCheckLocation is the location of the original message in the
+	// external storage, the message itself only contains the handle key.
+	ClaimCheckLocation string `json:"ccl,omitempty"`
 }
 
 // Encode encodes the message key to a byte slice.
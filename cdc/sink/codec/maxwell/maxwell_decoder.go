@@ -0,0 +1,290 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maxwell
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+
+	"github.com/pingcap/errors"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/internal"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+const (
+	insertType = "insert"
+	updateType = "update"
+	deleteType = "delete"
+)
+
+// batchDecoder decodes the maxwell messages into the original events.
+// The row messages are concatenated json objects, and the key contains
+// the message keys of the rows if the TiDB extension is enabled. The maxwell format doesn't carry the
+// column types, so the column values are decoded as strings or nil.
+type batchDecoder struct {
+	keys   []byte
+	values *json.Decoder
+
+	nextType  model.MessageType
+	nextValue json.RawMessage
+}
+
+// NewBatchDecoder creates a new maxwell batchDecoder.
+func NewBatchDecoder(key, value []byte) (codec.EventBatchDecoder, error) {
+	// The key of the DDL message is a json object, while the key of the
+	// row messages starts with the batch version.
+	if len(key) > 0 && key[0] != '{' {
+		if len(key) < 8 {
+			return nil, cerror.ErrMaxwellDecodeFailed.GenWithStack(
+				"the key is too short to contain the batch version")
+		}
+		version := binary.BigEndian.Uint64(key[:8])
+		if version != codec.BatchVersion1 {
+			return nil, cerror.ErrMaxwellDecodeFailed.GenWithStack(
+				"unexpected batch version %d", version)
+		}
+		key = key[8:]
+	} else {
+		key = nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	return &batchDecoder{
+		keys:   key,
+		values: decoder,
+	}, nil
+}
+
+// HasNext implements the EventBatchDecoder interface
+func (b *batchDecoder) HasNext() (model.MessageType, bool, error) {
+	if b.nextValue != nil {
+		return b.nextType, true, nil
+	}
+	if !b.values.More() {
+		return model.MessageTypeUnknown, false, nil
+	}
+
+	var value json.RawMessage
+	if err := b.values.Decode(&value); err != nil {
+		return model.MessageTypeUnknown, false,
+			cerror.WrapError(cerror.ErrMaxwellDecodeFailed, err)
+	}
+	header := struct {
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal(value, &header); err != nil {
+		return model.MessageTypeUnknown, false,
+			cerror.WrapError(cerror.ErrMaxwellDecodeFailed, err)
+	}
+
+	b.nextValue = value
+	switch header.Type {
+	case insertType, updateType, deleteType:
+		b.nextType = model.MessageTypeRow
+	default:
+		b.nextType = model.MessageTypeDDL
+	}
+	return b.nextType, true, nil
+}
+
+// NextResolvedEvent implements the EventBatchDecoder interface.
+// The maxwell protocol doesn't send resolved events.
+func (b *batchDecoder) NextResolvedEvent() (uint64, error) {
+	return 0, cerror.ErrMaxwellDecodeFailed.GenWithStack(
+		"maxwell protocol doesn't support resolved events")
+}
+
+// NextSyncPointEvent implements the EventBatchDecoder interface.
+// The maxwell protocol doesn't send syncpoint events.
+func (b *batchDecoder) NextSyncPointEvent() (uint64, error) {
+	return 0, cerror.ErrMaxwellDecodeFailed.GenWithStack(
+		"maxwell protocol doesn't support syncpoint events")
+}
+
+// NextRowChangedEvent implements the EventBatchDecoder interface
+func (b *batchDecoder) NextRowChangedEvent() (*model.RowChangedEvent, error) {
+	tp, hasNext, err := b.HasNext()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !hasNext || tp != model.MessageTypeRow {
+		return nil, cerror.ErrMaxwellDecodeFailed.GenWithStack(
+			"not found row changed event message")
+	}
+
+	msg := new(maxwellMessage)
+	if err := unmarshalUseNumber(b.nextValue, msg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	key, err := b.nextKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	b.nextValue = nil
+	return maxwellMsgToRowChange(key, msg), nil
+}
+
+// NextDDLEvent implements the EventBatchDecoder interface
+func (b *batchDecoder) NextDDLEvent() (*model.DDLEvent, error) {
+	tp, hasNext, err := b.HasNext()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !hasNext || tp != model.MessageTypeDDL {
+		return nil, cerror.ErrMaxwellDecodeFailed.GenWithStack(
+			"not found ddl event message")
+	}
+
+	msg := new(ddlMaxwellMessage)
+	if err := unmarshalUseNumber(b.nextValue, msg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	b.nextValue = nil
+	return maxwellMsgToDDLEvent(msg), nil
+}
+
+// nextKey returns the key of the next row, it returns nil if the
+// keys are not sent, which is the behavior of the old versions.
+func (b *batchDecoder) nextKey() (*internal.MessageKey, error) {
+	if len(b.keys) == 0 {
+		return nil, nil
+	}
+	if len(b.keys) < 8 {
+		return nil, cerror.ErrMaxwellDecodeFailed.GenWithStack(
+			"the length of the key is missing")
+	}
+	keyLen := binary.BigEndian.Uint64(b.keys[:8])
+	if uint64(len(b.keys)-8) < keyLen {
+		return nil, cerror.ErrMaxwellDecodeFailed.GenWithStack(
+			"the key is shorter than expected")
+	}
+	key := new(internal.MessageKey)
+	if err := key.Decode(b.keys[8 : 8+keyLen]); err != nil {
+		return nil, errors.Trace(err)
+	}
+	b.keys = b.keys[8+keyLen:]
+	return key, nil
+}
+
+func unmarshalUseNumber(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return cerror.WrapError(cerror.ErrMaxwellDecodeFailed, decoder.Decode(v))
+}
+
+func maxwellMsgToRowChange(
+	key *internal.MessageKey, msg *maxwellMessage,
+) *model.RowChangedEvent {
+	e := new(model.RowChangedEvent)
+	e.Table = &model.TableName{
+		Schema: msg.Database,
+		Table:  msg.Table,
+	}
+	if key != nil {
+		e.CommitTs = key.Ts
+		if key.Partition != nil {
+			e.Table.TableID = *key.Partition
+			e.Table.IsPartition = true
+		}
+	} else {
+		// The `ts` of the maxwell message is only accurate to seconds.
+		e.CommitTs = oracle.ComposeTS(msg.Ts*1000, 0)
+	}
+
+	switch msg.Type {
+	case insertType:
+		e.Columns = dataToColumns(msg.Data)
+	case updateType:
+		// `old` only contains the changed columns.
+		old := make(map[string]interface{}, len(msg.Data))
+		for name, value := range msg.Data {
+			old[name] = value
+		}
+		for name, value := range msg.Old {
+			old[name] = value
+		}
+		e.Columns = dataToColumns(msg.Data)
+		e.PreColumns = dataToColumns(old)
+	case deleteType:
+		e.PreColumns = dataToColumns(msg.Old)
+	}
+
+	names := make(map[string]struct{}, len(msg.PrimaryKeyColumns))
+	for _, name := range msg.PrimaryKeyColumns {
+		names[name] = struct{}{}
+	}
+	e.WithHandlePrimaryFlag(names)
+	return e
+}
+
+// dataToColumns converts the data of the maxwell message to columns,
+// which are sorted by the names. The numbers are kept as strings,
+// so no precision is lost.
+func dataToColumns(data map[string]interface{}) []*model.Column {
+	if len(data) == 0 {
+		return nil
+	}
+	columns := make([]*model.Column, 0, len(data))
+	for name, value := range data {
+		col := &model.Column{Name: name}
+		switch v := value.(type) {
+		case json.Number:
+			col.Value = v.String()
+		case nil:
+		default:
+			col.Value = v
+		}
+		columns = append(columns, col)
+	}
+	sort.Slice(columns, func(i, j int) bool {
+		return columns[i].Name < columns[j].Name
+	})
+	return columns
+}
+
+func maxwellMsgToDDLEvent(msg *ddlMaxwellMessage) *model.DDLEvent {
+	return &model.DDLEvent{
+		CommitTs: msg.Ts,
+		Query:    msg.SQL,
+		Type:     maxwellTypeToDDL(msg.Type),
+		TableInfo: &model.SimpleTableInfo{
+			Schema: msg.Database,
+			Table:  msg.Table,
+		},
+	}
+}
+
+// maxwellTypeToDDL returns the DDL type of the maxwell type, only the types
+// which can be restored exactly are handled, the others are `ActionNone`.
+func maxwellTypeToDDL(tp string) timodel.ActionType {
+	switch tp {
+	case "table-create":
+		return timodel.ActionCreateTable
+	case "table-drop":
+		return timodel.ActionDropTable
+	case "database-create":
+		return timodel.ActionCreateSchema
+	case "database-drop":
+		return timodel.ActionDropSchema
+	default:
+		return timodel.ActionNone
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maxwell
+
+import (
+	"context"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxwellDecodeRowChangedEvents(t *testing.T) {
+	t.Parallel()
+
+	newColumns := func(id int64, name string) []*model.Column {
+		return []*model.Column{
+			{
+				Name: "id", Type: mysql.TypeLong, Value: id,
+				Flag: model.HandleKeyFlag | model.PrimaryKeyFlag,
+			},
+			{Name: "name", Type: mysql.TypeVarchar, Value: []byte(name)},
+			{Name: "score", Type: mysql.TypeNewDecimal, Value: "1.50"},
+		}
+	}
+	expectedColumns := func(id, name string) []*model.Column {
+		return []*model.Column{
+			{Name: "id", Value: id, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag},
+			{Name: "name", Value: name},
+			{Name: "score", Value: "1.50"},
+		}
+	}
+	table := &model.TableName{Schema: "test", Table: "t1"}
+	events := []*model.RowChangedEvent{
+		{CommitTs: 424316552636792833, Table: table, Columns: newColumns(1, "a")},
+		{
+			CommitTs: 424316552636792834, Table: table,
+			Columns: newColumns(1, "b"), PreColumns: newColumns(1, "a"),
+		},
+		{CommitTs: 424316552636792835, Table: table, PreColumns: newColumns(1, "b")},
+	}
+	expected := []*model.RowChangedEvent{
+		{CommitTs: 424316552636792833, Table: table, Columns: expectedColumns("1", "a")},
+		{
+			CommitTs: 424316552636792834, Table: table,
+			Columns: expectedColumns("1", "b"), PreColumns: expectedColumns("1", "a"),
+		},
+		{CommitTs: 424316552636792835, Table: table, PreColumns: expectedColumns("1", "b")},
+	}
+
+	codecConfig := common.NewConfig(config.ProtocolMaxwell)
+	codecConfig.EnableTiDBExtension = true
+	encoder := newBatchEncoder(codecConfig)
+	for _, event := range events {
+		err := encoder.AppendRowChangedEvent(context.Background(), "", event, nil)
+		require.Nil(t, err)
+	}
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+
+	decoder, err := NewBatchDecoder(messages[0].Key, messages[0].Value)
+	require.Nil(t, err)
+	for _, e := range expected {
+		tp, hasNext, err := decoder.HasNext()
+		require.Nil(t, err)
+		require.True(t, hasNext)
+		require.Equal(t, model.MessageTypeRow, tp)
+		row, err := decoder.NextRowChangedEvent()
+		require.Nil(t, err)
+		require.Equal(t, e, row)
+	}
+	_, hasNext, err := decoder.HasNext()
+	require.Nil(t, err)
+	require.False(t, hasNext)
+
+	// Without the TiDB extension, only the batch version is in the key, and
+	// the commit ts is restored by the maxwell ts, which is accurate to seconds.
+	encoder = newBatchEncoder(common.NewConfig(config.ProtocolMaxwell))
+	require.Nil(t, encoder.AppendRowChangedEvent(context.Background(), "", events[0], nil))
+	messages = encoder.Build()
+	require.Len(t, messages, 1)
+	require.Len(t, messages[0].Key, 8)
+	require.NotContains(t, string(messages[0].Value), "primary_key_columns")
+	decoder, err = NewBatchDecoder(messages[0].Key, messages[0].Value)
+	require.Nil(t, err)
+	_, _, err = decoder.HasNext()
+	require.Nil(t, err)
+	row, err := decoder.NextRowChangedEvent()
+	require.Nil(t, err)
+	require.Less(t, row.CommitTs, events[0].CommitTs)
+	require.Equal(t, expected[0].Columns[1:], row.Columns[1:])
+	require.Equal(t, model.ColumnFlagType(0), row.Columns[0].Flag)
+}
+
+func TestMaxwellDecodeDDLEvent(t *testing.T) {
+	t.Parallel()
+
+	ddl := &model.DDLEvent{
+		CommitTs: 424316552636792833,
+		TableInfo: &model.SimpleTableInfo{
+			Schema: "test", Table: "t1",
+		},
+		Query: "create table t1(id int primary key)",
+		Type:  timodel.ActionCreateTable,
+	}
+	encoder := newBatchEncoder(common.NewConfig(config.ProtocolMaxwell))
+	msg, err := encoder.EncodeDDLEvent(ddl)
+	require.Nil(t, err)
+
+	decoder, err := NewBatchDecoder(msg.Key, msg.Value)
+	require.Nil(t, err)
+	tp, hasNext, err := decoder.HasNext()
+	require.Nil(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeDDL, tp)
+	decoded, err := decoder.NextDDLEvent()
+	require.Nil(t, err)
+	require.Equal(t, ddl, decoded)
+
+	_, hasNext, err = decoder.HasNext()
+	require.Nil(t, err)
+	require.False(t, hasNext)
+}
@@ -31,6 +31,10 @@ type BatchEncoder struct {
 	valueBuf    *bytes.Buffer
 	callbackBuf []func()
 	batchSize   int
+
+	// enableTiDBExtension makes the encoder send the message keys of the
+	// rows and the primary key columns, which are not in the maxwell format.
+	enableTiDBExtension bool
 }
 
 // EncodeCheckpointEvent implements the EventBatchEncoder interface
@@ -40,6 +44,12 @@ func (d *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error)
 	return nil, nil
 }
 
+// EncodeSyncPointEvent implements the EventBatchEncoder interface
+func (d *BatchEncoder) EncodeSyncPointEvent(ts uint64) (*common.Message, error) {
+	// Maxwell has no corresponding type to the syncpoint either.
+	return nil, nil
+}
+
 // AppendRowChangedEvent implements the EventBatchEncoder interface
 func (d *BatchEncoder) AppendRowChangedEvent(
 	_ context.Context,
@@ -47,11 +57,23 @@ func (d *BatchEncoder) AppendRowChangedEvent(
 	e *model.RowChangedEvent,
 	callback func(),
 ) error {
-	_, valueMsg := rowChangeToMaxwellMsg(e)
+	keyMsg, valueMsg := rowChangeToMaxwellMsg(e, d.enableTiDBExtension)
 	value, err := valueMsg.encode()
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if d.enableTiDBExtension {
+		key, err := keyMsg.Encode()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// The keys carry the commit ts of the rows, which is only
+		// accurate to seconds in the maxwell messages.
+		var keyLenByte [8]byte
+		binary.BigEndian.PutUint64(keyLenByte[:], uint64(len(key)))
+		d.keyBuf.Write(keyLenByte[:])
+		d.keyBuf.Write(key)
+	}
 	d.valueBuf.Write(value)
 	d.batchSize++
 	if callback != nil {
@@ -109,24 +131,27 @@ func (d *BatchEncoder) reset() {
 }
 
 // newBatchEncoder creates a new maxwell BatchEncoder.
-func newBatchEncoder() codec.EventBatchEncoder {
+func newBatchEncoder(config *common.Config) codec.EventBatchEncoder {
 	batch := &BatchEncoder{
-		keyBuf:      &bytes.Buffer{},
-		valueBuf:    &bytes.Buffer{},
-		callbackBuf: make([]func(), 0),
+		keyBuf:              &bytes.Buffer{},
+		valueBuf:            &bytes.Buffer{},
+		callbackBuf:         make([]func(), 0),
+		enableTiDBExtension: config.EnableTiDBExtension,
 	}
 	batch.reset()
 	return batch
 }
 
-type batchEncoderBuilder struct{}
+type batchEncoderBuilder struct {
+	config *common.Config
+}
 
 // NewBatchEncoderBuilder creates a maxwell batchEncoderBuilder.
-func NewBatchEncoderBuilder() codec.EncoderBuilder {
-	return &batchEncoderBuilder{}
+func NewBatchEncoderBuilder(config *common.Config) codec.EncoderBuilder {
+	return &batchEncoderBuilder{config: config}
 }
 
 // Build a `maxwellBatchEncoder`
 func (b *batchEncoderBuilder) Build() codec.EventBatchEncoder {
-	return newBatchEncoder()
+	return newBatchEncoder(b.config)
 }
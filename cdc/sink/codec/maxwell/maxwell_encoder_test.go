@@ -19,12 +19,17 @@ import (
 
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/stretchr/testify/require"
 )
 
 func TestMaxwellBatchCodec(t *testing.T) {
 	t.Parallel()
-	newEncoder := newBatchEncoder
+	newEncoder := func() codec.EventBatchEncoder {
+		return newBatchEncoder(common.NewConfig(config.ProtocolMaxwell))
+	}
 
 	rowCases := [][]*model.RowChangedEvent{{{
 		CommitTs: 1,
@@ -65,7 +70,7 @@ func TestMaxwellBatchCodec(t *testing.T) {
 }
 
 func TestMaxwellAppendRowChangedEventWithCallback(t *testing.T) {
-	encoder := newBatchEncoder()
+	encoder := newBatchEncoder(common.NewConfig(config.ProtocolMaxwell))
 	require.NotNil(t, encoder)
 
 	count := 0
@@ -35,6 +35,10 @@ type maxwellMessage struct {
 	Gtid     string                 `json:"gtid,omitempty"`
 	Data     map[string]interface{} `json:"data,omitempty"`
 	Old      map[string]interface{} `json:"old,omitempty"`
+	// PrimaryKeyColumns is the same as the `output_primary_key_columns`
+	// option of maxwell, it contains the names of the handle key columns.
+	// It's only sent if the TiDB extension is enabled.
+	PrimaryKeyColumns []string `json:"primary_key_columns,omitempty"`
 }
 
 // Encode encodes the message to bytes
@@ -43,7 +47,9 @@ func (m *maxwellMessage) encode() ([]byte, error) {
 	return data, cerror.WrapError(cerror.ErrMaxwellEncodeFailed, err)
 }
 
-func rowChangeToMaxwellMsg(e *model.RowChangedEvent) (*internal.MessageKey, *maxwellMessage) {
+func rowChangeToMaxwellMsg(
+	e *model.RowChangedEvent, enableTiDBExtension bool,
+) (*internal.MessageKey, *maxwellMessage) {
 	var partition *int64
 	if e.Table.IsPartition {
 		partition = &e.Table.TableID
@@ -65,9 +71,17 @@ func rowChangeToMaxwellMsg(e *model.RowChangedEvent) (*internal.MessageKey, *max
 
 	physicalTime, _ := tsoutil.ParseTS(e.CommitTs)
 	value.Ts = physicalTime.Unix()
+	if enableTiDBExtension {
+		for _, col := range e.HandleKeyColumns() {
+			value.PrimaryKeyColumns = append(value.PrimaryKeyColumns, col.Name)
+		}
+	}
 	if e.IsDelete() {
 		value.Type = "delete"
 		for _, v := range e.PreColumns {
+			if v == nil {
+				continue
+			}
 			switch v.Type {
 			case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
 				if v.Value == nil {
@@ -83,6 +97,9 @@ func rowChangeToMaxwellMsg(e *model.RowChangedEvent) (*internal.MessageKey, *max
 		}
 	} else {
 		for _, v := range e.Columns {
+			if v == nil {
+				continue
+			}
 			switch v.Type {
 			case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
 				if v.Value == nil {
@@ -101,6 +118,9 @@ func rowChangeToMaxwellMsg(e *model.RowChangedEvent) (*internal.MessageKey, *max
 		} else {
 			value.Type = "update"
 			for _, v := range e.PreColumns {
+				if v == nil {
+					continue
+				}
 				switch v.Type {
 				case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
 					if v.Value == nil {
@@ -14,11 +14,13 @@
 package open
 
 import (
+	"context"
 	"encoding/binary"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/claimcheck"
 	"github.com/pingcap/tiflow/cdc/sink/codec/internal"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 )
@@ -59,6 +61,24 @@ func (b *BatchMixedDecoder) NextResolvedEvent() (uint64, error) {
 	return resolvedTs, nil
 }
 
+// NextSyncPointEvent implements the EventBatchDecoder interface
+func (b *BatchMixedDecoder) NextSyncPointEvent() (uint64, error) {
+	if b.nextKey == nil {
+		if err := b.decodeNextKey(); err != nil {
+			return 0, err
+		}
+	}
+	b.mixedBytes = b.mixedBytes[b.nextKeyLen+8:]
+	if b.nextKey.Type != model.MessageTypeSyncPoint {
+		return 0, cerror.ErrOpenProtocolCodecInvalidData.GenWithStack("not found syncpoint event message")
+	}
+	valueLen := binary.BigEndian.Uint64(b.mixedBytes[:8])
+	b.mixedBytes = b.mixedBytes[valueLen+8:]
+	ts := b.nextKey.Ts
+	b.nextKey = nil
+	return ts, nil
+}
+
 // NextRowChangedEvent implements the EventBatchDecoder interface
 func (b *BatchMixedDecoder) NextRowChangedEvent() (*model.RowChangedEvent, error) {
 	if b.nextKey == nil {
@@ -77,6 +97,14 @@ func (b *BatchMixedDecoder) NextRowChangedEvent() (*model.RowChangedEvent, error
 	if err := rowMsg.decode(value); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if b.nextKey.ClaimCheckLocation != "" {
+		rowEvent, err := decodeClaimCheckRow(b.nextKey.ClaimCheckLocation)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		b.nextKey = nil
+		return rowEvent, nil
+	}
 	rowEvent := msgToRowChange(b.nextKey, rowMsg)
 	b.nextKey = nil
 	return rowEvent, nil
@@ -160,6 +188,24 @@ func (b *BatchDecoder) NextResolvedEvent() (uint64, error) {
 	return resolvedTs, nil
 }
 
+// NextSyncPointEvent implements the EventBatchDecoder interface
+func (b *BatchDecoder) NextSyncPointEvent() (uint64, error) {
+	if b.nextKey == nil {
+		if err := b.decodeNextKey(); err != nil {
+			return 0, err
+		}
+	}
+	b.keyBytes = b.keyBytes[b.nextKeyLen+8:]
+	if b.nextKey.Type != model.MessageTypeSyncPoint {
+		return 0, cerror.ErrOpenProtocolCodecInvalidData.GenWithStack("not found syncpoint event message")
+	}
+	valueLen := binary.BigEndian.Uint64(b.valueBytes[:8])
+	b.valueBytes = b.valueBytes[valueLen+8:]
+	ts := b.nextKey.Ts
+	b.nextKey = nil
+	return ts, nil
+}
+
 // NextRowChangedEvent implements the EventBatchDecoder interface
 func (b *BatchDecoder) NextRowChangedEvent() (*model.RowChangedEvent, error) {
 	if b.nextKey == nil {
@@ -178,6 +224,14 @@ func (b *BatchDecoder) NextRowChangedEvent() (*model.RowChangedEvent, error) {
 	if err := rowMsg.decode(value); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if b.nextKey.ClaimCheckLocation != "" {
+		rowEvent, err := decodeClaimCheckRow(b.nextKey.ClaimCheckLocation)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		b.nextKey = nil
+		return rowEvent, nil
+	}
 	rowEvent := msgToRowChange(b.nextKey, rowMsg)
 	b.nextKey = nil
 	return rowEvent, nil
@@ -223,6 +277,28 @@ func (b *BatchDecoder) decodeNextKey() error {
 	return nil
 }
 
+// decodeClaimCheckRow reads the original message from the claim-check
+// storage, which is a single row message, and decodes the row from it.
+func decodeClaimCheckRow(location string) (*model.RowChangedEvent, error) {
+	msg, err := claimcheck.ReadMessage(context.Background(), location)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	decoder, err := NewBatchDecoder(msg.Key, msg.Value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tp, hasNext, err := decoder.HasNext()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !hasNext || tp != model.MessageTypeRow {
+		return nil, cerror.ErrOpenProtocolCodecInvalidData.GenWithStack(
+			"not found row event message in the claim-check message %s", location)
+	}
+	return decoder.NextRowChangedEvent()
+}
+
 // NewBatchDecoder creates a new BatchDecoder.
 func NewBatchDecoder(key []byte, value []byte) (codec.EventBatchDecoder, error) {
 	version := binary.BigEndian.Uint64(key[:8])
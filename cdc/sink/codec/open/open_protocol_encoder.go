@@ -22,7 +22,9 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/claimcheck"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sink/codec/internal"
 
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
@@ -38,39 +40,47 @@ type BatchEncoder struct {
 	// configs
 	MaxMessageBytes int
 	MaxBatchSize    int
+
+	// claimCheck is used to send the large messages to the external
+	// storage, it is nil if the claim-check is disabled.
+	claimCheck *claimcheck.ClaimCheck
 }
 
 // AppendRowChangedEvent implements the EventBatchEncoder interface
 func (d *BatchEncoder) AppendRowChangedEvent(
-	_ context.Context,
+	ctx context.Context,
 	_ string,
 	e *model.RowChangedEvent,
 	callback func(),
 ) error {
-	keyMsg, valueMsg := rowChangeToMsg(e)
-	key, err := keyMsg.Encode()
+	key, value, err := encodeRowChangedEvent(e)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	value, err := valueMsg.encode()
-	if err != nil {
-		return errors.Trace(err)
-	}
-
-	var keyLenByte [8]byte
-	binary.BigEndian.PutUint64(keyLenByte[:], uint64(len(key)))
-	var valueLenByte [8]byte
-	binary.BigEndian.PutUint64(valueLenByte[:], uint64(len(value)))
 
 	// for single message that longer than max-message-size, do not send it.
 	// 16 is the length of `keyLenByte` and `valueLenByte`, 8 is the length of `versionHead`
 	length := len(key) + len(value) + common.MaxRecordOverhead + 16 + 8
+	if length > d.MaxMessageBytes && d.claimCheck != nil {
+		log.Warn("Single message too large, send it to the claim-check storage",
+			zap.Int("max-message-size", d.MaxMessageBytes), zap.Int("length", length), zap.Any("table", e.Table))
+		key, value, err = d.newClaimCheckMessage(ctx, e, key, value)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		length = len(key) + len(value) + common.MaxRecordOverhead + 16 + 8
+	}
 	if length > d.MaxMessageBytes {
 		log.Warn("Single message too large",
 			zap.Int("max-message-size", d.MaxMessageBytes), zap.Int("length", length), zap.Any("table", e.Table))
 		return cerror.ErrOpenProtocolCodecRowTooLarge.GenWithStackByArgs()
 	}
 
+	var keyLenByte [8]byte
+	binary.BigEndian.PutUint64(keyLenByte[:], uint64(len(key)))
+	var valueLenByte [8]byte
+	binary.BigEndian.PutUint64(valueLenByte[:], uint64(len(value)))
+
 	if len(d.messageBuf) == 0 ||
 		d.curBatchSize >= d.MaxBatchSize ||
 		d.messageBuf[len(d.messageBuf)-1].Length()+len(key)+len(value)+16 > d.MaxMessageBytes {
@@ -101,6 +111,58 @@ func (d *BatchEncoder) AppendRowChangedEvent(
 	return nil
 }
 
+// newClaimCheckMessage writes the row changed event into the claim-check
+// storage as a single row message, and returns the key and the value of
+// the message which only contains the handle key and the location of it.
+func (d *BatchEncoder) newClaimCheckMessage(
+	ctx context.Context, e *model.RowChangedEvent, key, value []byte,
+) ([]byte, []byte, error) {
+	keyBuf := new(bytes.Buffer)
+	var versionByte [8]byte
+	binary.BigEndian.PutUint64(versionByte[:], codec.BatchVersion1)
+	keyBuf.Write(versionByte[:])
+	var keyLenByte [8]byte
+	binary.BigEndian.PutUint64(keyLenByte[:], uint64(len(key)))
+	keyBuf.Write(keyLenByte[:])
+	keyBuf.Write(key)
+
+	valueBuf := new(bytes.Buffer)
+	var valueLenByte [8]byte
+	binary.BigEndian.PutUint64(valueLenByte[:], uint64(len(value)))
+	valueBuf.Write(valueLenByte[:])
+	valueBuf.Write(value)
+
+	location, err := d.claimCheck.WriteMessage(ctx, keyBuf.Bytes(), valueBuf.Bytes())
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	keyMsg, valueMsg := rowChangeToMsg(claimcheck.HandleKeyOnly(e))
+	keyMsg.ClaimCheckLocation = location
+	key, err = keyMsg.Encode()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	value, err = valueMsg.encode()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return key, value, nil
+}
+
+func encodeRowChangedEvent(e *model.RowChangedEvent) ([]byte, []byte, error) {
+	keyMsg, valueMsg := rowChangeToMsg(e)
+	key, err := keyMsg.Encode()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	value, err := valueMsg.encode()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return key, value, nil
+}
+
 // EncodeDDLEvent implements the EventBatchEncoder interface
 func (d *BatchEncoder) EncodeDDLEvent(e *model.DDLEvent) (*common.Message, error) {
 	keyMsg, valueMsg := ddlEventToMsg(e)
@@ -135,11 +197,31 @@ func (d *BatchEncoder) EncodeDDLEvent(e *model.DDLEvent) (*common.Message, error
 
 // EncodeCheckpointEvent implements the EventBatchEncoder interface
 func (d *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error) {
-	keyMsg := newResolvedMessage(ts)
-	key, err := keyMsg.Encode()
+	key, value, err := encodeKeyOnlyMessage(newResolvedMessage(ts))
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	ret := common.NewResolvedMsg(config.ProtocolOpen, key, value, ts)
+	return ret, nil
+}
+
+// EncodeSyncPointEvent implements the EventBatchEncoder interface
+func (d *BatchEncoder) EncodeSyncPointEvent(ts uint64) (*common.Message, error) {
+	key, value, err := encodeKeyOnlyMessage(newSyncPointMessage(ts))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ret := common.NewSyncPointMsg(config.ProtocolOpen, key, value, ts)
+	return ret, nil
+}
+
+// encodeKeyOnlyMessage encodes the message which has a key and an empty value,
+// such as the resolved and the syncpoint messages.
+func encodeKeyOnlyMessage(keyMsg *internal.MessageKey) ([]byte, []byte, error) {
+	key, err := keyMsg.Encode()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 
 	var keyLenByte [8]byte
 	binary.BigEndian.PutUint64(keyLenByte[:], uint64(len(key)))
@@ -155,9 +237,7 @@ func (d *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error)
 
 	valueBuf := new(bytes.Buffer)
 	valueBuf.Write(valueLenByte[:])
-
-	ret := common.NewResolvedMsg(config.ProtocolOpen, keyBuf.Bytes(), valueBuf.Bytes(), ts)
-	return ret, nil
+	return keyBuf.Bytes(), valueBuf.Bytes(), nil
 }
 
 // Build implements the EventBatchEncoder interface
@@ -183,7 +263,8 @@ func (d *BatchEncoder) tryBuildCallback() {
 }
 
 type batchEncoderBuilder struct {
-	config *common.Config
+	config     *common.Config
+	claimCheck *claimcheck.ClaimCheck
 }
 
 // Build a BatchEncoder
@@ -191,13 +272,27 @@ func (b *batchEncoderBuilder) Build() codec.EventBatchEncoder {
 	encoder := NewBatchEncoder()
 	encoder.(*BatchEncoder).MaxMessageBytes = b.config.MaxMessageBytes
 	encoder.(*BatchEncoder).MaxBatchSize = b.config.MaxBatchSize
+	encoder.(*BatchEncoder).claimCheck = b.claimCheck
 
 	return encoder
 }
 
 // NewBatchEncoderBuilder creates an open-protocol batchEncoderBuilder.
-func NewBatchEncoderBuilder(config *common.Config) codec.EncoderBuilder {
-	return &batchEncoderBuilder{config: config}
+func NewBatchEncoderBuilder(
+	ctx context.Context, config *common.Config,
+) (codec.EncoderBuilder, error) {
+	var (
+		claimCheck *claimcheck.ClaimCheck
+		err        error
+	)
+	if config.EnableClaimCheck() {
+		claimCheck, err = claimcheck.New(ctx, config.ClaimCheckStorageURI,
+			config.ClaimCheckRetention)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &batchEncoderBuilder{config: config, claimCheck: claimCheck}, nil
 }
 
 // NewBatchEncoder creates a new BatchEncoder.
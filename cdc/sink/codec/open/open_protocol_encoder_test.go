@@ -15,6 +15,7 @@ package open
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/pingcap/tidb/parser/mysql"
@@ -53,19 +54,19 @@ func TestMaxMessageBytes(t *testing.T) {
 	// for a single message, the overhead is 36(maxRecordOverhead) + 8(versionHea) = 44, just can hold it.
 	a := 88 + 44
 	config := common.NewConfig(config.ProtocolOpen).WithMaxMessageBytes(a)
-	encoder := NewBatchEncoderBuilder(config).Build()
+	encoder := (&batchEncoderBuilder{config: config}).Build()
 	err := encoder.AppendRowChangedEvent(ctx, topic, testEvent, nil)
 	require.Nil(t, err)
 
 	// cannot hold a single message
 	config = config.WithMaxMessageBytes(a - 1)
-	encoder = NewBatchEncoderBuilder(config).Build()
+	encoder = (&batchEncoderBuilder{config: config}).Build()
 	err = encoder.AppendRowChangedEvent(ctx, topic, testEvent, nil)
 	require.NotNil(t, err)
 
 	// make sure each batch's `Length` not greater than `max-message-bytes`
 	config = config.WithMaxMessageBytes(256)
-	encoder = NewBatchEncoderBuilder(config).Build()
+	encoder = (&batchEncoderBuilder{config: config}).Build()
 	for i := 0; i < 10000; i++ {
 		err := encoder.AppendRowChangedEvent(ctx, topic, testEvent, nil)
 		require.Nil(t, err)
@@ -81,7 +82,7 @@ func TestMaxBatchSize(t *testing.T) {
 	t.Parallel()
 	config := common.NewConfig(config.ProtocolOpen).WithMaxMessageBytes(1048576)
 	config.MaxBatchSize = 64
-	encoder := NewBatchEncoderBuilder(config).Build()
+	encoder := (&batchEncoderBuilder{config: config}).Build()
 
 	testEvent := &model.RowChangedEvent{
 		CommitTs: 1,
@@ -206,5 +207,78 @@ func TestOpenProtocolBatchCodec(t *testing.T) {
 	config := common.NewConfig(config.ProtocolOpen).WithMaxMessageBytes(8192)
 	config.MaxBatchSize = 64
 	tester := internal.NewDefaultBatchTester()
-	tester.TestBatchCodec(t, NewBatchEncoderBuilder(config), NewBatchDecoder)
+	tester.TestBatchCodec(t, &batchEncoderBuilder{config: config}, NewBatchDecoder)
+}
+
+func TestOpenProtocolSyncPointEvent(t *testing.T) {
+	t.Parallel()
+
+	encoder := NewBatchEncoder()
+	msg, err := encoder.EncodeSyncPointEvent(424316592563683329)
+	require.Nil(t, err)
+	require.Equal(t, model.MessageTypeSyncPoint, msg.Type)
+
+	decoder, err := NewBatchDecoder(msg.Key, msg.Value)
+	require.Nil(t, err)
+	tp, hasNext, err := decoder.HasNext()
+	require.Nil(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeSyncPoint, tp)
+	_, err = decoder.NextResolvedEvent()
+	require.Regexp(t, "not found resolved event message", err)
+
+	decoder, err = NewBatchDecoder(msg.Key, msg.Value)
+	require.Nil(t, err)
+	ts, err := decoder.NextSyncPointEvent()
+	require.Nil(t, err)
+	require.Equal(t, uint64(424316592563683329), ts)
+	_, hasNext, err = decoder.HasNext()
+	require.Nil(t, err)
+	require.False(t, hasNext)
+}
+
+func TestOpenProtocolClaimCheck(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	event := &model.RowChangedEvent{
+		CommitTs: 1,
+		Table:    &model.TableName{Schema: "a", Table: "b"},
+		Columns: []*model.Column{
+			{
+				Name: "id", Type: mysql.TypeLong, Value: int64(1),
+				Flag: model.HandleKeyFlag | model.PrimaryKeyFlag,
+			},
+			{Name: "col1", Type: mysql.TypeVarchar, Value: []byte(strings.Repeat("a", 1024))},
+		},
+	}
+	decodeRow := func(msg *common.Message) *model.RowChangedEvent {
+		decoder, err := NewBatchDecoder(msg.Key, msg.Value)
+		require.NoError(t, err)
+		tp, hasNext, err := decoder.HasNext()
+		require.NoError(t, err)
+		require.True(t, hasNext)
+		require.Equal(t, model.MessageTypeRow, tp)
+		row, err := decoder.NextRowChangedEvent()
+		require.NoError(t, err)
+		return row
+	}
+
+	// The row decoded from the message without the claim-check.
+	encoder := (&batchEncoderBuilder{config: common.NewConfig(config.ProtocolOpen)}).Build()
+	require.NoError(t, encoder.AppendRowChangedEvent(ctx, "", event, nil))
+	expected := decodeRow(encoder.Build()[0])
+
+	cfg := common.NewConfig(config.ProtocolOpen).WithMaxMessageBytes(300)
+	cfg.LargeMessageHandleOption = common.LargeMessageHandleOptionClaimCheck
+	cfg.ClaimCheckStorageURI = "file://" + t.TempDir()
+	builder, err := NewBatchEncoderBuilder(ctx, cfg)
+	require.NoError(t, err)
+	encoder = builder.Build()
+	require.NoError(t, encoder.AppendRowChangedEvent(ctx, "", event, nil))
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.LessOrEqual(t, messages[0].Length(), 300)
+	require.Equal(t, expected, decodeRow(messages[0]))
 }
@@ -76,6 +76,13 @@ func newResolvedMessage(ts uint64) *internal.MessageKey {
 	}
 }
 
+func newSyncPointMessage(ts uint64) *internal.MessageKey {
+	return &internal.MessageKey{
+		Ts:   ts,
+		Type: model.MessageTypeSyncPoint,
+	}
+}
+
 func rowChangeToMsg(e *model.RowChangedEvent) (*internal.MessageKey, *messageRow) {
 	var partition *int64
 	if e.Table.IsPartition {
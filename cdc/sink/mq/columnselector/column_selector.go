@@ -0,0 +1,158 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package columnselector
+
+import (
+	filter "github.com/pingcap/tidb/util/table-filter"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/mq/dispatcher"
+	"github.com/pingcap/tiflow/cdc/sink/mq/dispatcher/partition"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+type selector struct {
+	tableF  filter.Filter
+	columnM filter.ColumnFilter
+}
+
+func newSelector(
+	rule *config.ColumnSelector, caseSensitive bool,
+) (*selector, error) {
+	tableM, err := filter.Parse(rule.Matcher)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err, rule.Matcher)
+	}
+	if !caseSensitive {
+		tableM = filter.CaseInsensitive(tableM)
+	}
+	columnM, err := filter.ParseColumnFilter(rule.Columns)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err, rule.Columns)
+	}
+
+	return &selector{
+		tableF:  tableM,
+		columnM: columnM,
+	}, nil
+}
+
+// match returns true if the table matches the selector.
+func (s *selector) match(schema, table string) bool {
+	return s.tableF.MatchTable(schema, table)
+}
+
+// apply returns the event with only the selected columns.
+// The unselected columns are set to nil instead of being removed,
+// so the columns still match the `ColInfos` of the event.
+// The event is copied if any column is unselected, because the
+// original one may be shared with others, such as the redo log.
+func (s *selector) apply(event *model.RowChangedEvent) *model.RowChangedEvent {
+	columns, columnsChanged := s.selectColumns(event.Columns)
+	preColumns, preColumnsChanged := s.selectColumns(event.PreColumns)
+	if !columnsChanged && !preColumnsChanged {
+		return event
+	}
+
+	selected := *event
+	selected.Columns = columns
+	selected.PreColumns = preColumns
+	return &selected
+}
+
+func (s *selector) selectColumns(columns []*model.Column) ([]*model.Column, bool) {
+	var result []*model.Column
+	for i, col := range columns {
+		if col == nil || s.columnM.MatchColumn(col.Name) {
+			continue
+		}
+		if result == nil {
+			result = make([]*model.Column, len(columns))
+			copy(result, columns)
+		}
+		result[i] = nil
+	}
+	if result == nil {
+		return columns, false
+	}
+	return result, true
+}
+
+// ColumnSelector manages the column selectors of a changefeed.
+// The first selector which matches the table is used to select
+// the columns of the event, columns of tables matching no selector
+// are all selected.
+type ColumnSelector struct {
+	selectors []*selector
+}
+
+// New creates a ColumnSelector by the replica config.
+func New(cfg *config.ReplicaConfig) (*ColumnSelector, error) {
+	selectors := make([]*selector, 0, len(cfg.Sink.ColumnSelectors))
+	for _, rule := range cfg.Sink.ColumnSelectors {
+		s, err := newSelector(rule, cfg.CaseSensitive)
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, s)
+	}
+
+	return &ColumnSelector{
+		selectors: selectors,
+	}, nil
+}
+
+// Apply returns the event with only the selected columns.
+// Note: the event itself is never modified.
+func (c *ColumnSelector) Apply(event *model.RowChangedEvent) *model.RowChangedEvent {
+	for _, s := range c.selectors {
+		if s.match(event.Table.Schema, event.Table.Table) {
+			return s.apply(event)
+		}
+	}
+	return event
+}
+
+// VerifyTables checks whether the selectors remove the handle key columns
+// of the tables which are dispatched by the index-value dispatcher,
+// the dispatcher can't distribute the rows correctly without them.
+func (c *ColumnSelector) VerifyTables(
+	infos []*model.TableInfo, eventRouter *dispatcher.EventRouter,
+) error {
+	for _, info := range infos {
+		schema, table := info.TableName.Schema, info.TableName.Table
+		d := eventRouter.GetPartitionDispatcher(schema, table)
+		if _, ok := d.(*partition.IndexValueDispatcher); !ok {
+			continue
+		}
+		for _, s := range c.selectors {
+			if !s.match(schema, table) {
+				continue
+			}
+			for _, col := range info.Columns {
+				if !info.ColumnsFlag[col.ID].IsHandleKey() {
+					continue
+				}
+				if !s.columnM.MatchColumn(col.Name.O) {
+					return cerror.ErrColumnSelectorFailed.GenWithStack(
+						"the handle key column %s of table %s is not selected, "+
+							"which is required by the index-value dispatcher",
+						col.Name.O, info.TableName.String())
+				}
+			}
+			break
+		}
+	}
+	return nil
+}
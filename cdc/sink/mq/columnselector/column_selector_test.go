@@ -0,0 +1,172 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package columnselector
+
+import (
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	parser_types "github.com/pingcap/tidb/parser/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/mq/dispatcher"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewColumnSelector(t *testing.T) {
+	t.Parallel()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.ColumnSelectors = []*config.ColumnSelector{
+		{Matcher: []string{"test.*"}, Columns: []string{"a", "b"}},
+	}
+	selector, err := New(replicaConfig)
+	require.NoError(t, err)
+	require.Len(t, selector.selectors, 1)
+
+	replicaConfig.Sink.ColumnSelectors = []*config.ColumnSelector{
+		{Matcher: []string{"test.t1["}, Columns: []string{"a"}},
+	}
+	_, err = New(replicaConfig)
+	require.True(t, cerror.ErrFilterRuleInvalid.Equal(err))
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.ColumnSelectors = []*config.ColumnSelector{
+		{Matcher: []string{"test.t1"}, Columns: []string{"*", "!email"}},
+		{Matcher: []string{"test.*"}, Columns: []string{"id"}},
+	}
+	selector, err := New(replicaConfig)
+	require.NoError(t, err)
+
+	newEvent := func(table string) *model.RowChangedEvent {
+		return &model.RowChangedEvent{
+			Table: &model.TableName{Schema: "test", Table: table},
+			Columns: []*model.Column{
+				{Name: "id", Value: 1},
+				{Name: "name", Value: "a"},
+				{Name: "Email", Value: "a@pingcap.com"},
+			},
+			PreColumns: []*model.Column{
+				{Name: "id", Value: 1},
+				{Name: "name", Value: "b"},
+				nil,
+			},
+		}
+	}
+
+	// The first matched selector is used, and column names are case-insensitive.
+	event := newEvent("t1")
+	selected := selector.Apply(event)
+	require.Equal(t, []*model.Column{event.Columns[0], event.Columns[1], nil}, selected.Columns)
+	require.Equal(t, []*model.Column{event.PreColumns[0], event.PreColumns[1], nil}, selected.PreColumns)
+	// The original event is not modified.
+	require.Equal(t, newEvent("t1"), event)
+
+	event = newEvent("t2")
+	selected = selector.Apply(event)
+	require.Equal(t, []*model.Column{event.Columns[0], nil, nil}, selected.Columns)
+	require.Equal(t, []*model.Column{event.PreColumns[0], nil, nil}, selected.PreColumns)
+
+	// Events of the tables which don't match any selector are not changed.
+	event = &model.RowChangedEvent{
+		Table:   &model.TableName{Schema: "test1", Table: "t1"},
+		Columns: []*model.Column{{Name: "email", Value: "a@pingcap.com"}},
+	}
+	require.Same(t, event, selector.Apply(event))
+}
+
+func newTableInfo(table string) *model.TableInfo {
+	ftPK := parser_types.NewFieldType(mysql.TypeLong)
+	ftPK.SetFlag(mysql.PriKeyFlag | mysql.NotNullFlag)
+	ftVarchar := parser_types.NewFieldType(mysql.TypeVarchar)
+
+	return model.WrapTableInfo(1, "test", 0, &timodel.TableInfo{
+		ID:   1,
+		Name: timodel.CIStr{O: table, L: table},
+		Columns: []*timodel.ColumnInfo{
+			{
+				ID:        1,
+				Name:      timodel.CIStr{O: "id", L: "id"},
+				FieldType: *ftPK,
+				State:     timodel.StatePublic,
+			},
+			{
+				ID:        2,
+				Name:      timodel.CIStr{O: "email", L: "email"},
+				FieldType: *ftVarchar,
+				State:     timodel.StatePublic,
+			},
+		},
+		PKIsHandle: true,
+	})
+}
+
+func TestVerifyTables(t *testing.T) {
+	t.Parallel()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.DispatchRules = []*config.DispatchRule{
+		{Matcher: []string{"test.t1"}, PartitionRule: "index-value"},
+		{Matcher: []string{"test.t2"}, PartitionRule: "ts"},
+	}
+	infos := []*model.TableInfo{newTableInfo("t1"), newTableInfo("t2")}
+
+	testCases := []struct {
+		selectors []*config.ColumnSelector
+		expectErr bool
+	}{
+		{
+			// The handle key column of t1 is kept.
+			selectors: []*config.ColumnSelector{
+				{Matcher: []string{"test.*"}, Columns: []string{"*", "!email"}},
+			},
+		},
+		{
+			// The handle key column of t2 is removed,
+			// but t2 is not dispatched by the index-value dispatcher.
+			selectors: []*config.ColumnSelector{
+				{Matcher: []string{"test.t2"}, Columns: []string{"email"}},
+			},
+		},
+		{
+			// The handle key column of t1 is removed.
+			selectors: []*config.ColumnSelector{
+				{Matcher: []string{"test.*"}, Columns: []string{"email"}},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		replicaConfig.Sink.ColumnSelectors = tc.selectors
+		selector, err := New(replicaConfig)
+		require.NoError(t, err)
+		eventRouter, err := dispatcher.NewEventRouter(replicaConfig, "test")
+		require.NoError(t, err)
+
+		err = selector.VerifyTables(infos, eventRouter)
+		if tc.expectErr {
+			require.True(t, cerror.ErrColumnSelectorFailed.Equal(err))
+			require.ErrorContains(t, err, "handle key column id of table test.t1")
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
@@ -44,6 +44,7 @@ const (
 	partitionDispatchRuleTS
 	partitionDispatchRuleTable
 	partitionDispatchRuleIndexValue
+	partitionDispatchRuleColumns
 )
 
 func (r *partitionDispatchRule) fromString(rule string) {
@@ -59,13 +60,30 @@ func (r *partitionDispatchRule) fromString(rule string) {
 		log.Warn("rowid is deprecated, please use index-value instead.")
 	case "index-value":
 		*r = partitionDispatchRuleIndexValue
+	case "columns":
+		*r = partitionDispatchRuleColumns
 	default:
 		*r = partitionDispatchRuleDefault
-		log.Warn("the partition dispatch rule is not default/ts/table/index-value," +
+		log.Warn("the partition dispatch rule is not default/ts/table/index-value/columns," +
 			" use the default rule instead.")
 	}
 }
 
+func (r partitionDispatchRule) String() string {
+	switch r {
+	case partitionDispatchRuleTS:
+		return "ts"
+	case partitionDispatchRuleTable:
+		return "table"
+	case partitionDispatchRuleIndexValue:
+		return "index-value"
+	case partitionDispatchRuleColumns:
+		return "columns"
+	default:
+		return "default"
+	}
+}
+
 // EventRouter is a router, it determines which topic and which partition
 // an event should be dispatched to.
 type EventRouter struct {
@@ -161,6 +179,46 @@ func (s *EventRouter) GetPartitionForRowChange(
 	)
 }
 
+// GetPartitionDispatcher returns the partition dispatcher of the table.
+func (s *EventRouter) GetPartitionDispatcher(schema, table string) partition.Dispatcher {
+	_, partitionDispatcher := s.matchDispatcher(schema, table)
+	return partitionDispatcher
+}
+
+// GetDispatchInfo returns the target topic and the partition dispatch rule
+// of the table.
+func (s *EventRouter) GetDispatchInfo(schema, table string) (string, string) {
+	topicDispatcher, partitionDispatcher := s.matchDispatcher(schema, table)
+	var rule partitionDispatchRule
+	switch partitionDispatcher.(type) {
+	case *partition.TsDispatcher:
+		rule = partitionDispatchRuleTS
+	case *partition.TableDispatcher:
+		rule = partitionDispatchRuleTable
+	case *partition.IndexValueDispatcher:
+		rule = partitionDispatchRuleIndexValue
+	case *partition.ColumnsDispatcher:
+		rule = partitionDispatchRuleColumns
+	default:
+		rule = partitionDispatchRuleDefault
+	}
+	return topicDispatcher.Substitute(schema, table), rule.String()
+}
+
+// VerifyTables checks whether the columns of the columns dispatchers
+// exist in the tables and belong to NOT NULL unique keys of them.
+func (s *EventRouter) VerifyTables(infos []*model.TableInfo) error {
+	for _, info := range infos {
+		d := s.GetPartitionDispatcher(info.TableName.Schema, info.TableName.Table)
+		if d, ok := d.(*partition.ColumnsDispatcher); ok {
+			if err := d.VerifyTable(info); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // GetDLLDispatchRuleByProtocol returns the DDL
 // distribution rule according to the protocol.
 func (s *EventRouter) GetDLLDispatchRuleByProtocol(
@@ -238,6 +296,8 @@ func getPartitionDispatcher(
 				"switching on the old value, so please use caution!")
 		}
 		d = partition.NewIndexValueDispatcher()
+	case partitionDispatchRuleColumns:
+		d = partition.NewColumnsDispatcher(ruleConfig.Columns)
 	case partitionDispatchRuleTS:
 		d = partition.NewTsDispatcher()
 	case partitionDispatchRuleTable:
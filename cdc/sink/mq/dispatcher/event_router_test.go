@@ -55,6 +55,11 @@ func TestEventRouter(t *testing.T) {
 					PartitionRule: "index-value",
 					TopicRule:     "{schema}_world",
 				},
+				{
+					Matcher:       []string{"test_columns.*"},
+					PartitionRule: "columns",
+					Columns:       []string{"a", "b"},
+				},
 				{
 					Matcher:       []string{"test.*"},
 					PartitionRule: "rowid",
@@ -96,6 +101,10 @@ func TestEventRouter(t *testing.T) {
 	topicDispatcher, partitionDispatcher = d.matchDispatcher("test_index_value", "test")
 	require.IsType(t, &topic.DynamicTopicDispatcher{}, topicDispatcher)
 	require.IsType(t, &partition.IndexValueDispatcher{}, partitionDispatcher)
+
+	topicDispatcher, partitionDispatcher = d.matchDispatcher("test_columns", "test")
+	require.IsType(t, &topic.StaticTopicDispatcher{}, topicDispatcher)
+	require.IsType(t, &partition.ColumnsDispatcher{}, partitionDispatcher)
 }
 
 func TestGetActiveTopics(t *testing.T) {
@@ -209,6 +218,53 @@ func TestGetTopicForRowChange(t *testing.T) {
 	require.Equal(t, "a_table", topicName)
 }
 
+func TestGetDispatchInfo(t *testing.T) {
+	t.Parallel()
+
+	d, err := NewEventRouter(&config.ReplicaConfig{
+		Sink: &config.SinkConfig{
+			DispatchRules: []*config.DispatchRule{
+				{
+					Matcher:       []string{"test_table.*"},
+					PartitionRule: "table",
+					TopicRule:     "hello_{schema}_world",
+				},
+				{
+					Matcher:       []string{"test_index_value.*"},
+					PartitionRule: "rowid",
+				},
+				{
+					Matcher:       []string{"test_columns.*"},
+					PartitionRule: "columns",
+					Columns:       []string{"a", "b"},
+				},
+				{
+					Matcher:       []string{"test_ts.*"},
+					PartitionRule: "ts",
+					TopicRule:     "{schema}_{table}",
+				},
+			},
+		},
+	}, "test")
+	require.Nil(t, err)
+
+	topicName, rule := d.GetDispatchInfo("test_table", "t1")
+	require.Equal(t, "hello_test_table_world", topicName)
+	require.Equal(t, "table", rule)
+	topicName, rule = d.GetDispatchInfo("test_index_value", "t1")
+	require.Equal(t, "test", topicName)
+	require.Equal(t, "index-value", rule)
+	topicName, rule = d.GetDispatchInfo("test_columns", "t1")
+	require.Equal(t, "test", topicName)
+	require.Equal(t, "columns", rule)
+	topicName, rule = d.GetDispatchInfo("test_ts", "t1")
+	require.Equal(t, "test_ts_t1", topicName)
+	require.Equal(t, "ts", rule)
+	topicName, rule = d.GetDispatchInfo("others", "t1")
+	require.Equal(t, "test", topicName)
+	require.Equal(t, "default", rule)
+}
+
 func TestGetPartitionForRowChange(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,112 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partition
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/hash"
+)
+
+// ColumnsDispatcher is a partition dispatcher which dispatches events
+// based on the values of the given columns.
+type ColumnsDispatcher struct {
+	hasher  *hash.PositionInertia
+	lock    sync.Mutex
+	columns []string
+}
+
+// NewColumnsDispatcher creates a ColumnsDispatcher.
+func NewColumnsDispatcher(columns []string) *ColumnsDispatcher {
+	return &ColumnsDispatcher{
+		hasher:  hash.NewPositionInertia(),
+		columns: columns,
+	}
+}
+
+// DispatchRowChangedEvent returns the target partition to which
+// a row changed event should be dispatched.
+func (r *ColumnsDispatcher) DispatchRowChangedEvent(row *model.RowChangedEvent, partitionNum int32) int32 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.hasher.Reset()
+	r.hasher.Write([]byte(row.Table.Schema), []byte(row.Table.Table))
+
+	dispatchCols := row.Columns
+	if len(row.Columns) == 0 {
+		dispatchCols = row.PreColumns
+	}
+	// The columns are hashed in the configured order,
+	// so the result doesn't depend on the column order of the table.
+	for _, name := range r.columns {
+		for _, col := range dispatchCols {
+			if col == nil || !strings.EqualFold(col.Name, name) {
+				continue
+			}
+			r.hasher.Write([]byte(name), []byte(model.ColumnValueString(col.Value)))
+			break
+		}
+	}
+	return int32(r.hasher.Sum32() % uint32(partitionNum))
+}
+
+// VerifyTable checks whether all the columns exist in the table and
+// belong to a NOT NULL unique key, otherwise the rows with the same key
+// may be dispatched to different partitions.
+func (r *ColumnsDispatcher) VerifyTable(info *model.TableInfo) error {
+	for _, name := range r.columns {
+		offset := -1
+		for i, col := range info.Columns {
+			if strings.EqualFold(col.Name.O, name) {
+				offset = i
+				break
+			}
+		}
+		if offset < 0 {
+			return cerror.ErrDispatcherFailed.GenWithStack(
+				"the column %s of the columns dispatcher is not found in table %s",
+				name, info.TableName.String())
+		}
+		if !isInUniqueKey(info, offset) {
+			return cerror.ErrDispatcherFailed.GenWithStack(
+				"the column %s of the columns dispatcher is not in "+
+					"a not null unique key of table %s",
+				name, info.TableName.String())
+		}
+	}
+	return nil
+}
+
+// isInUniqueKey returns whether the column at the offset belongs to
+// the primary key or a NOT NULL unique key of the table.
+func isInUniqueKey(info *model.TableInfo, offset int) bool {
+	if info.PKIsHandle && mysql.HasPriKeyFlag(info.Columns[offset].GetFlag()) {
+		return true
+	}
+	for _, idx := range info.Indices {
+		if !info.IsIndexUnique(idx) {
+			continue
+		}
+		for _, idxCol := range idx.Columns {
+			if idxCol.Offset == offset {
+				return true
+			}
+		}
+	}
+	return false
+}
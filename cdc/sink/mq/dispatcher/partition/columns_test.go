@@ -0,0 +1,109 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partition
+
+import (
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	parser_types "github.com/pingcap/tidb/parser/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnsDispatcher(t *testing.T) {
+	t.Parallel()
+
+	newRow := func(a, b, c interface{}) *model.RowChangedEvent {
+		return &model.RowChangedEvent{
+			Table: &model.TableName{Schema: "test", Table: "t1"},
+			Columns: []*model.Column{
+				{Name: "a", Value: a},
+				{Name: "b", Value: b},
+				{Name: "c", Value: c},
+			},
+		}
+	}
+
+	d := NewColumnsDispatcher([]string{"A", "b"})
+	// The rows with the same values of the columns are
+	// dispatched to the same partition.
+	p1 := d.DispatchRowChangedEvent(newRow(1, 2, 3), 16)
+	p2 := d.DispatchRowChangedEvent(newRow(1, 2, 4), 16)
+	require.Equal(t, p1, p2)
+
+	// The pre columns are used for the delete events.
+	row := newRow(1, 2, 5)
+	row.PreColumns, row.Columns = row.Columns, nil
+	require.Equal(t, p1, d.DispatchRowChangedEvent(row, 16))
+
+	// The filtered columns are skipped.
+	row = newRow(1, 2, 6)
+	row.Columns[2] = nil
+	require.Equal(t, p1, d.DispatchRowChangedEvent(row, 16))
+
+	// The columns are hashed in the configured order.
+	d = NewColumnsDispatcher([]string{"b", "A"})
+	p3 := d.DispatchRowChangedEvent(newRow(1, 2, 3), 16)
+	require.Equal(t, p3, d.DispatchRowChangedEvent(&model.RowChangedEvent{
+		Table: &model.TableName{Schema: "test", Table: "t1"},
+		Columns: []*model.Column{
+			{Name: "b", Value: 2},
+			{Name: "a", Value: 1},
+		},
+	}, 16))
+}
+
+func TestColumnsDispatcherVerifyTable(t *testing.T) {
+	t.Parallel()
+
+	ftPK := parser_types.NewFieldType(mysql.TypeLong)
+	ftPK.SetFlag(mysql.PriKeyFlag | mysql.NotNullFlag)
+	ftNotNull := parser_types.NewFieldType(mysql.TypeLong)
+	ftNotNull.SetFlag(mysql.NotNullFlag | mysql.UniqueKeyFlag)
+	ftNullable := parser_types.NewFieldType(mysql.TypeLong)
+	ftNullable.SetFlag(mysql.UniqueKeyFlag)
+	ftVarchar := parser_types.NewFieldType(mysql.TypeVarchar)
+
+	info := model.WrapTableInfo(1, "test", 0, &timodel.TableInfo{
+		ID:   1,
+		Name: timodel.CIStr{O: "t1", L: "t1"},
+		Columns: []*timodel.ColumnInfo{
+			{ID: 1, Name: timodel.NewCIStr("id"), FieldType: *ftPK, Offset: 0},
+			{ID: 2, Name: timodel.NewCIStr("uk"), FieldType: *ftNotNull, Offset: 1},
+			{ID: 3, Name: timodel.NewCIStr("nullable"), FieldType: *ftNullable, Offset: 2},
+			{ID: 4, Name: timodel.NewCIStr("name"), FieldType: *ftVarchar, Offset: 3},
+		},
+		Indices: []*timodel.IndexInfo{
+			{
+				ID: 1, Name: timodel.NewCIStr("uk"), Unique: true,
+				Columns: []*timodel.IndexColumn{{Name: timodel.NewCIStr("uk"), Offset: 1}},
+			},
+			{
+				ID: 2, Name: timodel.NewCIStr("nullable"), Unique: true,
+				Columns: []*timodel.IndexColumn{{Name: timodel.NewCIStr("nullable"), Offset: 2}},
+			},
+		},
+		PKIsHandle: true,
+	})
+
+	require.NoError(t, NewColumnsDispatcher([]string{"ID", "uk"}).VerifyTable(info))
+
+	for _, columns := range [][]string{{"id", "not_exist"}, {"nullable"}, {"name"}} {
+		err := NewColumnsDispatcher(columns).VerifyTable(info)
+		require.True(t, cerror.ErrDispatcherFailed.Equal(err), columns)
+	}
+}
@@ -13,6 +13,13 @@
 
 package manager
 
+import (
+	"sync"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
 // pulsarTopicManager is the interface
 // that wraps the basic Pulsar topic management operations.
 // Right now it doesn't have any implementation,
@@ -38,3 +45,39 @@ func (m *pulsarTopicManager) GetPartitionNum(_ string) (int32, error) {
 func (m *pulsarTopicManager) CreateTopicAndWaitUntilVisible(_ string) (int32, error) {
 	return m.partitionNum, nil
 }
+
+// pulsarClientTopicManager gets the partition numbers of the topics
+// from the pulsar cluster, so that the events can be sent to multiple topics.
+type pulsarClientTopicManager struct {
+	client pulsar.Client
+	topics sync.Map
+}
+
+// NewPulsarClientTopicManager creates a new TopicManager by the pulsar client.
+func NewPulsarClientTopicManager(client pulsar.Client) *pulsarClientTopicManager {
+	return &pulsarClientTopicManager{
+		client: client,
+	}
+}
+
+// GetPartitionNum returns the number of partitions of the topic.
+// A non-partitioned topic is regarded as a topic with only one partition.
+func (m *pulsarClientTopicManager) GetPartitionNum(topic string) (int32, error) {
+	if partitions, ok := m.topics.Load(topic); ok {
+		return partitions.(int32), nil
+	}
+	partitions, err := m.client.TopicPartitions(topic)
+	if err != nil {
+		return 0, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+	partitionNum := int32(len(partitions))
+	m.topics.Store(topic, partitionNum)
+	return partitionNum, nil
+}
+
+// CreateTopicAndWaitUntilVisible gets the partition number of the topic.
+// The topic is created by the pulsar brokers if it doesn't exist
+// and `allowAutoTopicCreation` is enabled.
+func (m *pulsarClientTopicManager) CreateTopicAndWaitUntilVisible(topic string) (int32, error) {
+	return m.GetPartitionNum(topic)
+}
@@ -18,6 +18,7 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/pingcap/errors"
@@ -28,6 +29,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/codec/builder"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 	"github.com/pingcap/tiflow/cdc/sink/metrics"
+	"github.com/pingcap/tiflow/cdc/sink/mq/columnselector"
 	"github.com/pingcap/tiflow/cdc/sink/mq/dispatcher"
 	"github.com/pingcap/tiflow/cdc/sink/mq/manager"
 	"github.com/pingcap/tiflow/cdc/sink/mq/producer"
@@ -41,6 +43,10 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+// loadCheckpointTimeout is the timeout of loading the committed checkpoint
+// of a table when exactly-once is enabled.
+const loadCheckpointTimeout = time.Minute
+
 type resolvedTsEvent struct {
 	tableID  model.TableID
 	resolved model.ResolvedTs
@@ -49,6 +55,7 @@ type resolvedTsEvent struct {
 type mqSink struct {
 	mqProducer     producer.Producer
 	eventRouter    *dispatcher.EventRouter
+	columnSelector *columnselector.ColumnSelector
 	encoderBuilder codec.EncoderBuilder
 	protocol       config.Protocol
 
@@ -59,6 +66,19 @@ type mqSink struct {
 
 	statistics *metrics.Statistics
 
+	// txnProducer is not nil if exactly-once is enabled. In this case,
+	// the rows are held back until the resolved ts of the table is flushed,
+	// so that each transaction only contains the rows of the table whose
+	// checkpoint is recorded in it.
+	txnProducer producer.TxnProducer
+	pendingRows struct {
+		sync.Mutex
+		rows map[model.TableID][]mqEvent
+	}
+	// committedTsMap records the committed checkpoints of the tables
+	// loaded from Kafka, the rows before them are skipped.
+	committedTsMap sync.Map
+
 	role util.Role
 	id   model.ChangeFeedID
 }
@@ -81,6 +101,11 @@ func newMqSink(
 		return nil, errors.Trace(err)
 	}
 
+	columnSelector, err := columnselector.New(replicaConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	captureAddr := contextutil.CaptureAddrFromCtx(ctx)
 	changefeedID := contextutil.ChangefeedIDFromCtx(ctx)
 	role := contextutil.RoleFromCtx(ctx)
@@ -92,6 +117,7 @@ func newMqSink(
 	s := &mqSink{
 		mqProducer:     mqProducer,
 		eventRouter:    eventRouter,
+		columnSelector: columnSelector,
 		encoderBuilder: encoderBuilder,
 		protocol:       encoderConfig.Protocol,
 		topicManager:   topicManager,
@@ -101,6 +127,10 @@ func newMqSink(
 		role:           role,
 		id:             changefeedID,
 	}
+	if txnProducer, ok := mqProducer.(producer.TxnProducer); ok {
+		s.txnProducer = txnProducer
+		s.pendingRows.rows = make(map[model.TableID][]mqEvent)
+	}
 
 	go func() {
 		if err := s.run(ctx); err != nil && errors.Cause(err) != context.Canceled {
@@ -130,6 +160,25 @@ func (k *mqSink) AddTable(tableID model.TableID) error {
 			zap.Uint64("checkpointTs", checkpoint.(model.ResolvedTs).Ts))
 	}
 
+	if k.txnProducer != nil {
+		k.pendingRows.Lock()
+		delete(k.pendingRows.rows, tableID)
+		k.pendingRows.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), loadCheckpointTimeout)
+		defer cancel()
+		committedTs, err := k.txnProducer.InitTable(ctx, tableID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		log.Info("load committed checkpoint ts in MQ sink",
+			zap.String("namespace", k.id.Namespace),
+			zap.String("changefeed", k.id.ID),
+			zap.Int64("tableID", tableID),
+			zap.Uint64("committedTs", committedTs))
+		k.committedTsMap.Store(tableID, committedTs)
+	}
+
 	return nil
 }
 
@@ -144,12 +193,20 @@ func (k *mqSink) EmitRowChangedEvents(ctx context.Context, rows ...*model.RowCha
 			return errors.Trace(err)
 		}
 		partition := k.eventRouter.GetPartitionForRowChange(row, partitionNum)
-		err = k.flushWorker.addEvent(ctx, mqEvent{
-			row: row,
+		// The columns are selected after dispatching,
+		// so that the dispatchers can see all the columns.
+		event := mqEvent{
+			row: k.columnSelector.Apply(row),
 			key: TopicPartitionKey{
 				Topic: topic, Partition: partition,
 			},
-		})
+		}
+		if k.txnProducer != nil {
+			k.holdBackRow(event)
+			rowsCount++
+			continue
+		}
+		err = k.flushWorker.addEvent(ctx, event)
 		if err != nil {
 			return err
 		}
@@ -197,7 +254,12 @@ func (k *mqSink) bgFlushTs(ctx context.Context) error {
 				return nil
 			}
 			resolved := msg.resolved
-			err := k.flushTsToWorker(ctx, resolved)
+			if k.txnProducer != nil {
+				if err := k.releaseRows(ctx, msg.tableID, resolved); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			err := k.flushTsToWorker(ctx, msg.tableID, resolved)
 			if err != nil {
 				return errors.Trace(err)
 			}
@@ -209,9 +271,55 @@ func (k *mqSink) bgFlushTs(ctx context.Context) error {
 	}
 }
 
-func (k *mqSink) flushTsToWorker(ctx context.Context, resolvedTs model.ResolvedTs) error {
+// holdBackRow holds back the row until the resolved ts of its table is flushed.
+// The rows that have been committed before are skipped.
+func (k *mqSink) holdBackRow(event mqEvent) {
+	tableID := event.row.Table.TableID
+	if committedTs, ok := k.committedTsMap.Load(tableID); ok &&
+		event.row.CommitTs <= committedTs.(uint64) {
+		log.Debug("skip the committed row",
+			zap.Int64("tableID", tableID),
+			zap.Uint64("commitTs", event.row.CommitTs),
+			zap.Uint64("committedTs", committedTs.(uint64)))
+		return
+	}
+	k.pendingRows.Lock()
+	defer k.pendingRows.Unlock()
+	k.pendingRows.rows[tableID] = append(k.pendingRows.rows[tableID], event)
+}
+
+// releaseRows sends the held back rows of the table which are not
+// greater than the resolved ts to the flush worker.
+func (k *mqSink) releaseRows(
+	ctx context.Context, tableID model.TableID, resolved model.ResolvedTs,
+) error {
+	k.pendingRows.Lock()
+	rows := k.pendingRows.rows[tableID]
+	i := 0
+	for i < len(rows) && rows[i].row.CommitTs <= resolved.Ts {
+		i++
+	}
+	if i == len(rows) {
+		delete(k.pendingRows.rows, tableID)
+	} else {
+		k.pendingRows.rows[tableID] = rows[i:]
+	}
+	k.pendingRows.Unlock()
+
+	for _, event := range rows[:i] {
+		if err := k.flushWorker.addEvent(ctx, event); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (k *mqSink) flushTsToWorker(
+	ctx context.Context, tableID model.TableID, resolvedTs model.ResolvedTs,
+) error {
 	flushed := make(chan struct{})
 	flush := &flushEvent{
+		tableID:    tableID,
 		resolvedTs: resolvedTs,
 		flushed:    flushed,
 	}
@@ -337,7 +445,15 @@ func (k *mqSink) Close(ctx context.Context) error {
 
 func (k *mqSink) RemoveTable(cxt context.Context, tableID model.TableID) error {
 	// RemoveTable does nothing because FlushRowChangedEvents in mq sink had flushed
-	// all buffered events by force.
+	// all buffered events by force, except the rows held back for exactly-once,
+	// which are beyond the checkpoint of the table.
+	if k.txnProducer != nil {
+		k.pendingRows.Lock()
+		delete(k.pendingRows.rows, tableID)
+		k.pendingRows.Unlock()
+		k.committedTsMap.Delete(tableID)
+		k.txnProducer.RemoveTable(tableID)
+	}
 	return nil
 }
 
@@ -362,9 +478,14 @@ func (k *mqSink) run(ctx context.Context) error {
 
 // asyncFlushToPartitionZero writes message to
 // partition zero asynchronously and flush it immediately.
+// If exactly-once is enabled, the message is committed in its own
+// transaction, so it isn't mixed with the rows of any table.
 func (k *mqSink) asyncFlushToPartitionZero(
 	ctx context.Context, topic string, message *common.Message,
 ) error {
+	if k.txnProducer != nil {
+		return k.txnProducer.SyncSendMessage(ctx, topic, dispatcher.PartitionZero, message)
+	}
 	err := k.mqProducer.AsyncSendMessage(ctx, topic, dispatcher.PartitionZero, message)
 	if err != nil {
 		return err
@@ -446,14 +567,33 @@ func NewKafkaSaramaSink(ctx context.Context, sinkURI *url.URL,
 		return nil, cerror.WrapError(cerror.ErrKafkaCreateTopic, err)
 	}
 
-	sProducer, err := kafka.NewKafkaSaramaProducer(
-		ctx,
-		client,
-		adminClient,
-		baseConfig,
-		saramaConfig,
-		errCh,
-	)
+	var sProducer producer.Producer
+	if baseConfig.ExactlyOnce {
+		// The checkpoints are always recorded in the partition zero.
+		if err := kafka.CreateCheckpointTopic(adminClient, baseConfig); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := topicManager.CreateTopicAndWaitUntilVisible(
+			baseConfig.CheckpointTopic); err != nil {
+			return nil, cerror.WrapError(cerror.ErrKafkaCreateTopic, err)
+		}
+		sProducer, err = kafka.NewKafkaTxnProducer(
+			ctx,
+			client,
+			adminClient,
+			baseConfig,
+			saramaConfig,
+		)
+	} else {
+		sProducer, err = kafka.NewKafkaSaramaProducer(
+			ctx,
+			client,
+			adminClient,
+			baseConfig,
+			saramaConfig,
+			errCh,
+		)
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
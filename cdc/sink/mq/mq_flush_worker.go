@@ -41,6 +41,7 @@ type TopicPartitionKey struct {
 }
 
 type flushEvent struct {
+	tableID    model.TableID
 	resolvedTs model.ResolvedTs
 	flushed    chan<- struct{}
 }
@@ -61,6 +62,8 @@ type flushWorker struct {
 	// needsFlush is used to indicate whether the flush worker needs to flush the messages.
 	// It is also used to notify that the flush has completed.
 	needsFlush chan<- struct{}
+	// flushing is the flush event which needsFlush comes from.
+	flushing *flushEvent
 
 	encoder    codec.EventBatchEncoder
 	producer   producer.Producer
@@ -103,6 +106,7 @@ func (w *flushWorker) batch(
 		// we need to write the previous data to the producer as soon as possible.
 		if msg.flush != nil {
 			w.needsFlush = msg.flush.flushed
+			w.flushing = msg.flush
 			return index, nil
 		}
 
@@ -127,6 +131,7 @@ func (w *flushWorker) batch(
 			// we need to write the previous data to the producer as soon as possible.
 			if msg.flush != nil {
 				w.needsFlush = msg.flush.flushed
+				w.flushing = msg.flush
 				return index, nil
 			}
 
@@ -245,6 +250,10 @@ func (w *flushWorker) addEvent(ctx context.Context, event mqEvent) error {
 // and notify the mqSink that all events has been flushed.
 func (w *flushWorker) flushAndNotify(ctx context.Context) error {
 	start := time.Now()
+	// The checkpoint of the table is committed with its rows atomically.
+	if txnProducer, ok := w.producer.(producer.TxnProducer); ok && w.flushing != nil {
+		txnProducer.RecordCheckpoint(w.flushing.tableID, w.flushing.resolvedTs.ResolvedMark())
+	}
 	err := w.producer.Flush(ctx)
 	if err != nil {
 		return err
@@ -256,6 +265,7 @@ func (w *flushWorker) flushAndNotify(ctx context.Context) error {
 		close(w.needsFlush)
 		// NOTICE: Do not forget to reset the needsFlush.
 		w.needsFlush = nil
+		w.flushing = nil
 		log.Debug("flush worker flushed", zap.Duration("duration", time.Since(start)))
 	}
 
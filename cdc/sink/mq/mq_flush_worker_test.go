@@ -83,6 +83,49 @@ func NewMockProducer() *mockProducer {
 	}
 }
 
+type mockTxnProducer struct {
+	*mockProducer
+	// checkpoints records the checkpoint of each flush.
+	checkpoints map[model.TableID][]uint64
+	// syncEvent records the messages sent in separate transactions.
+	syncEvent map[TopicPartitionKey][]*common.Message
+}
+
+func (m *mockTxnProducer) SyncSendMessage(
+	ctx context.Context, topic string, partition int32, message *common.Message,
+) error {
+	key := TopicPartitionKey{
+		Topic:     topic,
+		Partition: partition,
+	}
+	m.syncEvent[key] = append(m.syncEvent[key], message)
+	return nil
+}
+
+func (m *mockTxnProducer) RecordCheckpoint(tableID model.TableID, checkpointTs uint64) {
+	m.checkpoints[tableID] = append(m.checkpoints[tableID], checkpointTs)
+}
+
+func (m *mockTxnProducer) InitTable(
+	ctx context.Context, tableID model.TableID,
+) (uint64, error) {
+	checkpoints := m.checkpoints[tableID]
+	if len(checkpoints) == 0 {
+		return 0, nil
+	}
+	return checkpoints[len(checkpoints)-1], nil
+}
+
+func (m *mockTxnProducer) RemoveTable(tableID model.TableID) {}
+
+func newMockTxnProducer() *mockTxnProducer {
+	return &mockTxnProducer{
+		mockProducer: NewMockProducer(),
+		checkpoints:  make(map[model.TableID][]uint64),
+		syncEvent:    make(map[TopicPartitionKey][]*common.Message),
+	}
+}
+
 func newTestWorker(ctx context.Context) (*flushWorker, *mockProducer) {
 	// 200 is about the size of a row change.
 	encoderConfig := common.NewConfig(config.ProtocolOpen).WithMaxMessageBytes(200)
@@ -479,6 +522,31 @@ func TestFlush(t *testing.T) {
 	require.True(t, flushed.Load())
 }
 
+func TestFlushRecordCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	worker, _ := newTestWorker(ctx)
+	defer worker.close()
+	producer := newMockTxnProducer()
+	worker.producer = producer
+
+	for _, resolved := range []model.ResolvedTs{
+		model.NewResolvedTs(10),
+		{Mode: model.BatchResolvedMode, Ts: 20, BatchID: 1},
+	} {
+		flushed := make(chan struct{}, 1)
+		worker.needsFlush = flushed
+		worker.flushing = &flushEvent{tableID: 1, resolvedTs: resolved, flushed: flushed}
+		require.NoError(t, worker.flushAndNotify(ctx))
+		require.Nil(t, worker.flushing)
+	}
+	// The checkpoint of the batch resolved ts doesn't cover the rows of its commitTs.
+	require.Equal(t, []uint64{10, 19}, producer.checkpoints[1])
+	require.Equal(t, 2, producer.flushedTimes)
+}
+
 func TestAbort(t *testing.T) {
 	t.Parallel()
 
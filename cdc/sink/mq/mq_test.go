@@ -22,9 +22,12 @@ import (
 	"github.com/Shopify/sarama"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	timodel "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 	"github.com/pingcap/tiflow/cdc/sink/codec/open"
+	"github.com/pingcap/tiflow/cdc/sink/mq/manager"
 	kafkap "github.com/pingcap/tiflow/cdc/sink/mq/producer/kafka"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/retry"
@@ -304,3 +307,75 @@ func TestFlushRowChangedEvents(t *testing.T) {
 		require.Equal(t, context.Canceled, errors.Cause(err))
 	}
 }
+
+func TestHoldBackRows(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	worker, _ := newTestWorker(ctx)
+	defer worker.close()
+	producer := newMockTxnProducer()
+	s := &mqSink{flushWorker: worker, txnProducer: producer}
+	s.pendingRows.rows = make(map[model.TableID][]mqEvent)
+	s.committedTsMap.Store(model.TableID(1), uint64(10))
+
+	for _, tableID := range []model.TableID{1, 2} {
+		for _, commitTs := range []uint64{10, 20, 30} {
+			s.holdBackRow(mqEvent{row: &model.RowChangedEvent{
+				CommitTs: commitTs,
+				Table:    &model.TableName{Schema: "a", Table: "b", TableID: tableID},
+			}})
+		}
+	}
+	// The committed row of table 1 is skipped.
+	require.Len(t, s.pendingRows.rows[1], 2)
+	require.Len(t, s.pendingRows.rows[2], 3)
+
+	// Only the rows of the flushed table are released.
+	require.NoError(t, s.releaseRows(ctx, 1, model.NewResolvedTs(20)))
+	event := <-worker.msgChan.Out()
+	require.Equal(t, model.TableID(1), event.row.Table.TableID)
+	require.Equal(t, uint64(20), event.row.CommitTs)
+	require.Len(t, s.pendingRows.rows[1], 1)
+	require.Len(t, s.pendingRows.rows[2], 3)
+
+	require.NoError(t, s.releaseRows(ctx, 1, model.NewResolvedTs(30)))
+	event = <-worker.msgChan.Out()
+	require.Equal(t, uint64(30), event.row.CommitTs)
+	require.NotContains(t, s.pendingRows.rows, model.TableID(1))
+
+	// The held back rows are dropped when the table is removed.
+	require.NoError(t, s.RemoveTable(ctx, 2))
+	require.NotContains(t, s.pendingRows.rows, model.TableID(2))
+}
+
+func TestEmitDDLEventExactlyOnce(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	producer := newMockTxnProducer()
+	encoderConfig := common.NewConfig(config.ProtocolCanalJSON)
+	s, err := newMqSink(ctx, manager.NewPulsarTopicManager(1), producer,
+		kafka.DefaultMockTopicName, config.GetDefaultReplicaConfig(),
+		encoderConfig, make(chan error, 1))
+	require.NoError(t, err)
+	defer func() {
+		cancel()
+		s.flushWorker.close()
+		s.resolvedBuffer.Close()
+	}()
+
+	// The DDL of canal-json is sent to partition zero. It's committed in
+	// its own transaction instead of the ongoing transaction of the rows.
+	require.NoError(t, s.EmitDDLEvent(ctx, &model.DDLEvent{
+		CommitTs:  100,
+		TableInfo: &model.SimpleTableInfo{Schema: "a", Table: "b"},
+		Query:     "create table a.b(id int primary key)",
+		Type:      timodel.ActionCreateTable,
+	}))
+	key := TopicPartitionKey{Topic: kafka.DefaultMockTopicName, Partition: 0}
+	require.Len(t, producer.syncEvent[key], 1)
+	require.Empty(t, producer.mqEvent)
+	require.Equal(t, 0, producer.flushedTimes)
+}
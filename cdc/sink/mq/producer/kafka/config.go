@@ -53,6 +53,15 @@ type Config struct {
 	DialTimeout  time.Duration
 	WriteTimeout time.Duration
 	ReadTimeout  time.Duration
+
+	// ExactlyOnce makes the producer commit a Kafka transaction on each flush,
+	// and record the checkpoints of the flushed tables in the same transaction.
+	ExactlyOnce bool
+	// CheckpointTopic is the topic where the table checkpoints are recorded,
+	// default to `<topic>-ticdc-checkpoint`.
+	CheckpointTopic string
+	// TransactionTimeout is the timeout of a Kafka transaction, default to `1m`.
+	TransactionTimeout time.Duration
 }
 
 // NewConfig returns a default Kafka configuration
@@ -60,15 +69,16 @@ func NewConfig() *Config {
 	return &Config{
 		Version: "2.4.0",
 		// MaxMessageBytes will be used to initialize producer
-		MaxMessageBytes:   config.DefaultMaxMessageBytes,
-		ReplicationFactor: 1,
-		Compression:       "none",
-		Credential:        &security.Credential{},
-		SASL:              &security.SASL{},
-		AutoCreate:        true,
-		DialTimeout:       10 * time.Second,
-		WriteTimeout:      10 * time.Second,
-		ReadTimeout:       10 * time.Second,
+		MaxMessageBytes:    config.DefaultMaxMessageBytes,
+		ReplicationFactor:  1,
+		Compression:        "none",
+		Credential:         &security.Credential{},
+		SASL:               &security.SASL{},
+		AutoCreate:         true,
+		DialTimeout:        10 * time.Second,
+		WriteTimeout:       10 * time.Second,
+		ReadTimeout:        10 * time.Second,
+		TransactionTimeout: time.Minute,
 	}
 }
 
@@ -183,7 +193,12 @@ func (c *Config) Apply(sinkURI *url.URL) error {
 		c.ReadTimeout = a
 	}
 
-	err := c.applySASL(params)
+	err := c.applyExactlyOnce(sinkURI)
+	if err != nil {
+		return err
+	}
+
+	err = c.applySASL(params)
 	if err != nil {
 		return err
 	}
@@ -196,6 +211,51 @@ func (c *Config) Apply(sinkURI *url.URL) error {
 	return nil
 }
 
+func (c *Config) applyExactlyOnce(sinkURI *url.URL) error {
+	params := sinkURI.Query()
+	s := params.Get("exactly-once")
+	if s != "" {
+		exactlyOnce, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		c.ExactlyOnce = exactlyOnce
+	}
+
+	s = params.Get("transaction-timeout")
+	if s != "" {
+		a, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		c.TransactionTimeout = a
+	}
+
+	s = params.Get("checkpoint-topic")
+	if s != "" {
+		c.CheckpointTopic = s
+	}
+	if !c.ExactlyOnce {
+		if c.CheckpointTopic != "" {
+			return cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"checkpoint-topic is only supported when exactly-once is enabled")
+		}
+		return nil
+	}
+
+	if c.TransactionTimeout <= 0 {
+		return cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"invalid transaction-timeout %s", c.TransactionTimeout)
+	}
+	if c.CheckpointTopic == "" {
+		topic := strings.TrimFunc(sinkURI.Path, func(r rune) bool {
+			return r == '/'
+		})
+		c.CheckpointTopic = topic + defaultCheckpointTopicSuffix
+	}
+	return nil
+}
+
 func (c *Config) applyTLS(params url.Values) error {
 	s := params.Get("ca")
 	if s != "" {
@@ -355,6 +415,20 @@ func NewSaramaConfig(ctx context.Context, c *Config) (*sarama.Config, error) {
 	}
 	config.Version = version
 
+	if c.ExactlyOnce {
+		// The transactional producer relies on the idempotent producer,
+		// which is introduced in Kafka 0.11.0.0.
+		if !version.IsAtLeast(sarama.V0_11_0_0) {
+			return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"exactly-once requires kafka-version 0.11.0.0 or later, but got %s", c.Version)
+		}
+		config.Producer.Idempotent = true
+		config.Net.MaxOpenRequests = 1
+		// The checkpoints are loaded with the read committed isolation level,
+		// so that the checkpoints of the aborted transactions are ignored.
+		config.Consumer.IsolationLevel = sarama.ReadCommitted
+	}
+
 	// Producer fetch metadata from brokers frequently, if metadata cannot be
 	// refreshed easily, this would indicate the network condition between the
 	// capture server and kafka broker is not good.
@@ -564,6 +564,77 @@ func TestApplyTLS(t *testing.T) {
 	}
 }
 
+func TestApplyExactlyOnce(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		URI                string
+		exactlyOnce        bool
+		checkpointTopic    string
+		transactionTimeout time.Duration
+		exceptErr          string
+	}{
+		{
+			name:               "exactly-once is disabled by default",
+			URI:                "kafka://127.0.0.1:9092/abc?kafka-version=2.6.0",
+			transactionTimeout: time.Minute,
+		},
+		{
+			name:               "exactly-once with the default checkpoint topic",
+			URI:                "kafka://127.0.0.1:9092/abc?kafka-version=2.6.0&exactly-once=true",
+			exactlyOnce:        true,
+			checkpointTopic:    "abc-ticdc-checkpoint",
+			transactionTimeout: time.Minute,
+		},
+		{
+			name: "exactly-once with the specified checkpoint topic",
+			URI: "kafka://127.0.0.1:9092/abc?kafka-version=2.6.0&exactly-once=true" +
+				"&checkpoint-topic=cp&transaction-timeout=30s",
+			exactlyOnce:        true,
+			checkpointTopic:    "cp",
+			transactionTimeout: 30 * time.Second,
+		},
+		{
+			name:      "checkpoint topic without exactly-once",
+			URI:       "kafka://127.0.0.1:9092/abc?kafka-version=2.6.0&checkpoint-topic=cp",
+			exceptErr: "checkpoint-topic is only supported when exactly-once is enabled",
+		},
+		{
+			name: "invalid transaction timeout",
+			URI: "kafka://127.0.0.1:9092/abc?kafka-version=2.6.0&exactly-once=true" +
+				"&transaction-timeout=0s",
+			exceptErr: "invalid transaction-timeout",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := NewConfig()
+			sinkURI, err := url.Parse(test.URI)
+			require.Nil(t, err)
+			err = cfg.applyExactlyOnce(sinkURI)
+			if test.exceptErr != "" {
+				require.Regexp(t, test.exceptErr, err.Error())
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, test.exactlyOnce, cfg.ExactlyOnce)
+			require.Equal(t, test.checkpointTopic, cfg.CheckpointTopic)
+			require.Equal(t, test.transactionTimeout, cfg.TransactionTimeout)
+		})
+	}
+
+	// The transactional producer requires Kafka 0.11.0.0 or later.
+	cfg := NewConfig()
+	cfg.ExactlyOnce = true
+	cfg.Version = "0.10.2.0"
+	_, err := NewSaramaConfig(context.Background(), cfg)
+	require.True(t, cerror.ErrKafkaInvalidConfig.Equal(err))
+}
+
 func TestCompleteSaramaSASLConfig(t *testing.T) {
 	t.Parallel()
 
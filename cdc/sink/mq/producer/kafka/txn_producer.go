@@ -0,0 +1,864 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	gerrors "errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sink/mq/producer"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/retry"
+	"github.com/pingcap/tiflow/pkg/sink/kafka"
+	"github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultCheckpointTopicSuffix = "-ticdc-checkpoint"
+
+	// checkpointTopicCleanupPolicy makes Kafka keep only the latest record
+	// of each key, so the size of the checkpoint topic is bounded by the
+	// number of tables.
+	checkpointTopicCleanupPolicy = "compact"
+	// markerKeySuffix is the key suffix of the load markers.
+	markerKeySuffix = "/marker"
+
+	initProducerIDMaxTries     = 10
+	initProducerIDBackoffDelay = 200
+)
+
+// Assert TxnProducer implementation
+var _ producer.TxnProducer = (*kafkaTxnProducer)(nil)
+
+// Checkpoint is the checkpoint of a table, it's recorded in the same Kafka
+// transaction as the rows of the table. All the rows of the table whose
+// commitTs is less than or equal to CheckpointTs have been committed.
+type Checkpoint struct {
+	Namespace    string `json:"namespace"`
+	Changefeed   string `json:"changefeed"`
+	TableID      int64  `json:"table-id"`
+	CheckpointTs uint64 `json:"checkpoint-ts"`
+}
+
+// loadMarker is committed to the checkpoint topic after the zombie producers
+// of a table are fenced. The checkpoint topic is read in order, so all the
+// checkpoints committed before have been loaded once the marker is read.
+type loadMarker struct {
+	Nonce string `json:"nonce"`
+}
+
+// txn is the state of a transactional ID.
+type txn struct {
+	transactionalID string
+	// coordinator is the transaction coordinator of the transactional ID.
+	coordinator   *sarama.Broker
+	producerID    int64
+	producerEpoch int16
+	// sequences are the sequence numbers of the next records.
+	sequences map[string]map[int32]int32
+}
+
+// kafkaTxnProducer is a Kafka producer that commits a transaction on each
+// Flush. Sarama doesn't provide the transactional producer, so the
+// transaction protocol is implemented on top of the broker APIs.
+//
+// Each table has its own transactional ID, which is initialized when the
+// table is added. So the producer of the table on the previous capture is
+// fenced when the table is moved, and its ongoing transaction is aborted.
+type kafkaTxnProducer struct {
+	client             sarama.Client
+	admin              kafka.ClusterAdminClient
+	saramaConfig       *sarama.Config
+	transactionTimeout time.Duration
+	checkpointTopic    string
+
+	// mu protects the ongoing transaction. It's held during the whole
+	// commit, since the transactions must be committed one by one.
+	mu struct {
+		sync.Mutex
+		// records are the records of the ongoing transaction,
+		// grouped by topic and partition.
+		records     map[string]map[int32][]*sarama.Record
+		checkpoints map[model.TableID]uint64
+		// tables are the transactions of the added tables.
+		tables map[model.TableID]*txn
+		// ddlTxn is the transaction of the broadcast messages.
+		ddlTxn *txn
+		// coordinators are the connected transaction coordinators by address.
+		coordinators map[string]*sarama.Broker
+		loader       *checkpointLoader
+		// err is the error that breaks the producer. The producer can't be
+		// used after a transaction is aborted, since the sequence numbers
+		// may be out of sync with the brokers. It should be recreated to
+		// get a new producer epoch.
+		err    error
+		closed bool
+	}
+
+	role util.Role
+	id   model.ChangeFeedID
+}
+
+// NewKafkaTxnProducer creates a transactional Kafka producer.
+func NewKafkaTxnProducer(
+	ctx context.Context,
+	client sarama.Client,
+	admin kafka.ClusterAdminClient,
+	config *Config,
+	saramaConfig *sarama.Config,
+) (*kafkaTxnProducer, error) {
+	changefeedID := contextutil.ChangefeedIDFromCtx(ctx)
+	role := contextutil.RoleFromCtx(ctx)
+	log.Info("Starting kafka transactional producer ...", zap.Any("config", config),
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID), zap.Any("role", role))
+
+	k := &kafkaTxnProducer{
+		client:             client,
+		admin:              admin,
+		saramaConfig:       saramaConfig,
+		transactionTimeout: config.TransactionTimeout,
+		checkpointTopic:    config.CheckpointTopic,
+		role:               role,
+		id:                 changefeedID,
+	}
+	k.mu.records = make(map[string]map[int32][]*sarama.Record)
+	k.mu.checkpoints = make(map[model.TableID]uint64)
+	k.mu.tables = make(map[model.TableID]*txn)
+	k.mu.coordinators = make(map[string]*sarama.Broker)
+	return k, nil
+}
+
+// CreateCheckpointTopic creates the checkpoint topic with one partition and
+// the compact cleanup policy. The existing checkpoint topic must be compacted,
+// otherwise the checkpoints could be deleted by the retention.
+func CreateCheckpointTopic(admin kafka.ClusterAdminClient, config *Config) error {
+	topics, err := admin.ListTopics()
+	if err != nil {
+		return cerror.WrapError(cerror.ErrKafkaCreateTopic, err)
+	}
+	if detail, ok := topics[config.CheckpointTopic]; ok {
+		policy, ok := detail.ConfigEntries["cleanup.policy"]
+		if !ok || policy == nil ||
+			!strings.Contains(*policy, checkpointTopicCleanupPolicy) {
+			return cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"the cleanup.policy of the checkpoint topic %s must be %s",
+				config.CheckpointTopic, checkpointTopicCleanupPolicy)
+		}
+		return nil
+	}
+	if !config.AutoCreate {
+		return cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"`auto-create-topic` is false, and the checkpoint topic %s not found",
+			config.CheckpointTopic)
+	}
+
+	cleanupPolicy := checkpointTopicCleanupPolicy
+	err = admin.CreateTopic(config.CheckpointTopic, &sarama.TopicDetail{
+		NumPartitions:     1,
+		ReplicationFactor: config.ReplicationFactor,
+		ConfigEntries: map[string]*string{
+			"cleanup.policy": &cleanupPolicy,
+		},
+	}, false)
+	if err != nil && !gerrors.Is(err, sarama.ErrTopicAlreadyExists) {
+		return cerror.WrapError(cerror.ErrKafkaCreateTopic, err)
+	}
+	log.Info("TiCDC create the checkpoint topic",
+		zap.String("topic", config.CheckpointTopic))
+	return nil
+}
+
+// newTxn finds the transaction coordinator of the transactional ID and gets
+// the producer ID and epoch. It also fences the producers with the same
+// transactional ID and aborts their ongoing transactions.
+func (k *kafkaTxnProducer) newTxn(
+	ctx context.Context, transactionalID string,
+) (*txn, error) {
+	coordinator, err := k.getCoordinator(transactionalID)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrKafkaTransaction, err)
+	}
+	t := &txn{
+		transactionalID: transactionalID,
+		coordinator:     coordinator,
+		sequences:       make(map[string]map[int32]int32),
+	}
+
+	// The coordinator returns ErrConcurrentTransactions if the ongoing
+	// transaction of the fenced producer is still being aborted.
+	err = retry.Do(ctx, func() error {
+		resp, err := coordinator.InitProducerID(&sarama.InitProducerIDRequest{
+			TransactionalID:    &t.transactionalID,
+			TransactionTimeout: k.transactionTimeout,
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if resp.Err != sarama.ErrNoError {
+			return errors.Trace(resp.Err)
+		}
+		t.producerID = resp.ProducerID
+		t.producerEpoch = resp.ProducerEpoch
+		return nil
+	}, retry.WithBackoffBaseDelay(initProducerIDBackoffDelay),
+		retry.WithMaxTries(initProducerIDMaxTries),
+		retry.WithIsRetryableErr(func(err error) bool {
+			return errors.Cause(err) == sarama.ErrConcurrentTransactions
+		}))
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrKafkaTransaction, err)
+	}
+	log.Info("kafka transactional ID initialized",
+		zap.String("transactionalID", t.transactionalID),
+		zap.Int64("producerID", t.producerID),
+		zap.Int16("producerEpoch", t.producerEpoch),
+		zap.String("namespace", k.id.Namespace),
+		zap.String("changefeed", k.id.ID), zap.Any("role", k.role))
+	return t, nil
+}
+
+func (k *kafkaTxnProducer) getCoordinator(transactionalID string) (*sarama.Broker, error) {
+	broker, err := k.client.Controller()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp, err := broker.FindCoordinator(&sarama.FindCoordinatorRequest{
+		Version:         1,
+		CoordinatorKey:  transactionalID,
+		CoordinatorType: sarama.CoordinatorTransaction,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resp.Err != sarama.ErrNoError {
+		return nil, errors.Trace(resp.Err)
+	}
+	if coordinator, ok := k.mu.coordinators[resp.Coordinator.Addr()]; ok {
+		return coordinator, nil
+	}
+	coordinator := resp.Coordinator
+	err = coordinator.Open(k.saramaConfig)
+	if err != nil && err != sarama.ErrAlreadyConnected {
+		return nil, errors.Trace(err)
+	}
+	k.mu.coordinators[coordinator.Addr()] = coordinator
+	return coordinator, nil
+}
+
+// AsyncSendMessage adds the message to the ongoing transaction,
+// it's sent to Kafka by the next Flush.
+func (k *kafkaTxnProducer) AsyncSendMessage(
+	ctx context.Context, topic string, partition int32, message *common.Message,
+) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.mu.closed {
+		return cerror.ErrKafkaProducerClosed.GenWithStackByArgs()
+	}
+	if k.mu.err != nil {
+		return k.mu.err
+	}
+	appendRecord(k.mu.records, topic, partition, message.Key, message.Value)
+	return nil
+}
+
+// SyncBroadcastMessage sends the message to all the partitions of the topic
+// in a separate transaction, which doesn't include the ongoing transaction.
+func (k *kafkaTxnProducer) SyncBroadcastMessage(
+	ctx context.Context, topic string, partitionsNum int32, message *common.Message,
+) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.mu.closed {
+		return cerror.ErrKafkaProducerClosed.GenWithStackByArgs()
+	}
+	if k.mu.err != nil {
+		return k.mu.err
+	}
+	records := make(map[string]map[int32][]*sarama.Record)
+	for i := int32(0); i < partitionsNum; i++ {
+		appendRecord(records, topic, i, message.Key, message.Value)
+	}
+	return k.commitDDLTxn(ctx, records)
+}
+
+// SyncSendMessage sends the message to the partition in a separate
+// transaction, which doesn't include the ongoing transaction.
+func (k *kafkaTxnProducer) SyncSendMessage(
+	ctx context.Context, topic string, partition int32, message *common.Message,
+) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.mu.closed {
+		return cerror.ErrKafkaProducerClosed.GenWithStackByArgs()
+	}
+	if k.mu.err != nil {
+		return k.mu.err
+	}
+	records := make(map[string]map[int32][]*sarama.Record)
+	appendRecord(records, topic, partition, message.Key, message.Value)
+	return k.commitDDLTxn(ctx, records)
+}
+
+// commitDDLTxn commits the records with the transactional ID of the
+// messages which don't belong to any table. It must be called with mu held.
+func (k *kafkaTxnProducer) commitDDLTxn(
+	ctx context.Context, records map[string]map[int32][]*sarama.Record,
+) error {
+	if k.mu.ddlTxn == nil {
+		t, err := k.newTxn(ctx, transactionalID(k.id, "ddl"))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		k.mu.ddlTxn = t
+	}
+	if err := k.commit(ctx, k.mu.ddlTxn, records); err != nil {
+		k.mu.err = err
+		return err
+	}
+	return nil
+}
+
+// RecordCheckpoint implements producer.TxnProducer.
+func (k *kafkaTxnProducer) RecordCheckpoint(tableID model.TableID, checkpointTs uint64) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if checkpointTs > k.mu.checkpoints[tableID] {
+		k.mu.checkpoints[tableID] = checkpointTs
+	}
+}
+
+// Flush commits the ongoing transaction with the transactional ID of its
+// table. The checkpoint is only recorded if there are records to commit.
+// The records without a checkpoint don't belong to any table, so they are
+// committed with the transactional ID of the DDL messages.
+func (k *kafkaTxnProducer) Flush(ctx context.Context) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.mu.closed {
+		return cerror.ErrKafkaFlushUnfinished.GenWithStackByArgs()
+	}
+	if k.mu.err != nil {
+		return k.mu.err
+	}
+
+	records, checkpoints := k.mu.records, k.mu.checkpoints
+	k.mu.records = make(map[string]map[int32][]*sarama.Record)
+	k.mu.checkpoints = make(map[model.TableID]uint64)
+	if len(records) == 0 {
+		return nil
+	}
+	if len(checkpoints) == 0 {
+		return k.commitDDLTxn(ctx, records)
+	}
+	// The rows of a table are flushed with the checkpoint of the table,
+	// so a transaction only contains the rows of one table.
+	if len(checkpoints) > 1 {
+		k.mu.err = cerror.ErrKafkaTransaction.GenWithStack(
+			"a transaction must contain at most one table, but got %d", len(checkpoints))
+		return k.mu.err
+	}
+	for tableID, checkpointTs := range checkpoints {
+		t, ok := k.mu.tables[tableID]
+		if !ok {
+			k.mu.err = cerror.ErrKafkaTransaction.GenWithStack(
+				"the transactional ID of table %d is not initialized", tableID)
+			return k.mu.err
+		}
+		value, err := json.Marshal(&Checkpoint{
+			Namespace:    k.id.Namespace,
+			Changefeed:   k.id.ID,
+			TableID:      tableID,
+			CheckpointTs: checkpointTs,
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		appendRecord(records, k.checkpointTopic, 0, []byte(checkpointKey(k.id, tableID)), value)
+		if err := k.commit(ctx, t, records); err != nil {
+			k.mu.err = err
+			return err
+		}
+	}
+	return nil
+}
+
+// InitTable initializes the transactional ID of the table, which fences the
+// zombie producers of the table, and returns the committed checkpoint of the
+// table. It returns 0 if the checkpoint is not found.
+func (k *kafkaTxnProducer) InitTable(
+	ctx context.Context, tableID model.TableID,
+) (uint64, error) {
+	loader, nonce, err := k.initTableTxn(ctx, tableID)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return loader.wait(ctx, markerKey(k.id, tableID), nonce, checkpointKey(k.id, tableID))
+}
+
+// initTableTxn initializes the transactional ID of the table, and commits
+// a load marker with the nonce of the new producer epoch.
+func (k *kafkaTxnProducer) initTableTxn(
+	ctx context.Context, tableID model.TableID,
+) (*checkpointLoader, string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.mu.closed {
+		return nil, "", cerror.ErrKafkaProducerClosed.GenWithStackByArgs()
+	}
+	if k.mu.err != nil {
+		return nil, "", k.mu.err
+	}
+	if k.mu.loader == nil {
+		loader, err := newCheckpointLoader(k.client, k.checkpointTopic)
+		if err != nil {
+			return nil, "", errors.Trace(err)
+		}
+		k.mu.loader = loader
+	}
+
+	delete(k.mu.tables, tableID)
+	t, err := k.newTxn(ctx, transactionalID(k.id, strconv.FormatInt(tableID, 10)))
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	nonce := fmt.Sprintf("%d-%d", t.producerID, t.producerEpoch)
+	value, err := json.Marshal(&loadMarker{Nonce: nonce})
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	records := make(map[string]map[int32][]*sarama.Record)
+	appendRecord(records, k.checkpointTopic, 0, []byte(markerKey(k.id, tableID)), value)
+	// The transactional ID is initialized again by the next InitTable
+	// if the commit fails.
+	if err := k.commit(ctx, t, records); err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	k.mu.tables[tableID] = t
+	return k.mu.loader, nonce, nil
+}
+
+// RemoveTable implements producer.TxnProducer.
+func (k *kafkaTxnProducer) RemoveTable(tableID model.TableID) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	delete(k.mu.tables, tableID)
+}
+
+// Close closes the producer, the ongoing transaction is aborted.
+func (k *kafkaTxnProducer) Close() error {
+	log.Info("stop the kafka transactional producer",
+		zap.String("namespace", k.id.Namespace),
+		zap.String("changefeed", k.id.ID), zap.Any("role", k.role))
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.mu.closed {
+		return nil
+	}
+	k.mu.closed = true
+
+	if k.mu.loader != nil {
+		k.mu.loader.close()
+	}
+	// Nothing has been sent to Kafka for the ongoing transaction,
+	// so there is no need to abort it.
+	for _, coordinator := range k.mu.coordinators {
+		if err := coordinator.Close(); err != nil {
+			log.Warn("close transaction coordinator with error", zap.Error(err),
+				zap.String("namespace", k.id.Namespace),
+				zap.String("changefeed", k.id.ID), zap.Any("role", k.role))
+		}
+	}
+	if err := k.client.Close(); err != nil {
+		log.Warn("close sarama client with error", zap.Error(err),
+			zap.String("namespace", k.id.Namespace),
+			zap.String("changefeed", k.id.ID), zap.Any("role", k.role))
+	}
+	if err := k.admin.Close(); err != nil {
+		log.Warn("close kafka cluster admin with error", zap.Error(err),
+			zap.String("namespace", k.id.Namespace),
+			zap.String("changefeed", k.id.ID), zap.Any("role", k.role))
+	}
+	return nil
+}
+
+func appendRecord(
+	records map[string]map[int32][]*sarama.Record,
+	topic string, partition int32, key, value []byte,
+) {
+	partitions, ok := records[topic]
+	if !ok {
+		partitions = make(map[int32][]*sarama.Record)
+		records[topic] = partitions
+	}
+	partitions[partition] = append(partitions[partition], &sarama.Record{
+		Key:   key,
+		Value: value,
+	})
+}
+
+// commit sends the records in a transaction of the transactional ID and
+// commits it. If the transaction fails, it's aborted.
+func (k *kafkaTxnProducer) commit(
+	ctx context.Context, t *txn, records map[string]map[int32][]*sarama.Record,
+) error {
+	start := time.Now()
+	err := k.addPartitionsToTxn(t, records)
+	if err == nil {
+		err = k.produce(ctx, t, records)
+	}
+	if err == nil {
+		err = k.endTxn(t, true)
+	}
+	if err != nil {
+		log.Warn("kafka transaction failed, abort it", zap.Error(err),
+			zap.String("transactionalID", t.transactionalID),
+			zap.String("namespace", k.id.Namespace),
+			zap.String("changefeed", k.id.ID), zap.Any("role", k.role))
+		if err := k.endTxn(t, false); err != nil {
+			log.Warn("abort kafka transaction failed", zap.Error(err),
+				zap.String("transactionalID", t.transactionalID),
+				zap.String("namespace", k.id.Namespace),
+				zap.String("changefeed", k.id.ID), zap.Any("role", k.role))
+		}
+		return cerror.WrapError(cerror.ErrKafkaTransaction, err)
+	}
+	log.Debug("kafka transaction committed",
+		zap.String("transactionalID", t.transactionalID),
+		zap.Duration("duration", time.Since(start)))
+	return nil
+}
+
+func (k *kafkaTxnProducer) addPartitionsToTxn(
+	t *txn, records map[string]map[int32][]*sarama.Record,
+) error {
+	topicPartitions := make(map[string][]int32, len(records))
+	for topic, partitions := range records {
+		for partition := range partitions {
+			topicPartitions[topic] = append(topicPartitions[topic], partition)
+		}
+	}
+	resp, err := t.coordinator.AddPartitionsToTxn(&sarama.AddPartitionsToTxnRequest{
+		TransactionalID: t.transactionalID,
+		ProducerID:      t.producerID,
+		ProducerEpoch:   t.producerEpoch,
+		TopicPartitions: topicPartitions,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for topic, partitionErrors := range resp.Errors {
+		for _, partitionError := range partitionErrors {
+			if partitionError.Err != sarama.ErrNoError {
+				return errors.Annotatef(partitionError.Err,
+					"add partition %s:%d to transaction", topic, partitionError.Partition)
+			}
+		}
+	}
+	return nil
+}
+
+// produce sends the records of the transaction to the partition leaders.
+// The records of a partition are split into batches which are no larger than
+// the max message bytes, and each round of requests carries at most one batch
+// for each partition.
+func (k *kafkaTxnProducer) produce(
+	ctx context.Context, t *txn, records map[string]map[int32][]*sarama.Record,
+) error {
+	type partitionBatches struct {
+		topic     string
+		partition int32
+		leader    *sarama.Broker
+		batches   [][]*sarama.Record
+	}
+	var all []*partitionBatches
+	rounds := 0
+	for topic, partitions := range records {
+		for partition, records := range partitions {
+			leader, err := k.client.Leader(topic, partition)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			batches := k.splitBatches(records)
+			if len(batches) > rounds {
+				rounds = len(batches)
+			}
+			all = append(all, &partitionBatches{
+				topic: topic, partition: partition, leader: leader, batches: batches,
+			})
+		}
+	}
+
+	now := time.Now().Truncate(time.Millisecond)
+	for round := 0; round < rounds; round++ {
+		if err := ctx.Err(); err != nil {
+			return errors.Trace(err)
+		}
+		requests := make(map[*sarama.Broker]*sarama.ProduceRequest)
+		sent := make(map[*partitionBatches]int)
+		for _, p := range all {
+			if round >= len(p.batches) {
+				continue
+			}
+			request, ok := requests[p.leader]
+			if !ok {
+				request = k.newProduceRequest(t)
+				requests[p.leader] = request
+			}
+			records := p.batches[round]
+			for i, record := range records {
+				record.OffsetDelta = int64(i)
+			}
+			request.AddBatch(p.topic, p.partition, &sarama.RecordBatch{
+				Version:          2,
+				Codec:            k.saramaConfig.Producer.Compression,
+				CompressionLevel: k.saramaConfig.Producer.CompressionLevel,
+				FirstTimestamp:   now,
+				MaxTimestamp:     now,
+				ProducerID:       t.producerID,
+				ProducerEpoch:    t.producerEpoch,
+				FirstSequence:    t.sequences[p.topic][p.partition],
+				IsTransactional:  true,
+				LastOffsetDelta:  int32(len(records) - 1),
+				Records:          records,
+			})
+			sent[p] = len(records)
+		}
+
+		for broker, request := range requests {
+			resp, err := broker.Produce(request)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			for p := range sent {
+				if p.leader != broker {
+					continue
+				}
+				block := resp.GetBlock(p.topic, p.partition)
+				if block == nil {
+					return errors.Annotatef(sarama.ErrIncompleteResponse,
+						"produce to %s:%d", p.topic, p.partition)
+				}
+				if block.Err != sarama.ErrNoError {
+					return errors.Annotatef(block.Err,
+						"produce to %s:%d", p.topic, p.partition)
+				}
+			}
+		}
+		for p, count := range sent {
+			sequences, ok := t.sequences[p.topic]
+			if !ok {
+				sequences = make(map[int32]int32)
+				t.sequences[p.topic] = sequences
+			}
+			sequences[p.partition] += int32(count)
+		}
+	}
+	return nil
+}
+
+func (k *kafkaTxnProducer) newProduceRequest(t *txn) *sarama.ProduceRequest {
+	request := &sarama.ProduceRequest{
+		TransactionalID: &t.transactionalID,
+		RequiredAcks:    sarama.WaitForAll,
+		Timeout:         int32(k.saramaConfig.Producer.Timeout / time.Millisecond),
+		Version:         3,
+	}
+	if k.saramaConfig.Producer.Compression == sarama.CompressionZSTD &&
+		k.saramaConfig.Version.IsAtLeast(sarama.V2_1_0_0) {
+		request.Version = 7
+	}
+	return request
+}
+
+// splitBatches splits the records into batches,
+// the size of each batch is no larger than the max message bytes.
+func (k *kafkaTxnProducer) splitBatches(records []*sarama.Record) [][]*sarama.Record {
+	// recordOverhead is a conservative estimation of the record overhead.
+	const recordOverhead = 64
+	var batches [][]*sarama.Record
+	start, size := 0, 0
+	for i, record := range records {
+		recordSize := len(record.Key) + len(record.Value) + recordOverhead
+		if i > start && size+recordSize > k.saramaConfig.Producer.MaxMessageBytes {
+			batches = append(batches, records[start:i])
+			start, size = i, 0
+		}
+		size += recordSize
+	}
+	return append(batches, records[start:])
+}
+
+func (k *kafkaTxnProducer) endTxn(t *txn, commit bool) error {
+	resp, err := t.coordinator.EndTxn(&sarama.EndTxnRequest{
+		TransactionalID:   t.transactionalID,
+		ProducerID:        t.producerID,
+		ProducerEpoch:     t.producerEpoch,
+		TransactionResult: commit,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if resp.Err != sarama.ErrNoError {
+		return errors.Trace(resp.Err)
+	}
+	return nil
+}
+
+func checkpointKey(changefeedID model.ChangeFeedID, tableID model.TableID) string {
+	return fmt.Sprintf("%s/%s/%d", changefeedID.Namespace, changefeedID.ID, tableID)
+}
+
+func markerKey(changefeedID model.ChangeFeedID, tableID model.TableID) string {
+	return checkpointKey(changefeedID, tableID) + markerKeySuffix
+}
+
+// transactionalID returns the transactional ID of a table or the broadcast
+// messages of the changefeed. It only depends on the changefeed and the
+// table, so the same table always gets the same transactional ID no matter
+// which capture it's scheduled to, and the zombie producers are fenced.
+func transactionalID(changefeedID model.ChangeFeedID, name string) string {
+	id := fmt.Sprintf("TiCDC_txn_%s_%s_%s",
+		changefeedID.Namespace, changefeedID.ID, name)
+	return commonInvalidChar.ReplaceAllString(id, "_")
+}
+
+// checkpointLoader tails the checkpoint topic with the read committed
+// isolation level, and caches the latest checkpoint of each key. It's created
+// once by a producer, so the checkpoint topic is only read from the beginning
+// once, and the compaction bounds the size of it.
+type checkpointLoader struct {
+	consumer          sarama.Consumer
+	partitionConsumer sarama.PartitionConsumer
+
+	mu struct {
+		sync.Mutex
+		checkpoints map[string]uint64
+		markers     map[string]string
+		err         error
+		// updated is closed and replaced once a record is loaded.
+		updated chan struct{}
+	}
+}
+
+func newCheckpointLoader(client sarama.Client, topic string) (*checkpointLoader, error) {
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrKafkaTransaction, err)
+	}
+	partitionConsumer, err := consumer.ConsumePartition(topic, 0, sarama.OffsetOldest)
+	if err != nil {
+		_ = consumer.Close()
+		return nil, cerror.WrapError(cerror.ErrKafkaTransaction, err)
+	}
+	l := newCheckpointLoaderWithConsumer(consumer, partitionConsumer)
+	go l.run()
+	return l, nil
+}
+
+func newCheckpointLoaderWithConsumer(
+	consumer sarama.Consumer, partitionConsumer sarama.PartitionConsumer,
+) *checkpointLoader {
+	l := &checkpointLoader{
+		consumer:          consumer,
+		partitionConsumer: partitionConsumer,
+	}
+	l.mu.checkpoints = make(map[string]uint64)
+	l.mu.markers = make(map[string]string)
+	l.mu.updated = make(chan struct{})
+	return l
+}
+
+func (l *checkpointLoader) run() {
+	for msg := range l.partitionConsumer.Messages() {
+		l.load(msg.Key, msg.Value)
+	}
+}
+
+func (l *checkpointLoader) load(key, value []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	defer func() {
+		close(l.mu.updated)
+		l.mu.updated = make(chan struct{})
+	}()
+
+	if bytes.HasSuffix(key, []byte(markerKeySuffix)) {
+		var marker loadMarker
+		if err := json.Unmarshal(value, &marker); err != nil {
+			l.mu.err = cerror.WrapError(cerror.ErrKafkaTransaction, err)
+			return
+		}
+		l.mu.markers[string(key)] = marker.Nonce
+		return
+	}
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(value, &checkpoint); err != nil {
+		l.mu.err = cerror.WrapError(cerror.ErrKafkaTransaction, err)
+		return
+	}
+	l.mu.checkpoints[string(key)] = checkpoint.CheckpointTs
+}
+
+// wait waits until the marker with the nonce is loaded, and returns the
+// checkpoint of the key. It returns 0 if the checkpoint is not found.
+func (l *checkpointLoader) wait(
+	ctx context.Context, markerKey, nonce, key string,
+) (uint64, error) {
+	for {
+		l.mu.Lock()
+		if l.mu.err != nil {
+			err := l.mu.err
+			l.mu.Unlock()
+			return 0, err
+		}
+		if l.mu.markers[markerKey] == nonce {
+			checkpointTs := l.mu.checkpoints[key]
+			l.mu.Unlock()
+			return checkpointTs, nil
+		}
+		updated := l.mu.updated
+		l.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return 0, errors.Trace(ctx.Err())
+		case <-updated:
+		}
+	}
+}
+
+func (l *checkpointLoader) close() {
+	if err := l.partitionConsumer.Close(); err != nil {
+		log.Warn("close checkpoint partition consumer with error", zap.Error(err))
+	}
+	if err := l.consumer.Close(); err != nil {
+		log.Warn("close checkpoint consumer with error", zap.Error(err))
+	}
+}
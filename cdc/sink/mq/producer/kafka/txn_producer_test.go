@@ -0,0 +1,327 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/kafka"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newTxnTestProducer(
+	ctx context.Context, t *testing.T, broker *sarama.MockBroker,
+	produceResponse sarama.MockResponse,
+) *kafkaTxnProducer {
+	topic := kafka.DefaultMockTopicName
+	sinkURI, err := url.Parse("kafka://" + broker.Addr() + "/" + topic +
+		"?kafka-version=0.11.0.0&exactly-once=true&partition-num=2")
+	require.Nil(t, err)
+	config := NewConfig()
+	require.Nil(t, config.Apply(sinkURI))
+	require.Equal(t, topic+defaultCheckpointTopicSuffix, config.CheckpointTopic)
+
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetController(broker.BrokerID()).
+			SetLeader(topic, 0, broker.BrokerID()).
+			SetLeader(topic, 1, broker.BrokerID()).
+			SetLeader(config.CheckpointTopic, 0, broker.BrokerID()),
+		"FindCoordinatorRequest": sarama.NewMockWrapper(&sarama.FindCoordinatorResponse{
+			Version:     1,
+			Coordinator: sarama.NewBroker(broker.Addr()),
+		}),
+		"InitProducerIDRequest": sarama.NewMockWrapper(&sarama.InitProducerIDResponse{
+			ProducerID:    1000,
+			ProducerEpoch: 1,
+		}),
+		"AddPartitionsToTxnRequest": sarama.NewMockWrapper(&sarama.AddPartitionsToTxnResponse{
+			Errors: map[string][]*sarama.PartitionError{},
+		}),
+		"ProduceRequest": produceResponse,
+		"EndTxnRequest":  sarama.NewMockWrapper(&sarama.EndTxnResponse{}),
+	})
+
+	ctx = contextutil.PutRoleInCtx(ctx, util.RoleTester)
+	saramaConfig, err := NewSaramaConfig(ctx, config)
+	require.Nil(t, err)
+	require.True(t, saramaConfig.Producer.Idempotent)
+	require.Equal(t, sarama.ReadCommitted, saramaConfig.Consumer.IsolationLevel)
+	client, err := sarama.NewClient(config.BrokerEndpoints, saramaConfig)
+	require.Nil(t, err)
+	adminClient, err := kafka.NewMockAdminClient(config.BrokerEndpoints, saramaConfig)
+	require.Nil(t, err)
+
+	producer, err := NewKafkaTxnProducer(ctx, client, adminClient, config, saramaConfig)
+	require.Nil(t, err)
+	return producer
+}
+
+// initTestTable initializes the transactional ID of the table
+// without loading the checkpoint.
+func initTestTable(
+	ctx context.Context, t *testing.T, producer *kafkaTxnProducer, tableID model.TableID,
+) {
+	producer.mu.Lock()
+	defer producer.mu.Unlock()
+	txn, err := producer.newTxn(ctx, transactionalID(producer.id, strconv.FormatInt(tableID, 10)))
+	require.Nil(t, err)
+	require.Equal(t, int64(1000), txn.producerID)
+	require.Equal(t, int16(1), txn.producerEpoch)
+	producer.mu.tables[tableID] = txn
+}
+
+func endTxnRequests(broker *sarama.MockBroker) []*sarama.EndTxnRequest {
+	var requests []*sarama.EndTxnRequest
+	for _, rr := range broker.History() {
+		if request, ok := rr.Request.(*sarama.EndTxnRequest); ok {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+func TestTxnProducerCommit(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	producer := newTxnTestProducer(ctx, t, broker,
+		sarama.NewMockProduceResponse(t).SetVersion(3))
+	defer producer.Close()
+	initTestTable(ctx, t, producer, 1)
+	txn := producer.mu.tables[1]
+
+	topic := kafka.DefaultMockTopicName
+	message := &common.Message{Key: []byte("key"), Value: []byte("value")}
+	for i := 0; i < 3; i++ {
+		require.Nil(t, producer.AsyncSendMessage(ctx, topic, 0, message))
+	}
+	require.Nil(t, producer.AsyncSendMessage(ctx, topic, 1, message))
+	producer.RecordCheckpoint(1, 100)
+	producer.RecordCheckpoint(1, 90)
+	require.Nil(t, producer.Flush(ctx))
+
+	requests := endTxnRequests(broker)
+	require.Len(t, requests, 1)
+	require.True(t, requests[0].TransactionResult)
+	require.Equal(t, int64(1000), requests[0].ProducerID)
+	require.Equal(t, txn.transactionalID, requests[0].TransactionalID)
+	for _, rr := range broker.History() {
+		if request, ok := rr.Request.(*sarama.AddPartitionsToTxnRequest); ok {
+			require.ElementsMatch(t, []int32{0, 1}, request.TopicPartitions[topic])
+			require.Equal(t, []int32{0}, request.TopicPartitions[producer.checkpointTopic])
+		}
+	}
+	require.Equal(t, int32(3), txn.sequences[topic][0])
+	require.Equal(t, int32(1), txn.sequences[topic][1])
+	require.Equal(t, int32(1), txn.sequences[producer.checkpointTopic][0])
+	require.Empty(t, producer.mu.records)
+	require.Empty(t, producer.mu.checkpoints)
+
+	// There is nothing to commit, and the checkpoint is dropped.
+	producer.RecordCheckpoint(1, 200)
+	require.Nil(t, producer.Flush(ctx))
+	require.Len(t, endTxnRequests(broker), 1)
+	require.Empty(t, producer.mu.checkpoints)
+
+	// The broadcast message is committed immediately
+	// with its own transactional ID.
+	require.Nil(t, producer.SyncBroadcastMessage(ctx, topic, 2, message))
+	requests = endTxnRequests(broker)
+	require.Len(t, requests, 2)
+	require.True(t, requests[1].TransactionResult)
+	require.Equal(t, producer.mu.ddlTxn.transactionalID, requests[1].TransactionalID)
+	require.Equal(t, int32(1), producer.mu.ddlTxn.sequences[topic][0])
+	require.Equal(t, int32(1), producer.mu.ddlTxn.sequences[topic][1])
+	require.Equal(t, int32(3), txn.sequences[topic][0])
+
+	// The message sent to one partition is committed immediately
+	// with the transactional ID of the broadcast messages.
+	require.Nil(t, producer.SyncSendMessage(ctx, topic, 0, message))
+	requests = endTxnRequests(broker)
+	require.Len(t, requests, 3)
+	require.Equal(t, producer.mu.ddlTxn.transactionalID, requests[2].TransactionalID)
+	require.Equal(t, int32(2), producer.mu.ddlTxn.sequences[topic][0])
+	require.Equal(t, int32(1), producer.mu.ddlTxn.sequences[topic][1])
+
+	// The records without a checkpoint don't belong to any table,
+	// they are committed with the same transactional ID.
+	require.Nil(t, producer.AsyncSendMessage(ctx, topic, 0, message))
+	require.Nil(t, producer.Flush(ctx))
+	requests = endTxnRequests(broker)
+	require.Len(t, requests, 4)
+	require.True(t, requests[3].TransactionResult)
+	require.Equal(t, producer.mu.ddlTxn.transactionalID, requests[3].TransactionalID)
+	require.Equal(t, int32(3), producer.mu.ddlTxn.sequences[topic][0])
+	require.Nil(t, producer.mu.err)
+
+	// The producer returns an error after it's closed.
+	require.Nil(t, producer.Close())
+	err := producer.AsyncSendMessage(ctx, topic, 0, message)
+	require.True(t, cerror.ErrKafkaProducerClosed.Equal(err))
+	err = producer.SyncBroadcastMessage(ctx, topic, 2, message)
+	require.True(t, cerror.ErrKafkaProducerClosed.Equal(err))
+	err = producer.SyncSendMessage(ctx, topic, 0, message)
+	require.True(t, cerror.ErrKafkaProducerClosed.Equal(err))
+}
+
+func TestTxnProducerUninitializedTable(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	producer := newTxnTestProducer(ctx, t, broker,
+		sarama.NewMockProduceResponse(t).SetVersion(3))
+	defer producer.Close()
+
+	message := &common.Message{Key: []byte("key"), Value: []byte("value")}
+	require.Nil(t, producer.AsyncSendMessage(ctx, kafka.DefaultMockTopicName, 0, message))
+	producer.RecordCheckpoint(1, 100)
+	err := producer.Flush(ctx)
+	require.True(t, cerror.ErrKafkaTransaction.Equal(err))
+	require.Empty(t, endTxnRequests(broker))
+}
+
+func TestTxnProducerAbort(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	topic := kafka.DefaultMockTopicName
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	producer := newTxnTestProducer(ctx, t, broker,
+		sarama.NewMockProduceResponse(t).SetVersion(3).
+			SetError(topic, 1, sarama.ErrNotLeaderForPartition))
+	defer producer.Close()
+	initTestTable(ctx, t, producer, 1)
+
+	message := &common.Message{Key: []byte("key"), Value: []byte("value")}
+	require.Nil(t, producer.AsyncSendMessage(ctx, topic, 0, message))
+	require.Nil(t, producer.AsyncSendMessage(ctx, topic, 1, message))
+	producer.RecordCheckpoint(1, 100)
+	err := producer.Flush(ctx)
+	require.True(t, cerror.ErrKafkaTransaction.Equal(err))
+
+	requests := endTxnRequests(broker)
+	require.Len(t, requests, 1)
+	require.False(t, requests[0].TransactionResult)
+
+	// The producer is broken after the transaction is aborted.
+	err = producer.AsyncSendMessage(ctx, topic, 0, message)
+	require.True(t, cerror.ErrKafkaTransaction.Equal(err))
+	err = producer.Flush(ctx)
+	require.True(t, cerror.ErrKafkaTransaction.Equal(err))
+	require.Len(t, endTxnRequests(broker), 1)
+}
+
+func TestTransactionalID(t *testing.T) {
+	t.Parallel()
+
+	// The transactional ID doesn't depend on the capture,
+	// so the zombie producers can be fenced after the table is moved.
+	changefeedID := model.DefaultChangeFeedID("test:cf")
+	require.Equal(t, "TiCDC_txn_default_test_cf_1", transactionalID(changefeedID, "1"))
+	require.Equal(t, "TiCDC_txn_default_test_cf_ddl", transactionalID(changefeedID, "ddl"))
+}
+
+func TestCheckpointLoader(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changefeedID := model.DefaultChangeFeedID("test")
+	loader := newCheckpointLoaderWithConsumer(nil, nil)
+	load := func(key string, value interface{}) {
+		data, err := json.Marshal(value)
+		require.Nil(t, err)
+		loader.load([]byte(key), data)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		checkpointTs, err := loader.wait(ctx,
+			markerKey(changefeedID, 1), "1000-2", checkpointKey(changefeedID, 1))
+		require.Nil(t, err)
+		require.Equal(t, uint64(200), checkpointTs)
+	}()
+
+	load(checkpointKey(changefeedID, 1), &Checkpoint{CheckpointTs: 100})
+	load(checkpointKey(changefeedID, 2), &Checkpoint{CheckpointTs: 300})
+	// The marker of the previous epoch doesn't finish the loading.
+	load(markerKey(changefeedID, 1), &loadMarker{Nonce: "1000-1"})
+	load(checkpointKey(changefeedID, 1), &Checkpoint{CheckpointTs: 200})
+	load(markerKey(changefeedID, 1), &loadMarker{Nonce: "1000-2"})
+	<-done
+
+	// The checkpoints are cached, the table without checkpoint gets 0.
+	load(markerKey(changefeedID, 3), &loadMarker{Nonce: "1000-1"})
+	checkpointTs, err := loader.wait(ctx,
+		markerKey(changefeedID, 3), "1000-1", checkpointKey(changefeedID, 3))
+	require.Nil(t, err)
+	require.Equal(t, uint64(0), checkpointTs)
+
+	// A corrupted record breaks the loader.
+	loader.load([]byte(checkpointKey(changefeedID, 4)), []byte("{"))
+	_, err = loader.wait(ctx,
+		markerKey(changefeedID, 4), "1000-1", checkpointKey(changefeedID, 4))
+	require.True(t, cerror.ErrKafkaTransaction.Equal(err))
+}
+
+func TestCreateCheckpointTopic(t *testing.T) {
+	t.Parallel()
+
+	admin := kafka.NewClusterAdminClientMockImpl()
+	config := NewConfig()
+	config.CheckpointTopic = "checkpoint"
+	config.AutoCreate = false
+	err := CreateCheckpointTopic(admin, config)
+	require.True(t, cerror.ErrKafkaInvalidConfig.Equal(err))
+
+	config.AutoCreate = true
+	require.Nil(t, CreateCheckpointTopic(admin, config))
+	topics, err := admin.ListTopics()
+	require.Nil(t, err)
+	detail := topics[config.CheckpointTopic]
+	require.Equal(t, int32(1), detail.NumPartitions)
+	require.Equal(t, "compact", *detail.ConfigEntries["cleanup.policy"])
+	// The existing compacted topic is accepted.
+	require.Nil(t, CreateCheckpointTopic(admin, config))
+
+	// The existing topic which is not compacted is rejected.
+	config.CheckpointTopic = admin.GetDefaultMockTopicName()
+	err = CreateCheckpointTopic(admin, config)
+	require.True(t, cerror.ErrKafkaInvalidConfig.Equal(err))
+}
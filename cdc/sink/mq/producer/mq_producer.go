@@ -16,6 +16,7 @@ package producer
 import (
 	"context"
 
+	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 )
 
@@ -35,3 +36,24 @@ type Producer interface {
 	// Close closes the producer and client(s).
 	Close() error
 }
+
+// TxnProducer is a producer that writes the messages in transactions,
+// a transaction is committed on each Flush.
+type TxnProducer interface {
+	Producer
+	// RecordCheckpoint records the checkpoint of the table in the ongoing
+	// transaction, the checkpoint is committed atomically with the messages
+	// by the next Flush.
+	RecordCheckpoint(tableID model.TableID, checkpointTs uint64)
+	// SyncSendMessage sends a message synchronously in a separate
+	// transaction, which doesn't include the ongoing transaction.
+	SyncSendMessage(
+		ctx context.Context, topic string, partition int32, message *common.Message,
+	) error
+	// InitTable fences the zombie producers of the table, whose ongoing
+	// transactions are aborted, and returns the committed checkpoint of the
+	// table. It returns 0 if the checkpoint is not found.
+	InitTable(ctx context.Context, tableID model.TableID) (uint64, error)
+	// RemoveTable releases the transactional state of the table.
+	RemoveTable(tableID model.TableID)
+}
@@ -23,6 +23,8 @@
 // Notice:
 // 1. All option in url queries start with lowercase chars, e.g. `tlsAllowInsecureConnection`, `maxConnectionsPerBroker`.
 // 2. Use `auth` to config authentication plugin type, `auth.*` to config auth params.
+// 3. Use `maxMessageBytes` to config the max message size, which must not be larger than
+// the `maxMessageSize` of the brokers (5 MB by default).
 // See:
 //  1. https://pulsar.apache.org/docs/en/reference-cli-tools/#pulsar-client
 //  2. https://github.com/apache/pulsar-client-go/tree/master/pulsar/internal/auth
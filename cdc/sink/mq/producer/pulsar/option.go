@@ -28,10 +28,18 @@ import (
 type Option struct {
 	clientOptions   *pulsar.ClientOptions
 	producerOptions *pulsar.ProducerOptions
+	maxMessageBytes int
 }
 
 const route = "$route"
 
+const (
+	// defaultMaxMessageBytes is the default `maxMessageSize` of pulsar brokers.
+	defaultMaxMessageBytes = 5 * 1024 * 1024
+	// defaultBatchingMaxSize is the default `BatchingMaxSize` of pulsar producers.
+	defaultBatchingMaxSize = 128 * 1024
+)
+
 func parseSinkOptions(u *url.URL) (opt *Option, err error) {
 	switch u.Scheme {
 	case "pulsar", "pulsar+ssl":
@@ -46,6 +54,11 @@ func parseSinkOptions(u *url.URL) (opt *Option, err error) {
 	opt = &Option{
 		clientOptions:   c,
 		producerOptions: p,
+		maxMessageBytes: parseMaxMessageBytes(u),
+	}
+	// A batch is sent as a single message, so it must not exceed the limit.
+	if !p.DisableBatching && opt.maxMessageBytes < defaultBatchingMaxSize {
+		p.BatchingMaxSize = uint(opt.maxMessageBytes)
 	}
 
 	p.MessageRouter = func(message *pulsar.ProducerMessage, metadata pulsar.TopicMetadata) int {
@@ -120,6 +133,16 @@ func parseProducerOptions(u *url.URL) (opt *pulsar.ProducerOptions) {
 	return opt
 }
 
+// parseMaxMessageBytes returns the max size of a message sent by the producer.
+// It must not be larger than the `maxMessageSize` of the pulsar brokers.
+func parseMaxMessageBytes(u *url.URL) int {
+	maxMessageBytes := values(u.Query()).Int("maxMessageBytes")
+	if maxMessageBytes <= 0 {
+		return defaultMaxMessageBytes
+	}
+	return maxMessageBytes
+}
+
 type values url.Values
 
 func (vs values) Int(name string) int {
@@ -19,6 +19,7 @@ import (
 	"strconv"
 
 	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
@@ -39,27 +40,76 @@ func NewProducer(u *url.URL, errCh chan error) (*Producer, error) {
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
 	}
+	client, producer, partitionNum, err := newClientAndProducer(opt)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Producer{
+		errCh:        errCh,
+		opt:          *opt,
+		client:       client,
+		producer:     producer,
+		partitionNum: partitionNum,
+	}, nil
+}
+
+// NewClient creates a pulsar client by the sink URI. It also returns the
+// options used to create producers, in which the topic is not set.
+// The caller is responsible for closing the client.
+func NewClient(u *url.URL) (pulsar.Client, pulsar.ProducerOptions, error) {
+	opt, err := parseSinkOptions(u)
+	if err != nil {
+		return nil, pulsar.ProducerOptions{}, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
 	client, err := pulsar.NewClient(*opt.clientOptions)
 	if err != nil {
-		return nil, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+		return nil, pulsar.ProducerOptions{}, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+	producerOptions := *opt.producerOptions
+	producerOptions.Topic = ""
+	return client, producerOptions, nil
+}
+
+func newClientAndProducer(opt *Option) (pulsar.Client, pulsar.Producer, int, error) {
+	client, err := pulsar.NewClient(*opt.clientOptions)
+	if err != nil {
+		return nil, nil, 0, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
 	}
 	producer, err := client.CreateProducer(*opt.producerOptions)
 	if err != nil {
 		client.Close()
-		return nil, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+		return nil, nil, 0, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
 	}
 	partitions, err := client.TopicPartitions(opt.producerOptions.Topic)
 	if err != nil {
+		producer.Close()
 		client.Close()
-		return nil, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+		return nil, nil, 0, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+	return client, producer, len(partitions), nil
+}
+
+// GetTopic returns the topic name from the sink URI.
+// The topic can be specified either by the path or by the `topic` parameter.
+func GetTopic(u *url.URL) string {
+	return parseProducerOptions(u).Topic
+}
+
+// GetMaxMessageBytes returns the max message size of the producers
+// created by the sink URI, which is set by the `maxMessageBytes` parameter.
+func GetMaxMessageBytes(u *url.URL) int {
+	return parseMaxMessageBytes(u)
+}
+
+// NewProducerMessage creates a pulsar message which will be routed to
+// the given partition by the producers created with the options returned by NewClient.
+func NewProducerMessage(message *common.Message, partition int32) *pulsar.ProducerMessage {
+	return &pulsar.ProducerMessage{
+		Payload:    message.Value,
+		Key:        string(message.Key),
+		Properties: createProperties(message, partition),
+		EventTime:  message.PhysicalTime(),
 	}
-	return &Producer{
-		errCh:        errCh,
-		opt:          *opt,
-		client:       client,
-		producer:     producer,
-		partitionNum: len(partitions),
-	}, nil
 }
 
 // Producer provide a way to send msg to pulsar.
@@ -89,12 +139,7 @@ func createProperties(message *common.Message, partition int32) map[string]strin
 func (p *Producer) AsyncSendMessage(
 	ctx context.Context, _ string, partition int32, message *common.Message,
 ) error {
-	p.producer.SendAsync(ctx, &pulsar.ProducerMessage{
-		Payload:    message.Value,
-		Key:        string(message.Key),
-		Properties: createProperties(message, partition),
-		EventTime:  message.PhysicalTime(),
-	}, p.errors)
+	p.producer.SendAsync(ctx, NewProducerMessage(message, partition), p.errors)
 	return nil
 }
 
@@ -113,12 +158,7 @@ func (p *Producer) SyncBroadcastMessage(
 	ctx context.Context, _ string, _ int32, message *common.Message,
 ) error {
 	for partition := 0; partition < p.partitionNum; partition++ {
-		_, err := p.producer.Send(ctx, &pulsar.ProducerMessage{
-			Payload:    message.Value,
-			Key:        string(message.Key),
-			Properties: createProperties(message, int32(partition)),
-			EventTime:  message.PhysicalTime(),
-		})
+		_, err := p.producer.Send(ctx, NewProducerMessage(message, int32(partition)))
 		if err != nil {
 			return cerror.WrapError(cerror.ErrPulsarSendMessage, p.producer.Flush())
 		}
@@ -815,6 +815,17 @@ func (s *mysqlSink) prepareDMLs(rows []*model.RowChangedEvent, bucket int) *prep
 	return dmls
 }
 
+// PrepareDMLs converts the row changed events to the SQLs and arguments which
+// are executed by a MySQL sink with the default parameters and old value
+// enabled, it's used to show what applying redo logs executes.
+func PrepareDMLs(rows []*model.RowChangedEvent) ([]string, [][]interface{}) {
+	params := defaultParams.Clone()
+	params.enableOldValue = true
+	s := &mysqlSink{params: params}
+	dmls := s.prepareDMLs(rows, 0)
+	return dmls.sqls, dmls.values
+}
+
 func (s *mysqlSink) execDMLs(ctx context.Context, rows []*model.RowChangedEvent, bucket int) error {
 	failpoint.Inject("SinkFlushDMLPanic", func() {
 		time.Sleep(time.Second)
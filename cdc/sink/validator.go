@@ -15,12 +15,24 @@ package sink
 
 import (
 	"context"
+	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/pingcap/tiflow/cdc/contextutil"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sink/mq/columnselector"
+	"github.com/pingcap/tiflow/cdc/sink/mq/dispatcher"
+	"github.com/pingcap/tiflow/cdc/sink/mq/producer/kafka"
+	ddlfactory "github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/factory"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/factory"
+	mqutil "github.com/pingcap/tiflow/cdc/sinkv2/util/mq"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	psink "github.com/pingcap/tiflow/pkg/sink"
+	pkafka "github.com/pingcap/tiflow/pkg/sink/kafka"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
 	"github.com/pingcap/tiflow/pkg/util"
 )
 
@@ -34,6 +46,11 @@ func Validate(ctx context.Context, sinkURI string, cfg *config.ReplicaConfig) er
 
 	errCh := make(chan error)
 	ctx, cancel := context.WithCancel(contextutil.PutRoleInCtx(ctx, util.RoleClient))
+	// The storage and webhook sinks are only implemented by the new sink.
+	if isNewSinkOnlyURI(sinkURI) {
+		defer cancel()
+		return validateNewSinkOnly(ctx, sinkURI, cfg, errCh)
+	}
 	s, err := New(ctx, model.DefaultChangeFeedID("sink-verify"), sinkURI, cfg, errCh)
 	if err != nil {
 		cancel()
@@ -56,6 +73,287 @@ func Validate(ctx context.Context, sinkURI string, cfg *config.ReplicaConfig) er
 	return nil
 }
 
+// ValidateTables checks the tables against the dispatch rules and the column
+// selectors of the MQ sink, the columns required by the partition dispatchers
+// must exist in the tables and must not be removed by the column selectors.
+func ValidateTables(
+	sinkURIStr string, cfg *config.ReplicaConfig, tableInfos []*model.TableInfo,
+) error {
+	sinkURI, err := url.Parse(sinkURIStr)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	if !psink.IsMQScheme(strings.ToLower(sinkURI.Scheme)) {
+		return nil
+	}
+
+	selector, err := columnselector.New(cfg)
+	if err != nil {
+		return err
+	}
+	// The topic doesn't matter, we only need the partition dispatchers.
+	eventRouter, err := dispatcher.NewEventRouter(cfg, "")
+	if err != nil {
+		return err
+	}
+	if err := eventRouter.VerifyTables(tableInfos); err != nil {
+		return err
+	}
+	return selector.VerifyTables(tableInfos, eventRouter)
+}
+
+// ValidateSyncPoint checks whether the syncpoints can be sent to the sink.
+// The MQ sink sends them as the syncpoint events of the protocol, which are
+// not supported by some protocols or only sent with the TiDB extension.
+func ValidateSyncPoint(sinkURIStr string, cfg *config.ReplicaConfig) error {
+	sinkURI, err := url.Parse(sinkURIStr)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	if !psink.IsMQScheme(strings.ToLower(sinkURI.Scheme)) {
+		return nil
+	}
+
+	protocol, err := mqutil.GetProtocol(cfg.Sink.Protocol)
+	if err != nil {
+		return err
+	}
+	encoderConfig := common.NewConfig(protocol)
+	if err := encoderConfig.Apply(sinkURI, cfg); err != nil {
+		return err
+	}
+	return encoderConfig.ValidateSyncPoint()
+}
+
+// TableDispatch is the target topic and the partition dispatch rule of
+// a table in the MQ sink.
+type TableDispatch struct {
+	Topic         string
+	PartitionRule string
+}
+
+// GetTableDispatches returns the dispatch info of the tables for the MQ sink.
+// It returns nil if the sink is not a MQ sink.
+func GetTableDispatches(
+	sinkURIStr string, cfg *config.ReplicaConfig, tables []model.TableName,
+) (map[model.TableName]TableDispatch, error) {
+	sinkURI, err := url.Parse(sinkURIStr)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	if !psink.IsMQScheme(strings.ToLower(sinkURI.Scheme)) {
+		return nil, nil
+	}
+	topic, err := mqutil.GetTopic(sinkURI)
+	if err != nil {
+		return nil, err
+	}
+	eventRouter, err := dispatcher.NewEventRouter(cfg, topic)
+	if err != nil {
+		return nil, err
+	}
+	dispatches := make(map[model.TableName]TableDispatch, len(tables))
+	for _, table := range tables {
+		tableTopic, rule := eventRouter.GetDispatchInfo(table.Schema, table.Table)
+		dispatches[table] = TableDispatch{Topic: tableTopic, PartitionRule: rule}
+	}
+	return dispatches, nil
+}
+
+// Preflight checks the sink without replicating anything to it, and returns
+// the problems found. The Kafka sink is checked by the admin client, which
+// never creates the topics. The other sinks are connected by the DDL sink and
+// the event sink created by the new sink factories, and the tables must exist
+// in the downstream for the MySQL compatible sink.
+func Preflight(
+	ctx context.Context, sinkURIStr string,
+	cfg *config.ReplicaConfig, tables []model.TableName,
+) []string {
+	var problems []string
+	if err := preCheckSinkURI(sinkURIStr); err != nil {
+		return append(problems, err.Error())
+	}
+	sinkURI, err := url.Parse(sinkURIStr)
+	if err != nil {
+		return append(problems, err.Error())
+	}
+
+	changefeedID := model.DefaultChangeFeedID("sink-verify")
+	ctx = contextutil.PutRoleInCtx(ctx, util.RoleClient)
+	ctx = contextutil.PutChangefeedIDInCtx(ctx, changefeedID)
+
+	if psink.IsKafkaScheme(strings.ToLower(sinkURI.Scheme)) {
+		return preflightKafka(ctx, sinkURI, cfg, tables, pkafka.NewSaramaAdminClient)
+	}
+
+	ddlSink, err := ddlfactory.New(ctx, sinkURIStr, cfg.Clone())
+	if err != nil {
+		problems = append(problems,
+			fmt.Sprintf("failed to connect the DDL sink: %s", err.Error()))
+	} else if err := ddlSink.Close(); err != nil {
+		problems = append(problems,
+			fmt.Sprintf("failed to close the DDL sink: %s", err.Error()))
+	}
+	sinkCtx, cancel := context.WithCancel(ctx)
+	errCh := make(chan error, 1)
+	eventSink, err := factory.New(sinkCtx, sinkURIStr, cfg.Clone(), errCh)
+	// NOTICE: We have to cancel the context before we close it,
+	// otherwise we will write data to closed chan after sink closed.
+	cancel()
+	if err != nil {
+		problems = append(problems,
+			fmt.Sprintf("failed to connect the event sink: %s", err.Error()))
+	} else if err := eventSink.Close(); err != nil {
+		problems = append(problems,
+			fmt.Sprintf("failed to close the event sink: %s", err.Error()))
+	}
+	if len(problems) != 0 {
+		return problems
+	}
+
+	if !psink.IsMySQLCompatibleScheme(strings.ToLower(sinkURI.Scheme)) {
+		return problems
+	}
+	missing, err := getMissingTables(ctx, sinkURI, cfg, changefeedID, tables)
+	if err != nil {
+		return append(problems,
+			fmt.Sprintf("failed to check tables in the downstream: %s", err.Error()))
+	}
+	for _, table := range missing {
+		problems = append(problems, fmt.Sprintf(
+			"table %s does not exist in the downstream, or the sink user "+
+				"has no privilege on it", table.QuoteString()))
+	}
+	return problems
+}
+
+// preflightKafka checks the Kafka sink by the admin client. The topics of the
+// tables must exist if `auto-create-topic` is false, and they must have enough
+// partitions for the `partition-num` in the sink URI.
+func preflightKafka(
+	ctx context.Context, sinkURI *url.URL, cfg *config.ReplicaConfig,
+	tables []model.TableName, adminClientCreator pkafka.ClusterAdminClientCreator,
+) []string {
+	var problems []string
+	topic, err := mqutil.GetTopic(sinkURI)
+	if err != nil {
+		return append(problems, err.Error())
+	}
+	baseConfig := kafka.NewConfig()
+	if err := baseConfig.Apply(sinkURI); err != nil {
+		return append(problems, err.Error())
+	}
+	saramaConfig, err := kafka.NewSaramaConfig(ctx, baseConfig)
+	if err != nil {
+		return append(problems, err.Error())
+	}
+	protocol, err := mqutil.GetProtocol(cfg.Sink.Protocol)
+	if err != nil {
+		return append(problems, err.Error())
+	}
+	if _, err := mqutil.GetEncoderConfig(sinkURI, protocol, cfg,
+		saramaConfig.Producer.MaxMessageBytes); err != nil {
+		problems = append(problems, err.Error())
+	}
+	eventRouter, err := dispatcher.NewEventRouter(cfg, topic)
+	if err != nil {
+		return append(problems, err.Error())
+	}
+
+	adminClient, err := adminClientCreator(baseConfig.BrokerEndpoints, saramaConfig)
+	if err != nil {
+		return append(problems,
+			fmt.Sprintf("failed to connect the Kafka cluster: %s", err.Error()))
+	}
+	defer adminClient.Close()
+	existingTopics, err := adminClient.ListTopics()
+	if err != nil {
+		return append(problems,
+			fmt.Sprintf("failed to list the Kafka topics: %s", err.Error()))
+	}
+
+	// The partition number of the default topic is checked by AdjustConfig.
+	partitionNum := baseConfig.PartitionNum
+	if err := kafka.AdjustConfig(adminClient, baseConfig, saramaConfig, topic); err != nil {
+		problems = append(problems, err.Error())
+	}
+	topics := []string{topic}
+	seen := map[string]struct{}{topic: {}}
+	for _, table := range tables {
+		tableTopic, _ := eventRouter.GetDispatchInfo(table.Schema, table.Table)
+		if _, ok := seen[tableTopic]; !ok {
+			seen[tableTopic] = struct{}{}
+			topics = append(topics, tableTopic)
+		}
+	}
+	for _, t := range topics {
+		detail, ok := existingTopics[t]
+		if !ok {
+			if !baseConfig.AutoCreate {
+				problems = append(problems, fmt.Sprintf(
+					"topic %s does not exist, and auto-create-topic is false", t))
+			}
+			continue
+		}
+		if t != topic && partitionNum > detail.NumPartitions {
+			problems = append(problems, fmt.Sprintf(
+				"the number of partition (%d) specified in sink-uri is more than "+
+					"that of topic %s (%d)", partitionNum, t, detail.NumPartitions))
+		}
+	}
+	return problems
+}
+
+// getMissingTables returns the tables that can not be found in the
+// MySQL compatible downstream.
+func getMissingTables(
+	ctx context.Context, sinkURI *url.URL, cfg *config.ReplicaConfig,
+	changefeedID model.ChangeFeedID, tables []model.TableName,
+) ([]model.TableName, error) {
+	mysqlCfg := pmysql.NewConfig()
+	if err := mysqlCfg.Apply(ctx, changefeedID, sinkURI, cfg); err != nil {
+		return nil, err
+	}
+	dsnStr, err := pmysql.GenerateDSN(ctx, sinkURI, mysqlCfg, pmysql.CreateMySQLDBConn)
+	if err != nil {
+		return nil, err
+	}
+	db, err := pmysql.CreateMySQLDBConn(ctx, dsnStr)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	return pmysql.GetMissingTables(ctx, db, tables)
+}
+
+func isNewSinkOnlyURI(sinkURIStr string) bool {
+	sinkURI, err := url.Parse(sinkURIStr)
+	if err != nil {
+		return false
+	}
+	scheme := strings.ToLower(sinkURI.Scheme)
+	return psink.IsStorageScheme(scheme) || psink.IsWebhookScheme(scheme)
+}
+
+// validateNewSinkOnly creates a sink which is only implemented by the new
+// sink factory and closes it immediately.
+func validateNewSinkOnly(
+	ctx context.Context, sinkURI string,
+	cfg *config.ReplicaConfig, errCh chan error,
+) error {
+	if !config.GetGlobalServerConfig().Debug.EnableNewSink {
+		return cerror.ErrSinkURIInvalid.GenWithStack(
+			"the storage and webhook sinks require the new sink, " +
+				"please enable debug.enable-new-sink")
+	}
+	ctx = contextutil.PutChangefeedIDInCtx(ctx, model.DefaultChangeFeedID("sink-verify"))
+	s, err := factory.New(ctx, sinkURI, cfg, errCh)
+	if err != nil {
+		return err
+	}
+	return s.Close()
+}
+
 // preCheckSinkURI do some pre-check for sink URI.
 // 1. Check if sink URI is empty.
 // 2. Check if we use correct IPv6 format in URI.(if needed)
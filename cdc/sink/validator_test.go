@@ -14,8 +14,13 @@
 package sink
 
 import (
+	"context"
+	"net/url"
 	"testing"
 
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	pkafka "github.com/pingcap/tiflow/pkg/sink/kafka"
 	"github.com/stretchr/testify/require"
 )
 
@@ -87,3 +92,145 @@ func TestPreCheckSinkURI(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTableDispatches(t *testing.T) {
+	t.Parallel()
+
+	tables := []model.TableName{
+		{Schema: "test", Table: "t1"},
+		{Schema: "others", Table: "t2"},
+	}
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Sink.DispatchRules = []*config.DispatchRule{{
+		Matcher:       []string{"test.*"},
+		PartitionRule: "table",
+		TopicRule:     "{schema}_{table}",
+	}}
+
+	// The dispatch info is only available for MQ sinks.
+	dispatches, err := GetTableDispatches("blackhole://", cfg, tables)
+	require.Nil(t, err)
+	require.Nil(t, dispatches)
+
+	dispatches, err = GetTableDispatches("kafka://127.0.0.1:9092/topic1", cfg, tables)
+	require.Nil(t, err)
+	require.Equal(t, map[model.TableName]TableDispatch{
+		tables[0]: {Topic: "test_t1", PartitionRule: "table"},
+		tables[1]: {Topic: "topic1", PartitionRule: "default"},
+	}, dispatches)
+
+	_, err = GetTableDispatches("kafka://127.0.0.1:9092/", cfg, tables)
+	require.Regexp(t, "no topic is specified in sink-uri", err)
+}
+
+func TestValidateSyncPoint(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		sinkURI  string
+		protocol string
+		err      string
+	}{
+		{sinkURI: "blackhole://", protocol: "canal"},
+		{sinkURI: "mysql://127.0.0.1:3306/", protocol: "maxwell"},
+		{sinkURI: "kafka://127.0.0.1:9092/topic", protocol: "open-protocol"},
+		{sinkURI: "kafka://127.0.0.1:9092/topic", protocol: "craft"},
+		{sinkURI: "kafka://127.0.0.1:9092/topic", protocol: "debezium"},
+		{
+			sinkURI: "kafka://127.0.0.1:9092/topic", protocol: "canal",
+			err: "ErrSyncPointNotSupported",
+		},
+		{
+			sinkURI: "kafka://127.0.0.1:9092/topic", protocol: "maxwell",
+			err: "ErrSyncPointNotSupported",
+		},
+		{
+			sinkURI: "kafka://127.0.0.1:9092/topic", protocol: "canal-json",
+			err: "enable-tidb-extension must be true",
+		},
+		{
+			sinkURI:  "kafka://127.0.0.1:9092/topic?enable-tidb-extension=true",
+			protocol: "canal-json",
+		},
+		{
+			sinkURI:  "kafka://127.0.0.1:9092/topic?enable-tidb-extension=true",
+			protocol: "avro",
+			err:      "avro-enable-watermark must be true",
+		},
+		{
+			sinkURI: "kafka://127.0.0.1:9092/topic?" +
+				"enable-tidb-extension=true&avro-enable-watermark=true",
+			protocol: "avro",
+		},
+	}
+	for _, tc := range testCases {
+		cfg := config.GetDefaultReplicaConfig()
+		cfg.Sink.Protocol = tc.protocol
+		err := ValidateSyncPoint(tc.sinkURI, cfg)
+		if tc.err == "" {
+			require.Nil(t, err, tc.sinkURI)
+		} else {
+			require.Regexp(t, tc.err, err, tc.sinkURI)
+		}
+	}
+}
+
+func TestPreflight(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := config.GetDefaultReplicaConfig()
+	tables := []model.TableName{{Schema: "test", Table: "t1"}}
+
+	problems := Preflight(ctx, "blackhole://", cfg, tables)
+	require.Empty(t, problems)
+
+	problems = Preflight(ctx, "", cfg, tables)
+	require.Len(t, problems, 1)
+	require.Contains(t, problems[0], "sink uri is empty")
+
+	problems = Preflight(ctx, "unknown://127.0.0.1:3306/", cfg, tables)
+	require.Len(t, problems, 2)
+	require.Contains(t, problems[0], "failed to connect the DDL sink")
+	require.Contains(t, problems[1], "failed to connect the event sink")
+}
+
+func TestPreflightKafka(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Sink.Protocol = config.ProtocolOpen.String()
+	cfg.Sink.DispatchRules = []*config.DispatchRule{{
+		Matcher:   []string{"test.*"},
+		TopicRule: "{schema}_{table}",
+	}}
+	tables := []model.TableName{
+		{Schema: "test", Table: "t1"},
+		{Schema: "others", Table: "t2"},
+	}
+
+	// The missing topics are created by the changefeed.
+	sinkURI, err := url.Parse("kafka://127.0.0.1:9092/" +
+		pkafka.DefaultMockTopicName + "?partition-num=2")
+	require.Nil(t, err)
+	problems := preflightKafka(ctx, sinkURI, cfg, tables, pkafka.NewMockAdminClient)
+	require.Empty(t, problems)
+
+	// The missing topics are reported if they can't be created.
+	sinkURI, err = url.Parse("kafka://127.0.0.1:9092/" +
+		pkafka.DefaultMockTopicName + "?partition-num=2&auto-create-topic=false")
+	require.Nil(t, err)
+	problems = preflightKafka(ctx, sinkURI, cfg, tables, pkafka.NewMockAdminClient)
+	require.Equal(t, []string{
+		"topic test_t1 does not exist, and auto-create-topic is false",
+	}, problems)
+
+	// The default topic doesn't have enough partitions.
+	sinkURI, err = url.Parse("kafka://127.0.0.1:9092/" +
+		pkafka.DefaultMockTopicName + "?partition-num=4")
+	require.Nil(t, err)
+	problems = preflightKafka(ctx, sinkURI, cfg, tables, pkafka.NewMockAdminClient)
+	require.Len(t, problems, 1)
+	require.Contains(t, problems[0], "specified in sink-uri is more than that of actual topic")
+}
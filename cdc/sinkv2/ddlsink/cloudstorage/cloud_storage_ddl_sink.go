@@ -0,0 +1,140 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink"
+	"github.com/pingcap/tiflow/cdc/sinkv2/metrics"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"go.uber.org/zap"
+)
+
+// Assert DDLEventSink implementation
+var _ ddlsink.DDLEventSink = (*ddlSink)(nil)
+
+// ddlSink writes a schema file for each DDL and
+// records the checkpoint ts in the metadata file,
+// a syncpoint file is written for each syncpoint.
+type ddlSink struct {
+	// id indicates this sink belongs to which processor(changefeed).
+	id         model.ChangeFeedID
+	storage    storage.ExternalStorage
+	statistics *metrics.Statistics
+}
+
+// NewCloudStorageDDLSink creates a DDL sink for cloud storage.
+func NewCloudStorageDDLSink(
+	ctx context.Context,
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+) (*ddlSink, error) {
+	cfg := cloudstorage.NewConfig()
+	if err := cfg.Apply(sinkURI, replicaConfig); err != nil {
+		return nil, errors.Trace(err)
+	}
+	extStorage, err := cloudstorage.GetExternalStorage(ctx, sinkURI)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return &ddlSink{
+		id:         contextutil.ChangefeedIDFromCtx(ctx),
+		storage:    extStorage,
+		statistics: metrics.NewStatistics(ctx, sink.RowSink),
+	}, nil
+}
+
+// WriteDDLEvent writes the definition of the table changed by the DDL.
+func (d *ddlSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
+	// Schema level DDLs don't change the definition of any table.
+	if ddl.TableInfo == nil || ddl.TableInfo.Table == "" {
+		log.Info("Cloud storage sink skips DDL",
+			zap.String("namespace", d.id.Namespace),
+			zap.String("changefeed", d.id.ID),
+			zap.String("query", ddl.Query))
+		return nil
+	}
+
+	return d.statistics.RecordDDLExecution(func() error {
+		data, err := json.Marshal(cloudstorage.NewTableDef(ddl))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		path := cloudstorage.SchemaFilePath(ddl.TableInfo.Schema, ddl.TableInfo.Table, ddl.CommitTs)
+		if err := d.storage.WriteFile(ctx, path, data); err != nil {
+			return cerror.WrapError(cerror.ErrStorageSinkAPI, err)
+		}
+		return nil
+	})
+}
+
+// WriteCheckpointTs writes the checkpoint ts to the metadata file.
+func (d *ddlSink) WriteCheckpointTs(ctx context.Context,
+	ts uint64, tables []model.TableName,
+) error {
+	data, err := json.Marshal(cloudstorage.Metadata{CheckpointTs: ts})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := d.storage.WriteFile(ctx, cloudstorage.MetadataFileName, data); err != nil {
+		return cerror.WrapError(cerror.ErrStorageSinkAPI, err)
+	}
+	return nil
+}
+
+// WriteSyncPoint writes the syncpoint file, which records the syncpoint ts and
+// the tables replicated at that time.
+func (d *ddlSink) WriteSyncPoint(ctx context.Context,
+	ts uint64, tables []model.TableName,
+) error {
+	syncPoint := cloudstorage.SyncPoint{
+		SyncPointTs: ts,
+		Tables:      make([]cloudstorage.SyncPointTable, 0, len(tables)),
+	}
+	for _, table := range tables {
+		syncPoint.Tables = append(syncPoint.Tables, cloudstorage.SyncPointTable{
+			Schema: table.Schema,
+			Table:  table.Table,
+		})
+	}
+	data, err := json.Marshal(syncPoint)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := d.storage.WriteFile(ctx, cloudstorage.SyncPointFilePath(ts), data); err != nil {
+		return cerror.WrapError(cerror.ErrStorageSinkAPI, err)
+	}
+	log.Info("Cloud storage sink writes syncpoint",
+		zap.String("namespace", d.id.Namespace),
+		zap.String("changefeed", d.id.ID),
+		zap.Uint64("syncPointTs", ts))
+	return nil
+}
+
+// Close closes the sink.
+func (d *ddlSink) Close() error {
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudStorageWriteDDLEvent(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	parentDir := t.TempDir()
+	sinkURI, err := url.Parse(fmt.Sprintf("file://%s?protocol=canal-json", parentDir))
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	s, err := NewCloudStorageDDLSink(ctx, sinkURI, replicaConfig)
+	require.Nil(t, err)
+
+	ddl := &model.DDLEvent{
+		CommitTs: 100,
+		Query:    "alter table test.t1 add column name varchar(32)",
+		TableInfo: &model.SimpleTableInfo{
+			Schema: "test",
+			Table:  "t1",
+			ColumnInfo: []*model.ColumnInfo{
+				{Name: "id", Type: mysql.TypeLong},
+				{Name: "name", Type: mysql.TypeVarchar},
+			},
+		},
+	}
+	require.Nil(t, s.WriteDDLEvent(ctx, ddl))
+	data, err := os.ReadFile(filepath.Join(parentDir, "test", "t1", "schema_100.json"))
+	require.Nil(t, err)
+	def := cloudstorage.TableDef{}
+	require.Nil(t, json.Unmarshal(data, &def))
+	require.Equal(t, cloudstorage.NewTableDef(ddl), def)
+
+	// Schema level DDLs are skipped.
+	require.Nil(t, s.WriteDDLEvent(ctx, &model.DDLEvent{
+		CommitTs:  101,
+		Query:     "create database test2",
+		TableInfo: &model.SimpleTableInfo{Schema: "test2"},
+	}))
+	_, err = os.Stat(filepath.Join(parentDir, "test2"))
+	require.True(t, os.IsNotExist(err))
+
+	require.Nil(t, s.WriteCheckpointTs(ctx, 200, nil))
+	data, err = os.ReadFile(filepath.Join(parentDir, cloudstorage.MetadataFileName))
+	require.Nil(t, err)
+	require.JSONEq(t, `{"checkpoint-ts":200}`, string(data))
+
+	require.Nil(t, s.WriteSyncPoint(ctx, 200, []model.TableName{{Schema: "test", Table: "t1"}}))
+	data, err = os.ReadFile(filepath.Join(parentDir, cloudstorage.SyncPointFilePath(200)))
+	require.Nil(t, err)
+	require.JSONEq(t, `{"syncpoint-ts":200,"tables":[{"schema":"test","table":"t1"}]}`, string(data))
+
+	require.Nil(t, s.Close())
+}
@@ -28,6 +28,10 @@ type DDLEventSink interface {
 	// Note: This is a synchronous and thread-safe method.
 	// This only for MQSink for now.
 	WriteCheckpointTs(ctx context.Context, ts uint64, tables []model.TableName) error
+	// WriteSyncPoint writes a syncpoint to the sink, all the events committed
+	// before ts have been written to the sink when it's called.
+	// Note: This is a synchronous and thread-safe method.
+	WriteSyncPoint(ctx context.Context, ts uint64, tables []model.TableName) error
 	// Close closes the sink.
 	Close() error
 }
@@ -20,9 +20,11 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/mq/producer/kafka"
 	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink"
 	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/blackhole"
+	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/cloudstorage"
 	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/mq"
 	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/mq/ddlproducer"
 	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/mysql"
+	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/webhook"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink"
@@ -46,6 +48,10 @@ func New(
 			kafka.NewAdminClientImpl, ddlproducer.NewKafkaDDLProducer)
 	case sink.BlackHoleSchema:
 		return blackhole.New(), nil
+	case sink.FileSchema, sink.S3Schema:
+		return cloudstorage.NewCloudStorageDDLSink(ctx, sinkURI, cfg)
+	case sink.HTTPSchema, sink.HTTPSSchema:
+		return webhook.NewWebhookDDLSink(ctx, sinkURI, cfg)
 	case sink.MySQLSSLSchema, sink.MySQLSchema, sink.TiDBSchema, sink.TiDBSSLSchema:
 		return mysql.NewMySQLDDLSink(ctx, sinkURI, cfg, pmysql.CreateMySQLDBConn)
 	default:
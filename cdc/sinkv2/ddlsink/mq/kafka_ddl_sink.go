@@ -48,6 +48,10 @@ func NewKafkaDDLSink(
 	if err := baseConfig.Apply(sinkURI); err != nil {
 		return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
 	}
+	if baseConfig.ExactlyOnce {
+		return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"exactly-once is not supported by the new sink yet")
+	}
 	saramaConfig, err := kafka.NewSaramaConfig(ctx, baseConfig)
 	if err != nil {
 		return nil, errors.Trace(err)
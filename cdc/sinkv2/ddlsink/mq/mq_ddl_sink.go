@@ -137,8 +137,39 @@ func (k *ddlSink) WriteCheckpointTs(ctx context.Context,
 	if msg == nil {
 		return nil
 	}
+	return k.broadcastToTopics(ctx, msg, tables)
+}
+
+// WriteSyncPoint broadcasts the syncpoint marker to all the partitions of the
+// topics of the tables, the consumer of each partition can take a consistent
+// snapshot once the marker is received.
+func (k *ddlSink) WriteSyncPoint(ctx context.Context,
+	ts uint64, tables []model.TableName,
+) error {
+	encoder := k.encoderBuilder.Build()
+	msg, err := encoder.EncodeSyncPointEvent(ts)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The changefeed can't enable the syncpoint with the protocols which
+	// don't support it, the changefeeds created before the check skip it.
+	if msg == nil {
+		return nil
+	}
+	log.Info("Emit syncpoint",
+		zap.Uint64("syncPointTs", ts),
+		zap.String("namespace", k.id.Namespace),
+		zap.String("changefeed", k.id.ID))
+	return k.broadcastToTopics(ctx, msg, tables)
+}
+
+// broadcastToTopics sends the message to all partitions of the topics which
+// the tables are dispatched to.
+func (k *ddlSink) broadcastToTopics(ctx context.Context,
+	msg *common.Message, tables []model.TableName,
+) error {
 	// NOTICE: When there are no tables to replicate,
-	// we need to send checkpoint ts to the default topic.
+	// we need to send the message to the default topic.
 	// This will be compatible with the old behavior.
 	if len(tables) == 0 {
 		topic := k.eventRouter.GetDefaultTopic()
@@ -146,8 +177,8 @@ func (k *ddlSink) WriteCheckpointTs(ctx context.Context,
 		if err != nil {
 			return errors.Trace(err)
 		}
-		log.Debug("Emit checkpointTs to default topic",
-			zap.String("topic", topic), zap.Uint64("checkpointTs", ts))
+		log.Debug("Emit message to default topic",
+			zap.String("topic", topic), zap.Uint64("ts", msg.Ts))
 		err = k.producer.SyncBroadcastMessage(ctx, topic, partitionNum, msg)
 		return errors.Trace(err)
 	}
@@ -301,3 +301,44 @@ func TestWriteCheckpointTsWhenCanalJsonTiDBExtensionIsDisable(t *testing.T) {
 	require.Len(t, s.producer.(*ddlproducer.MockDDLProducer).GetAllEvents(),
 		0, "No topic and partition should be broadcast")
 }
+
+func TestWriteSyncPoint(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leader, topic := initBroker(t, kafka.DefaultMockPartitionNum)
+	defer leader.Close()
+	uriTemplate := "kafka://%s/%s?kafka-version=0.9.0.0&max-batch-size=1" +
+		"&max-message-bytes=1048576&partition-num=1" +
+		"&kafka-client-id=unit-test&auto-create-topic=false&compression=gzip" +
+		"&protocol=open-protocol"
+	uri := fmt.Sprintf(uriTemplate, leader.Addr(), topic)
+
+	sinkURI, err := url.Parse(uri)
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	s, err := NewKafkaDDLSink(ctx, sinkURI, replicaConfig,
+		kafka.NewMockAdminClient, ddlproducer.NewMockDDLProducer)
+	require.Nil(t, err)
+	require.NotNil(t, s)
+
+	syncPointTs := uint64(417318403368288260)
+	err = s.WriteSyncPoint(ctx, syncPointTs, nil)
+	require.Nil(t, err)
+
+	producer := s.producer.(*ddlproducer.MockDDLProducer)
+	require.Len(t, producer.GetAllEvents(), 3, "All partitions should be broadcast")
+	for partition := int32(0); partition < 3; partition++ {
+		events := producer.GetEvents(mqv1.TopicPartitionKey{
+			Topic:     "mock_topic",
+			Partition: partition,
+		})
+		require.Len(t, events, 1)
+		require.Equal(t, model.MessageTypeSyncPoint, events[0].Type)
+		require.Equal(t, syncPointTs, events[0].Ts)
+	}
+}
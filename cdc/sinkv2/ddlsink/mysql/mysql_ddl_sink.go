@@ -17,6 +17,7 @@ import (
 	"context"
 	"database/sql"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -39,6 +40,11 @@ import (
 
 const (
 	defaultDDLMaxRetry uint64 = 20
+
+	// syncPointSchema and syncPointTable are where the syncpoints are
+	// recorded, they are the same as the ones used by the old sink.
+	syncPointSchema = "tidb_cdc"
+	syncPointTable  = "syncpoint_v1"
 )
 
 // Assert DDLEventSink implementation
@@ -52,6 +58,12 @@ type mysqlDDLSink struct {
 	// statistics is the statistics of this sink.
 	// We use it to record the DDL count.
 	statistics *metrics.Statistics
+
+	syncPointMu struct {
+		sync.Mutex
+		// tableCreated indicates whether the syncpoint table is created.
+		tableCreated bool
+	}
 }
 
 // NewMySQLDDLSink creates a new mysqlDDLSink.
@@ -196,6 +208,64 @@ func (m *mysqlDDLSink) WriteCheckpointTs(_ context.Context, _ uint64, _ []model.
 	return nil
 }
 
+// WriteSyncPoint records the syncpoint with the current ts of the downstream
+// in the syncpoint table.
+func (m *mysqlDDLSink) WriteSyncPoint(
+	ctx context.Context, ts uint64, _ []model.TableName,
+) error {
+	m.syncPointMu.Lock()
+	defer m.syncPointMu.Unlock()
+	if !m.syncPointMu.tableCreated {
+		if err := m.createSyncPointTable(ctx); err != nil {
+			return err
+		}
+		m.syncPointMu.tableCreated = true
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, err)
+	}
+	var secondaryTs string
+	err = tx.QueryRowContext(ctx, "select @@tidb_current_ts").Scan(&secondaryTs)
+	if err == nil {
+		_, err = tx.ExecContext(ctx, "insert ignore into "+syncPointSchema+"."+syncPointTable+
+			" (cf, primary_ts, secondary_ts) VALUES (?,?,?)",
+			m.id.Namespace+"_"+m.id.ID, ts, secondaryTs)
+	}
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			log.Error("Failed to rollback", zap.String("namespace", m.id.Namespace),
+				zap.String("changefeed", m.id.ID), zap.Error(rbErr))
+		}
+		return cerror.WrapError(cerror.ErrMySQLTxnError, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, err)
+	}
+	log.Info("Write syncpoint succeeded",
+		zap.String("namespace", m.id.Namespace),
+		zap.String("changefeed", m.id.ID),
+		zap.Uint64("primaryTs", ts),
+		zap.String("secondaryTs", secondaryTs))
+	return nil
+}
+
+func (m *mysqlDDLSink) createSyncPointTable(ctx context.Context) error {
+	queries := []string{
+		"CREATE DATABASE IF NOT EXISTS " + syncPointSchema,
+		"CREATE TABLE IF NOT EXISTS " + syncPointSchema + "." + syncPointTable +
+			" (cf varchar(255),primary_ts varchar(18),secondary_ts varchar(18)," +
+			"PRIMARY KEY ( `cf`, `primary_ts` ) )",
+	}
+	for _, query := range queries {
+		if _, err := m.db.ExecContext(ctx, query); err != nil {
+			return cerror.WrapError(cerror.ErrMySQLTxnError, err)
+		}
+	}
+	return nil
+}
+
 // Close closes the database connection.
 func (m *mysqlDDLSink) Close() error {
 	if err := m.db.Close(); err != nil {
@@ -16,6 +16,7 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"net/url"
 	"testing"
 
@@ -93,6 +94,61 @@ func TestWriteDDLEvent(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestWriteSyncPoint(t *testing.T) {
+	t.Parallel()
+
+	dbIndex := 0
+	var mock sqlmock.Sqlmock
+	mockGetDBConn := func(ctx context.Context, dsnStr string) (*sql.DB, error) {
+		defer func() {
+			dbIndex++
+		}()
+		if dbIndex == 0 {
+			// test db
+			db, err := pmysql.MockTestDB(true)
+			require.Nil(t, err)
+			return db, nil
+		}
+		// normal db
+		db, m, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.Nil(t, err)
+		mock = m
+		return db, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx = contextutil.PutChangefeedIDInCtx(ctx, model.DefaultChangeFeedID("test-changefeed"))
+	sinkURI, err := url.Parse("mysql://127.0.0.1:4000")
+	require.Nil(t, err)
+	sink, err := NewMySQLDDLSink(ctx, sinkURI, config.GetDefaultReplicaConfig(), mockGetDBConn)
+	require.Nil(t, err)
+
+	insert := "insert ignore into tidb_cdc.syncpoint_v1 (cf, primary_ts, secondary_ts) VALUES (?,?,?)"
+	// The syncpoint table is only created once.
+	mock.ExpectExec("CREATE DATABASE IF NOT EXISTS tidb_cdc").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS tidb_cdc.syncpoint_v1 " +
+		"(cf varchar(255),primary_ts varchar(18),secondary_ts varchar(18)," +
+		"PRIMARY KEY ( `cf`, `primary_ts` ) )").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	for _, ts := range []uint64{100, 200} {
+		mock.ExpectBegin()
+		mock.ExpectQuery("select @@tidb_current_ts").
+			WillReturnRows(sqlmock.NewRows([]string{"@@tidb_current_ts"}).AddRow(ts + 1))
+		mock.ExpectExec(insert).
+			WithArgs("default_test-changefeed", ts, fmt.Sprint(ts+1)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+	}
+	mock.ExpectClose()
+
+	require.Nil(t, sink.WriteSyncPoint(ctx, 100, nil))
+	require.Nil(t, sink.WriteSyncPoint(ctx, 200, nil))
+	require.Nil(t, sink.Close())
+	require.Nil(t, mock.ExpectationsWereMet())
+}
+
 func TestNeedSwitchDB(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,126 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/builder"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sinkv2/ddlsink"
+	"github.com/pingcap/tiflow/cdc/sinkv2/metrics"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/webhook"
+)
+
+// Assert DDLEventSink implementation
+var _ ddlsink.DDLEventSink = (*ddlSink)(nil)
+
+// ddlSink posts the encoded DDLs, checkpoints and syncpoints to the HTTP endpoint.
+type ddlSink struct {
+	client     *webhook.Client
+	encoder    codec.EventBatchEncoder
+	statistics *metrics.Statistics
+}
+
+// NewWebhookDDLSink creates a DDL sink for webhook.
+func NewWebhookDDLSink(
+	ctx context.Context,
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+) (*ddlSink, error) {
+	cfg := webhook.NewConfig()
+	if err := cfg.Apply(sinkURI, replicaConfig); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	encoderConfig := common.NewConfig(cfg.Protocol)
+	if err := encoderConfig.Apply(sinkURI, replicaConfig); err != nil {
+		return nil, cerror.WrapError(cerror.ErrWebhookSinkInvalidConfig, err)
+	}
+	if err := encoderConfig.Validate(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrWebhookSinkInvalidConfig, err)
+	}
+	encoderBuilder, err := builder.NewEventBatchEncoderBuilder(ctx, encoderConfig)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrWebhookSinkInvalidConfig, err)
+	}
+
+	client, err := webhook.NewClient(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return &ddlSink{
+		client:     client,
+		encoder:    encoderBuilder.Build(),
+		statistics: metrics.NewStatistics(ctx, sink.RowSink),
+	}, nil
+}
+
+// WriteDDLEvent posts the DDL to the endpoint.
+func (d *ddlSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
+	return d.statistics.RecordDDLExecution(func() error {
+		msg, err := d.encoder.EncodeDDLEvent(ddl)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if msg == nil {
+			return nil
+		}
+		return d.client.Send(ctx, model.MessageTypeDDL, []*common.Message{msg})
+	})
+}
+
+// WriteCheckpointTs posts the checkpoint ts to the endpoint.
+func (d *ddlSink) WriteCheckpointTs(ctx context.Context,
+	ts uint64, tables []model.TableName,
+) error {
+	msg, err := d.encoder.EncodeCheckpointEvent(ts)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Some protocols don't send the checkpoints, e.g. canal-json
+	// without the TiDB extension.
+	if msg == nil {
+		return nil
+	}
+	return d.client.Send(ctx, model.MessageTypeResolved, []*common.Message{msg})
+}
+
+// WriteSyncPoint posts the syncpoint to the endpoint.
+func (d *ddlSink) WriteSyncPoint(ctx context.Context,
+	ts uint64, tables []model.TableName,
+) error {
+	msg, err := d.encoder.EncodeSyncPointEvent(ts)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if msg == nil {
+		return nil
+	}
+	return d.client.Send(ctx, model.MessageTypeSyncPoint, []*common.Message{msg})
+}
+
+// Close closes the sink.
+func (d *ddlSink) Close() error {
+	d.client.Close()
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/webhook"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookWriteDDLEvent(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type request struct {
+		messageType string
+		body        map[string]interface{}
+	}
+	var (
+		mu       sync.Mutex
+		requests []request
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.Nil(t, err)
+		body := make(map[string]interface{})
+		require.Nil(t, json.Unmarshal(data, &body))
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, request{
+			messageType: r.Header.Get(webhook.MessageTypeHeader),
+			body:        body,
+		})
+	}))
+	defer server.Close()
+
+	sinkURI, err := url.Parse(server.URL + "?protocol=canal-json&enable-tidb-extension=true")
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	s, err := NewWebhookDDLSink(ctx, sinkURI, replicaConfig)
+	require.Nil(t, err)
+
+	ddl := &model.DDLEvent{
+		CommitTs: 100,
+		Query:    "alter table test.t1 add column name varchar(32)",
+		Type:     timodel.ActionAddColumn,
+		TableInfo: &model.SimpleTableInfo{
+			Schema: "test",
+			Table:  "t1",
+			ColumnInfo: []*model.ColumnInfo{
+				{Name: "id", Type: mysql.TypeLong},
+				{Name: "name", Type: mysql.TypeVarchar},
+			},
+		},
+	}
+	require.Nil(t, s.WriteDDLEvent(ctx, ddl))
+	require.Nil(t, s.WriteCheckpointTs(ctx, 200, nil))
+	require.Nil(t, s.WriteSyncPoint(ctx, 200, nil))
+
+	mu.Lock()
+	require.Len(t, requests, 3)
+	require.Equal(t, "ddl", requests[0].messageType)
+	require.Equal(t, ddl.Query, requests[0].body["sql"])
+	require.Equal(t, "resolved", requests[1].messageType)
+	require.Equal(t, "syncpoint", requests[2].messageType)
+	require.Equal(t, "TIDB_SYNCPOINT", requests[2].body["type"])
+	mu.Unlock()
+
+	require.Nil(t, s.Close())
+}
@@ -0,0 +1,126 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"context"
+	"net/url"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/builder"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
+	"github.com/pingcap/tiflow/cdc/sinkv2/metrics"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"go.uber.org/zap"
+)
+
+// Assert EventSink[E event.TableEvent] implementation
+var _ eventsink.EventSink[*model.RowChangedEvent] = (*dmlSink)(nil)
+
+// dmlSink is the cloud storage sink.
+// It writes the rows of each table into data files in the external storage,
+// such as the local file system and S3.
+type dmlSink struct {
+	// id indicates this sink belongs to which processor(changefeed).
+	id model.ChangeFeedID
+	// worker flushes the rows to the external storage.
+	worker *dmlWorker
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewCloudStorageSink creates a cloud storage sink.
+func NewCloudStorageSink(
+	ctx context.Context,
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+	errCh chan error,
+) (*dmlSink, error) {
+	changefeedID := contextutil.ChangefeedIDFromCtx(ctx)
+
+	cfg := cloudstorage.NewConfig()
+	if err := cfg.Apply(sinkURI, replicaConfig); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	encoderConfig := common.NewConfig(cfg.Protocol)
+	if err := encoderConfig.Apply(sinkURI, replicaConfig); err != nil {
+		return nil, cerror.WrapError(cerror.ErrStorageSinkInvalidConfig, err)
+	}
+	if err := encoderConfig.Validate(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrStorageSinkInvalidConfig, err)
+	}
+	encoderBuilder, err := builder.NewEventBatchEncoderBuilder(ctx, encoderConfig)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrStorageSinkInvalidConfig, err)
+	}
+
+	extStorage, err := cloudstorage.GetExternalStorage(ctx, sinkURI)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	statistics := metrics.NewStatistics(ctx, sink.RowSink)
+	ctx, cancel := context.WithCancel(ctx)
+	s := &dmlSink{
+		id:     changefeedID,
+		worker: newDMLWorker(changefeedID, cfg, extStorage, encoderBuilder.Build(), statistics),
+		cancel: cancel,
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.worker.run(ctx); err != nil && errors.Cause(err) != context.Canceled {
+			select {
+			case <-ctx.Done():
+				return
+			case errCh <- err:
+			default:
+				log.Error("Error channel is full in cloud storage sink", zap.Error(err),
+					zap.String("namespace", changefeedID.Namespace),
+					zap.String("changefeed", changefeedID.ID))
+			}
+		}
+	}()
+
+	return s, nil
+}
+
+// WriteEvents writes events to the sink.
+// This is an asynchronously and thread-safe method.
+func (s *dmlSink) WriteEvents(rows ...*eventsink.RowChangeCallbackableEvent) error {
+	for _, row := range rows {
+		// This never be blocked because this is an unbounded channel.
+		s.worker.msgChan.In() <- row
+	}
+	return nil
+}
+
+// Close closes the sink.
+func (s *dmlSink) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	s.worker.close()
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
+	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink/state"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func newTestEvents(
+	commitTs uint64, count int, tableStatus *state.TableSinkState, acked *int64,
+) []*eventsink.RowChangeCallbackableEvent {
+	events := make([]*eventsink.RowChangeCallbackableEvent, 0, count)
+	for i := 0; i < count; i++ {
+		row := &model.RowChangedEvent{
+			CommitTs: commitTs,
+			Table:    &model.TableName{Schema: "test", Table: "t1"},
+			Columns: []*model.Column{
+				{Name: "id", Type: mysql.TypeLong, Value: int64(i)},
+				{Name: "name", Type: mysql.TypeVarchar, Value: fmt.Sprintf("name%d", i)},
+			},
+		}
+		events = append(events, &eventsink.RowChangeCallbackableEvent{
+			Event: row,
+			Callback: func() {
+				atomic.AddInt64(acked, 1)
+			},
+			SinkState: tableStatus,
+		})
+	}
+	return events
+}
+
+func TestCloudStorageWriteEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	parentDir := t.TempDir()
+	uri := fmt.Sprintf("file://%s?flush-interval=500ms&file-size=1024&protocol=csv", parentDir)
+	sinkURI, err := url.Parse(uri)
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	errCh := make(chan error, 1)
+
+	s, err := NewCloudStorageSink(ctx, sinkURI, replicaConfig, errCh)
+	require.Nil(t, err)
+
+	var acked int64
+	tableStatus := state.TableSinkSinking
+	commitTs := oracle.GoTimeToTS(time.Date(2022, 9, 1, 10, 35, 12, 0, time.UTC))
+	err = s.WriteEvents(newTestEvents(commitTs, 100, &tableStatus, &acked)...)
+	require.Nil(t, err)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&acked) == 100
+	}, 5*time.Second, 100*time.Millisecond)
+	require.Len(t, errCh, 0)
+
+	tableDir := filepath.Join(parentDir, "test", "t1", "2022-09-01-10-00")
+	data, err := os.ReadFile(filepath.Join(tableDir, "manifest.json"))
+	require.Nil(t, err)
+	manifest := &cloudstorage.Manifest{}
+	require.Nil(t, json.Unmarshal(data, manifest))
+	require.Equal(t, "csv", manifest.Protocol)
+	// The rows are split into files because of the small file size.
+	require.Greater(t, len(manifest.Files), 1)
+
+	rows := 0
+	for _, file := range manifest.Files {
+		require.Equal(t, commitTs, file.MinCommitTs)
+		require.Equal(t, commitTs, file.MaxCommitTs)
+		data, err := os.ReadFile(filepath.Join(tableDir, file.Name))
+		require.Nil(t, err)
+		require.LessOrEqual(t, len(data), 1024)
+		lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+		require.Len(t, lines, file.Rows)
+		rows += file.Rows
+	}
+	require.Equal(t, 100, rows)
+	require.Equal(t, fmt.Sprintf(`"I","t1","test",%d,0,"name0"`, commitTs),
+		firstLine(t, filepath.Join(tableDir, "CDC000001.csv")))
+
+	// Rows of the stopping table are skipped.
+	tableStatus.Store(state.TableSinkStopping)
+	err = s.WriteEvents(newTestEvents(commitTs, 10, &tableStatus, &acked)...)
+	require.Nil(t, err)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&acked) == 110
+	}, 5*time.Second, 100*time.Millisecond)
+	data, err = os.ReadFile(filepath.Join(tableDir, "manifest.json"))
+	require.Nil(t, err)
+	newManifest := &cloudstorage.Manifest{}
+	require.Nil(t, json.Unmarshal(data, newManifest))
+	require.Equal(t, manifest, newManifest)
+
+	require.Nil(t, s.Close())
+}
+
+func firstLine(t *testing.T, path string) string {
+	data, err := os.ReadFile(path)
+	require.Nil(t, err)
+	return strings.SplitN(string(data), "\n", 2)[0]
+}
@@ -0,0 +1,309 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
+	"github.com/pingcap/tiflow/cdc/sinkv2/metrics"
+	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink/state"
+	"github.com/pingcap/tiflow/pkg/chann"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"go.uber.org/zap"
+)
+
+// lineTerminator separates the encoded rows in a data file.
+var lineTerminator = []byte("\n")
+
+// tableWindowKey identifies the files of a table in a window.
+// Partitions of a table share the same files, so the table ID is not used.
+type tableWindowKey struct {
+	schema string
+	table  string
+	window string
+}
+
+// dataFile is a data file to be written to the external storage.
+type dataFile struct {
+	info   cloudstorage.FileInfo
+	buf    bytes.Buffer
+	events []*eventsink.RowChangeCallbackableEvent
+}
+
+// dmlWorker flushes the rows to the external storage periodically.
+type dmlWorker struct {
+	// changeFeedID indicates this sink belongs to which processor(changefeed).
+	changeFeedID model.ChangeFeedID
+	// msgChan caches the rows to be written.
+	// It is an unbounded channel.
+	msgChan *chann.Chann[*eventsink.RowChangeCallbackableEvent]
+	// ticker used to flush the rows when the interval is reached.
+	ticker  *time.Ticker
+	config  *cloudstorage.Config
+	storage storage.ExternalStorage
+	// encoder is used to encode the rows.
+	encoder codec.EventBatchEncoder
+	// manifests caches the manifest of the current window of each table.
+	manifests map[tableWindowKey]*cloudstorage.Manifest
+	// statistics is used to record DML metrics.
+	statistics *metrics.Statistics
+}
+
+func newDMLWorker(
+	id model.ChangeFeedID,
+	cfg *cloudstorage.Config,
+	extStorage storage.ExternalStorage,
+	encoder codec.EventBatchEncoder,
+	statistics *metrics.Statistics,
+) *dmlWorker {
+	return &dmlWorker{
+		changeFeedID: id,
+		msgChan:      chann.New[*eventsink.RowChangeCallbackableEvent](),
+		ticker:       time.NewTicker(cfg.FlushInterval),
+		config:       cfg,
+		storage:      extStorage,
+		encoder:      encoder,
+		manifests:    make(map[tableWindowKey]*cloudstorage.Manifest),
+		statistics:   statistics,
+	}
+}
+
+// run collects the rows and flushes them to the external storage
+// until it encounters an error or is interrupted.
+func (w *dmlWorker) run(ctx context.Context) (retErr error) {
+	defer func() {
+		w.ticker.Stop()
+		log.Info("Cloud storage sink worker exited", zap.Error(retErr),
+			zap.String("namespace", w.changeFeedID.Namespace),
+			zap.String("changefeed", w.changeFeedID.ID))
+	}()
+	log.Info("Cloud storage sink worker started",
+		zap.String("namespace", w.changeFeedID.Namespace),
+		zap.String("changefeed", w.changeFeedID.ID))
+
+	var events []*eventsink.RowChangeCallbackableEvent
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case event, ok := <-w.msgChan.Out():
+			if !ok {
+				log.Warn("Cloud storage sink worker channel closed")
+				return nil
+			}
+			events = append(events, event)
+		case <-w.ticker.C:
+			if len(events) == 0 {
+				continue
+			}
+			if err := w.flush(ctx, events); err != nil {
+				return errors.Trace(err)
+			}
+			events = nil
+		}
+	}
+}
+
+// flush writes the rows to the data files, then updates the manifests.
+// The callbacks are called after the manifests are written, so a row may be
+// written more than once if the sink is restarted, but it is never lost.
+func (w *dmlWorker) flush(
+	ctx context.Context,
+	events []*eventsink.RowChangeCallbackableEvent,
+) error {
+	groups := make(map[tableWindowKey][]*eventsink.RowChangeCallbackableEvent)
+	// keys keeps the order of the groups to make the output deterministic.
+	var keys []tableWindowKey
+	for _, event := range events {
+		// Skip this event when the table is stopping.
+		if event.GetTableSinkState() == state.TableSinkStopping {
+			event.Callback()
+			log.Debug("Skip event of stopped table", zap.Any("event", event))
+			continue
+		}
+		key := tableWindowKey{
+			schema: event.Event.Table.Schema,
+			table:  event.Event.Table.Table,
+			window: cloudstorage.GetWindow(event.Event.CommitTs, w.config.WindowDuration),
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], event)
+	}
+
+	for _, key := range keys {
+		if err := w.flushTableWindow(ctx, key, groups[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *dmlWorker) flushTableWindow(
+	ctx context.Context,
+	key tableWindowKey,
+	events []*eventsink.RowChangeCallbackableEvent,
+) error {
+	manifest, err := w.getManifest(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	files, err := w.encode(ctx, events, len(manifest.Files))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		path := cloudstorage.DataFilePath(key.schema, key.table, key.window, file.info.Name)
+		err := w.statistics.RecordBatchExecution(func() (int, error) {
+			if err := w.storage.WriteFile(ctx, path, file.buf.Bytes()); err != nil {
+				return 0, cerror.WrapError(cerror.ErrStorageSinkAPI, err)
+			}
+			return file.info.Rows, nil
+		})
+		if err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, file.info)
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	manifestPath := cloudstorage.ManifestFilePath(key.schema, key.table, key.window)
+	if err := w.storage.WriteFile(ctx, manifestPath, data); err != nil {
+		return cerror.WrapError(cerror.ErrStorageSinkAPI, err)
+	}
+
+	for _, file := range files {
+		for _, event := range file.events {
+			event.Callback()
+		}
+	}
+	return nil
+}
+
+// encode encodes the rows into data files, a new file is started
+// once the size of the current one exceeds the configured file size.
+func (w *dmlWorker) encode(
+	ctx context.Context,
+	events []*eventsink.RowChangeCallbackableEvent,
+	fileCount int,
+) ([]*dataFile, error) {
+	for _, event := range events {
+		err := w.encoder.AppendRowChangedEvent(ctx, "", event.Event, nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		w.statistics.ObserveRows(event.Event)
+	}
+	w.statistics.AddRowsCount(len(events))
+
+	msgs := w.encoder.Build()
+	// Both csv and canal-json encode one row into one message.
+	if len(msgs) != len(events) {
+		return nil, cerror.ErrStorageSinkAPI.GenWithStack(
+			"the number of encoded messages %d doesn't match the number of rows %d",
+			len(msgs), len(events))
+	}
+
+	var files []*dataFile
+	var current *dataFile
+	for i, msg := range msgs {
+		if current == nil || current.buf.Len()+len(msg.Value)+len(lineTerminator) > w.config.FileSize {
+			fileCount++
+			current = &dataFile{
+				info: cloudstorage.FileInfo{
+					Name: cloudstorage.DataFileName(fileCount, w.config.Protocol),
+				},
+			}
+			files = append(files, current)
+		}
+		commitTs := events[i].Event.CommitTs
+		if current.info.Rows == 0 || commitTs < current.info.MinCommitTs {
+			current.info.MinCommitTs = commitTs
+		}
+		if commitTs > current.info.MaxCommitTs {
+			current.info.MaxCommitTs = commitTs
+		}
+		current.info.Rows++
+		current.buf.Write(msg.Value)
+		current.buf.Write(lineTerminator)
+		current.events = append(current.events, events[i])
+	}
+	return files, nil
+}
+
+// getManifest returns the manifest of the table in the window.
+// The manifest written before the sink is restarted is loaded,
+// so the index of the data files continues increasing.
+func (w *dmlWorker) getManifest(
+	ctx context.Context, key tableWindowKey,
+) (*cloudstorage.Manifest, error) {
+	if manifest, ok := w.manifests[key]; ok {
+		return manifest, nil
+	}
+
+	// Only the manifest of the latest window of the table is kept.
+	for k := range w.manifests {
+		if k.schema == key.schema && k.table == key.table {
+			delete(w.manifests, k)
+		}
+	}
+
+	manifest := &cloudstorage.Manifest{
+		Schema:   key.schema,
+		Table:    key.table,
+		Window:   key.window,
+		Protocol: w.config.Protocol.String(),
+	}
+	path := cloudstorage.ManifestFilePath(key.schema, key.table, key.window)
+	exists, err := w.storage.FileExists(ctx, path)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrStorageSinkAPI, err)
+	}
+	if exists {
+		data, err := w.storage.ReadFile(ctx, path)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrStorageSinkAPI, err)
+		}
+		if err := json.Unmarshal(data, manifest); err != nil {
+			return nil, cerror.WrapError(cerror.ErrStorageSinkAPI, err)
+		}
+	}
+	w.manifests[key] = manifest
+	return manifest, nil
+}
+
+func (w *dmlWorker) close() {
+	w.msgChan.Close()
+	// We must finish consuming the data here,
+	// otherwise it will cause the channel to not close properly.
+	for range w.msgChan.Out() {
+		// Do nothing. We do not care about the data.
+	}
+}
@@ -20,8 +20,11 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/blackhole"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/cloudstorage"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/mq"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/mq/dmlproducer"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/txn"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/webhook"
 	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
@@ -63,6 +66,35 @@ func New(ctx context.Context,
 		}
 		s.rowSink = mqs
 		s.sinkType = sink.RowSink
+	case sink.PulsarSchema, sink.PulsarSSLSchema:
+		mqs, err := mq.NewPulsarDMLSink(ctx, sinkURI, cfg, errCh,
+			dmlproducer.NewPulsarDMLProducer)
+		if err != nil {
+			return nil, err
+		}
+		s.rowSink = mqs
+		s.sinkType = sink.RowSink
+	case sink.MySQLSchema, sink.MySQLSSLSchema, sink.TiDBSchema, sink.TiDBSSLSchema:
+		txnSink, err := txn.NewMySQLSink(ctx, sinkURI, cfg, errCh, txn.DefaultConflictDetectorSlots)
+		if err != nil {
+			return nil, err
+		}
+		s.txnSink = txnSink
+		s.sinkType = sink.TxnSink
+	case sink.FileSchema, sink.S3Schema:
+		storageSink, err := cloudstorage.NewCloudStorageSink(ctx, sinkURI, cfg, errCh)
+		if err != nil {
+			return nil, err
+		}
+		s.rowSink = storageSink
+		s.sinkType = sink.RowSink
+	case sink.HTTPSchema, sink.HTTPSSchema:
+		webhookSink, err := webhook.NewWebhookSink(ctx, sinkURI, cfg, errCh)
+		if err != nil {
+			return nil, err
+		}
+		s.rowSink = webhookSink
+		s.sinkType = sink.RowSink
 	case sink.BlackHoleSchema:
 		bs := blackhole.New()
 		s.rowSink = bs
@@ -71,6 +71,15 @@ func newForTest(ctx context.Context,
 		}
 		s.rowSink = mqs
 		s.sinkType = sink.RowSink
+	case "pulsar", "pulsar+ssl":
+		mqs, err := mq.NewPulsarDMLSink(ctx, sinkURI, cfg, errCh,
+			// Use mock pulsar producer for test.
+			dmlproducer.NewPulsarDMLMockProducer)
+		if err != nil {
+			return nil, err
+		}
+		s.rowSink = mqs
+		s.sinkType = sink.RowSink
 	default:
 		return nil,
 			cerror.ErrSinkURIInvalid.GenWithStack("the sink scheme (%s) is not supported", schema)
@@ -109,3 +118,75 @@ func TestSinkFactory(t *testing.T) {
 	err = sinkFactory.Close()
 	require.Nil(t, err, "sink factory can be closed")
 }
+
+func TestPulsarSinkFactory(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	uri := "pulsar://127.0.0.1:6650/pulsar-test?protocol=canal-json"
+	sinkURI, err := url.Parse(uri)
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	errCh := make(chan error, 1)
+
+	sinkFactory, err := newForTest(ctx, uri, replicaConfig, errCh)
+	require.NotNil(t, sinkFactory)
+	require.Nil(t, err)
+	require.Equal(t, sink.RowSink, sinkFactory.sinkType)
+	require.NotNil(t, sinkFactory.rowSink)
+
+	tableSink := sinkFactory.CreateTableSink(1)
+	require.NotNil(t, tableSink, "table sink can be created")
+
+	err = sinkFactory.Close()
+	require.Nil(t, err, "sink factory can be closed")
+}
+
+func TestCloudStorageSinkFactory(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	uri := fmt.Sprintf("file://%s?protocol=csv", t.TempDir())
+	replicaConfig := config.GetDefaultReplicaConfig()
+	errCh := make(chan error, 1)
+
+	sinkFactory, err := New(ctx, uri, replicaConfig, errCh)
+	require.NotNil(t, sinkFactory)
+	require.Nil(t, err)
+	require.Equal(t, sink.RowSink, sinkFactory.sinkType)
+	require.NotNil(t, sinkFactory.rowSink)
+
+	tableSink := sinkFactory.CreateTableSink(1)
+	require.NotNil(t, tableSink, "table sink can be created")
+
+	err = sinkFactory.Close()
+	require.Nil(t, err, "sink factory can be closed")
+}
+
+func TestWebhookSinkFactory(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	uri := "http://127.0.0.1:8080/events?protocol=canal-json"
+	replicaConfig := config.GetDefaultReplicaConfig()
+	errCh := make(chan error, 1)
+
+	sinkFactory, err := New(ctx, uri, replicaConfig, errCh)
+	require.NotNil(t, sinkFactory)
+	require.Nil(t, err)
+	require.Equal(t, sink.RowSink, sinkFactory.sinkType)
+	require.NotNil(t, sinkFactory.rowSink)
+
+	tableSink := sinkFactory.CreateTableSink(1)
+	require.NotNil(t, tableSink, "table sink can be created")
+
+	err = sinkFactory.Close()
+	require.Nil(t, err, "sink factory can be closed")
+}
@@ -0,0 +1,36 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlproducer
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	mqv1 "github.com/pingcap/tiflow/cdc/sink/mq"
+	"github.com/pingcap/tiflow/cdc/sink/mq/manager"
+)
+
+// DefaultMockPulsarPartitionNum is the partition number of the topics
+// reported by the topic manager of the mock pulsar producer.
+const DefaultMockPulsarPartitionNum = 4
+
+// NewPulsarDMLMockProducer creates a mock producer for pulsar.
+func NewPulsarDMLMockProducer(_ context.Context, _ *url.URL,
+	_ chan error,
+) (DMLProducer, manager.TopicManager, error) {
+	return &MockDMLProducer{
+		events: make(map[mqv1.TopicPartitionKey][]*common.Message),
+	}, manager.NewPulsarTopicManager(DefaultMockPulsarPartitionNum), nil
+}
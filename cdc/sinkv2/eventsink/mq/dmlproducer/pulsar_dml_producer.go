@@ -0,0 +1,209 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlproducer
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/contextutil"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sink/mq/manager"
+	pulsarv1 "github.com/pingcap/tiflow/cdc/sink/mq/producer/pulsar"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var _ DMLProducer = (*pulsarDMLProducer)(nil)
+
+// PulsarFactory is a function to create a pulsar producer.
+// Pulsar doesn't support querying the partition number by an admin client,
+// so the factory also returns a topic manager backed by the pulsar client.
+// errCh is used to report error to the caller(i.e. processor,owner).
+type PulsarFactory func(ctx context.Context, sinkURI *url.URL,
+	errCh chan error) (DMLProducer, manager.TopicManager, error)
+
+// pulsarDMLProducer is used to send messages to pulsar.
+type pulsarDMLProducer struct {
+	// id indicates which processor (changefeed) this sink belongs to.
+	id model.ChangeFeedID
+	// client is the pulsar client used by the producers.
+	client pulsar.Client
+	// producerOptions is used to create the producers of the topics.
+	producerOptions pulsar.ProducerOptions
+	// producersMu is used to protect `producers`.
+	producersMu sync.Mutex
+	// producers are used to send messages to pulsar asynchronously.
+	// A pulsar producer is bound to a topic, so we create one for each topic.
+	// It's set to nil after the producers are closed.
+	producers map[string]pulsar.Producer
+	// errCh is used to report the send error.
+	errCh chan error
+	// closedMu is used to protect `closed`.
+	// We need to ensure that closed producers are never written to.
+	closedMu sync.RWMutex
+	// closed is used to indicate whether the producer is closed.
+	// We also use it to guard against double closes.
+	closed bool
+}
+
+// NewPulsarDMLProducer creates a new pulsar producer.
+func NewPulsarDMLProducer(
+	ctx context.Context,
+	sinkURI *url.URL,
+	errCh chan error,
+) (DMLProducer, manager.TopicManager, error) {
+	changefeedID := contextutil.ChangefeedIDFromCtx(ctx)
+	log.Info("Starting pulsar DML producer ...",
+		zap.String("namespace", changefeedID.Namespace),
+		zap.String("changefeed", changefeedID.ID))
+
+	client, producerOptions, err := pulsarv1.NewClient(sinkURI)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	return &pulsarDMLProducer{
+		id:              changefeedID,
+		client:          client,
+		producerOptions: producerOptions,
+		producers:       make(map[string]pulsar.Producer),
+		errCh:           errCh,
+		closed:          false,
+	}, manager.NewPulsarClientTopicManager(client), nil
+}
+
+// getProducer returns the producer of the topic, and creates it if necessary.
+// Creating a producer is a network call, so it's done without holding any
+// lock, and a slow topic doesn't block the other topics or closing. If the
+// producer of the topic is created concurrently, the loser is closed.
+func (p *pulsarDMLProducer) getProducer(topic string) (pulsar.Producer, error) {
+	p.producersMu.Lock()
+	producer, ok := p.producers[topic]
+	p.producersMu.Unlock()
+	if ok {
+		return producer, nil
+	}
+
+	options := p.producerOptions
+	options.Topic = topic
+	producer, err := p.client.CreateProducer(options)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+
+	p.producersMu.Lock()
+	// The producers are cleared once they are closed by Close.
+	closed := p.producers == nil
+	existing, ok := p.producers[topic]
+	if !closed && !ok {
+		p.producers[topic] = producer
+	}
+	p.producersMu.Unlock()
+	if closed {
+		producer.Close()
+		return nil, cerror.ErrPulsarProducerClosed.GenWithStackByArgs()
+	}
+	if ok {
+		producer.Close()
+		return existing, nil
+	}
+	log.Info("Pulsar DML producer created for the topic",
+		zap.String("namespace", p.id.Namespace),
+		zap.String("changefeed", p.id.ID),
+		zap.String("topic", topic))
+	return producer, nil
+}
+
+func (p *pulsarDMLProducer) AsyncSendMessage(
+	ctx context.Context, topic string,
+	partition int32, message *common.Message,
+) error {
+	producer, err := p.getProducer(topic)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// We have to hold the lock to avoid writing to a closed producer.
+	p.closedMu.RLock()
+	defer p.closedMu.RUnlock()
+
+	// If the producer is closed, we should skip the message and return an error.
+	if p.closed {
+		return cerror.ErrPulsarProducerClosed.GenWithStackByArgs()
+	}
+
+	producer.SendAsync(ctx, pulsarv1.NewProducerMessage(message, partition),
+		func(_ pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
+			if err != nil {
+				p.reportError(ctx, cerror.WrapError(cerror.ErrPulsarSendMessage, err))
+				return
+			}
+			if message.Callback != nil {
+				message.Callback()
+			}
+		})
+	return nil
+}
+
+func (p *pulsarDMLProducer) reportError(ctx context.Context, err error) {
+	select {
+	case <-ctx.Done():
+	case p.errCh <- err:
+		log.Error("Pulsar DML producer send error", zap.Error(err),
+			zap.String("namespace", p.id.Namespace),
+			zap.String("changefeed", p.id.ID))
+	default:
+		log.Error("Error channel is full in pulsar DML producer", zap.Error(err),
+			zap.String("namespace", p.id.Namespace),
+			zap.String("changefeed", p.id.ID))
+	}
+}
+
+func (p *pulsarDMLProducer) Close() {
+	// We have to hold the lock to synchronize closing with writing.
+	p.closedMu.Lock()
+	defer p.closedMu.Unlock()
+	// If the producer has already been closed, we should skip this close operation.
+	if p.closed {
+		log.Warn("Pulsar DML producer already closed",
+			zap.String("namespace", p.id.Namespace),
+			zap.String("changefeed", p.id.ID))
+		return
+	}
+	p.closed = true
+	// Same as the kafka DML producer, close it asynchronously to avoid
+	// getting stuck with an unhealthy pulsar cluster.
+	go func() {
+		start := time.Now()
+		p.producersMu.Lock()
+		producers := p.producers
+		p.producers = nil
+		p.producersMu.Unlock()
+		for _, producer := range producers {
+			producer.Close()
+		}
+		p.client.Close()
+		log.Info("Pulsar DML producer closed",
+			zap.Duration("duration", time.Since(start)),
+			zap.String("namespace", p.id.Namespace),
+			zap.String("changefeed", p.id.ID))
+	}()
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/codec/builder"
 	"github.com/pingcap/tiflow/cdc/sink/codec/common"
 	mqv1 "github.com/pingcap/tiflow/cdc/sink/mq"
+	"github.com/pingcap/tiflow/cdc/sink/mq/columnselector"
 	"github.com/pingcap/tiflow/cdc/sink/mq/dispatcher"
 	"github.com/pingcap/tiflow/cdc/sink/mq/manager"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
@@ -49,6 +50,8 @@ type dmlSink struct {
 	worker *worker
 	// eventRouter used to route events to the right topic and partition.
 	eventRouter *dispatcher.EventRouter
+	// columnSelector used to select the columns to be sent.
+	columnSelector *columnselector.ColumnSelector
 	// topicManager used to manage topics.
 	// It is also responsible for creating topics.
 	topicManager manager.TopicManager
@@ -61,6 +64,7 @@ func newSink(ctx context.Context,
 	producer dmlproducer.DMLProducer,
 	topicManager manager.TopicManager,
 	eventRouter *dispatcher.EventRouter,
+	columnSelector *columnselector.ColumnSelector,
 	encoderConfig *common.Config,
 	errCh chan error,
 ) (*dmlSink, error) {
@@ -80,6 +84,7 @@ func newSink(ctx context.Context,
 		protocol:       encoderConfig.Protocol,
 		worker:         w,
 		eventRouter:    eventRouter,
+		columnSelector: columnSelector,
 		topicManager:   topicManager,
 		encoderBuilder: encoderBuilder,
 	}
@@ -112,6 +117,9 @@ func (s *dmlSink) WriteEvents(rows ...*eventsink.RowChangeCallbackableEvent) err
 			return errors.Trace(err)
 		}
 		partition := s.eventRouter.GetPartitionForRowChange(row.Event, partitionNum)
+		// The columns are selected after dispatching,
+		// so that the dispatchers can see all the columns.
+		row.Event = s.columnSelector.Apply(row.Event)
 		// This never be blocked because this is an unbounded channel.
 		s.worker.msgChan.In() <- mqEvent{
 			key: mqv1.TopicPartitionKey{
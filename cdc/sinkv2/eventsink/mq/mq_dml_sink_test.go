@@ -22,6 +22,7 @@ import (
 
 	"github.com/Shopify/sarama"
 	"github.com/pingcap/tiflow/cdc/model"
+	mqv1 "github.com/pingcap/tiflow/cdc/sink/mq"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/mq/dmlproducer"
 	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink/state"
@@ -98,3 +99,63 @@ func TestWriteEvents(t *testing.T) {
 	err = s.Close()
 	require.Nil(t, err)
 }
+
+func TestPulsarWriteEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	uri := "pulsar://127.0.0.1:6650/pulsar-test?protocol=canal-json"
+	sinkURI, err := url.Parse(uri)
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.DispatchRules = []*config.DispatchRule{
+		{Matcher: []string{"a.b"}, PartitionRule: "ts"},
+		{Matcher: []string{"a.c"}, PartitionRule: "ts", TopicRule: "{schema}_{table}"},
+	}
+	require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	errCh := make(chan error, 1)
+
+	s, err := NewPulsarDMLSink(ctx, sinkURI, replicaConfig, errCh,
+		dmlproducer.NewPulsarDMLMockProducer)
+	require.Nil(t, err)
+	require.NotNil(t, s)
+
+	tableStatus := state.TableSinkSinking
+	events := make([]*eventsink.RowChangeCallbackableEvent, 0, 16)
+	for i := 0; i < 16; i++ {
+		table := "b"
+		if i >= 8 {
+			table = "c"
+		}
+		events = append(events, &eventsink.RowChangeCallbackableEvent{
+			Event: &model.RowChangedEvent{
+				CommitTs: uint64(i),
+				Table:    &model.TableName{Schema: "a", Table: table},
+				Columns:  []*model.Column{{Name: "col1", Type: 1, Value: "aa"}},
+			},
+			Callback:  func() {},
+			SinkState: &tableStatus,
+		})
+	}
+
+	err = s.WriteEvents(events...)
+	// Wait for the events to be received by the worker.
+	time.Sleep(time.Second)
+	require.Nil(t, err)
+	require.Len(t, errCh, 0)
+	producer := s.worker.producer.(*dmlproducer.MockDMLProducer)
+	require.Len(t, producer.GetAllEvents(), 16)
+	// The events are dispatched to the topics by the topic rules,
+	// and to the partitions by commit ts.
+	for _, topic := range []string{"pulsar-test", "a_c"} {
+		for i := int32(0); i < dmlproducer.DefaultMockPulsarPartitionNum; i++ {
+			require.Len(t, producer.GetEvents(mqv1.TopicPartitionKey{
+				Topic: topic, Partition: i,
+			}), 2)
+		}
+	}
+	err = s.Close()
+	require.Nil(t, err)
+}
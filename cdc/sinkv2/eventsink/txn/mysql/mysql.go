@@ -70,24 +70,69 @@ func NewMySQLBackend(
 	dbConnFactory pmysql.Factory,
 ) (*mysqlBackend, error) {
 	changefeedID := contextutil.ChangefeedIDFromCtx(ctx)
+	cfg, db, err := openDB(ctx, changefeedID, sinkURI, replicaConfig, dbConnFactory)
+	if err != nil {
+		return nil, err
+	}
+	return newMySQLBackend(ctx, changefeedID, cfg, db), nil
+}
+
+// NewMySQLBackends creates a group of MySQL backends with a shared DB connection pool.
+// The number of backends is equal to the worker count in the sink URI.
+// NOTICE: Closing any of the backends closes the shared DB, so the caller
+// must ensure that all the backends are stopped before closing them.
+func NewMySQLBackends(
+	ctx context.Context,
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+	dbConnFactory pmysql.Factory,
+) ([]*mysqlBackend, error) {
+	changefeedID := contextutil.ChangefeedIDFromCtx(ctx)
+	cfg, db, err := openDB(ctx, changefeedID, sinkURI, replicaConfig, dbConnFactory)
+	if err != nil {
+		return nil, err
+	}
+
+	backends := make([]*mysqlBackend, 0, cfg.WorkerCount)
+	for i := 0; i < cfg.WorkerCount; i++ {
+		backends = append(backends, newMySQLBackend(ctx, changefeedID, cfg, db))
+	}
+	return backends, nil
+}
+
+func openDB(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+	dbConnFactory pmysql.Factory,
+) (*pmysql.Config, *sql.DB, error) {
 	cfg := pmysql.NewConfig()
 	err := cfg.Apply(ctx, changefeedID, sinkURI, replicaConfig)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	dsnStr, err := pmysql.GenerateDSN(ctx, sinkURI, cfg, dbConnFactory)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	db, err := dbConnFactory(ctx, dsnStr)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	db.SetMaxIdleConns(cfg.WorkerCount)
 	db.SetMaxOpenConns(cfg.WorkerCount)
+	return cfg, db, nil
+}
 
+func newMySQLBackend(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	cfg *pmysql.Config,
+	db *sql.DB,
+) *mysqlBackend {
 	ctx, cancel := context.WithCancel(ctx)
 	s := &mysqlBackend{
 		changefeedID: changefeedID,
@@ -102,7 +147,7 @@ func NewMySQLBackend(
 		zap.String("changefeedID", fmt.Sprintf("%s.%s", changefeedID.Namespace, changefeedID.ID)),
 		zap.Bool("forceReplicate", s.cfg.ForceReplicate),
 		zap.Bool("enableOldValue", s.cfg.EnableOldValue))
-	return s, nil
+	return s
 }
 
 // OnTxnEvent implements interface backend.
@@ -689,6 +689,47 @@ func TestNewMySQLBackend(t *testing.T) {
 	require.Nil(t, sink.Close())
 }
 
+func TestNewMySQLBackends(t *testing.T) {
+	dbIndex := 0
+	mockGetDBConn := func(ctx context.Context, dsnStr string) (*sql.DB, error) {
+		defer func() { dbIndex++ }()
+
+		if dbIndex == 0 {
+			// test db
+			db, err := pmysql.MockTestDB(true)
+			require.Nil(t, err)
+			return db, nil
+		}
+
+		// normal db
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		mock.ExpectClose()
+		require.Nil(t, err)
+		return db, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changefeed := "test-changefeed"
+	contextutil.PutChangefeedIDInCtx(ctx, model.DefaultChangeFeedID(changefeed))
+	sinkURI, err := url.Parse("mysql://127.0.0.1:4000/?time-zone=UTC&worker-count=4&safe-mode=false")
+	require.Nil(t, err)
+	backends, err := NewMySQLBackends(ctx, sinkURI,
+		config.GetDefaultReplicaConfig(), mockGetDBConn)
+	require.Nil(t, err)
+	require.Len(t, backends, 4)
+	// Only one test db and one normal db should be opened.
+	require.Equal(t, 2, dbIndex)
+	for _, backend := range backends {
+		require.Equal(t, backends[0].db, backend.db)
+		require.False(t, backend.cfg.SafeMode)
+	}
+	for _, backend := range backends {
+		require.Nil(t, backend.Close())
+	}
+}
+
 func TestNewMySQLBackendWithIPv6Address(t *testing.T) {
 	dbIndex := 0
 	mockGetDBConn := func(ctx context.Context, dsnStr string) (*sql.DB, error) {
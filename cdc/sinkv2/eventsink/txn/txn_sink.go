@@ -14,13 +14,23 @@
 package txn
 
 import (
+	"context"
+	"net/url"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/txn/mysql"
 	"github.com/pingcap/tiflow/pkg/causality"
+	"github.com/pingcap/tiflow/pkg/config"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+	"go.uber.org/zap"
 )
 
 const (
-	defaultConflictDetectorSlots int64 = 1024 * 1024
+	// DefaultConflictDetectorSlots indicates the default slot count of conflict detector.
+	DefaultConflictDetectorSlots int64 = 1024 * 1024
 )
 
 // Assert EventSink[E event.TableEvent] implementation
@@ -32,7 +42,29 @@ type sink struct {
 	workers          []*worker
 }
 
-func newSink(backends []backend, errCh chan<- error, conflictDetectorSlots int64) sink {
+// NewMySQLSink creates a mysql sink with given parameters.
+// It creates a backend for each worker, the number of workers is
+// controlled by the `worker-count` parameter in the sink URI.
+func NewMySQLSink(
+	ctx context.Context,
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+	errCh chan<- error,
+	conflictDetectorSlots int64,
+) (*sink, error) {
+	backendImpls, err := mysql.NewMySQLBackends(ctx, sinkURI, replicaConfig, pmysql.CreateMySQLDBConn)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	backends := make([]backend, 0, len(backendImpls))
+	for _, impl := range backendImpls {
+		backends = append(backends, impl)
+	}
+	return newSink(backends, errCh, conflictDetectorSlots), nil
+}
+
+func newSink(backends []backend, errCh chan<- error, conflictDetectorSlots int64) *sink {
 	workers := make([]*worker, 0, len(backends))
 	for i, backend := range backends {
 		w := newWorker(i, backend, errCh)
@@ -40,7 +72,7 @@ func newSink(backends []backend, errCh chan<- error, conflictDetectorSlots int64
 		workers = append(workers, w)
 	}
 	detector := causality.NewConflictDetector[*worker, *txnEvent](workers, conflictDetectorSlots)
-	return sink{conflictDetector: detector, workers: workers}
+	return &sink{conflictDetector: detector, workers: workers}
 }
 
 // WriteEvents writes events to the sink.
@@ -60,5 +92,13 @@ func (s *sink) Close() error {
 	for _, w := range s.workers {
 		w.Close()
 	}
+	// Backends may share resources (e.g. the DB connection pool),
+	// so only close them after all workers are stopped.
+	for _, w := range s.workers {
+		if err := w.backend.Close(); err != nil {
+			log.Info("transaction sink backend close fail",
+				zap.Int("workerID", w.ID), zap.Error(err))
+		}
+	}
 	return nil
 }
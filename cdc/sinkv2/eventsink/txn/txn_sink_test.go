@@ -65,7 +65,7 @@ func TestTxnSink(t *testing.T) {
 		bes = append(bes, &blackhole{block: int32(1), n: notify.Notifier{}})
 	}
 	errCh := make(chan error, 1)
-	sink := newSink(bes, errCh, defaultConflictDetectorSlots)
+	sink := newSink(bes, errCh, DefaultConflictDetectorSlots)
 
 	// Test `WriteEvents` shouldn't be blocked by slow workers.
 	var handled uint32 = 0
@@ -47,6 +47,7 @@ func newWorker(ID int, backend backend, errCh chan<- error) *worker {
 		txnCh:   chann.New[txnWithNotifier](chann.Cap(-1 /*unbounded*/)),
 		stopped: make(chan struct{}),
 		backend: backend,
+		errCh:   errCh,
 	}
 }
 
@@ -65,12 +66,6 @@ func (w *worker) runBackgroundLoop() {
 	w.wg.Add(1)
 	go func() {
 		defer w.wg.Done()
-		defer func() {
-			if err := w.backend.Close(); err != nil {
-				log.Info("transaction sink backend close fail",
-					zap.Error(err))
-			}
-		}()
 		w.timer = time.NewTimer(w.backend.MaxFlushInterval())
 		for {
 			select {
@@ -0,0 +1,155 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
+	"github.com/pingcap/tiflow/cdc/sinkv2/metrics"
+	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink/state"
+	"github.com/pingcap/tiflow/pkg/chann"
+	"github.com/pingcap/tiflow/pkg/sink/webhook"
+	"go.uber.org/zap"
+)
+
+// dmlWorker sends the rows to the endpoint once the batch is full
+// or the flush interval is reached.
+type dmlWorker struct {
+	// changeFeedID indicates this sink belongs to which processor(changefeed).
+	changeFeedID model.ChangeFeedID
+	// msgChan caches the rows to be sent.
+	// It is an unbounded channel.
+	msgChan *chann.Chann[*eventsink.RowChangeCallbackableEvent]
+	// ticker used to flush the rows when the interval is reached.
+	ticker *time.Ticker
+	config *webhook.Config
+	client *webhook.Client
+	// encoder is used to encode the rows.
+	encoder codec.EventBatchEncoder
+	// statistics is used to record DML metrics.
+	statistics *metrics.Statistics
+}
+
+func newDMLWorker(
+	id model.ChangeFeedID,
+	cfg *webhook.Config,
+	client *webhook.Client,
+	encoder codec.EventBatchEncoder,
+	statistics *metrics.Statistics,
+) *dmlWorker {
+	return &dmlWorker{
+		changeFeedID: id,
+		msgChan:      chann.New[*eventsink.RowChangeCallbackableEvent](),
+		ticker:       time.NewTicker(cfg.FlushInterval),
+		config:       cfg,
+		client:       client,
+		encoder:      encoder,
+		statistics:   statistics,
+	}
+}
+
+// run collects the rows and sends them to the endpoint
+// until it encounters an error or is interrupted.
+func (w *dmlWorker) run(ctx context.Context) (retErr error) {
+	defer func() {
+		w.ticker.Stop()
+		log.Info("Webhook sink worker exited", zap.Error(retErr),
+			zap.String("namespace", w.changeFeedID.Namespace),
+			zap.String("changefeed", w.changeFeedID.ID))
+	}()
+	log.Info("Webhook sink worker started",
+		zap.String("namespace", w.changeFeedID.Namespace),
+		zap.String("changefeed", w.changeFeedID.ID))
+
+	events := make([]*eventsink.RowChangeCallbackableEvent, 0, w.config.BatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case event, ok := <-w.msgChan.Out():
+			if !ok {
+				log.Warn("Webhook sink worker channel closed")
+				return nil
+			}
+			// Skip this event when the table is stopping.
+			if event.GetTableSinkState() == state.TableSinkStopping {
+				event.Callback()
+				log.Debug("Skip event of stopped table", zap.Any("event", event))
+				continue
+			}
+			events = append(events, event)
+			if len(events) < w.config.BatchSize {
+				continue
+			}
+		case <-w.ticker.C:
+			if len(events) == 0 {
+				continue
+			}
+		}
+		if err := w.flush(ctx, events); err != nil {
+			return errors.Trace(err)
+		}
+		events = events[:0]
+	}
+}
+
+// flush sends the rows in one request. The callbacks are called after the
+// endpoint accepts the request, so the checkpoint of the table sink never
+// passes the rows which are not delivered. A row may be delivered more than
+// once if the sink is restarted, but it is never lost.
+func (w *dmlWorker) flush(
+	ctx context.Context,
+	events []*eventsink.RowChangeCallbackableEvent,
+) error {
+	for _, event := range events {
+		err := w.encoder.AppendRowChangedEvent(ctx, "", event.Event, nil)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		w.statistics.ObserveRows(event.Event)
+	}
+	w.statistics.AddRowsCount(len(events))
+	msgs := w.encoder.Build()
+
+	err := w.statistics.RecordBatchExecution(func() (int, error) {
+		if err := w.client.Send(ctx, model.MessageTypeRow, msgs); err != nil {
+			return 0, err
+		}
+		return len(events), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		event.Callback()
+	}
+	return nil
+}
+
+func (w *dmlWorker) close() {
+	w.msgChan.Close()
+	// We must finish consuming the data here,
+	// otherwise it will cause the channel to not close properly.
+	for range w.msgChan.Out() {
+		// Do nothing. We do not care about the data.
+	}
+	w.client.Close()
+}
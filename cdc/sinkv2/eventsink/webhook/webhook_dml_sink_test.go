@@ -0,0 +1,163 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink"
+	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink/state"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEvents(
+	count int, tableStatus *state.TableSinkState, acked *int64,
+) []*eventsink.RowChangeCallbackableEvent {
+	events := make([]*eventsink.RowChangeCallbackableEvent, 0, count)
+	for i := 0; i < count; i++ {
+		row := &model.RowChangedEvent{
+			CommitTs: 100,
+			Table:    &model.TableName{Schema: "test", Table: "t1"},
+			Columns: []*model.Column{
+				{Name: "id", Type: mysql.TypeLong, Value: int64(i)},
+				{Name: "name", Type: mysql.TypeVarchar, Value: fmt.Sprintf("name%d", i)},
+			},
+		}
+		events = append(events, &eventsink.RowChangeCallbackableEvent{
+			Event: row,
+			Callback: func() {
+				atomic.AddInt64(acked, 1)
+			},
+			SinkState: tableStatus,
+		})
+	}
+	return events
+}
+
+func newTestSink(
+	ctx context.Context, t *testing.T, uri string, errCh chan error,
+) *dmlSink {
+	sinkURI, err := url.Parse(uri)
+	require.Nil(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.Nil(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	s, err := NewWebhookSink(ctx, sinkURI, replicaConfig, errCh)
+	require.Nil(t, err)
+	return s
+}
+
+func TestWebhookWriteEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		batches [][]string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/events", r.URL.Path)
+		require.Equal(t, "abc", r.URL.Query().Get("token"))
+		body, err := io.ReadAll(r.Body)
+		require.Nil(t, err)
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, strings.Split(strings.TrimSuffix(string(body), "\n"), "\n"))
+	}))
+	defer server.Close()
+
+	uri := server.URL + "/events?token=abc&protocol=canal-json&batch-size=30&flush-interval=100ms"
+	errCh := make(chan error, 1)
+	s := newTestSink(ctx, t, uri, errCh)
+
+	var acked int64
+	tableStatus := state.TableSinkSinking
+	err := s.WriteEvents(newTestEvents(100, &tableStatus, &acked)...)
+	require.Nil(t, err)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&acked) == 100
+	}, 5*time.Second, 100*time.Millisecond)
+	require.Len(t, errCh, 0)
+
+	mu.Lock()
+	rows := 0
+	for _, batch := range batches {
+		require.LessOrEqual(t, len(batch), 30)
+		rows += len(batch)
+	}
+	require.Equal(t, 100, rows)
+	require.Contains(t, batches[0][0], `"name":"name0"`)
+	requests := len(batches)
+	mu.Unlock()
+
+	// Rows of the stopping table are skipped.
+	tableStatus.Store(state.TableSinkStopping)
+	err = s.WriteEvents(newTestEvents(10, &tableStatus, &acked)...)
+	require.Nil(t, err)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&acked) == 110
+	}, 5*time.Second, 100*time.Millisecond)
+	mu.Lock()
+	require.Len(t, batches, requests)
+	mu.Unlock()
+
+	require.Nil(t, s.Close())
+}
+
+func TestWebhookWriteEventsFailed(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	uri := server.URL + "?protocol=canal-json&flush-interval=100ms"
+	errCh := make(chan error, 1)
+	s := newTestSink(ctx, t, uri, errCh)
+
+	var acked int64
+	tableStatus := state.TableSinkSinking
+	err := s.WriteEvents(newTestEvents(10, &tableStatus, &acked)...)
+	require.Nil(t, err)
+	select {
+	case err := <-errCh:
+		require.True(t, cerror.ErrWebhookSinkAPI.Equal(err))
+	case <-time.After(5 * time.Second):
+		t.Fatal("the error is not reported")
+	}
+	// The rows are not acknowledged, so the checkpoint doesn't advance.
+	require.Equal(t, int64(0), atomic.LoadInt64(&acked))
+
+	require.Nil(t, s.Close())
+}
@@ -0,0 +1,628 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verification
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/quotes"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultChunkSize is the default max number of rows of a chunk.
+	defaultChunkSize = 10000
+	// syncpointTable is the table where the mysql sink records the syncpoint
+	// maps, it's cf, primary_ts and secondary_ts.
+	syncpointTable = "tidb_cdc.syncpoint_v1"
+)
+
+// DiffConfig is the config of the row-level data verification.
+type DiffConfig struct {
+	Filter filter.Filter
+	// ChunkSize is the max number of rows of a chunk, defaults to defaultChunkSize.
+	ChunkSize int
+	// SyncPointTs is the primary ts of a syncpoint. If it's not 0, the upstream
+	// is read at the ts, and the downstream is read at the secondary ts recorded
+	// in the syncpoint table by the changefeed.
+	SyncPointTs  uint64
+	ChangefeedID model.ChangeFeedID
+}
+
+// TableDiff is the data verification result of a table.
+type TableDiff struct {
+	Schema           string `json:"schema"`
+	Table            string `json:"table"`
+	Chunks           int    `json:"chunks"`
+	MismatchedChunks int    `json:"mismatched_chunks"`
+	// MissingRows are the rows that only exist in the upstream.
+	MissingRows int `json:"missing_rows"`
+	// ExtraRows are the rows that only exist in the downstream.
+	ExtraRows int `json:"extra_rows"`
+	// DifferentRows are the rows with the same key but different values.
+	DifferentRows int `json:"different_rows"`
+	// Skipped is the reason why the table is not verified.
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// Consistent returns whether the data of the table is consistent.
+func (d *TableDiff) Consistent() bool {
+	return d.Skipped == "" && d.MismatchedChunks == 0
+}
+
+// DiffReport is the data verification result of all tables.
+type DiffReport struct {
+	Tables []*TableDiff `json:"tables"`
+	// FixSQL are the statements which make the downstream consistent with
+	// the upstream.
+	FixSQL []string `json:"fix_sql"`
+}
+
+// Consistent returns whether the data of all tables is consistent.
+func (r *DiffReport) Consistent() bool {
+	for _, table := range r.Tables {
+		if !table.Consistent() {
+			return false
+		}
+	}
+	return true
+}
+
+// DiffData compares the data of tables between the upstream and the downstream.
+// Tables are split into chunks by the primary key or a not null unique key,
+// mismatched chunks are split recursively until the rows can be compared one
+// by one, and the different rows are reported with the SQL to fix them.
+func DiffData(
+	ctx context.Context, upstream, downstream *sql.DB, cfg *DiffConfig,
+) (*DiffReport, error) {
+	if cfg.ChunkSize <= 0 {
+		cfg.ChunkSize = defaultChunkSize
+	}
+	up, err := upstream.Conn(ctx)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLConnectionError, err)
+	}
+	defer up.Close()
+	down, err := downstream.Conn(ctx)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLConnectionError, err)
+	}
+	defer down.Close()
+
+	d := &differ{up: up, down: down, cfg: cfg, report: &DiffReport{}}
+	if err := d.setSnapshot(ctx); err != nil {
+		return nil, err
+	}
+	if err := d.diff(ctx); err != nil {
+		return nil, err
+	}
+	return d.report, nil
+}
+
+// leafRows is the max number of rows of a chunk whose rows are compared one by
+// one, larger mismatched chunks are split into halves. It can be changed in tests.
+var leafRows int64 = 64
+
+type differ struct {
+	up     *sql.Conn
+	down   *sql.Conn
+	cfg    *DiffConfig
+	report *DiffReport
+}
+
+// setSnapshot makes the upstream and the downstream sessions read the data at
+// the syncpoint, so that the data of both sides is comparable.
+func (d *differ) setSnapshot(ctx context.Context) error {
+	if d.cfg.SyncPointTs == 0 {
+		return nil
+	}
+	var secondaryTs string
+	err := d.down.QueryRowContext(ctx,
+		"SELECT secondary_ts FROM "+syncpointTable+" WHERE cf = ? AND primary_ts = ?",
+		d.cfg.ChangefeedID.Namespace+"_"+d.cfg.ChangefeedID.ID, d.cfg.SyncPointTs).
+		Scan(&secondaryTs)
+	if err == sql.ErrNoRows {
+		return cerror.ErrSyncPointNotFound.GenWithStackByArgs(
+			d.cfg.SyncPointTs, d.cfg.ChangefeedID.ID)
+	}
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	if _, err := d.up.ExecContext(ctx,
+		"SET @@tidb_snapshot = ?", d.cfg.SyncPointTs); err != nil {
+		return cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	if _, err := d.down.ExecContext(ctx,
+		"SET @@tidb_snapshot = ?", secondaryTs); err != nil {
+		return cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	log.Info("verify data at syncpoint",
+		zap.Uint64("primaryTs", d.cfg.SyncPointTs),
+		zap.String("secondaryTs", secondaryTs))
+	return nil
+}
+
+func (d *differ) diff(ctx context.Context) error {
+	dbs, err := queryStrings(ctx, d.up, "SHOW DATABASES")
+	if err != nil {
+		return err
+	}
+	for _, db := range dbs {
+		if d.cfg.Filter.ShouldIgnoreTable(db, "") {
+			continue
+		}
+		tables, err := queryStrings(ctx, d.up,
+			fmt.Sprintf("SHOW FULL TABLES FROM %s WHERE Table_type = 'BASE TABLE'",
+				quotes.QuoteName(db)))
+		if err != nil {
+			return err
+		}
+		for _, table := range tables {
+			if d.cfg.Filter.ShouldIgnoreTable(db, table) {
+				continue
+			}
+			result := &TableDiff{Schema: db, Table: table}
+			d.report.Tables = append(d.report.Tables, result)
+			if err := d.diffTable(ctx, result); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *differ) diffTable(ctx context.Context, result *TableDiff) error {
+	columnsQuery := "SELECT COLUMN_NAME FROM information_schema.COLUMNS " +
+		"WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION"
+	columns, err := queryStrings(ctx, d.up, columnsQuery, result.Schema, result.Table)
+	if err != nil {
+		return err
+	}
+	downColumns, err := queryStrings(ctx, d.down, columnsQuery, result.Schema, result.Table)
+	if err != nil {
+		return err
+	}
+	if strings.Join(columns, ",") != strings.Join(downColumns, ",") {
+		result.Skipped = fmt.Sprintf("columns are different, upstream: %v, downstream: %v",
+			columns, downColumns)
+		return nil
+	}
+	keys, err := d.getKeyColumns(ctx, result.Schema, result.Table)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		result.Skipped = "no primary key or not null unique key"
+		return nil
+	}
+
+	t := newTableDiffer(d, result, columns, keys)
+	chunks, err := t.splitChunks(ctx)
+	if err != nil {
+		return err
+	}
+	result.Chunks = len(chunks)
+	for _, c := range chunks {
+		equal, upCount, downCount, err := t.compareChecksum(ctx, c)
+		if err != nil {
+			return err
+		}
+		if equal {
+			continue
+		}
+		result.MismatchedChunks++
+		if err := t.diffChunk(ctx, c, upCount, downCount); err != nil {
+			return err
+		}
+	}
+	if !result.Consistent() {
+		log.Warn("table data mismatch", zap.Any("result", result))
+	}
+	return nil
+}
+
+// getKeyColumns returns the columns of the primary key, or the first unique
+// key whose columns are all not null.
+func (d *differ) getKeyColumns(ctx context.Context, db, table string) ([]string, error) {
+	rows, err := d.up.QueryContext(ctx,
+		"SELECT INDEX_NAME, COLUMN_NAME, NULLABLE FROM information_schema.STATISTICS "+
+			"WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND NON_UNIQUE = 0 "+
+			"ORDER BY INDEX_NAME, SEQ_IN_INDEX", db, table)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			log.Error("getKeyColumns close rows failed", zap.Error(err))
+		}
+	}()
+
+	var names []string
+	indexes := make(map[string][]string)
+	nullable := make(map[string]bool)
+	for rows.Next() {
+		var index, column, null string
+		if err = rows.Scan(&index, &column, &null); err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		if _, ok := indexes[index]; !ok {
+			names = append(names, index)
+		}
+		indexes[index] = append(indexes[index], column)
+		if null == "YES" {
+			nullable[index] = true
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	if columns, ok := indexes["PRIMARY"]; ok {
+		return columns, nil
+	}
+	for _, name := range names {
+		if !nullable[name] {
+			return indexes[name], nil
+		}
+	}
+	return nil, nil
+}
+
+// chunk is the key range (lower, upper] of a table, nil means unbounded.
+type chunk struct {
+	lower []string
+	upper []string
+}
+
+type tableDiffer struct {
+	*differ
+	result *TableDiff
+
+	table     string
+	columns   []string
+	keyIdx    []int
+	selectCol string
+	keyCol    string
+	checksum  string
+}
+
+func newTableDiffer(d *differ, result *TableDiff, columns, keys []string) *tableDiffer {
+	t := &tableDiffer{
+		differ:  d,
+		result:  result,
+		table:   quotes.QuoteSchema(result.Schema, result.Table),
+		columns: columns,
+	}
+	quotedColumns := make([]string, 0, len(columns))
+	isNull := make([]string, 0, len(columns))
+	for _, column := range columns {
+		quotedColumns = append(quotedColumns, quotes.QuoteName(column))
+		isNull = append(isNull, fmt.Sprintf("ISNULL(%s)", quotes.QuoteName(column)))
+	}
+	quotedKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		quotedKeys = append(quotedKeys, quotes.QuoteName(key))
+		for i, column := range columns {
+			if column == key {
+				t.keyIdx = append(t.keyIdx, i)
+			}
+		}
+	}
+	t.selectCol = strings.Join(quotedColumns, ", ")
+	t.keyCol = strings.Join(quotedKeys, ", ")
+	// the same checksum as doChecksum, but for a range of rows.
+	t.checksum = fmt.Sprintf("BIT_XOR(CAST(CRC32(CONCAT_WS(',', %s, %s)) AS UNSIGNED))",
+		t.selectCol, strings.Join(isNull, ", "))
+	return t
+}
+
+// where returns the condition of the chunk.
+func (t *tableDiffer) where(c chunk) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(t.keyIdx)), ", ") + ")"
+	if c.lower != nil {
+		conds = append(conds, fmt.Sprintf("(%s) > %s", t.keyCol, placeholders))
+		for _, v := range c.lower {
+			args = append(args, v)
+		}
+	}
+	if c.upper != nil {
+		conds = append(conds, fmt.Sprintf("(%s) <= %s", t.keyCol, placeholders))
+		for _, v := range c.upper {
+			args = append(args, v)
+		}
+	}
+	if len(conds) == 0 {
+		return "TRUE", nil
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// keyAt returns the key of the row at the offset of the chunk in key order,
+// it returns nil if there is no such row.
+func (t *tableDiffer) keyAt(
+	ctx context.Context, conn *sql.Conn, c chunk, offset int64,
+) ([]string, error) {
+	where, args := t.where(c)
+	// nolint:gosec
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s ORDER BY %s LIMIT 1 OFFSET %d",
+		t.keyCol, t.table, where, t.keyCol, offset)
+	key := make([]sql.NullString, len(t.keyIdx))
+	dest := make([]interface{}, len(key))
+	for i := range key {
+		dest[i] = &key[i]
+	}
+	err := conn.QueryRowContext(ctx, query, args...).Scan(dest...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	result := make([]string, len(key))
+	for i := range key {
+		result[i] = key[i].String
+	}
+	return result, nil
+}
+
+// splitChunks splits the table into chunks of ChunkSize rows in the upstream.
+func (t *tableDiffer) splitChunks(ctx context.Context) ([]chunk, error) {
+	var chunks []chunk
+	var lower []string
+	for {
+		upper, err := t.keyAt(ctx, t.up, chunk{lower: lower}, int64(t.cfg.ChunkSize-1))
+		if err != nil {
+			return nil, err
+		}
+		// the last chunk is unbounded, so that it also covers the extra
+		// rows in the downstream.
+		chunks = append(chunks, chunk{lower: lower, upper: upper})
+		if upper == nil {
+			return chunks, nil
+		}
+		lower = upper
+	}
+}
+
+func (t *tableDiffer) chunkChecksum(
+	ctx context.Context, conn *sql.Conn, c chunk,
+) (count int64, checksum uint64, err error) {
+	where, args := t.where(c)
+	// nolint:gosec
+	query := fmt.Sprintf("SELECT COUNT(*), COALESCE(%s, 0) FROM %s WHERE %s",
+		t.checksum, t.table, where)
+	err = conn.QueryRowContext(ctx, query, args...).Scan(&count, &checksum)
+	return count, checksum, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+}
+
+func (t *tableDiffer) compareChecksum(
+	ctx context.Context, c chunk,
+) (equal bool, upCount, downCount int64, err error) {
+	upCount, upChecksum, err := t.chunkChecksum(ctx, t.up, c)
+	if err != nil {
+		return false, 0, 0, err
+	}
+	downCount, downChecksum, err := t.chunkChecksum(ctx, t.down, c)
+	if err != nil {
+		return false, 0, 0, err
+	}
+	return upCount == downCount && upChecksum == downChecksum, upCount, downCount, nil
+}
+
+// diffChunk splits the mismatched chunk into halves by the side with more
+// rows until it's small enough, then compares the rows one by one.
+func (t *tableDiffer) diffChunk(ctx context.Context, c chunk, upCount, downCount int64) error {
+	if upCount <= leafRows && downCount <= leafRows {
+		return t.diffRows(ctx, c)
+	}
+	conn, count := t.up, upCount
+	if downCount > upCount {
+		conn, count = t.down, downCount
+	}
+	mid, err := t.keyAt(ctx, conn, c, (count-1)/2)
+	if err != nil {
+		return err
+	}
+	if mid == nil {
+		// the rows are changed since the checksum, compare them directly.
+		return t.diffRows(ctx, c)
+	}
+	for _, sub := range []chunk{{lower: c.lower, upper: mid}, {lower: mid, upper: c.upper}} {
+		equal, upCount, downCount, err := t.compareChecksum(ctx, sub)
+		if err != nil {
+			return err
+		}
+		if equal {
+			continue
+		}
+		if err := t.diffChunk(ctx, sub, upCount, downCount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *tableDiffer) queryRows(
+	ctx context.Context, conn *sql.Conn, c chunk,
+) ([][]sql.NullString, error) {
+	where, args := t.where(c)
+	// nolint:gosec
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s ORDER BY %s",
+		t.selectCol, t.table, where, t.keyCol)
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			log.Error("queryRows close rows failed", zap.Error(err))
+		}
+	}()
+
+	var result [][]sql.NullString
+	for rows.Next() {
+		row := make([]sql.NullString, len(t.columns))
+		dest := make([]interface{}, len(row))
+		for i := range row {
+			dest[i] = &row[i]
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		result = append(result, row)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	return result, nil
+}
+
+// diffRows compares the rows of the chunk by key, and generates the SQL to
+// make the downstream rows the same as the upstream.
+func (t *tableDiffer) diffRows(ctx context.Context, c chunk) error {
+	upRows, err := t.queryRows(ctx, t.up, c)
+	if err != nil {
+		return err
+	}
+	downRows, err := t.queryRows(ctx, t.down, c)
+	if err != nil {
+		return err
+	}
+
+	downByKey := make(map[string][]sql.NullString, len(downRows))
+	for _, row := range downRows {
+		downByKey[t.rowKey(row)] = row
+	}
+	for _, row := range upRows {
+		key := t.rowKey(row)
+		downRow, ok := downByKey[key]
+		delete(downByKey, key)
+		switch {
+		case !ok:
+			t.result.MissingRows++
+		case !rowEqual(row, downRow):
+			t.result.DifferentRows++
+		default:
+			continue
+		}
+		t.report.FixSQL = append(t.report.FixSQL, t.replaceSQL(row))
+	}
+	// keep the order of the downstream rows.
+	for _, row := range downRows {
+		if _, ok := downByKey[t.rowKey(row)]; ok {
+			t.result.ExtraRows++
+			t.report.FixSQL = append(t.report.FixSQL, t.deleteSQL(row))
+		}
+	}
+	return nil
+}
+
+func (t *tableDiffer) rowKey(row []sql.NullString) string {
+	key := make([]string, 0, len(t.keyIdx))
+	for _, i := range t.keyIdx {
+		key = append(key, fmt.Sprintf("%q", row[i].String))
+	}
+	return strings.Join(key, ",")
+}
+
+func (t *tableDiffer) replaceSQL(row []sql.NullString) string {
+	quotedColumns := make([]string, 0, len(t.columns))
+	values := make([]string, 0, len(row))
+	for i, column := range t.columns {
+		quotedColumns = append(quotedColumns, quotes.QuoteName(column))
+		values = append(values, formatValue(row[i]))
+	}
+	return fmt.Sprintf("REPLACE INTO %s (%s) VALUES (%s);",
+		t.table, strings.Join(quotedColumns, ","), strings.Join(values, ","))
+}
+
+func (t *tableDiffer) deleteSQL(row []sql.NullString) string {
+	conds := make([]string, 0, len(t.keyIdx))
+	for _, i := range t.keyIdx {
+		conds = append(conds, fmt.Sprintf("%s = %s",
+			quotes.QuoteName(t.columns[i]), formatValue(row[i])))
+	}
+	return fmt.Sprintf("DELETE FROM %s WHERE %s LIMIT 1;",
+		t.table, strings.Join(conds, " AND "))
+}
+
+func rowEqual(a, b []sql.NullString) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+var sqlStringEscaper = strings.NewReplacer(
+	`\`, `\\`, `'`, `\'`, "\x00", `\0`, "\n", `\n`, "\r", `\r`, "\x1a", `\Z`)
+
+// formatValue formats the value as a SQL literal, binary values are hex encoded.
+func formatValue(v sql.NullString) string {
+	if !v.Valid {
+		return "NULL"
+	}
+	if !utf8.ValidString(v.String) {
+		return "x'" + hex.EncodeToString([]byte(v.String)) + "'"
+	}
+	return "'" + sqlStringEscaper.Replace(v.String) + "'"
+}
+
+// queryStrings returns the first column of the query result.
+func queryStrings(
+	ctx context.Context, conn *sql.Conn, query string, args ...interface{},
+) ([]string, error) {
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			log.Error("queryStrings close rows failed", zap.Error(err))
+		}
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	var result []string
+	for rows.Next() {
+		var s string
+		dest := make([]interface{}, len(columns))
+		dest[0] = &s
+		for i := 1; i < len(dest); i++ {
+			dest[i] = new(sql.RawBytes)
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		result = append(result, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,236 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verification
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testColumnsQuery = "SELECT COLUMN_NAME FROM information_schema.COLUMNS " +
+		"WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION"
+	testKeysQuery = "SELECT INDEX_NAME, COLUMN_NAME, NULLABLE FROM information_schema.STATISTICS " +
+		"WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND NON_UNIQUE = 0 " +
+		"ORDER BY INDEX_NAME, SEQ_IN_INDEX"
+	testChecksumQuery = "SELECT COUNT(*), COALESCE(BIT_XOR(CAST(CRC32(CONCAT_WS(',', " +
+		"`id`, `v`, ISNULL(`id`), ISNULL(`v`))) AS UNSIGNED)), 0) FROM `test`.`t` WHERE "
+	testRowsQuery = "SELECT `id`, `v` FROM `test`.`t` WHERE "
+)
+
+func newTestDBs(t *testing.T) (up *sql.DB, upMock sqlmock.Sqlmock, down *sql.DB, downMock sqlmock.Sqlmock) {
+	up, upMock, err := sqlmock.New()
+	require.Nil(t, err)
+	down, downMock, err = sqlmock.New()
+	require.Nil(t, err)
+	return up, upMock, down, downMock
+}
+
+func newTestDiffConfig(t *testing.T) *DiffConfig {
+	f, err := filter.NewFilter(config.GetDefaultReplicaConfig(), "")
+	require.Nil(t, err)
+	return &DiffConfig{Filter: f}
+}
+
+// expectTable expects the queries to list the table test.t(id, v) with the
+// primary key id.
+func expectTable(upMock, downMock sqlmock.Sqlmock) {
+	upMock.ExpectQuery("SHOW DATABASES").
+		WillReturnRows(sqlmock.NewRows([]string{"Database"}).AddRow("mysql").AddRow("test"))
+	upMock.ExpectQuery(regexp.QuoteMeta("SHOW FULL TABLES FROM `test` WHERE Table_type = 'BASE TABLE'")).
+		WillReturnRows(sqlmock.NewRows([]string{"Tables_in_test", "Table_type"}).
+			AddRow("t", "BASE TABLE"))
+	for _, mock := range []sqlmock.Sqlmock{upMock, downMock} {
+		mock.ExpectQuery(regexp.QuoteMeta(testColumnsQuery)).WithArgs("test", "t").
+			WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME"}).AddRow("id").AddRow("v"))
+	}
+	upMock.ExpectQuery(regexp.QuoteMeta(testKeysQuery)).WithArgs("test", "t").
+		WillReturnRows(sqlmock.NewRows([]string{"INDEX_NAME", "COLUMN_NAME", "NULLABLE"}).
+			AddRow("PRIMARY", "id", ""))
+}
+
+func expectChecksum(mock sqlmock.Sqlmock, where string, count int64, checksum uint64, args ...string) {
+	query := mock.ExpectQuery(regexp.QuoteMeta(testChecksumQuery + where))
+	if len(args) > 0 {
+		query = query.WithArgs(args[0])
+	}
+	query.WillReturnRows(sqlmock.NewRows([]string{"count", "checksum"}).AddRow(count, checksum))
+}
+
+func expectRows(mock sqlmock.Sqlmock, where, arg string, rows ...[2]string) {
+	result := sqlmock.NewRows([]string{"id", "v"})
+	for _, row := range rows {
+		result.AddRow(row[0], row[1])
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(testRowsQuery + where + " ORDER BY `id`")).
+		WithArgs(arg).WillReturnRows(result)
+}
+
+func TestDiffData(t *testing.T) {
+	t.Parallel()
+
+	up, upMock, down, downMock := newTestDBs(t)
+	expectTable(upMock, downMock)
+
+	// The table is split into (-inf, 3] and (3, +inf).
+	upMock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT `id` FROM `test`.`t` WHERE TRUE ORDER BY `id` LIMIT 1 OFFSET 2")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("3"))
+	upMock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT `id` FROM `test`.`t` WHERE (`id`) > (?) ORDER BY `id` LIMIT 1 OFFSET 2")).
+		WithArgs("3").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	// The row 2 is different.
+	expectChecksum(upMock, "(`id`) <= (?)", 3, 100, "3")
+	expectChecksum(downMock, "(`id`) <= (?)", 3, 101, "3")
+	expectRows(upMock, "(`id`) <= (?)", "3", [2]string{"1", "a"}, [2]string{"2", "b"}, [2]string{"3", "c"})
+	expectRows(downMock, "(`id`) <= (?)", "3", [2]string{"1", "a"}, [2]string{"2", "x"}, [2]string{"3", "c"})
+	// The row 4 is missing and the row 6 is extra.
+	expectChecksum(upMock, "(`id`) > (?)", 2, 200, "3")
+	expectChecksum(downMock, "(`id`) > (?)", 2, 201, "3")
+	expectRows(upMock, "(`id`) > (?)", "3", [2]string{"4", "d'"}, [2]string{"5", "e"})
+	expectRows(downMock, "(`id`) > (?)", "3", [2]string{"5", "e"}, [2]string{"6", "f"})
+
+	report, err := DiffData(context.Background(), up, down, &DiffConfig{
+		Filter:    newTestDiffConfig(t).Filter,
+		ChunkSize: 3,
+	})
+	require.Nil(t, err)
+	require.False(t, report.Consistent())
+	require.Equal(t, []*TableDiff{{
+		Schema:           "test",
+		Table:            "t",
+		Chunks:           2,
+		MismatchedChunks: 2,
+		MissingRows:      1,
+		ExtraRows:        1,
+		DifferentRows:    1,
+	}}, report.Tables)
+	require.Equal(t, []string{
+		"REPLACE INTO `test`.`t` (`id`,`v`) VALUES ('2','b');",
+		"REPLACE INTO `test`.`t` (`id`,`v`) VALUES ('4','d\\'');",
+		"DELETE FROM `test`.`t` WHERE `id` = '6' LIMIT 1;",
+	}, report.FixSQL)
+	require.Nil(t, upMock.ExpectationsWereMet())
+	require.Nil(t, downMock.ExpectationsWereMet())
+}
+
+func TestDiffDataSplitChunk(t *testing.T) {
+	old := leafRows
+	leafRows = 1
+	defer func() { leafRows = old }()
+
+	up, upMock, down, downMock := newTestDBs(t)
+	expectTable(upMock, downMock)
+	upMock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT `id` FROM `test`.`t` WHERE TRUE ORDER BY `id` LIMIT 1 OFFSET 9999")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	expectChecksum(upMock, "TRUE", 2, 300)
+	expectChecksum(downMock, "TRUE", 2, 301)
+
+	// The mismatched chunk is split at the row 1, only the second half is
+	// compared row by row.
+	upMock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT `id` FROM `test`.`t` WHERE TRUE ORDER BY `id` LIMIT 1 OFFSET 0")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	expectChecksum(upMock, "(`id`) <= (?)", 1, 100, "1")
+	expectChecksum(downMock, "(`id`) <= (?)", 1, 100, "1")
+	expectChecksum(upMock, "(`id`) > (?)", 1, 200, "1")
+	expectChecksum(downMock, "(`id`) > (?)", 1, 201, "1")
+	expectRows(upMock, "(`id`) > (?)", "1", [2]string{"2", "b"})
+	expectRows(downMock, "(`id`) > (?)", "1", [2]string{"2", "c"})
+
+	report, err := DiffData(context.Background(), up, down, newTestDiffConfig(t))
+	require.Nil(t, err)
+	require.Len(t, report.Tables, 1)
+	require.Equal(t, 1, report.Tables[0].Chunks)
+	require.Equal(t, 1, report.Tables[0].DifferentRows)
+	require.Equal(t, []string{
+		"REPLACE INTO `test`.`t` (`id`,`v`) VALUES ('2','b');",
+	}, report.FixSQL)
+	require.Nil(t, upMock.ExpectationsWereMet())
+	require.Nil(t, downMock.ExpectationsWereMet())
+}
+
+func TestDiffDataSkipTable(t *testing.T) {
+	t.Parallel()
+
+	up, upMock, down, downMock := newTestDBs(t)
+	upMock.ExpectQuery("SHOW DATABASES").
+		WillReturnRows(sqlmock.NewRows([]string{"Database"}).AddRow("test"))
+	upMock.ExpectQuery(regexp.QuoteMeta("SHOW FULL TABLES FROM `test` WHERE Table_type = 'BASE TABLE'")).
+		WillReturnRows(sqlmock.NewRows([]string{"Tables_in_test", "Table_type"}).
+			AddRow("t1", "BASE TABLE").AddRow("t2", "BASE TABLE"))
+	// The columns of t1 are different.
+	upMock.ExpectQuery(regexp.QuoteMeta(testColumnsQuery)).WithArgs("test", "t1").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME"}).AddRow("id").AddRow("v"))
+	downMock.ExpectQuery(regexp.QuoteMeta(testColumnsQuery)).WithArgs("test", "t1").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME"}).AddRow("id"))
+	// t2 only has a nullable unique key.
+	for _, mock := range []sqlmock.Sqlmock{upMock, downMock} {
+		mock.ExpectQuery(regexp.QuoteMeta(testColumnsQuery)).WithArgs("test", "t2").
+			WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME"}).AddRow("id"))
+	}
+	upMock.ExpectQuery(regexp.QuoteMeta(testKeysQuery)).WithArgs("test", "t2").
+		WillReturnRows(sqlmock.NewRows([]string{"INDEX_NAME", "COLUMN_NAME", "NULLABLE"}).
+			AddRow("uk", "id", "YES"))
+
+	report, err := DiffData(context.Background(), up, down, newTestDiffConfig(t))
+	require.Nil(t, err)
+	require.False(t, report.Consistent())
+	require.Len(t, report.Tables, 2)
+	require.Regexp(t, "columns are different.*", report.Tables[0].Skipped)
+	require.Equal(t, "no primary key or not null unique key", report.Tables[1].Skipped)
+	require.Empty(t, report.FixSQL)
+}
+
+func TestDiffDataSyncPoint(t *testing.T) {
+	t.Parallel()
+
+	syncpointQuery := regexp.QuoteMeta(
+		"SELECT secondary_ts FROM tidb_cdc.syncpoint_v1 WHERE cf = ? AND primary_ts = ?")
+	cfg := newTestDiffConfig(t)
+	cfg.SyncPointTs = 100
+	cfg.ChangefeedID = model.DefaultChangeFeedID("test")
+
+	up, upMock, down, downMock := newTestDBs(t)
+	downMock.ExpectQuery(syncpointQuery).WithArgs("default_test", 100).
+		WillReturnRows(sqlmock.NewRows([]string{"secondary_ts"}))
+	_, err := DiffData(context.Background(), up, down, cfg)
+	require.True(t, cerror.ErrSyncPointNotFound.Equal(err))
+
+	up, upMock, down, downMock = newTestDBs(t)
+	downMock.ExpectQuery(syncpointQuery).WithArgs("default_test", 100).
+		WillReturnRows(sqlmock.NewRows([]string{"secondary_ts"}).AddRow("200"))
+	upMock.ExpectExec(regexp.QuoteMeta("SET @@tidb_snapshot = ?")).WithArgs(100).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	downMock.ExpectExec(regexp.QuoteMeta("SET @@tidb_snapshot = ?")).WithArgs("200").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	upMock.ExpectQuery("SHOW DATABASES").
+		WillReturnRows(sqlmock.NewRows([]string{"Database"}))
+	report, err := DiffData(context.Background(), up, down, cfg)
+	require.Nil(t, err)
+	require.True(t, report.Consistent())
+	require.Nil(t, upMock.ExpectationsWereMet())
+	require.Nil(t, downMock.ExpectationsWereMet())
+}
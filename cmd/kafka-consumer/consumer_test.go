@@ -0,0 +1,64 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/sink/codec/maxwell"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeMaxwellHandleKeys(t *testing.T) {
+	t.Parallel()
+
+	event := &model.RowChangedEvent{
+		CommitTs: 424316552636792833,
+		Table:    &model.TableName{Schema: "test", Table: "t1"},
+		Columns: []*model.Column{
+			{
+				Name: "id", Type: mysql.TypeLong, Value: int64(1),
+				Flag: model.HandleKeyFlag | model.PrimaryKeyFlag,
+			},
+			{Name: "name", Type: mysql.TypeVarchar, Value: []byte("a")},
+		},
+	}
+	codecConfig := common.NewConfig(config.ProtocolMaxwell)
+	codecConfig.EnableTiDBExtension = true
+	encoder := maxwell.NewBatchEncoderBuilder(codecConfig).Build()
+	err := encoder.AppendRowChangedEvent(context.Background(), "", event, nil)
+	require.NoError(t, err)
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+
+	// The handle keys and the commit ts are only sent with the TiDB
+	// extension, the consumer needs them to replicate the rows.
+	c := &Consumer{protocol: config.ProtocolMaxwell, enableTiDBExtension: true}
+	decoder, err := c.newBatchDecoder(messages[0].Key, messages[0].Value)
+	require.NoError(t, err)
+	tp, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeRow, tp)
+	row, err := decoder.NextRowChangedEvent()
+	require.NoError(t, err)
+	require.Equal(t, event.CommitTs, row.CommitTs)
+	require.Equal(t, model.HandleKeyFlag|model.PrimaryKeyFlag, row.Columns[0].Flag)
+	require.Equal(t, model.ColumnFlagType(0), row.Columns[1].Flag)
+}
@@ -37,7 +37,11 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink"
 	"github.com/pingcap/tiflow/cdc/sink/codec"
+	"github.com/pingcap/tiflow/cdc/sink/codec/avro"
 	"github.com/pingcap/tiflow/cdc/sink/codec/canal"
+	"github.com/pingcap/tiflow/cdc/sink/codec/craft"
+	"github.com/pingcap/tiflow/cdc/sink/codec/debezium"
+	"github.com/pingcap/tiflow/cdc/sink/codec/maxwell"
 	"github.com/pingcap/tiflow/cdc/sink/codec/open"
 	"github.com/pingcap/tiflow/cdc/sink/mq/dispatcher"
 	cmdUtil "github.com/pingcap/tiflow/pkg/cmd/util"
@@ -64,6 +68,8 @@ var (
 
 	protocol            config.Protocol
 	enableTiDBExtension bool
+	// schemaRegistryURI is the schema registry used by the avro protocol
+	schemaRegistryURI string
 
 	// eventRouterReplicaConfig only used to initialize the consumer's eventRouter
 	// which then can be used to check RowChangedEvent dispatched correctness
@@ -75,7 +81,9 @@ var (
 	ca, cert, key string
 )
 
-func init() {
+// parseFlags parses the flags and the upstream URI. It's called by main
+// instead of init, so the flags of `go test` are not parsed by it.
+func parseFlags() {
 	var (
 		upstreamURIStr string
 		configFile     string
@@ -180,13 +188,26 @@ func init() {
 		if err != nil {
 			log.Panic("invalid enable-tidb-extension of upstream-uri")
 		}
-		if protocol != config.ProtocolCanalJSON && b {
-			log.Panic("enable-tidb-extension only work with canal-json")
+		if protocol != config.ProtocolCanalJSON && protocol != config.ProtocolAvro &&
+			protocol != config.ProtocolMaxwell && b {
+			log.Panic("enable-tidb-extension only work with canal-json / avro / maxwell")
 		}
 
 		enableTiDBExtension = b
 	}
 
+	if protocol == config.ProtocolAvro {
+		schemaRegistryURI = upstreamURI.Query().Get("schema-registry")
+		if schemaRegistryURI == "" {
+			log.Panic("schema-registry of upstream-uri is required by avro protocol")
+		}
+		// The commit ts is only sent in the TiDB extension, and the changefeed
+		// should enable `avro-enable-watermark` to send the resolved ts.
+		if !enableTiDBExtension {
+			log.Panic("enable-tidb-extension of upstream-uri is required by avro protocol")
+		}
+	}
+
 	if configFile != "" {
 		eventRouterReplicaConfig = config.GetDefaultReplicaConfig()
 		eventRouterReplicaConfig.Sink.Protocol = protocol.String()
@@ -278,6 +299,8 @@ func newSaramaConfig() (*sarama.Config, error) {
 }
 
 func main() {
+	parseFlags()
+
 	/**
 	 * Construct a new Sarama configuration.
 	 * The Kafka cluster version has to be defined before the consumer/producer is initialized.
@@ -376,6 +399,10 @@ type Consumer struct {
 	protocol            config.Protocol
 	enableTiDBExtension bool
 
+	// avroDecoderBuilder is only used by the avro protocol, the decoders share
+	// the schemas fetched from the schema registry.
+	avroDecoderBuilder *avro.BatchDecoderBuilder
+
 	eventRouter *dispatcher.EventRouter
 }
 
@@ -395,6 +422,14 @@ func NewConsumer(ctx context.Context) (*Consumer, error) {
 	c.protocol = protocol
 	c.enableTiDBExtension = enableTiDBExtension
 
+	if c.protocol == config.ProtocolAvro {
+		c.avroDecoderBuilder, err = avro.NewBatchDecoderBuilder(
+			ctx, schemaRegistryURI, c.enableTiDBExtension)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	// this means user has input config file to enable dispatcher check
 	// some protocol does not provide enough information to check the
 	// dispatched partition match or not. such as `open-protocol`, which
@@ -487,6 +522,27 @@ func (g *eventsGroup) Resolve(resolveTs uint64) []*model.RowChangedEvent {
 	return result
 }
 
+// newBatchDecoder creates the decoder of the message by the protocol.
+func (c *Consumer) newBatchDecoder(key, value []byte) (codec.EventBatchDecoder, error) {
+	switch c.protocol {
+	case config.ProtocolOpen, config.ProtocolDefault:
+		return open.NewBatchDecoder(key, value)
+	case config.ProtocolCanalJSON:
+		return canal.NewBatchDecoder(value, c.enableTiDBExtension), nil
+	case config.ProtocolAvro:
+		return c.avroDecoderBuilder.Build(key, value), nil
+	case config.ProtocolMaxwell:
+		return maxwell.NewBatchDecoder(key, value)
+	case config.ProtocolCraft:
+		return craft.NewBatchDecoder(value)
+	case config.ProtocolDebezium:
+		return debezium.NewBatchDecoder(key, value)
+	default:
+		log.Panic("Protocol not supported", zap.Any("Protocol", c.protocol))
+	}
+	return nil, nil
+}
+
 // ConsumeClaim must start a consumer loop of ConsumerGroupClaim's Messages().
 func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	ctx := context.Background()
@@ -500,23 +556,15 @@ func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 
 	eventGroups := make(map[int64]*eventsGroup)
 	for message := range claim.Messages() {
-		var (
-			decoder codec.EventBatchDecoder
-			err     error
-		)
-		switch c.protocol {
-		case config.ProtocolOpen, config.ProtocolDefault:
-			decoder, err = open.NewBatchDecoder(message.Key, message.Value)
-		case config.ProtocolCanalJSON:
-			decoder = canal.NewBatchDecoder(message.Value, c.enableTiDBExtension)
-		default:
-			log.Panic("Protocol not supported", zap.Any("Protocol", c.protocol))
-		}
+		decoder, err := c.newBatchDecoder(message.Key, message.Value)
 		if err != nil {
 			return errors.Trace(err)
 		}
 
 		counter := 0
+		// maxCommitTs is the max commit ts of the rows in the message,
+		// it is only used by the protocols without resolved events.
+		var maxCommitTs uint64
 		for {
 			tp, hasNext, err := decoder.HasNext()
 			if err != nil {
@@ -548,6 +596,13 @@ func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 				if partition == 0 {
 					c.appendDDL(ddl)
 				}
+				if !c.hasResolvedEvents() {
+					if err := c.waitDDLBarrier(
+						session.Context(), sink, partition, eventGroups, ddl,
+					); err != nil {
+						return errors.Trace(err)
+					}
+				}
 			case model.MessageTypeRow:
 				row, err := decoder.NextRowChangedEvent()
 				if err != nil {
@@ -592,6 +647,9 @@ func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 					eventGroups[tableID] = group
 				}
 				group.Append(row)
+				if row.CommitTs > maxCommitTs {
+					maxCommitTs = row.CommitTs
+				}
 			case model.MessageTypeResolved:
 				ts, err := decoder.NextResolvedEvent()
 				if err != nil {
@@ -606,23 +664,7 @@ func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 						zap.Int32("partition", partition))
 				}
 				if ts > resolvedTs {
-					for tableID, group := range eventGroups {
-						events := group.Resolve(ts)
-						if len(events) == 0 {
-							continue
-						}
-						if err := sink.EmitRowChangedEvents(ctx, events...); err != nil {
-							log.Panic("emit row changed event failed",
-								zap.Any("events", events),
-								zap.Error(err),
-								zap.Int32("partition", partition))
-						}
-						commitTs := events[len(events)-1].CommitTs
-						lastCommitTs, ok := sink.tablesMap.Load(tableID)
-						if !ok || lastCommitTs.(uint64) < commitTs {
-							sink.tablesMap.Store(tableID, commitTs)
-						}
-					}
+					emitEventGroups(ctx, sink, partition, eventGroups, ts)
 					log.Debug("update sink resolved ts",
 						zap.Uint64("ts", ts),
 						zap.Int32("partition", partition))
@@ -630,10 +672,31 @@ func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 				} else {
 					log.Info("redundant sink resolved ts", zap.Uint64("ts", ts), zap.Int32("partition", partition))
 				}
+			case model.MessageTypeSyncPoint:
+				// all the events before the syncpoint have been sent to the
+				// partition, the consumer only records it for now.
+				ts, err := decoder.NextSyncPointEvent()
+				if err != nil {
+					log.Panic("decode message value failed", zap.ByteString("value", message.Value))
+				}
+				log.Info("syncpoint received", zap.Uint64("ts", ts), zap.Int32("partition", partition))
 			}
 			session.MarkMessage(message, "")
 		}
 
+		// The rows are flushed once they are received if there are no resolved
+		// events, because the partitions without rows are never resolved.
+		if !c.hasResolvedEvents() && maxCommitTs > 0 {
+			emitEventGroups(ctx, sink, partition, eventGroups, maxCommitTs)
+			if err := syncFlushRowChangedEvents(ctx, sink, maxCommitTs); err != nil {
+				log.Panic("flush row changed event failed",
+					zap.Error(err), zap.Int32("partition", partition))
+			}
+			if maxCommitTs > atomic.LoadUint64(&sink.resolvedTs) {
+				atomic.StoreUint64(&sink.resolvedTs, maxCommitTs)
+			}
+		}
+
 		if counter > kafkaMaxBatchSize {
 			log.Panic("Open Protocol max-batch-size exceeded", zap.Int("max-batch-size", kafkaMaxBatchSize),
 				zap.Int("actual-batch-size", counter))
@@ -643,6 +706,60 @@ func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 	return nil
 }
 
+// emitEventGroups emits the events whose commit ts are not greater than
+// the resolved ts to the partition sink.
+func emitEventGroups(
+	ctx context.Context, sink *partitionSink, partition int32,
+	eventGroups map[int64]*eventsGroup, resolvedTs uint64,
+) {
+	for tableID, group := range eventGroups {
+		events := group.Resolve(resolvedTs)
+		if len(events) == 0 {
+			continue
+		}
+		if err := sink.EmitRowChangedEvents(ctx, events...); err != nil {
+			log.Panic("emit row changed event failed",
+				zap.Any("events", events),
+				zap.Error(err),
+				zap.Int32("partition", partition))
+		}
+		commitTs := events[len(events)-1].CommitTs
+		lastCommitTs, ok := sink.tablesMap.Load(tableID)
+		if !ok || lastCommitTs.(uint64) < commitTs {
+			sink.tablesMap.Store(tableID, commitTs)
+		}
+	}
+}
+
+// hasResolvedEvents returns whether the protocol sends resolved events.
+func (c *Consumer) hasResolvedEvents() bool {
+	return c.protocol != config.ProtocolMaxwell
+}
+
+// waitDDLBarrier is used by the protocols without resolved events, the DDL is
+// sent to all partitions, so the partition is resolved at the commit ts of the
+// DDL, and the following rows must wait until the DDL is executed.
+// It returns the error of the context if the consumer is closed while waiting.
+func (c *Consumer) waitDDLBarrier(
+	ctx context.Context, sink *partitionSink, partition int32,
+	eventGroups map[int64]*eventsGroup, ddl *model.DDLEvent,
+) error {
+	emitEventGroups(ctx, sink, partition, eventGroups, ddl.CommitTs)
+	if ddl.CommitTs > atomic.LoadUint64(&sink.resolvedTs) {
+		atomic.StoreUint64(&sink.resolvedTs, ddl.CommitTs)
+	}
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for atomic.LoadUint64(&c.globalResolvedTs) < ddl.CommitTs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
 // append DDL wait to be handled, only consider the constraint among DDLs.
 // for DDL a / b received in the order, a.CommitTs < b.CommitTs should be true.
 func (c *Consumer) appendDDL(ddl *model.DDLEvent) {
@@ -740,89 +740,1170 @@ var doc = `{
                     }
                 }
             }
+        },
+        "/api/v2/captures": {
+            "get": {
+                "description": "list all captures in cdc cluster",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "capture"
+                ],
+                "summary": "List captures",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ListResponse-v2_Capture"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/captures/{capture_id}/drain": {
+            "put": {
+                "description": "Drain all tables at the target captures in cdc cluster",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "capture"
+                ],
+                "summary": "Drain captures",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "capture_id",
+                        "name": "capture_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/v2.DrainCaptureResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/changefeeds": {
+            "get": {
+                "description": "list all changefeeds in cdc cluster",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed"
+                ],
+                "summary": "List changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "state",
+                        "name": "state",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ListResponse-v2_ChangefeedCommonInfo"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/changefeeds/{changefeed_id}": {
+            "get": {
+                "description": "get detail information of a changefeed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed"
+                ],
+                "summary": "Get changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ChangefeedDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a changefeed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed"
+                ],
+                "summary": "Remove a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/changefeeds/{changefeed_id}/events": {
+            "get": {
+                "description": "list the state changes, admin jobs, errors and config updates of a changefeed, the oldest event comes first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed"
+                ],
+                "summary": "List changefeed events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ListResponse-v2_ChangefeedEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/changefeeds/{changefeed_id}/pause": {
+            "post": {
+                "description": "Pause a changefeed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed"
+                ],
+                "summary": "Pause a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/changefeeds/{changefeed_id}/tables": {
+            "get": {
+                "description": "list the replication statistics of all tables of a changefeed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed"
+                ],
+                "summary": "List table statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ListResponse-v2_TableStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/changefeeds/{changefeed_id}/tables/move_table": {
+            "post": {
+                "description": "move one table to the target capture",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed"
+                ],
+                "summary": "move table",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "move table request",
+                        "name": "MoveTable",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.MoveTableReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/changefeeds/{changefeed_id}/tables/rebalance_table": {
+            "post": {
+                "description": "rebalance all tables of a changefeed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed"
+                ],
+                "summary": "rebalance tables",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/changefeeds/{changefeed_id}/verify_data": {
+            "get": {
+                "description": "get the status of the latest data verification job of a changefeed, the report is included if the job is finished",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed"
+                ],
+                "summary": "Get the data verification of a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.DataVerificationStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "start a row-level data verification job of a changefeed, the status and the report are returned by the get api",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed"
+                ],
+                "summary": "Verify the data of a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data verification config",
+                        "name": "verifyConfig",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.DataVerificationConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/v2.DataVerificationStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/health": {
+            "get": {
+                "description": "check if CDC cluster is health",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "common"
+                ],
+                "summary": "Check if CDC cluster is health",
+                "responses": {
+                    "200": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/log": {
+            "post": {
+                "description": "change TiCDC log level dynamically",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "common"
+                ],
+                "summary": "Change TiCDC log level",
+                "parameters": [
+                    {
+                        "description": "log level",
+                        "name": "log_level",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.LogLevelReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/owner/resign": {
+            "post": {
+                "description": "notify the current owner to resign",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "owner"
+                ],
+                "summary": "notify the owner to resign",
+                "responses": {
+                    "200": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/processors": {
+            "get": {
+                "description": "list all processors in the TiCDC cluster",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "processor"
+                ],
+                "summary": "List processors",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ListResponse-v2_ProcessorCommonInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/processors/{changefeed_id}/{capture_id}": {
+            "get": {
+                "description": "get the detail information of a processor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "processor"
+                ],
+                "summary": "Get processor detail information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "capture_id",
+                        "name": "capture_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ProcessorDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/processors/{changefeed_id}/{capture_id}/tables": {
+            "get": {
+                "description": "get the replication statistics of tables replicated by a processor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "processor"
+                ],
+                "summary": "Get table statistics of a processor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "capture_id",
+                        "name": "capture_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ListResponse-v2_TableStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/status": {
+            "get": {
+                "description": "get the status of a server(capture)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "common"
+                ],
+                "summary": "Get server status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ServerStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
         "config.ColumnSelector": {
             "type": "object",
             "properties": {
-                "columns": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "matcher": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "config.DispatchRule": {
+            "type": "object",
+            "properties": {
+                "dispatcher": {
+                    "description": "Deprecated, please use PartitionRule.",
+                    "type": "string"
+                },
+                "matcher": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "partition": {
+                    "description": "PartitionRule is an alias added for DispatcherRule to mitigate confusions.\nIn the future release, the DispatcherRule is expected to be removed .",
+                    "type": "string"
+                },
+                "topic": {
+                    "type": "string"
+                }
+            }
+        },
+        "config.SinkConfig": {
+            "type": "object",
+            "properties": {
+                "column-selectors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.ColumnSelector"
+                    }
+                },
+                "dispatchers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.DispatchRule"
+                    }
+                },
+                "protocol": {
+                    "type": "string"
+                },
+                "schema-registry": {
+                    "type": "string"
+                },
+                "transaction-atomicity": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Capture": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_owner": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.CaptureTaskStatus": {
+            "type": "object",
+            "properties": {
+                "capture_id": {
+                    "type": "string"
+                },
+                "table_ids": {
+                    "description": "Table list, containing tables that processor should process",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "type": "integer"
                     }
                 },
-                "matcher": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+                "table_operations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.TableOperation"
                     }
                 }
             }
         },
-        "config.DispatchRule": {
+        "model.ChangefeedCommonInfo": {
             "type": "object",
             "properties": {
-                "dispatcher": {
-                    "description": "Deprecated, please use PartitionRule.",
+                "checkpoint_time": {
                     "type": "string"
                 },
-                "matcher": {
+                "checkpoint_tso": {
+                    "type": "integer"
+                },
+                "error": {
+                    "$ref": "#/definitions/model.RunningError"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "upstream_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ChangefeedConfig": {
+            "type": "object",
+            "properties": {
+                "changefeed_id": {
+                    "type": "string"
+                },
+                "filter_rules": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "partition": {
-                    "description": "PartitionRule is an alias added for DispatcherRule to mitigate confusions.\nIn the future release, the DispatcherRule is expected to be removed .",
+                "force_replicate": {
+                    "description": "if true, force to replicate some ineligible tables",
+                    "type": "boolean",
+                    "default": false
+                },
+                "ignore_ineligible_table": {
+                    "type": "boolean",
+                    "default": false
+                },
+                "ignore_txn_start_ts": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "mounter_worker_num": {
+                    "type": "integer",
+                    "default": 16
+                },
+                "namespace": {
                     "type": "string"
                 },
-                "topic": {
+                "sink_config": {
+                    "$ref": "#/definitions/config.SinkConfig"
+                },
+                "sink_uri": {
                     "type": "string"
+                },
+                "start_ts": {
+                    "type": "integer"
+                },
+                "target_ts": {
+                    "type": "integer"
+                },
+                "timezone": {
+                    "description": "timezone used when checking sink uri",
+                    "type": "string",
+                    "default": "system"
                 }
             }
         },
-        "config.SinkConfig": {
+        "model.ChangefeedDetail": {
             "type": "object",
             "properties": {
-                "column-selectors": {
+                "checkpoint_time": {
+                    "type": "string"
+                },
+                "checkpoint_tso": {
+                    "type": "integer"
+                },
+                "create_time": {
+                    "type": "string"
+                },
+                "creator_version": {
+                    "type": "string"
+                },
+                "error": {
+                    "$ref": "#/definitions/model.RunningError"
+                },
+                "error_history": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/config.ColumnSelector"
+                        "type": "integer"
                     }
                 },
-                "dispatchers": {
+                "id": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "resolved_ts": {
+                    "type": "integer"
+                },
+                "sink_uri": {
+                    "type": "string"
+                },
+                "sort_engine": {
+                    "type": "string"
+                },
+                "start_ts": {
+                    "type": "integer"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "target_ts": {
+                    "type": "integer"
+                },
+                "task_status": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/config.DispatchRule"
+                        "$ref": "#/definitions/model.CaptureTaskStatus"
                     }
                 },
-                "protocol": {
+                "upstream_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.HTTPError": {
+            "type": "object",
+            "properties": {
+                "error_code": {
                     "type": "string"
                 },
-                "schema-registry": {
+                "error_msg": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProcessorCommonInfo": {
+            "type": "object",
+            "properties": {
+                "capture_id": {
                     "type": "string"
                 },
-                "transaction-atomicity": {
+                "changefeed_id": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProcessorDetail": {
+            "type": "object",
+            "properties": {
+                "checkpoint_ts": {
+                    "description": "The maximum event CommitTs that has been synchronized.",
+                    "type": "integer"
+                },
+                "count": {
+                    "description": "The count of events that have been replicated.",
+                    "type": "integer"
+                },
+                "error": {
+                    "description": "Error code when error happens",
+                    "$ref": "#/definitions/model.RunningError"
+                },
+                "resolved_ts": {
+                    "description": "The event that satisfies CommitTs \u003c= ResolvedTs can be synchronized.",
+                    "type": "integer"
+                },
+                "table_ids": {
+                    "description": "all table ids that this processor are replicating",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "model.RunningError": {
+            "type": "object",
+            "properties": {
+                "addr": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ServerStatus": {
+            "type": "object",
+            "properties": {
+                "cluster_id": {
+                    "type": "string"
+                },
+                "git_hash": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_owner": {
+                    "type": "boolean"
+                },
+                "liveness": {
+                    "type": "integer"
+                },
+                "pid": {
+                    "type": "integer"
+                },
+                "version": {
                     "type": "string"
                 }
             }
         },
-        "model.Capture": {
+        "model.TableOperation": {
+            "type": "object",
+            "properties": {
+                "boundary_ts": {
+                    "description": "if the operation is a delete operation, BoundaryTs is checkpoint ts\nif the operation is an add operation, BoundaryTs is start ts",
+                    "type": "integer"
+                },
+                "delete": {
+                    "type": "boolean"
+                },
+                "flag": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.Capture": {
             "type": "object",
             "properties": {
                 "address": {
                     "type": "string"
                 },
+                "cluster_id": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
                 "is_owner": {
                     "type": "boolean"
+                },
+                "labels": {
+                    "description": "Labels of the capture, which are used by placement rules.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "model.CaptureTaskStatus": {
+        "v2.CaptureTaskStatus": {
             "type": "object",
             "properties": {
                 "capture_id": {
@@ -834,16 +1915,10 @@ var doc = `{
                     "items": {
                         "type": "integer"
                     }
-                },
-                "table_operations": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/model.TableOperation"
-                    }
                 }
             }
         },
-        "model.ChangefeedCommonInfo": {
+        "v2.ChangefeedCommonInfo": {
             "type": "object",
             "properties": {
                 "checkpoint_time": {
@@ -853,7 +1928,7 @@ var doc = `{
                     "type": "integer"
                 },
                 "error": {
-                    "$ref": "#/definitions/model.RunningError"
+                    "$ref": "#/definitions/v2.RunningError"
                 },
                 "id": {
                     "type": "string"
@@ -869,130 +1944,262 @@ var doc = `{
                 }
             }
         },
-        "model.ChangefeedConfig": {
+        "v2.ChangefeedConfigChange": {
             "type": "object",
             "properties": {
-                "changefeed_id": {
+                "from": {},
+                "path": {
                     "type": "string"
                 },
-                "filter_rules": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "to": {}
+            }
+        },
+        "v2.ChangefeedDetail": {
+            "type": "object",
+            "properties": {
+                "checkpoint_time": {
+                    "type": "string"
                 },
-                "force_replicate": {
-                    "description": "if true, force to replicate some ineligible tables",
-                    "type": "boolean",
-                    "default": false
+                "checkpoint_tso": {
+                    "type": "integer"
                 },
-                "ignore_ineligible_table": {
-                    "type": "boolean",
-                    "default": false
+                "create_time": {
+                    "type": "string"
                 },
-                "ignore_txn_start_ts": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "creator_version": {
+                    "type": "string"
                 },
-                "mounter_worker_num": {
-                    "type": "integer",
-                    "default": 16
+                "error": {
+                    "$ref": "#/definitions/v2.RunningError"
+                },
+                "id": {
+                    "type": "string"
                 },
                 "namespace": {
                     "type": "string"
                 },
-                "sink_config": {
-                    "$ref": "#/definitions/config.SinkConfig"
+                "resolved_ts": {
+                    "type": "integer"
                 },
                 "sink_uri": {
                     "type": "string"
                 },
+                "sort_engine": {
+                    "type": "string"
+                },
                 "start_ts": {
                     "type": "integer"
                 },
+                "state": {
+                    "type": "string"
+                },
                 "target_ts": {
                     "type": "integer"
                 },
-                "timezone": {
-                    "description": "timezone used when checking sink uri",
-                    "type": "string",
-                    "default": "system"
+                "task_status": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.CaptureTaskStatus"
+                    }
+                },
+                "upstream_id": {
+                    "type": "integer"
                 }
             }
         },
-        "model.ChangefeedDetail": {
+        "v2.ChangefeedEvent": {
             "type": "object",
             "properties": {
-                "checkpoint_time": {
+                "admin_job": {
                     "type": "string"
                 },
-                "checkpoint_tso": {
-                    "type": "integer"
+                "config_changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.ChangefeedConfigChange"
+                    }
                 },
-                "create_time": {
+                "error": {
+                    "$ref": "#/definitions/v2.RunningError"
+                },
+                "message": {
                     "type": "string"
                 },
-                "creator_version": {
+                "old_state": {
                     "type": "string"
                 },
-                "error": {
-                    "$ref": "#/definitions/model.RunningError"
+                "origin": {
+                    "description": "Where the admin job comes from, one of \"api\", \"cli\" and \"auto\".",
+                    "type": "string"
                 },
-                "error_history": {
+                "state": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "The type of the event, one of \"state-changed\", \"admin-job\", \"error\"\nand \"config-updated\".",
+                    "type": "string"
+                }
+            }
+        },
+        "v2.DataVerificationConfig": {
+            "type": "object",
+            "properties": {
+                "chunk_size": {
+                    "type": "integer"
+                },
+                "sync_point_ts": {
+                    "description": "SyncPointTs is the syncpoint of the changefeed to verify the data at,\nthe latest data is verified if it's 0.",
+                    "type": "integer"
+                },
+                "upstream_tidb": {
+                    "$ref": "#/definitions/v2.TiDBConfig"
+                }
+            }
+        },
+        "v2.DataVerificationResult": {
+            "type": "object",
+            "properties": {
+                "consistent": {
+                    "type": "boolean"
+                },
+                "fix_sql": {
+                    "description": "FixSQL makes the downstream data the same as the upstream.",
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "string"
                     }
                 },
-                "id": {
+                "tables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.TableDataDiff"
+                    }
+                }
+            }
+        },
+        "v2.DataVerificationStatus": {
+            "type": "object",
+            "properties": {
+                "end_time": {
                     "type": "string"
                 },
-                "namespace": {
+                "error": {
                     "type": "string"
                 },
-                "resolved_ts": {
-                    "type": "integer"
+                "result": {
+                    "$ref": "#/definitions/v2.DataVerificationResult",
+                    "description": "Result is only available if the job is finished."
                 },
-                "sink_uri": {
+                "start_time": {
                     "type": "string"
                 },
-                "sort_engine": {
+                "state": {
+                    "description": "State is one of running, finished and failed.",
                     "type": "string"
+                }
+            }
+        },
+        "v2.DrainCaptureResp": {
+            "type": "object",
+            "properties": {
+                "current_table_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.ListResponse-v2_Capture": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.Capture"
+                    }
                 },
-                "start_ts": {
+                "total": {
                     "type": "integer"
+                }
+            }
+        },
+        "v2.ListResponse-v2_ChangefeedCommonInfo": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.ChangefeedCommonInfo"
+                    }
                 },
-                "state": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.ListResponse-v2_ChangefeedEvent": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.ChangefeedEvent"
+                    }
                 },
-                "target_ts": {
+                "total": {
                     "type": "integer"
+                }
+            }
+        },
+        "v2.ListResponse-v2_ProcessorCommonInfo": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.ProcessorCommonInfo"
+                    }
                 },
-                "task_status": {
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.ListResponse-v2_TableStats": {
+            "type": "object",
+            "properties": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.CaptureTaskStatus"
+                        "$ref": "#/definitions/v2.TableStats"
                     }
                 },
-                "upstream_id": {
+                "total": {
                     "type": "integer"
                 }
             }
         },
-        "model.HTTPError": {
+        "v2.LogLevelReq": {
             "type": "object",
             "properties": {
-                "error_code": {
+                "log_level": {
                     "type": "string"
-                },
-                "error_msg": {
+                }
+            }
+        },
+        "v2.MoveTableReq": {
+            "type": "object",
+            "properties": {
+                "capture_id": {
                     "type": "string"
+                },
+                "table_id": {
+                    "type": "integer"
                 }
             }
         },
-        "model.ProcessorCommonInfo": {
+        "v2.ProcessorCommonInfo": {
             "type": "object",
             "properties": {
                 "capture_id": {
@@ -1006,7 +2213,7 @@ var doc = `{
                 }
             }
         },
-        "model.ProcessorDetail": {
+        "v2.ProcessorDetail": {
             "type": "object",
             "properties": {
                 "checkpoint_ts": {
@@ -1019,7 +2226,7 @@ var doc = `{
                 },
                 "error": {
                     "description": "Error code when error happens",
-                    "$ref": "#/definitions/model.RunningError"
+                    "$ref": "#/definitions/v2.RunningError"
                 },
                 "resolved_ts": {
                     "description": "The event that satisfies CommitTs \u003c= ResolvedTs can be synchronized.",
@@ -1034,7 +2241,7 @@ var doc = `{
                 }
             }
         },
-        "model.RunningError": {
+        "v2.RunningError": {
             "type": "object",
             "properties": {
                 "addr": {
@@ -1048,7 +2255,7 @@ var doc = `{
                 }
             }
         },
-        "model.ServerStatus": {
+        "v2.ServerStatus": {
             "type": "object",
             "properties": {
                 "cluster_id": {
@@ -1074,21 +2281,91 @@ var doc = `{
                 }
             }
         },
-        "model.TableOperation": {
+        "v2.TableDataDiff": {
             "type": "object",
             "properties": {
-                "boundary_ts": {
-                    "description": "if the operation is a delete operation, BoundaryTs is checkpoint ts\nif the operation is an add operation, BoundaryTs is start ts",
+                "chunks": {
                     "type": "integer"
                 },
-                "delete": {
-                    "type": "boolean"
+                "database_name": {
+                    "type": "string"
                 },
-                "flag": {
+                "different_rows": {
                     "type": "integer"
                 },
-                "status": {
+                "extra_rows": {
+                    "type": "integer"
+                },
+                "mismatched_chunks": {
+                    "type": "integer"
+                },
+                "missing_rows": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "string"
+                },
+                "table_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "v2.TableStats": {
+            "type": "object",
+            "properties": {
+                "capture_id": {
+                    "type": "string"
+                },
+                "checkpoint_lag": {
+                    "description": "The lag of checkpoint ts and resolved ts in seconds.",
+                    "type": "number"
+                },
+                "checkpoint_ts": {
+                    "type": "integer"
+                },
+                "resolved_lag": {
+                    "type": "number"
+                },
+                "resolved_ts": {
+                    "type": "integer"
+                },
+                "sink_bytes_per_second": {
+                    "type": "number"
+                },
+                "sink_rows_per_second": {
+                    "description": "The throughput of rows emitted to the sink.",
+                    "type": "number"
+                },
+                "sorter_backlog": {
+                    "description": "The number of events received by the sorter but not yet output.",
+                    "type": "integer"
+                },
+                "state": {
+                    "description": "The state of the table pipeline, such as \"Replicating\".",
+                    "type": "string"
+                },
+                "table_id": {
+                    "type": "integer"
+                },
+                "table_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "v2.TiDBConfig": {
+            "type": "object",
+            "properties": {
+                "host": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "port": {
                     "type": "integer"
+                },
+                "user": {
+                    "type": "string"
                 }
             }
         }
@@ -18,6 +18,7 @@ import (
 	"strings"
 
 	"github.com/pingcap/tiflow/pkg/httputil"
+	"github.com/pingcap/tiflow/pkg/security"
 )
 
 // HTTPMethod represents HTTP method.
@@ -66,6 +67,9 @@ type CDCRESTClient struct {
 
 	// Client is a wrapped http client.
 	Client *httputil.Client
+
+	// apiCredential is used for authenticating to the cdc server.
+	apiCredential *security.APICredential
 }
 
 // NewCDCRESTClient creates a new CDCRESTClient.
@@ -31,6 +31,8 @@ type Config struct {
 	APIPath string
 	// Credential holds the security Credential used for generating tls config
 	Credential *security.Credential
+	// APICredential holds the credential used for authenticating to the cdc server
+	APICredential *security.APICredential
 	// API verion
 	Version string
 }
@@ -83,6 +85,7 @@ func CDCRESTClientFromConfig(config *Config) (*CDCRESTClient, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	restClient.apiCredential = config.APICredential
 
 	return restClient, nil
 }
@@ -89,3 +89,22 @@ func TestCDCRESTClientUsingTLS(t *testing.T) {
 		require.Equal(t, usingTLS, tc.UsingTLS)
 	}
 }
+
+func TestCDCRESTClientAPICredential(t *testing.T) {
+	client, err := CDCRESTClientFromConfig(&Config{
+		Host:          "127.0.0.1:8300",
+		APIPath:       "/api",
+		Version:       "v2",
+		APICredential: &security.APICredential{Token: "token"},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "Bearer token", client.Get().headers.Get("Authorization"))
+
+	client, err = CDCRESTClientFromConfig(&Config{
+		Host:    "127.0.0.1:8300",
+		APIPath: "/api",
+		Version: "v2",
+	})
+	require.Nil(t, err)
+	require.Empty(t, client.Get().headers.Get("Authorization"))
+}
@@ -88,6 +88,9 @@ func NewRequest(c *CDCRESTClient) *Request {
 	}
 	r.WithHeader("Accept", "application/json")
 	r.WithHeader(middleware.ClientVersionHeader, version.ReleaseVersion)
+	if auth := c.apiCredential.AuthorizationHeader(); auth != "" {
+		r.WithHeader("Authorization", auth)
+	}
 	return r
 }
 
@@ -31,6 +31,9 @@ type ChangefeedsGetter interface {
 type ChangefeedInterface interface {
 	// Create creates a changefeed
 	Create(ctx context.Context, cfg *v2.ChangefeedConfig) (*v2.ChangeFeedInfo, error)
+	// DryRun validates a changefeed config without creating the changefeed
+	DryRun(ctx context.Context,
+		cfg *v2.ChangefeedConfig) (*v2.ChangefeedPreflightResult, error)
 	// GetInfo gets a changefeed's info
 	GetInfo(ctx context.Context, name string) (*v2.ChangeFeedInfo, error)
 	// VerifyTable verifies table for a changefeed
@@ -40,6 +43,16 @@ type ChangefeedInterface interface {
 		name string) (*v2.ChangeFeedInfo, error)
 	// Resume resumes a changefeed with given config
 	Resume(ctx context.Context, cfg *v2.ResumeChangefeedConfig, name string) error
+	// Get gets the detail of a changefeed
+	Get(ctx context.Context, name string) (*v2.ChangefeedDetail, error)
+	// List lists the changefeeds in the given state
+	List(ctx context.Context, state string) ([]v2.ChangefeedCommonInfo, error)
+	// Pause pauses a changefeed
+	Pause(ctx context.Context, name string) error
+	// Delete removes a changefeed
+	Delete(ctx context.Context, name string) error
+	// History lists the recent events of a changefeed
+	History(ctx context.Context, name string) ([]v2.ChangefeedEvent, error)
 }
 
 // changefeeds implements ChangefeedInterface
@@ -65,6 +78,19 @@ func (c *changefeeds) Create(ctx context.Context,
 	return result, err
 }
 
+func (c *changefeeds) DryRun(ctx context.Context,
+	cfg *v2.ChangefeedConfig,
+) (*v2.ChangefeedPreflightResult, error) {
+	result := &v2.ChangefeedPreflightResult{}
+	err := c.client.Post().
+		WithURI("changefeeds").
+		WithParam("dry_run", "true").
+		WithBody(cfg).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
 func (c *changefeeds) VerifyTable(ctx context.Context,
 	cfg *v2.VerifyTableConfig,
 ) (*v2.Tables, error) {
@@ -112,3 +138,58 @@ func (c *changefeeds) Resume(ctx context.Context,
 		WithBody(cfg).
 		Do(ctx).Error()
 }
+
+// Get gets the detail of a changefeed
+func (c *changefeeds) Get(ctx context.Context,
+	name string,
+) (*v2.ChangefeedDetail, error) {
+	result := &v2.ChangefeedDetail{}
+	u := fmt.Sprintf("changefeeds/%s", name)
+	err := c.client.Get().
+		WithURI(u).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+// List returns the list of changefeeds
+func (c *changefeeds) List(ctx context.Context,
+	state string,
+) ([]v2.ChangefeedCommonInfo, error) {
+	result := &v2.ListResponse[v2.ChangefeedCommonInfo]{}
+	err := c.client.Get().
+		WithURI("changefeeds").
+		WithParam("state", state).
+		Do(ctx).
+		Into(result)
+	return result.Items, err
+}
+
+// Pause the changefeed
+func (c *changefeeds) Pause(ctx context.Context, name string) error {
+	u := fmt.Sprintf("changefeeds/%s/pause", name)
+	return c.client.Post().
+		WithURI(u).
+		Do(ctx).Error()
+}
+
+// Delete removes the changefeed
+func (c *changefeeds) Delete(ctx context.Context, name string) error {
+	u := fmt.Sprintf("changefeeds/%s", name)
+	return c.client.Delete().
+		WithURI(u).
+		Do(ctx).Error()
+}
+
+// History lists the recent events of the changefeed
+func (c *changefeeds) History(ctx context.Context,
+	name string,
+) ([]v2.ChangefeedEvent, error) {
+	result := &v2.ListResponse[v2.ChangefeedEvent]{}
+	u := fmt.Sprintf("changefeeds/%s/events", name)
+	err := c.client.Get().
+		WithURI(u).
+		Do(ctx).
+		Into(result)
+	return result.Items, err
+}
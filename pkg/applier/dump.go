@@ -0,0 +1,457 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/util/sqlexec"
+	tfilter "github.com/pingcap/tidb/util/table-filter"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/redo"
+	"github.com/pingcap/tiflow/cdc/redo/reader"
+	"github.com/pingcap/tiflow/cdc/sink/mysql"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/quotes"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+const (
+	// DumpFormatJSON dumps redo logs as JSON lines.
+	DumpFormatJSON = "json"
+	// DumpFormatSQL dumps redo logs as SQL statements.
+	DumpFormatSQL = "sql"
+
+	dumpReadBatch = 1024
+)
+
+// RedoDumpConfig is the configuration used by a redo log dumper
+type RedoDumpConfig struct {
+	Storage string
+	Dir     string
+	// TableFilter only dumps the events of the matched tables, all tables are
+	// dumped if it's empty.
+	TableFilter []string
+	// StartTs and EndTs only dump the events whose commit ts is in (StartTs, EndTs],
+	// they default to the checkpoint ts and resolved ts in the redo meta.
+	StartTs uint64
+	EndTs   uint64
+	// TxnStartTs only dumps the events of the transaction if it's not 0.
+	TxnStartTs uint64
+	// Format is the output format of events, it's DumpFormatJSON or DumpFormatSQL.
+	Format string
+	// SummaryOnly only outputs the summary statistics if it's true.
+	SummaryOnly bool
+	// EncryptionKeyPath and EncryptionKeyEnv specify the key of encrypted redo logs.
+	EncryptionKeyPath string
+	EncryptionKeyEnv  string
+}
+
+// RedoDumpTableStats is the statistics of the dumped events of a table
+type RedoDumpTableStats struct {
+	Schema        string `json:"schema"`
+	Table         string `json:"table"`
+	Inserts       uint64 `json:"inserts"`
+	Updates       uint64 `json:"updates"`
+	Deletes       uint64 `json:"deletes"`
+	DDLs          uint64 `json:"ddls"`
+	FirstCommitTs uint64 `json:"first-commit-ts"`
+	LastCommitTs  uint64 `json:"last-commit-ts"`
+	// CheckpointGapMs is the physical time between the checkpoint ts in meta
+	// and the first event of the table.
+	CheckpointGapMs int64 `json:"checkpoint-gap-ms"`
+	// ResolvedGapMs is the physical time between the last event of the table
+	// and the resolved ts in meta.
+	ResolvedGapMs int64 `json:"resolved-gap-ms"`
+}
+
+// Rows returns the number of dumped row changed events of the table.
+func (s *RedoDumpTableStats) Rows() uint64 {
+	return s.Inserts + s.Updates + s.Deletes
+}
+
+func (s *RedoDumpTableStats) observe(commitTs uint64) {
+	if s.FirstCommitTs == 0 || commitTs < s.FirstCommitTs {
+		s.FirstCommitTs = commitTs
+	}
+	if commitTs > s.LastCommitTs {
+		s.LastCommitTs = commitTs
+	}
+}
+
+// RedoDumpSummary is the summary of a redo log dump
+type RedoDumpSummary struct {
+	CheckpointTs uint64                `json:"checkpoint-ts"`
+	ResolvedTs   uint64                `json:"resolved-ts"`
+	StartTs      uint64                `json:"start-ts"`
+	EndTs        uint64                `json:"end-ts"`
+	Tables       []*RedoDumpTableStats `json:"tables"`
+}
+
+// dumpRow is the JSON representation of a row changed event.
+type dumpRow struct {
+	Type       string                 `json:"type"`
+	StartTs    uint64                 `json:"start-ts"`
+	CommitTs   uint64                 `json:"commit-ts"`
+	Schema     string                 `json:"schema"`
+	Table      string                 `json:"table"`
+	TableID    int64                  `json:"table-id"`
+	Op         string                 `json:"op"`
+	Columns    map[string]interface{} `json:"columns,omitempty"`
+	PreColumns map[string]interface{} `json:"pre-columns,omitempty"`
+}
+
+// dumpDDL is the JSON representation of a DDL event.
+type dumpDDL struct {
+	Type     string `json:"type"`
+	StartTs  uint64 `json:"start-ts"`
+	CommitTs uint64 `json:"commit-ts"`
+	Schema   string `json:"schema,omitempty"`
+	Table    string `json:"table,omitempty"`
+	Query    string `json:"query"`
+}
+
+// RedoDumper dumps the events in redo logs for inspection
+type RedoDumper struct {
+	cfg    *RedoDumpConfig
+	filter tfilter.Filter
+	stats  map[string]*RedoDumpTableStats
+}
+
+// NewRedoDumper creates a new RedoDumper instance
+func NewRedoDumper(cfg *RedoDumpConfig) (*RedoDumper, error) {
+	switch cfg.Format {
+	case "":
+		cfg.Format = DumpFormatJSON
+	case DumpFormatJSON, DumpFormatSQL:
+	default:
+		return nil, cerror.WrapError(cerror.ErrRedoConfigInvalid,
+			errors.Errorf("unsupported redo dump format %s", cfg.Format))
+	}
+	d := &RedoDumper{cfg: cfg, stats: make(map[string]*RedoDumpTableStats)}
+	if len(cfg.TableFilter) > 0 {
+		f, err := tfilter.Parse(cfg.TableFilter)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err, cfg.TableFilter)
+		}
+		d.filter = tfilter.CaseInsensitive(f)
+	}
+	return d, nil
+}
+
+// Dump writes the events in redo logs to w and returns the summary of them.
+func (d *RedoDumper) Dump(ctx context.Context, w io.Writer) (*RedoDumpSummary, error) {
+	rd, err := createRedoReader(ctx, &RedoApplierConfig{
+		Storage: d.cfg.Storage,
+		Dir:     d.cfg.Dir,
+
+		EncryptionKeyPath: d.cfg.EncryptionKeyPath,
+		EncryptionKeyEnv:  d.cfg.EncryptionKeyEnv,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer rd.Close() //nolint:errcheck
+
+	checkpointTs, resolvedTs, err := rd.ReadMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+	summary := &RedoDumpSummary{
+		CheckpointTs: checkpointTs,
+		ResolvedTs:   resolvedTs,
+		StartTs:      checkpointTs,
+		EndTs:        resolvedTs,
+	}
+	if d.cfg.StartTs != 0 {
+		summary.StartTs = d.cfg.StartTs
+	}
+	if d.cfg.EndTs != 0 {
+		summary.EndTs = d.cfg.EndTs
+	}
+
+	if summary.StartTs < summary.EndTs {
+		err = rd.ResetReader(ctx, summary.StartTs, summary.EndTs)
+		if err != nil {
+			return nil, err
+		}
+		if err := d.dumpEvents(ctx, rd, w); err != nil {
+			return nil, err
+		}
+	}
+
+	summary.Tables = make([]*RedoDumpTableStats, 0, len(d.stats))
+	for _, stats := range d.stats {
+		stats.CheckpointGapMs = oracle.ExtractPhysical(stats.FirstCommitTs) -
+			oracle.ExtractPhysical(checkpointTs)
+		stats.ResolvedGapMs = oracle.ExtractPhysical(resolvedTs) -
+			oracle.ExtractPhysical(stats.LastCommitTs)
+		summary.Tables = append(summary.Tables, stats)
+	}
+	sort.Slice(summary.Tables, func(i, j int) bool {
+		if summary.Tables[i].Schema != summary.Tables[j].Schema {
+			return summary.Tables[i].Schema < summary.Tables[j].Schema
+		}
+		return summary.Tables[i].Table < summary.Tables[j].Table
+	})
+	return summary, nil
+}
+
+// dumpEvents merges the DDL events into the row changed events by commit ts,
+// both of them are sorted by the redo log reader.
+func (d *RedoDumper) dumpEvents(ctx context.Context, rd reader.RedoLogReader, w io.Writer) error {
+	var ddls []*model.DDLEvent
+	for {
+		redoDDLs, err := rd.ReadNextDDL(ctx, dumpReadBatch)
+		if err != nil {
+			return err
+		}
+		if len(redoDDLs) == 0 {
+			break
+		}
+		for _, redoDDL := range redoDDLs {
+			if d.matchDDL(redoDDL.DDL) {
+				ddls = append(ddls, redoDDL.DDL)
+			}
+		}
+	}
+
+	for {
+		redoLogs, err := rd.ReadNextLog(ctx, dumpReadBatch)
+		if err != nil {
+			return err
+		}
+		if len(redoLogs) == 0 {
+			break
+		}
+		for _, redoLog := range redoLogs {
+			row := redo.LogToRow(redoLog)
+			if !d.matchRow(row) {
+				continue
+			}
+			for len(ddls) > 0 && ddls[0].CommitTs < row.CommitTs {
+				if err := d.dumpDDL(w, ddls[0]); err != nil {
+					return err
+				}
+				ddls = ddls[1:]
+			}
+			if err := d.dumpRow(w, row); err != nil {
+				return err
+			}
+		}
+	}
+	for _, ddl := range ddls {
+		if err := d.dumpDDL(w, ddl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *RedoDumper) matchRow(row *model.RowChangedEvent) bool {
+	if d.cfg.TxnStartTs != 0 && row.StartTs != d.cfg.TxnStartTs {
+		return false
+	}
+	return d.filter == nil || d.filter.MatchTable(row.Table.Schema, row.Table.Table)
+}
+
+func (d *RedoDumper) matchDDL(ddl *model.DDLEvent) bool {
+	if d.cfg.TxnStartTs != 0 && ddl.StartTs != d.cfg.TxnStartTs {
+		return false
+	}
+	if d.filter == nil {
+		return true
+	}
+	if ddl.TableInfo == nil {
+		return false
+	}
+	if ddl.TableInfo.Table == "" {
+		return d.filter.MatchSchema(ddl.TableInfo.Schema)
+	}
+	return d.filter.MatchTable(ddl.TableInfo.Schema, ddl.TableInfo.Table)
+}
+
+func (d *RedoDumper) getStats(schema, table string) *RedoDumpTableStats {
+	key := quotes.QuoteSchema(schema, table)
+	stats, ok := d.stats[key]
+	if !ok {
+		stats = &RedoDumpTableStats{Schema: schema, Table: table}
+		d.stats[key] = stats
+	}
+	return stats
+}
+
+func (d *RedoDumper) dumpRow(w io.Writer, row *model.RowChangedEvent) error {
+	stats := d.getStats(row.Table.Schema, row.Table.Table)
+	stats.observe(row.CommitTs)
+	op := "update"
+	switch {
+	case row.IsInsert():
+		op = "insert"
+		stats.Inserts++
+	case row.IsDelete():
+		op = "delete"
+		stats.Deletes++
+	default:
+		stats.Updates++
+	}
+	if d.cfg.SummaryOnly {
+		return nil
+	}
+
+	if d.cfg.Format == DumpFormatSQL {
+		query, err := rowToSQL(row)
+		if err != nil {
+			return err
+		}
+		// the MySQL sink skips the rows without handle keys
+		if query == "" {
+			return nil
+		}
+		return writeLine(w, query)
+	}
+	return writeJSONLine(w, &dumpRow{
+		Type:       "row",
+		StartTs:    row.StartTs,
+		CommitTs:   row.CommitTs,
+		Schema:     row.Table.Schema,
+		Table:      row.Table.Table,
+		TableID:    row.Table.TableID,
+		Op:         op,
+		Columns:    columnsToMap(row.Columns),
+		PreColumns: columnsToMap(row.PreColumns),
+	})
+}
+
+func (d *RedoDumper) dumpDDL(w io.Writer, ddl *model.DDLEvent) error {
+	var schema, table string
+	if ddl.TableInfo != nil {
+		schema, table = ddl.TableInfo.Schema, ddl.TableInfo.Table
+		if table != "" {
+			stats := d.getStats(schema, table)
+			stats.observe(ddl.CommitTs)
+			stats.DDLs++
+		}
+	}
+	if d.cfg.SummaryOnly {
+		return nil
+	}
+
+	if d.cfg.Format == DumpFormatSQL {
+		query := strings.TrimRight(strings.TrimSpace(ddl.Query), ";")
+		if schema != "" {
+			return writeLine(w, fmt.Sprintf("USE %s; %s;", quotes.QuoteName(schema), query))
+		}
+		return writeLine(w, query+";")
+	}
+	return writeJSONLine(w, &dumpDDL{
+		Type:     "ddl",
+		StartTs:  ddl.StartTs,
+		CommitTs: ddl.CommitTs,
+		Schema:   schema,
+		Table:    table,
+		Query:    ddl.Query,
+	})
+}
+
+// WriteSummary writes the summary in the format of the dumper.
+func (d *RedoDumper) WriteSummary(w io.Writer, summary *RedoDumpSummary) error {
+	if d.cfg.Format == DumpFormatJSON {
+		return writeJSONLine(w, &struct {
+			Type string `json:"type"`
+			*RedoDumpSummary
+		}{Type: "summary", RedoDumpSummary: summary})
+	}
+
+	lines := []string{
+		fmt.Sprintf("-- checkpoint-ts: %d, resolved-ts: %d, dumped range: (%d, %d]",
+			summary.CheckpointTs, summary.ResolvedTs, summary.StartTs, summary.EndTs),
+	}
+	for _, stats := range summary.Tables {
+		lines = append(lines, fmt.Sprintf(
+			"-- %s: rows: %d (insert: %d, update: %d, delete: %d), ddls: %d, "+
+				"first-commit-ts: %d, last-commit-ts: %d, "+
+				"checkpoint-gap: %dms, resolved-gap: %dms",
+			quotes.QuoteSchema(stats.Schema, stats.Table), stats.Rows(),
+			stats.Inserts, stats.Updates, stats.Deletes, stats.DDLs,
+			stats.FirstCommitTs, stats.LastCommitTs,
+			stats.CheckpointGapMs, stats.ResolvedGapMs))
+	}
+	return writeLine(w, strings.Join(lines, "\n"))
+}
+
+func writeLine(w io.Writer, line string) error {
+	_, err := io.WriteString(w, line+"\n")
+	return errors.Trace(err)
+}
+
+func writeJSONLine(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return writeLine(w, string(data))
+}
+
+func columnsToMap(cols []*model.Column) map[string]interface{} {
+	if len(cols) == 0 {
+		return nil
+	}
+	m := make(map[string]interface{}, len(cols))
+	for _, col := range cols {
+		if col == nil {
+			continue
+		}
+		value := col.Value
+		if b, ok := value.([]byte); ok && isTextColumn(col) {
+			value = string(b)
+		}
+		m[col.Name] = value
+	}
+	return m
+}
+
+// rowToSQL converts a row changed event to the SQLs which are executed by
+// the MySQL sink when the redo logs are applied. The arguments are
+// interpolated in the same way as the MySQL driver does.
+func rowToSQL(row *model.RowChangedEvent) (string, error) {
+	sqls, values := mysql.PrepareDMLs([]*model.RowChangedEvent{row})
+	lines := make([]string, 0, len(sqls))
+	for i, query := range sqls {
+		query = strings.ReplaceAll(query, "%", "%%")
+		query = strings.ReplaceAll(query, "?", "%?")
+		line, err := sqlexec.EscapeSQL(query, values[i]...)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		if !strings.HasSuffix(line, ";") {
+			line += ";"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// isTextColumn follows the MySQL sink, []byte values of columns with a non
+// binary charset are strings.
+func isTextColumn(col *model.Column) bool {
+	return col.Charset != "" && col.Charset != charset.CharsetBin
+}
@@ -0,0 +1,207 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/redo"
+	"github.com/pingcap/tiflow/cdc/redo/reader"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func newDumpTestReader() (reader.RedoLogReader, error) {
+	dmls := []*model.RowChangedEvent{
+		{
+			StartTs:  oracle.ComposeTS(1900, 0),
+			CommitTs: oracle.ComposeTS(2000, 0),
+			Table:    &model.TableName{Schema: "test", Table: "t1", TableID: 1},
+			Columns: []*model.Column{
+				{Name: "a", Type: mysql.TypeLong, Value: 1, Flag: model.HandleKeyFlag},
+				{Name: "b", Type: mysql.TypeVarchar, Charset: "utf8mb4", Value: []byte("it's")},
+			},
+		},
+		{
+			StartTs:  oracle.ComposeTS(2900, 0),
+			CommitTs: oracle.ComposeTS(3000, 0),
+			Table:    &model.TableName{Schema: "test", Table: "t1", TableID: 1},
+			PreColumns: []*model.Column{
+				{Name: "a", Type: mysql.TypeLong, Value: 1, Flag: model.HandleKeyFlag},
+				{Name: "b", Type: mysql.TypeVarchar, Charset: "utf8mb4", Value: []byte("it's")},
+			},
+			Columns: []*model.Column{
+				{Name: "a", Type: mysql.TypeLong, Value: 2, Flag: model.HandleKeyFlag},
+				{Name: "b", Type: mysql.TypeVarchar, Charset: "utf8mb4", Value: nil},
+			},
+		},
+		{
+			StartTs:  oracle.ComposeTS(3900, 0),
+			CommitTs: oracle.ComposeTS(4000, 0),
+			Table:    &model.TableName{Schema: "test", Table: "t2", TableID: 2},
+			PreColumns: []*model.Column{
+				{
+					Name: "c", Type: mysql.TypeVarString, Value: []byte("ab"),
+					Flag: model.HandleKeyFlag | model.BinaryFlag,
+				},
+			},
+		},
+	}
+	ddls := []*model.DDLEvent{
+		{
+			StartTs:  oracle.ComposeTS(2400, 0),
+			CommitTs: oracle.ComposeTS(2500, 0),
+			TableInfo: &model.SimpleTableInfo{
+				Schema: "test", Table: "t2", TableID: 2,
+			},
+			Query: "create table t2 (c varbinary(16) primary key)",
+			Type:  timodel.ActionCreateTable,
+		},
+	}
+	redoLogCh := make(chan *model.RedoRowChangedEvent, len(dmls))
+	for _, dml := range dmls {
+		redoLogCh <- redo.RowToRedo(dml)
+	}
+	close(redoLogCh)
+	ddlEventCh := make(chan *model.RedoDDLEvent, len(ddls))
+	for _, ddl := range ddls {
+		ddlEventCh <- redo.DDLToRedo(ddl)
+	}
+	close(ddlEventCh)
+	return NewMockReader(oracle.ComposeTS(1000, 0), oracle.ComposeTS(5000, 0),
+		redoLogCh, ddlEventCh), nil
+}
+
+func TestDumpJSON(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	createRedoReaderBak := createRedoReader
+	createRedoReader = func(ctx context.Context, cfg *RedoApplierConfig) (reader.RedoLogReader, error) {
+		return newDumpTestReader()
+	}
+	defer func() {
+		createRedoReader = createRedoReaderBak
+	}()
+
+	d, err := NewRedoDumper(&RedoDumpConfig{Storage: "blackhole://"})
+	require.Nil(t, err)
+	var buf bytes.Buffer
+	summary, err := d.Dump(ctx, &buf)
+	require.Nil(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+	events := make([]map[string]interface{}, 0, len(lines))
+	for _, line := range lines {
+		event := make(map[string]interface{})
+		require.Nil(t, json.Unmarshal([]byte(line), &event))
+		events = append(events, event)
+	}
+	// The DDL is merged into the rows by commit ts.
+	require.Equal(t, "row", events[0]["type"])
+	require.Equal(t, "insert", events[0]["op"])
+	require.Equal(t, map[string]interface{}{"a": float64(1), "b": "it's"}, events[0]["columns"])
+	require.Equal(t, "ddl", events[1]["type"])
+	require.Equal(t, "create table t2 (c varbinary(16) primary key)", events[1]["query"])
+	require.Equal(t, "update", events[2]["op"])
+	require.Equal(t, "delete", events[3]["op"])
+	require.Equal(t, "t2", events[3]["table"])
+
+	require.Equal(t, oracle.ComposeTS(1000, 0), summary.StartTs)
+	require.Equal(t, oracle.ComposeTS(5000, 0), summary.EndTs)
+	require.Equal(t, []*RedoDumpTableStats{
+		{
+			Schema: "test", Table: "t1", Inserts: 1, Updates: 1,
+			FirstCommitTs:   oracle.ComposeTS(2000, 0),
+			LastCommitTs:    oracle.ComposeTS(3000, 0),
+			CheckpointGapMs: 1000,
+			ResolvedGapMs:   2000,
+		},
+		{
+			Schema: "test", Table: "t2", Deletes: 1, DDLs: 1,
+			FirstCommitTs:   oracle.ComposeTS(2500, 0),
+			LastCommitTs:    oracle.ComposeTS(4000, 0),
+			CheckpointGapMs: 1500,
+			ResolvedGapMs:   1000,
+		},
+	}, summary.Tables)
+
+	buf.Reset()
+	require.Nil(t, d.WriteSummary(&buf, summary))
+	require.Contains(t, buf.String(), `"type":"summary"`)
+	require.Contains(t, buf.String(), `"checkpoint-gap-ms":1500`)
+}
+
+func TestDumpSQL(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	createRedoReaderBak := createRedoReader
+	createRedoReader = func(ctx context.Context, cfg *RedoApplierConfig) (reader.RedoLogReader, error) {
+		return newDumpTestReader()
+	}
+	defer func() {
+		createRedoReader = createRedoReaderBak
+	}()
+
+	d, err := NewRedoDumper(&RedoDumpConfig{Format: DumpFormatSQL})
+	require.Nil(t, err)
+	var buf bytes.Buffer
+	_, err = d.Dump(ctx, &buf)
+	require.Nil(t, err)
+	// The SQLs are the same as the ones executed by the MySQL sink in safe mode.
+	require.Equal(t, "REPLACE INTO `test`.`t1`(`a`,`b`) VALUES (1,'it\\'s');\n"+
+		"USE `test`; create table t2 (c varbinary(16) primary key);\n"+
+		"DELETE FROM `test`.`t1` WHERE `a` = 1 LIMIT 1;\n"+
+		"REPLACE INTO `test`.`t1`(`a`,`b`) VALUES (2,NULL);\n"+
+		"DELETE FROM `test`.`t2` WHERE `c` = _binary'ab' LIMIT 1;\n", buf.String())
+
+	// Filter by table.
+	d, err = NewRedoDumper(&RedoDumpConfig{
+		Format: DumpFormatSQL, TableFilter: []string{"test.t2"},
+	})
+	require.Nil(t, err)
+	buf.Reset()
+	summary, err := d.Dump(ctx, &buf)
+	require.Nil(t, err)
+	require.Equal(t, "USE `test`; create table t2 (c varbinary(16) primary key);\n"+
+		"DELETE FROM `test`.`t2` WHERE `c` = _binary'ab' LIMIT 1;\n", buf.String())
+	require.Len(t, summary.Tables, 1)
+
+	buf.Reset()
+	require.Nil(t, d.WriteSummary(&buf, summary))
+	require.Contains(t, buf.String(), "-- `test`.`t2`: rows: 1 (insert: 0, update: 0, delete: 1), ddls: 1")
+
+	// Filter by the start ts of the transaction.
+	d, err = NewRedoDumper(&RedoDumpConfig{
+		Format: DumpFormatSQL, TxnStartTs: oracle.ComposeTS(2900, 0),
+	})
+	require.Nil(t, err)
+	buf.Reset()
+	_, err = d.Dump(ctx, &buf)
+	require.Nil(t, err)
+	require.Equal(t, "DELETE FROM `test`.`t1` WHERE `a` = 1 LIMIT 1;\n"+
+		"REPLACE INTO `test`.`t1`(`a`,`b`) VALUES (2,NULL);\n", buf.String())
+
+	_, err = NewRedoDumper(&RedoDumpConfig{Format: "csv"})
+	require.Regexp(t, "ErrRedoConfigInvalid", err)
+}
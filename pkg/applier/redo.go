@@ -16,17 +16,24 @@ package applier
 import (
 	"context"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/contextutil"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/redo"
+	"github.com/pingcap/tiflow/cdc/redo/common"
 	"github.com/pingcap/tiflow/cdc/redo/reader"
 	"github.com/pingcap/tiflow/cdc/sink"
 	"github.com/pingcap/tiflow/cdc/sink/mysql"
+	ddlfactory "github.com/pingcap/tiflow/cdc/sinkv2/ddlsink/factory"
+	"github.com/pingcap/tiflow/cdc/sinkv2/eventsink/factory"
+	"github.com/pingcap/tiflow/cdc/sinkv2/tablesink"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	psink "github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -36,6 +43,10 @@ const (
 	applierChangefeed = "redo-applier"
 	emitBatch         = mysql.DefaultMaxTxnRow
 	readBatch         = mysql.DefaultWorkerCount * emitBatch
+
+	// flushCheckInterval is the interval to check whether the events are
+	// flushed by table sinks.
+	flushCheckInterval = 50 * time.Millisecond
 )
 
 var errApplyFinished = errors.New("apply finished, can exit safely")
@@ -45,6 +56,9 @@ type RedoApplierConfig struct {
 	SinkURI string
 	Storage string
 	Dir     string
+	// EncryptionKeyPath and EncryptionKeyEnv specify the key of encrypted redo logs.
+	EncryptionKeyPath string
+	EncryptionKeyEnv  string
 }
 
 // RedoApplier implements a redo log applier
@@ -69,9 +83,14 @@ func (rac *RedoApplierConfig) toLogReaderConfig() (string, *reader.LogReaderConf
 	if err != nil {
 		return "", nil, cerror.WrapError(cerror.ErrConsistentStorage, err)
 	}
+	key, err := common.LoadEncryptionKey(rac.EncryptionKeyPath, rac.EncryptionKeyEnv)
+	if err != nil {
+		return "", nil, err
+	}
 	cfg := &reader.LogReaderConfig{
-		Dir:       uri.Path,
-		S3Storage: redo.IsS3StorageEnabled(uri.Scheme),
+		Dir:           uri.Path,
+		S3Storage:     redo.IsS3StorageEnabled(uri.Scheme),
+		EncryptionKey: key,
 	}
 	if cfg.S3Storage {
 		cfg.S3URI = *uri
@@ -109,6 +128,14 @@ func (ra *RedoApplier) consumeLogs(ctx context.Context) error {
 	}
 	log.Info("apply redo log starts", zap.Uint64("checkpointTs", checkpointTs), zap.Uint64("resolvedTs", resolvedTs))
 
+	sinkURI, err := url.Parse(ra.cfg.SinkURI)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	if !psink.IsMySQLCompatibleScheme(strings.ToLower(sinkURI.Scheme)) {
+		return ra.applyToEventSink(ctx, checkpointTs, resolvedTs)
+	}
+
 	// MySQL sink will use the following replication config
 	// - EnableOldValue: default true
 	// - ForceReplicate: default false
@@ -189,6 +216,138 @@ func (ra *RedoApplier) consumeLogs(ctx context.Context) error {
 	return errApplyFinished
 }
 
+// applyToEventSink replays redo logs to the sinks which are not compatible
+// with MySQL, such as MQ and storage sinks. Rows are appended to table sinks
+// in commit ts order, and each resolved ts barrier is written as a checkpoint
+// only after all rows before it are flushed, so that downstream consumers
+// see a transaction consistent snapshot at every barrier.
+func (ra *RedoApplier) applyToEventSink(
+	ctx context.Context, checkpointTs, resolvedTs uint64,
+) error {
+	ctx = contextutil.PutRoleInCtx(ctx, util.RoleRedoLogApplier)
+	ctx = contextutil.PutChangefeedIDInCtx(ctx, model.DefaultChangeFeedID(applierChangefeed))
+	replicaConfig := config.GetDefaultReplicaConfig()
+	sinkFactory, err := factory.New(ctx, ra.cfg.SinkURI, replicaConfig, ra.errCh)
+	if err != nil {
+		return err
+	}
+	ddlSink, err := ddlfactory.New(ctx, ra.cfg.SinkURI, replicaConfig)
+	if err != nil {
+		sinkFactory.Close() //nolint:errcheck
+		return err
+	}
+
+	tableSinks := make(map[model.TableID]tablesink.TableSink)
+	tableNames := make([]model.TableName, 0)
+	defer func() {
+		ra.rd.Close() //nolint:errcheck
+		for _, tableSink := range tableSinks {
+			tableSink.Close(ctx) //nolint:errcheck
+		}
+		ddlSink.Close()     //nolint:errcheck
+		sinkFactory.Close() //nolint:errcheck
+	}()
+
+	var ddls []*model.DDLEvent
+	for {
+		redoDDLs, err := ra.rd.ReadNextDDL(ctx, readBatch)
+		if err != nil {
+			return err
+		}
+		if len(redoDDLs) == 0 {
+			break
+		}
+		for _, redoDDL := range redoDDLs {
+			ddls = append(ddls, redoDDL.DDL)
+		}
+	}
+
+	// lastBarrier records the max resolved ts that all rows before it have
+	// been flushed to downstream.
+	lastBarrier := checkpointTs
+	flush := func(barrier uint64) error {
+		if barrier <= lastBarrier {
+			return nil
+		}
+		for _, tableSink := range tableSinks {
+			if err := tableSink.UpdateResolvedTs(model.NewResolvedTs(barrier)); err != nil {
+				return err
+			}
+		}
+		ticker := time.NewTicker(flushCheckInterval)
+		defer ticker.Stop()
+		for {
+			flushed := true
+			for _, tableSink := range tableSinks {
+				if tableSink.GetCheckpointTs().Ts < barrier {
+					flushed = false
+					break
+				}
+			}
+			if flushed {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return errors.Trace(ctx.Err())
+			case <-ticker.C:
+			}
+		}
+		lastBarrier = barrier
+		return ddlSink.WriteCheckpointTs(ctx, barrier, tableNames)
+	}
+	// execDDLsBefore executes the DDLs whose commit ts is less than commitTs
+	// after all rows before them are flushed.
+	execDDLsBefore := func(commitTs uint64) error {
+		for len(ddls) > 0 && ddls[0].CommitTs < commitTs {
+			if err := flush(ddls[0].CommitTs - 1); err != nil {
+				return err
+			}
+			if err := ddlSink.WriteDDLEvent(ctx, ddls[0]); err != nil {
+				return err
+			}
+			ddls = ddls[1:]
+		}
+		return nil
+	}
+
+	for {
+		redoLogs, err := ra.rd.ReadNextLog(ctx, readBatch)
+		if err != nil {
+			return err
+		}
+		if len(redoLogs) == 0 {
+			break
+		}
+
+		for _, redoLog := range redoLogs {
+			row := redo.LogToRow(redoLog)
+			if err := execDDLsBefore(row.CommitTs); err != nil {
+				return err
+			}
+			tableSink, ok := tableSinks[row.Table.TableID]
+			if !ok {
+				tableSink = sinkFactory.CreateTableSink(row.Table.TableID)
+				tableSinks[row.Table.TableID] = tableSink
+				tableNames = append(tableNames, *row.Table)
+			}
+			tableSink.AppendRowChangedEvents(row)
+		}
+		// Rows with the same commit ts as the last one may be in the next
+		// batch, so only the rows before it are complete transactions.
+		if err := flush(redoLogs[len(redoLogs)-1].Row.CommitTs - 1); err != nil {
+			return err
+		}
+	}
+	if err := execDDLsBefore(resolvedTs + 1); err != nil {
+		return err
+	}
+	if err := flush(resolvedTs); err != nil {
+		return err
+	}
+	return errApplyFinished
+}
+
 var createRedoReader = createRedoReaderImpl
 
 func createRedoReaderImpl(ctx context.Context, cfg *RedoApplierConfig) (reader.RedoLogReader, error) {
@@ -17,14 +17,20 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/phayes/freeport"
+	timodel "github.com/pingcap/tidb/parser/model"
+	tmysql "github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/redo"
 	"github.com/pingcap/tiflow/cdc/redo/reader"
 	"github.com/pingcap/tiflow/cdc/sink/mysql"
+	"github.com/pingcap/tiflow/pkg/sink/webhook"
 	"github.com/stretchr/testify/require"
 )
 
@@ -255,3 +261,79 @@ func TestApplyMeetSinkError(t *testing.T) {
 	err = ap.Apply(ctx)
 	require.Regexp(t, "CDC:ErrMySQLConnectionError", err)
 }
+
+func TestApplyToEventSink(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu           sync.Mutex
+		messageTypes []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		messageTypes = append(messageTypes, r.Header.Get(webhook.MessageTypeHeader))
+	}))
+	defer server.Close()
+
+	checkpointTs := uint64(1000)
+	resolvedTs := uint64(2000)
+	redoLogCh := make(chan *model.RedoRowChangedEvent, 1024)
+	ddlEventCh := make(chan *model.RedoDDLEvent, 1024)
+	createMockReader := func(ctx context.Context, cfg *RedoApplierConfig) (reader.RedoLogReader, error) {
+		return NewMockReader(checkpointTs, resolvedTs, redoLogCh, ddlEventCh), nil
+	}
+	createRedoReaderBak := createRedoReader
+	createRedoReader = createMockReader
+	defer func() {
+		createRedoReader = createRedoReaderBak
+	}()
+
+	newRow := func(commitTs uint64, table string, tableID int64) *model.RowChangedEvent {
+		return &model.RowChangedEvent{
+			StartTs:  commitTs - 10,
+			CommitTs: commitTs,
+			Table:    &model.TableName{Schema: "test", Table: table, TableID: tableID},
+			Columns: []*model.Column{
+				{Name: "a", Type: tmysql.TypeLong, Value: 1, Flag: model.HandleKeyFlag},
+			},
+		}
+	}
+	for _, row := range []*model.RowChangedEvent{
+		newRow(1200, "t1", 1), newRow(1200, "t1", 1), newRow(1400, "t2", 2),
+	} {
+		redoLogCh <- redo.RowToRedo(row)
+	}
+	ddlEventCh <- redo.DDLToRedo(&model.DDLEvent{
+		StartTs:  1290,
+		CommitTs: 1300,
+		TableInfo: &model.SimpleTableInfo{
+			Schema: "test", Table: "t2", TableID: 2,
+			ColumnInfo: []*model.ColumnInfo{{Name: "a", Type: tmysql.TypeLong}},
+		},
+		Query: "create table t2 (a int primary key)",
+		Type:  timodel.ActionCreateTable,
+	})
+	close(redoLogCh)
+	close(ddlEventCh)
+
+	cfg := &RedoApplierConfig{
+		SinkURI: server.URL + "?protocol=canal-json&enable-tidb-extension=true&flush-interval=50ms",
+	}
+	ap := NewRedoApplier(cfg)
+	require.Nil(t, ap.Apply(ctx))
+
+	mu.Lock()
+	defer mu.Unlock()
+	// The DDL is sent after the rows before it are flushed, and the resolved
+	// ts is sent after all rows are flushed.
+	events := make([]string, 0, len(messageTypes))
+	for _, tp := range messageTypes {
+		if tp != "resolved" && (len(events) == 0 || events[len(events)-1] != tp) {
+			events = append(events, tp)
+		}
+	}
+	require.Equal(t, []string{"row", "ddl", "row"}, events)
+	require.Equal(t, "resolved", messageTypes[len(messageTypes)-1])
+}
@@ -111,6 +111,7 @@ type createChangefeedOptions struct {
 
 	changefeedID            string
 	disableGCSafePointCheck bool
+	dryRun                  bool
 	startTs                 uint64
 	timezone                string
 
@@ -131,6 +132,7 @@ func (o *createChangefeedOptions) addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
 	cmd.PersistentFlags().BoolVarP(&o.disableGCSafePointCheck, "disable-gc-check", "", false, "Disable GC safe point check")
 	cmd.PersistentFlags().Uint64Var(&o.startTs, "start-ts", 0, "Start ts of changefeed")
+	cmd.PersistentFlags().BoolVar(&o.dryRun, "dry-run", false, "Validate the changefeed against the upstream and the sink without creating it")
 	cmd.PersistentFlags().StringVar(&o.timezone, "tz", "SYSTEM", "timezone used when checking sink uri (changefeed timezone is determined by cdc server)")
 	// we don't support specify these flags below when cdc version >= 6.2.0
 	_ = cmd.PersistentFlags().MarkHidden("tz")
@@ -302,6 +304,10 @@ func (o *createChangefeedOptions) run(ctx context.Context, cmd *cobra.Command) e
 		o.startTs = oracle.ComposeTS(tso.Timestamp, tso.LogicTime)
 	}
 
+	if o.dryRun {
+		return o.runDryRun(ctx, cmd)
+	}
+
 	if !o.commonChangefeedOptions.noConfirm {
 		if err = confirmLargeDataGap(cmd, tso.Timestamp, o.startTs, "create"); err != nil {
 			return err
@@ -380,6 +386,39 @@ func (o *createChangefeedOptions) run(ctx context.Context, cmd *cobra.Command) e
 	return nil
 }
 
+// runDryRun validates the changefeed by the pre-flight check of the server,
+// and prints the result without creating the changefeed.
+func (o *createChangefeedOptions) runDryRun(ctx context.Context, cmd *cobra.Command) error {
+	createChangefeedCfg := o.getChangefeedConfig()
+	createChangefeedCfg.ReplicaConfig.IgnoreIneligibleTable = o.commonChangefeedOptions.noConfirm
+	result, err := o.apiClient.Changefeeds().DryRun(ctx, createChangefeedCfg)
+	if err != nil {
+		return err
+	}
+
+	printTables := func(title string, tables []v2.PreflightTable) {
+		cmd.Printf("%s (%d):\n", title, len(tables))
+		for _, table := range tables {
+			cmd.Printf("  %s.%s", table.Schema, table.Table)
+			if table.Topic != "" {
+				cmd.Printf(" -> topic: %s, partition: %s", table.Topic, table.PartitionRule)
+			}
+			cmd.Println()
+		}
+	}
+	printTables("Eligible tables", result.EligibleTables)
+	printTables("Ineligible tables", result.IneligibleTables)
+	if len(result.Problems) == 0 {
+		cmd.Printf("Dry run passed, changefeed %s can be created\n", o.changefeedID)
+		return nil
+	}
+	cmd.Printf("Problems (%d):\n", len(result.Problems))
+	for _, problem := range result.Problems {
+		cmd.Printf("  %s\n", problem)
+	}
+	return errors.New("dry run failed, please fix the problems above")
+}
+
 // newCmdCreateChangefeed creates the `cli changefeed create` command.
 func newCmdCreateChangefeed(f factory.Factory) *cobra.Command {
 	commonChangefeedOptions := newChangefeedCommonOptions()
@@ -202,3 +202,49 @@ func TestChangefeedCreateCli(t *testing.T) {
 	require.NoError(t, o.complete(context.Background(), f, cmd))
 	require.Contains(t, o.validate(cmd).Error(), "creating changefeed with `--sort-dir`")
 }
+
+func TestChangefeedCreateDryRunCli(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	f := newMockFactory(ctrl)
+
+	cmd := new(cobra.Command)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	o := newCreateChangefeedOptions(newChangefeedCommonOptions())
+	o.addFlags(cmd)
+	require.Nil(t, cmd.ParseFlags([]string{
+		"--sink-uri=kafka://127.0.0.1:9092/topic?protocol=open-protocol",
+		"--changefeed-id=abc",
+		"--dry-run",
+	}))
+	require.NoError(t, o.complete(context.Background(), f, cmd))
+	require.True(t, o.dryRun)
+
+	// The changefeed is not created in the dry run mode.
+	f.tso.EXPECT().Query(gomock.Any(), gomock.Any()).Return(&v2.Tso{
+		Timestamp: time.Now().Unix() * 1000,
+	}, nil).Times(2)
+	f.changefeedsv2.EXPECT().DryRun(gomock.Any(), gomock.Any()).Return(
+		&v2.ChangefeedPreflightResult{
+			EligibleTables: []v2.PreflightTable{{
+				Schema: "test", Table: "t1", Topic: "topic", PartitionRule: "default",
+			}},
+		}, nil)
+	require.NoError(t, o.run(context.Background(), cmd))
+	out, err := ioutil.ReadAll(b)
+	require.Nil(t, err)
+	require.Contains(t, string(out), "test.t1 -> topic: topic, partition: default")
+	require.Contains(t, string(out), "Dry run passed")
+
+	f.changefeedsv2.EXPECT().DryRun(gomock.Any(), gomock.Any()).Return(
+		&v2.ChangefeedPreflightResult{
+			IneligibleTables: []v2.PreflightTable{{Schema: "test", Table: "t2"}},
+			Problems:         []string{"table test.t2 is ineligible"},
+		}, nil)
+	err = o.run(context.Background(), cmd)
+	require.Contains(t, err.Error(), "dry run failed")
+	out, err = ioutil.ReadAll(b)
+	require.Nil(t, err)
+	require.Contains(t, string(out), "table test.t2 is ineligible")
+}
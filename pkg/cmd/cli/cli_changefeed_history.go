@@ -0,0 +1,81 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// historyChangefeedOptions defines flags for the `cli changefeed history` command.
+type historyChangefeedOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	changefeedID string
+}
+
+// newHistoryChangefeedOptions creates new options for the `cli changefeed history` command.
+func newHistoryChangefeedOptions() *historyChangefeedOptions {
+	return &historyChangefeedOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *historyChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *historyChangefeedOptions) complete(f factory.Factory) error {
+	apiClient, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	return nil
+}
+
+// run the `cli changefeed history` command.
+func (o *historyChangefeedOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+
+	events, err := o.apiClient.Changefeeds().History(ctx, o.changefeedID)
+	if err != nil {
+		return err
+	}
+	return util.JSONPrint(cmd, events)
+}
+
+// newCmdHistoryChangefeed creates the `cli changefeed history` command.
+func newCmdHistoryChangefeed(f factory.Factory) *cobra.Command {
+	o := newHistoryChangefeedOptions()
+
+	command := &cobra.Command{
+		Use:   "history",
+		Short: "List the recent events of a replication task (changefeed), from the oldest to the newest",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pingcap/errors"
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/api/v2/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangefeedHistoryCli(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cf := mock.NewMockChangefeedInterface(ctrl)
+	f := &mockFactory{changefeedsv2: cf}
+	cmd := newCmdHistoryChangefeed(f)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+
+	cf.EXPECT().History(gomock.Any(), "abc").Return([]v2.ChangefeedEvent{
+		{
+			Time:     time.Now(),
+			Type:     string(model.ChangefeedEventAdminJob),
+			AdminJob: model.AdminStop.String(),
+			Origin:   string(model.AdminJobOriginCLI),
+		},
+		{
+			Time:     time.Now(),
+			Type:     string(model.ChangefeedEventStateChanged),
+			OldState: string(model.StateNormal),
+			State:    string(model.StateStopped),
+		},
+	}, nil)
+	os.Args = []string{"history", "--changefeed-id=abc"}
+	require.Nil(t, cmd.Execute())
+	out, err := ioutil.ReadAll(b)
+	require.Nil(t, err)
+	require.Contains(t, string(out), `"admin_job": "stop changefeed"`)
+	require.Contains(t, string(out), `"origin": "cli"`)
+	require.Contains(t, string(out), `"state": "stopped"`)
+
+	o := newHistoryChangefeedOptions()
+	require.Nil(t, o.complete(f))
+	o.changefeedID = "abc"
+	cf.EXPECT().History(gomock.Any(), "abc").Return(nil, errors.New("test"))
+	require.NotNil(t, o.run(cmd))
+}
@@ -43,6 +43,7 @@ type ClientGetter interface {
 	GetServerAddr() string
 	GetLogLevel() string
 	GetCredential() *security.Credential
+	GetAPICredential() *security.APICredential
 }
 
 // ClientFlags specifies the parameters needed to construct the client.
@@ -53,6 +54,10 @@ type ClientFlags struct {
 	caPath     string
 	certPath   string
 	keyPath    string
+
+	authToken    string
+	authUser     string
+	authPassword string
 }
 
 var _ ClientGetter = &ClientFlags{}
@@ -114,6 +119,12 @@ func (c *ClientFlags) AddFlags(cmd *cobra.Command) {
 		"Private key path for TLS connection to CDC server")
 	cmd.PersistentFlags().StringVar(&c.logLevel, "log-level", "warn",
 		"log level (etc: debug|info|warn|error)")
+	cmd.PersistentFlags().StringVar(&c.authToken, "auth-token", "",
+		"Bearer token for authenticating to CDC server")
+	cmd.PersistentFlags().StringVar(&c.authUser, "auth-user", "",
+		"User name of HTTP basic authentication for authenticating to CDC server")
+	cmd.PersistentFlags().StringVar(&c.authPassword, "auth-password", "",
+		"Password of HTTP basic authentication for authenticating to CDC server")
 }
 
 // GetCredential returns credential.
@@ -127,3 +138,12 @@ func (c *ClientFlags) GetCredential() *security.Credential {
 		CertAllowedCN: certAllowedCN,
 	}
 }
+
+// GetAPICredential returns the credential for authenticating to CDC server.
+func (c *ClientFlags) GetAPICredential() *security.APICredential {
+	return &security.APICredential{
+		Token:    c.authToken,
+		User:     c.authUser,
+		Password: c.authPassword,
+	}
+}
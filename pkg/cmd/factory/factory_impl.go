@@ -88,6 +88,11 @@ func (f *factoryImpl) GetCredential() *security.Credential {
 	return f.clientGetter.GetCredential()
 }
 
+// GetAPICredential returns the credential for authenticating to CDC server.
+func (f *factoryImpl) GetAPICredential() *security.APICredential {
+	return f.clientGetter.GetAPICredential()
+}
+
 // EtcdClient creates new cdc etcd client.
 func (f *factoryImpl) EtcdClient() (*etcd.CDCEtcdClientImpl, error) {
 	ctx := cmdconetxt.GetDefaultContext()
@@ -208,7 +213,8 @@ func (f *factoryImpl) APIV1Client() (apiv1client.APIV1Interface, error) {
 		return nil, errors.Trace(err)
 	}
 	log.Info(serverAddr)
-	client, err := apiv1client.NewAPIClient(serverAddr, f.clientGetter.GetCredential())
+	client, err := apiv1client.NewAPIClient(serverAddr,
+		f.clientGetter.GetCredential(), f.clientGetter.GetAPICredential())
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -222,14 +228,16 @@ func (f *factoryImpl) APIV2Client() (apiv2client.APIV2Interface, error) {
 		return nil, errors.Trace(err)
 	}
 	log.Info(serverAddr)
-	client, err := apiv1client.NewAPIClient(serverAddr, f.clientGetter.GetCredential())
+	client, err := apiv1client.NewAPIClient(serverAddr,
+		f.clientGetter.GetCredential(), f.clientGetter.GetAPICredential())
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	if err := checkCDCVersion(client); err != nil {
 		return nil, errors.Trace(err)
 	}
-	return apiv2client.NewAPIClient(serverAddr, f.clientGetter.GetCredential())
+	return apiv2client.NewAPIClient(serverAddr,
+		f.clientGetter.GetCredential(), f.clientGetter.GetAPICredential())
 }
 
 // findServerAddr find the cdc server address by the following logic
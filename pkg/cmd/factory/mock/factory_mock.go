@@ -85,6 +85,20 @@ func (mr *MockFactoryMockRecorder) EtcdClient() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EtcdClient", reflect.TypeOf((*MockFactory)(nil).EtcdClient))
 }
 
+// GetAPICredential mocks base method.
+func (m *MockFactory) GetAPICredential() *security.APICredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPICredential")
+	ret0, _ := ret[0].(*security.APICredential)
+	return ret0
+}
+
+// GetAPICredential indicates an expected call of GetAPICredential.
+func (mr *MockFactoryMockRecorder) GetAPICredential() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPICredential", reflect.TypeOf((*MockFactory)(nil).GetAPICredential))
+}
+
 // GetCredential mocks base method.
 func (m *MockFactory) GetCredential() *security.Credential {
 	m.ctrl.T.Helper()
@@ -209,6 +223,20 @@ func (m *MockClientGetter) EXPECT() *MockClientGetterMockRecorder {
 	return m.recorder
 }
 
+// GetAPICredential mocks base method.
+func (m *MockClientGetter) GetAPICredential() *security.APICredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPICredential")
+	ret0, _ := ret[0].(*security.APICredential)
+	return ret0
+}
+
+// GetAPICredential indicates an expected call of GetAPICredential.
+func (mr *MockClientGetterMockRecorder) GetAPICredential() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPICredential", reflect.TypeOf((*MockClientGetter)(nil).GetAPICredential))
+}
+
 // GetCredential mocks base method.
 func (m *MockClientGetter) GetCredential() *security.Credential {
 	m.ctrl.T.Helper()
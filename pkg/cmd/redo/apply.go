@@ -33,7 +33,7 @@ func newapplyRedoOptions() *applyRedoOptions {
 // addFlags receives a *cobra.Command reference and binds
 // flags related to template printing to it.
 func (o *applyRedoOptions) addFlags(cmd *cobra.Command) {
-	cmd.Flags().StringVar(&o.sinkURI, "sink-uri", "", "target database sink-uri")
+	cmd.Flags().StringVar(&o.sinkURI, "sink-uri", "", "target sink-uri, eg, MySQL, TiDB, Kafka or storage sinks")
 	// the possible error returned from MarkFlagRequired is `no such flag`
 	cmd.MarkFlagRequired("sink-uri") //nolint:errcheck
 }
@@ -46,6 +46,9 @@ func (o *applyRedoOptions) run(cmd *cobra.Command) error {
 		Storage: o.storage,
 		SinkURI: o.sinkURI,
 		Dir:     o.dir,
+
+		EncryptionKeyPath: o.encryptionKeyPath,
+		EncryptionKeyEnv:  o.encryptionKeyEnv,
 	}
 	ap := applier.NewRedoApplier(cfg)
 	err := ap.Apply(ctx)
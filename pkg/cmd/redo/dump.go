@@ -0,0 +1,90 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redo
+
+import (
+	"github.com/pingcap/tiflow/pkg/applier"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/spf13/cobra"
+)
+
+// dumpOptions defines flags for the `redo dump` command.
+type dumpOptions struct {
+	options
+	tables      []string
+	startTs     uint64
+	endTs       uint64
+	txnStartTs  uint64
+	format      string
+	summaryOnly bool
+}
+
+// newDumpOptions creates new dumpOptions for the `redo dump` command.
+func newDumpOptions() *dumpOptions {
+	return &dumpOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *dumpOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&o.tables, "table", nil, "only dump the tables matched by the table filter rules, eg, \"test.*\"")
+	cmd.Flags().Uint64Var(&o.startTs, "start-ts", 0, "only dump the events whose commit ts is larger than start-ts, default to the checkpoint ts in redo meta")
+	cmd.Flags().Uint64Var(&o.endTs, "end-ts", 0, "only dump the events whose commit ts is not larger than end-ts, default to the resolved ts in redo meta")
+	cmd.Flags().Uint64Var(&o.txnStartTs, "txn-start-ts", 0, "only dump the events of the transaction with the start ts")
+	cmd.Flags().StringVar(&o.format, "format", applier.DumpFormatJSON, "output format of the events (json|sql)")
+	cmd.Flags().BoolVar(&o.summaryOnly, "summary", false, "only output the summary statistics of each table")
+}
+
+// run runs the `redo dump` command.
+func (o *dumpOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	d, err := applier.NewRedoDumper(&applier.RedoDumpConfig{
+		Storage:     o.storage,
+		Dir:         o.dir,
+		TableFilter: o.tables,
+		StartTs:     o.startTs,
+		EndTs:       o.endTs,
+		TxnStartTs:  o.txnStartTs,
+		Format:      o.format,
+		SummaryOnly: o.summaryOnly,
+
+		EncryptionKeyPath: o.encryptionKeyPath,
+		EncryptionKeyEnv:  o.encryptionKeyEnv,
+	})
+	if err != nil {
+		return err
+	}
+	summary, err := d.Dump(ctx, cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	return d.WriteSummary(cmd.OutOrStdout(), summary)
+}
+
+// newCmdDump creates the `redo dump` command.
+func newCmdDump(opt *options) *cobra.Command {
+	o := newDumpOptions()
+	command := &cobra.Command{
+		Use:   "dump",
+		Short: "Dump the events in redo logs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			o.options = *opt
+			return o.run(cmd)
+		},
+	}
+	o.addFlags(command)
+
+	return command
+}
@@ -36,6 +36,9 @@ func (o *metaOptions) run(cmd *cobra.Command) error {
 	cfg := &applier.RedoApplierConfig{
 		Storage: o.storage,
 		Dir:     o.dir,
+
+		EncryptionKeyPath: o.encryptionKeyPath,
+		EncryptionKeyEnv:  o.encryptionKeyEnv,
 	}
 	ap := applier.NewRedoApplier(cfg)
 	checkpointTs, resolvedTs, err := ap.ReadMeta(ctx)
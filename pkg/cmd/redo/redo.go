@@ -21,9 +21,11 @@ import (
 
 // options defines flags for the `redo` command.
 type options struct {
-	storage  string
-	dir      string
-	logLevel string
+	storage           string
+	dir               string
+	logLevel          string
+	encryptionKeyPath string
+	encryptionKeyEnv  string
 }
 
 // newOptions creates new options for the `server` command.
@@ -37,6 +39,8 @@ func (o *options) addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVar(&o.storage, "storage", "", "storage of redo log, specify the url where backup redo logs will store, eg, \"s3://bucket/path/prefix\"")
 	cmd.PersistentFlags().StringVar(&o.dir, "tmp-dir", "", "temporary path used to download redo log with S3 backend")
 	cmd.PersistentFlags().StringVar(&o.logLevel, "log-level", "info", "log level (etc: debug|info|warn|error)")
+	cmd.PersistentFlags().StringVar(&o.encryptionKeyPath, "encryption-key-path", "", "path of the file containing the hex encoded key of encrypted redo logs")
+	cmd.PersistentFlags().StringVar(&o.encryptionKeyEnv, "encryption-key-env", "", "environment variable containing the hex encoded key of encrypted redo logs")
 	// the possible error returned from MarkFlagRequired is `no such flag`
 	cmd.MarkFlagRequired("storage") //nolint:errcheck
 }
@@ -70,6 +74,7 @@ func NewCmdRedo() *cobra.Command {
 	// Add subcommands.
 	cmds.AddCommand(newCmdApply(o))
 	cmds.AddCommand(newCmdMeta(o))
+	cmds.AddCommand(newCmdDump(o))
 
 	return cmds
 }
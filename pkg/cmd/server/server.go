@@ -73,6 +73,7 @@ func (o *options) addFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&o.serverConfig.LogLevel, "log-level", o.serverConfig.LogLevel, "log level (etc: debug|info|warn|error)")
 
 	cmd.Flags().StringVar(&o.serverConfig.DataDir, "data-dir", o.serverConfig.DataDir, "the path to the directory used to store TiCDC-generated data")
+	cmd.Flags().StringToStringVar(&o.serverConfig.Labels, "labels", o.serverConfig.Labels, "labels of the capture used by placement rules, e.g. zone=z1,tier=dedicated")
 
 	cmd.Flags().DurationVar((*time.Duration)(&o.serverConfig.OwnerFlushInterval), "owner-flush-interval", time.Duration(o.serverConfig.OwnerFlushInterval), "owner flushes changefeed status interval")
 	_ = cmd.Flags().MarkHidden("owner-flush-interval")
@@ -235,6 +236,8 @@ func (o *options) complete(cmd *cobra.Command) error {
 			cfg.Sorter.SortDir = config.DefaultSortDir
 		case "cluster-id":
 			cfg.ClusterID = o.serverConfig.ClusterID
+		case "labels":
+			cfg.Labels = o.serverConfig.Labels
 		case "pd", "config":
 			// do nothing
 		default:
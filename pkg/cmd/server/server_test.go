@@ -174,6 +174,7 @@ func TestParseCfg(t *testing.T) {
 			WorkerPoolSize:      0,
 			RegionScanLimit:     40,
 			RegionRetryDuration: config.TomlDuration(time.Minute),
+			MemoryQuota:         config.DefaultKVClientMemoryQuota,
 		},
 		Debug: &config.DebugConfig{
 			TableActor: &config.TableActorConfig{
@@ -207,10 +208,12 @@ func TestParseCfg(t *testing.T) {
 			},
 			EnableSchedulerV3: true,
 			Scheduler: &config.SchedulerConfig{
-				HeartbeatTick:        2,
-				MaxTaskConcurrency:   10,
-				CheckBalanceInterval: 60000000000,
-				AddTableBatchSize:    50,
+				HeartbeatTick:             2,
+				MaxTaskConcurrency:        10,
+				CheckBalanceInterval:      60000000000,
+				AddTableBatchSize:         50,
+				BalanceByTraffic:          false,
+				TrafficImbalanceThreshold: 0.2,
 			},
 		},
 		ClusterID: "default",
@@ -282,6 +285,7 @@ server-worker-pool-size = 16
 heartbeat-tick = 3
 max-task-concurrency = 11
 check-balance-interval = "10s"
+balance-by-traffic = true
 `, dataDir)
 	err := os.WriteFile(configPath, []byte(configContent), 0o644)
 	require.Nil(t, err)
@@ -330,6 +334,7 @@ check-balance-interval = "10s"
 			WorkerPoolSize:      0,
 			RegionScanLimit:     40,
 			RegionRetryDuration: config.TomlDuration(3 * time.Second),
+			MemoryQuota:         config.DefaultKVClientMemoryQuota,
 		},
 		Debug: &config.DebugConfig{
 			TableActor: &config.TableActorConfig{
@@ -362,10 +367,12 @@ check-balance-interval = "10s"
 			},
 			EnableSchedulerV3: true,
 			Scheduler: &config.SchedulerConfig{
-				HeartbeatTick:        3,
-				MaxTaskConcurrency:   11,
-				CheckBalanceInterval: config.TomlDuration(10 * time.Second),
-				AddTableBatchSize:    50,
+				HeartbeatTick:             3,
+				MaxTaskConcurrency:        11,
+				CheckBalanceInterval:      config.TomlDuration(10 * time.Second),
+				AddTableBatchSize:         50,
+				BalanceByTraffic:          true,
+				TrafficImbalanceThreshold: 0.2,
 			},
 		},
 		ClusterID: "default",
@@ -476,6 +483,7 @@ cert-allowed-cn = ["dd","ee"]
 			WorkerPoolSize:      0,
 			RegionScanLimit:     40,
 			RegionRetryDuration: config.TomlDuration(time.Minute),
+			MemoryQuota:         config.DefaultKVClientMemoryQuota,
 		},
 		Debug: &config.DebugConfig{
 			TableActor: &config.TableActorConfig{
@@ -509,10 +517,12 @@ cert-allowed-cn = ["dd","ee"]
 			},
 			EnableSchedulerV3: true,
 			Scheduler: &config.SchedulerConfig{
-				HeartbeatTick:        2,
-				MaxTaskConcurrency:   10,
-				CheckBalanceInterval: 60000000000,
-				AddTableBatchSize:    50,
+				HeartbeatTick:             2,
+				MaxTaskConcurrency:        10,
+				CheckBalanceInterval:      60000000000,
+				AddTableBatchSize:         50,
+				BalanceByTraffic:          false,
+				TrafficImbalanceThreshold: 0.2,
 			},
 		},
 		ClusterID: "default",
@@ -573,10 +583,12 @@ unknown3 = 3
 		},
 		EnableSchedulerV3: true,
 		Scheduler: &config.SchedulerConfig{
-			HeartbeatTick:        2,
-			MaxTaskConcurrency:   10,
-			CheckBalanceInterval: 60000000000,
-			AddTableBatchSize:    50,
+			HeartbeatTick:             2,
+			MaxTaskConcurrency:        10,
+			CheckBalanceInterval:      60000000000,
+			AddTableBatchSize:         50,
+			BalanceByTraffic:          false,
+			TrafficImbalanceThreshold: 0.2,
 		},
 	}, o.serverConfig.Debug)
 }
@@ -0,0 +1,140 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/hex"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// AuthRole is the role of a requester of the TiCDC HTTP API.
+type AuthRole string
+
+const (
+	// AuthRoleReadOnly can only query the status of the cluster and changefeeds.
+	AuthRoleReadOnly AuthRole = "read-only"
+	// AuthRoleOperator can also create, update, pause, resume and remove
+	// changefeeds.
+	AuthRoleOperator AuthRole = "operator"
+	// AuthRoleAdmin can access all APIs, including owner, capture and unsafe APIs.
+	AuthRoleAdmin AuthRole = "admin"
+)
+
+var authRoleLevels = map[AuthRole]int{
+	AuthRoleReadOnly: 1,
+	AuthRoleOperator: 2,
+	AuthRoleAdmin:    3,
+}
+
+// IsValid returns true if the role is one of the known roles.
+func (r AuthRole) IsValid() bool {
+	_, ok := authRoleLevels[r]
+	return ok
+}
+
+// Covers returns true if the role is granted all privileges of the given role.
+func (r AuthRole) Covers(role AuthRole) bool {
+	return r.IsValid() && authRoleLevels[r] >= authRoleLevels[role]
+}
+
+// AuthConfig represents the authentication config of the TiCDC HTTP API.
+// The authentication is enabled if any credential is configured.
+type AuthConfig struct {
+	// Tokens are the static bearer tokens.
+	Tokens []*AuthTokenConfig `toml:"tokens" json:"tokens"`
+	// Users are the users of HTTP basic authentication.
+	Users []*AuthUserConfig `toml:"users" json:"users"`
+	// Certs map the common names of TLS client certificates to roles,
+	// it requires TLS to be enabled.
+	Certs []*AuthCertConfig `toml:"certs" json:"certs"`
+}
+
+// AuthTokenConfig represents a static bearer token.
+type AuthTokenConfig struct {
+	// TokenHash is the hex encoded SHA-256 hash of the token.
+	TokenHash string   `toml:"token-hash" json:"token-hash"`
+	Role      AuthRole `toml:"role" json:"role"`
+}
+
+// AuthUserConfig represents a user of HTTP basic authentication.
+type AuthUserConfig struct {
+	Name string `toml:"name" json:"name"`
+	// PasswordHash is the bcrypt hash of the password,
+	// e.g. generated by `htpasswd -nbB <name> <password>`.
+	PasswordHash string   `toml:"password-hash" json:"password-hash"`
+	Role         AuthRole `toml:"role" json:"role"`
+}
+
+// AuthCertConfig maps the common name of a TLS client certificate to a role.
+type AuthCertConfig struct {
+	CommonName string   `toml:"common-name" json:"common-name"`
+	Role       AuthRole `toml:"role" json:"role"`
+}
+
+// IsEnabled returns true if the authentication is enabled.
+func (c *AuthConfig) IsEnabled() bool {
+	return c != nil && (len(c.Tokens) != 0 || len(c.Users) != 0 || len(c.Certs) != 0)
+}
+
+// ValidateAndAdjust validates the authentication config.
+func (c *AuthConfig) ValidateAndAdjust() error {
+	for _, token := range c.Tokens {
+		if hash, err := hex.DecodeString(token.TokenHash); err != nil || len(hash) != 32 {
+			return cerror.ErrInvalidServerOption.GenWithStack(
+				"auth token-hash must be a hex encoded SHA-256 hash")
+		}
+		if err := validateAuthRole(token.Role); err != nil {
+			return err
+		}
+	}
+	names := make(map[string]struct{}, len(c.Users))
+	for _, user := range c.Users {
+		if user.Name == "" {
+			return cerror.ErrInvalidServerOption.GenWithStack("empty auth user name is not allowed")
+		}
+		if _, ok := names[user.Name]; ok {
+			return cerror.ErrInvalidServerOption.GenWithStack(
+				"duplicated auth user %s", user.Name)
+		}
+		names[user.Name] = struct{}{}
+		if _, err := bcrypt.Cost([]byte(user.PasswordHash)); err != nil {
+			return cerror.ErrInvalidServerOption.GenWithStack(
+				"auth password-hash of user %s must be a bcrypt hash", user.Name)
+		}
+		if err := validateAuthRole(user.Role); err != nil {
+			return err
+		}
+	}
+	for _, cert := range c.Certs {
+		if cert.CommonName == "" {
+			return cerror.ErrInvalidServerOption.GenWithStack(
+				"empty auth certificate common-name is not allowed")
+		}
+		if err := validateAuthRole(cert.Role); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateAuthRole(role AuthRole) error {
+	if !role.IsValid() {
+		return cerror.ErrInvalidServerOption.GenWithStack(
+			"invalid auth role %q, must be one of %q, %q and %q",
+			role, AuthRoleReadOnly, AuthRoleOperator, AuthRoleAdmin)
+	}
+	return nil
+}
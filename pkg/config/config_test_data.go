@@ -94,7 +94,8 @@ const (
     "worker-concurrent": 8,
     "worker-pool-size": 0,
     "region-scan-limit": 40,
-    "region-retry-duration": 60000000000
+    "region-retry-duration": 60000000000,
+    "memory-quota": 1073741824
   },
   "debug": {
     "table-actor": {
@@ -130,7 +131,9 @@ const (
       "heartbeat-tick": 2,
       "max-task-concurrency": 10,
       "check-balance-interval": 60000000000,
-      "add-table-batch-size": 50
+      "add-table-batch-size": 50,
+      "balance-by-traffic": false,
+      "traffic-imbalance-threshold": 0.2
     },
     "enable-new-sink": false
   },
@@ -173,7 +176,10 @@ const (
     "level": "none",
     "max-log-size": 64,
     "flush-interval": 2000,
-    "storage": ""
+    "storage": "",
+    "compression": "",
+    "encryption-key-path": "",
+    "encryption-key-env": ""
   }
 }`
 
@@ -210,7 +216,10 @@ const (
     "level": "none",
     "max-log-size": 64,
     "flush-interval": 2000,
-    "storage": ""
+    "storage": "",
+    "compression": "",
+    "encryption-key-path": "",
+    "encryption-key-env": ""
   }
 }`
 )
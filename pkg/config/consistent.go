@@ -13,10 +13,48 @@
 
 package config
 
+import (
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	// ConsistentCompressionNone disables the compression of redo logs.
+	ConsistentCompressionNone = "none"
+	// ConsistentCompressionLZ4 compresses redo logs with lz4.
+	ConsistentCompressionLZ4 = "lz4"
+	// ConsistentCompressionZstd compresses redo logs with zstd.
+	ConsistentCompressionZstd = "zstd"
+)
+
 // ConsistentConfig represents replication consistency config for a changefeed
 type ConsistentConfig struct {
 	Level             string `toml:"level" json:"level"`
 	MaxLogSize        int64  `toml:"max-log-size" json:"max-log-size"`
 	FlushIntervalInMs int64  `toml:"flush-interval" json:"flush-interval"`
 	Storage           string `toml:"storage" json:"storage"`
+	// Compression is the compression algorithm of redo log records,
+	// it can be none, lz4 or zstd. Empty means none.
+	Compression string `toml:"compression" json:"compression"`
+	// EncryptionKeyPath is the path of a local file containing the hex encoded
+	// AES key used to encrypt redo log records.
+	EncryptionKeyPath string `toml:"encryption-key-path" json:"encryption-key-path"`
+	// EncryptionKeyEnv is the name of an environment variable containing the
+	// hex encoded AES key used to encrypt redo log records.
+	EncryptionKeyEnv string `toml:"encryption-key-env" json:"encryption-key-env"`
+}
+
+// ValidateAndAdjust validates the consistency config and adjusts it if necessary.
+func (c *ConsistentConfig) ValidateAndAdjust() error {
+	switch c.Compression {
+	case "", ConsistentCompressionNone, ConsistentCompressionLZ4, ConsistentCompressionZstd:
+	default:
+		return cerror.WrapError(cerror.ErrRedoConfigInvalid,
+			errors.Errorf("unsupported redo log compression: %s", c.Compression))
+	}
+	if c.EncryptionKeyPath != "" && c.EncryptionKeyEnv != "" {
+		return cerror.WrapError(cerror.ErrRedoConfigInvalid,
+			errors.New("encryption-key-path and encryption-key-env can not be both set"))
+	}
+	return nil
 }
@@ -25,6 +25,9 @@ type KVClientConfig struct {
 	RegionScanLimit int `toml:"region-scan-limit" json:"region-scan-limit"`
 	// the total retry duration of connecting a region
 	RegionRetryDuration TomlDuration `toml:"region-retry-duration" json:"region-retry-duration"`
+	// the memory quota of the events buffered in the kv client of a changefeed,
+	// 0 means unlimited
+	MemoryQuota uint64 `toml:"memory-quota" json:"memory-quota"`
 }
 
 // ValidateAndAdjust validates and adjusts the kv client configuration
@@ -0,0 +1,65 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+
+	filter "github.com/pingcap/tidb/util/table-filter"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// PlacementConfig represents the placement rules of a changefeed, which
+// restrict the captures that tables can be replicated on.
+type PlacementConfig struct {
+	Rules []*PlacementRule `toml:"rules" json:"rules"`
+}
+
+// PlacementRule represents a placement rule for the tables it matches.
+// The first rule that matches a table takes effect.
+type PlacementRule struct {
+	// Matcher is a list of table filter rules, e.g. `billing.*`.
+	Matcher []string `toml:"matcher" json:"matcher"`
+	// Labels restricts the matched tables to captures that have all
+	// the labels, e.g. `tier = "dedicated"`.
+	Labels map[string]string `toml:"labels" json:"labels"`
+	// SpreadBy is a label key, the matched tables are spread evenly across
+	// the captures that have different values of the label, e.g. `zone`.
+	// Captures without the label are not eligible for the matched tables.
+	SpreadBy string `toml:"spread-by" json:"spread-by"`
+}
+
+// ValidateAndAdjust validates the placement configuration.
+func (c *PlacementConfig) ValidateAndAdjust() error {
+	for i, rule := range c.Rules {
+		if rule == nil || len(rule.Matcher) == 0 {
+			return cerror.ErrPlacementRuleInvalid.GenWithStackByArgs(
+				fmt.Sprintf("the matcher of placement rule %d is empty", i))
+		}
+		if _, err := filter.Parse(rule.Matcher); err != nil {
+			return cerror.WrapError(cerror.ErrPlacementRuleInvalid, err, rule.Matcher)
+		}
+		if len(rule.Labels) == 0 && rule.SpreadBy == "" {
+			return cerror.ErrPlacementRuleInvalid.GenWithStackByArgs(
+				fmt.Sprintf("placement rule %d must specify labels or spread-by", i))
+		}
+		for key, value := range rule.Labels {
+			if key == "" || value == "" {
+				return cerror.ErrPlacementRuleInvalid.GenWithStackByArgs(
+					fmt.Sprintf("placement rule %d contains empty label key or value", i))
+			}
+		}
+	}
+	return nil
+}
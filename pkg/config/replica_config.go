@@ -61,6 +61,7 @@ type replicaConfig struct {
 	Mounter          *MounterConfig    `toml:"mounter" json:"mounter"`
 	Sink             *SinkConfig       `toml:"sink" json:"sink"`
 	Consistent       *ConsistentConfig `toml:"consistent" json:"consistent"`
+	Placement        *PlacementConfig  `toml:"placement" json:"placement,omitempty"`
 }
 
 // Marshal returns the json marshal format of a ReplicationConfig
@@ -126,6 +127,16 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error {
 			return err
 		}
 	}
+	if c.Placement != nil {
+		if err := c.Placement.ValidateAndAdjust(); err != nil {
+			return err
+		}
+	}
+	if c.Consistent != nil {
+		if err := c.Consistent.ValidateAndAdjust(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -104,6 +104,13 @@ func TestReplicaConfigValidate(t *testing.T) {
 	require.Regexp(t, ".*dispatcher and partition cannot be configured both.*",
 		conf.ValidateAndAdjust(nil))
 
+	conf = GetDefaultReplicaConfig()
+	conf.Sink.DispatchRules = []*DispatchRule{
+		{Matcher: []string{"a.b"}, PartitionRule: "columns"},
+	}
+	require.Regexp(t, ".*columns must be configured for the columns partition rule.*",
+		conf.ValidateAndAdjust(nil))
+
 	// Correct sink configuration.
 	conf = GetDefaultReplicaConfig()
 	conf.Sink.DispatchRules = []*DispatchRule{
@@ -117,4 +124,40 @@ func TestReplicaConfigValidate(t *testing.T) {
 	require.Equal(t, "d1", rules[0].PartitionRule)
 	require.Equal(t, "p1", rules[1].PartitionRule)
 	require.Equal(t, "", rules[2].PartitionRule)
+
+	// Incorrect placement configuration.
+	conf = GetDefaultReplicaConfig()
+	conf.Placement = &PlacementConfig{Rules: []*PlacementRule{
+		{Labels: map[string]string{"tier": "dedicated"}},
+	}}
+	require.Regexp(t, ".*matcher of placement rule 0 is empty.*",
+		conf.ValidateAndAdjust(nil))
+	conf.Placement.Rules[0] = &PlacementRule{
+		Matcher: []string{"billing.["},
+		Labels:  map[string]string{"tier": "dedicated"},
+	}
+	require.Regexp(t, ".*placement rule is invalid.*",
+		conf.ValidateAndAdjust(nil))
+	conf.Placement.Rules[0] = &PlacementRule{Matcher: []string{"billing.*"}}
+	require.Regexp(t, ".*must specify labels or spread-by.*",
+		conf.ValidateAndAdjust(nil))
+
+	// Correct placement configuration.
+	conf.Placement.Rules = []*PlacementRule{
+		{Matcher: []string{"billing.*"}, Labels: map[string]string{"tier": "dedicated"}},
+		{Matcher: []string{"*.*"}, SpreadBy: "zone"},
+	}
+	require.Nil(t, conf.ValidateAndAdjust(nil))
+
+	// Incorrect consistent configuration.
+	conf = GetDefaultReplicaConfig()
+	conf.Consistent.Compression = "gzip"
+	require.Regexp(t, ".*unsupported redo log compression: gzip.*",
+		conf.ValidateAndAdjust(nil))
+	conf.Consistent.Compression = ConsistentCompressionZstd
+	conf.Consistent.EncryptionKeyPath = "/tmp/redo.key"
+	conf.Consistent.EncryptionKeyEnv = "REDO_KEY"
+	require.Regexp(t, ".*can not be both set.*", conf.ValidateAndAdjust(nil))
+	conf.Consistent.EncryptionKeyEnv = ""
+	require.Nil(t, conf.ValidateAndAdjust(nil))
 }
@@ -35,6 +35,15 @@ type SchedulerConfig struct {
 	// When there are only 2 captures, and a large number of tables, this can be helpful to prevent
 	// oom caused by all tables dispatched to only one capture.
 	AddTableBatchSize int `toml:"add-table-batch-size" json:"add-table-batch-size"`
+	// BalanceByTraffic makes the balance scheduler balance tables by the
+	// replication traffic reported by captures, instead of by table count.
+	BalanceByTraffic bool `toml:"balance-by-traffic" json:"balance-by-traffic"`
+	// TrafficImbalanceThreshold is the ratio by which the traffic of a capture
+	// can exceed the average traffic of all captures before tables are moved
+	// away from it, used by the traffic balance scheduler.
+	// It prevents tables from being moved back and forth among captures that
+	// have similar traffic.
+	TrafficImbalanceThreshold float64 `toml:"traffic-imbalance-threshold" json:"traffic-imbalance-threshold"`
 }
 
 // NewDefaultSchedulerConfig return the default scheduler configuration.
@@ -45,6 +54,9 @@ func NewDefaultSchedulerConfig() *SchedulerConfig {
 		// TODO: no need to check balance each minute, relax the interval.
 		CheckBalanceInterval: TomlDuration(time.Minute),
 		AddTableBatchSize:    50,
+
+		BalanceByTraffic:          false,
+		TrafficImbalanceThreshold: 0.2,
 	}
 }
 
@@ -67,6 +79,10 @@ func (c *SchedulerConfig) ValidateAndAdjust() error {
 		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
 			"add-table-batch-size must be large than 0")
 	}
+	if c.BalanceByTraffic && c.TrafficImbalanceThreshold <= 0 {
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"traffic-imbalance-threshold must be larger than 0")
+	}
 
 	return nil
 }
@@ -44,6 +44,10 @@ const (
 
 	// DefaultTableMemoryQuota is the default memory quota for each table.
 	DefaultTableMemoryQuota = 10 * 1024 * 1024 // 10 MB
+
+	// DefaultKVClientMemoryQuota is the default memory quota for the kv client
+	// of each changefeed.
+	DefaultKVClientMemoryQuota = 1024 * 1024 * 1024 // 1 GB
 )
 
 var (
@@ -118,6 +122,7 @@ var defaultServerConfig = &ServerConfig{
 		// The default TiKV region election timeout is [10s, 20s],
 		// Use 1 minute to cover region leader missing.
 		RegionRetryDuration: TomlDuration(time.Minute),
+		MemoryQuota:         DefaultKVClientMemoryQuota,
 	},
 	Debug: &DebugConfig{
 		TableActor: &TableActorConfig{
@@ -175,6 +180,14 @@ type ServerConfig struct {
 	KVClient            *KVClientConfig `toml:"kv-client" json:"kv-client"`
 	Debug               *DebugConfig    `toml:"debug" json:"debug"`
 	ClusterID           string          `toml:"cluster-id" json:"cluster-id"`
+
+	// Labels are advertised by the capture, and are used to match the
+	// placement rules of changefeeds, e.g. `zone = "us-west-1a"`.
+	Labels map[string]string `toml:"labels" json:"labels,omitempty"`
+
+	// Auth is the authentication config of the HTTP API, it is disabled if
+	// no credential is configured.
+	Auth *AuthConfig `toml:"auth" json:"auth,omitempty"`
 }
 
 // Marshal returns the json marshal format of a ServerConfig
@@ -244,6 +257,13 @@ func (c *ServerConfig) ValidateAndAdjust() error {
 	if c.GcTTL == 0 {
 		return cerror.ErrInvalidServerOption.GenWithStack("empty GC TTL is not allowed")
 	}
+	for key, value := range c.Labels {
+		if key == "" || value == "" {
+			return cerror.ErrInvalidServerOption.GenWithStack(
+				"empty label key or value is not allowed, key: %s, value: %s",
+				key, value)
+		}
+	}
 	// 5s is minimum lease ttl in etcd(PD)
 	if c.CaptureSessionTTL < 5 {
 		log.Warn("capture session ttl too small, set to default value 10s")
@@ -262,6 +282,16 @@ func (c *ServerConfig) ValidateAndAdjust() error {
 		}
 	}
 
+	if c.Auth.IsEnabled() {
+		if err := c.Auth.ValidateAndAdjust(); err != nil {
+			return errors.Trace(err)
+		}
+		if len(c.Auth.Certs) != 0 && (c.Security == nil || !c.Security.IsTLSEnabled()) {
+			return cerror.ErrInvalidServerOption.GenWithStack(
+				"auth certs require TLS to be enabled")
+		}
+	}
+
 	defaultCfg := GetDefaultServerConfig()
 	if c.Sorter == nil {
 		c.Sorter = defaultCfg.Sorter
@@ -14,10 +14,13 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestServerConfigMarshal(t *testing.T) {
@@ -82,6 +85,48 @@ func TestServerConfigValidateAndAdjust(t *testing.T) {
 	conf.Debug.Messages.ServerWorkerPoolSize = 0
 	require.Nil(t, conf.ValidateAndAdjust())
 	require.EqualValues(t, GetDefaultServerConfig().Debug.Messages.ServerWorkerPoolSize, conf.Debug.Messages.ServerWorkerPoolSize)
+	conf.Labels = map[string]string{"zone": ""}
+	require.Regexp(t, ".*empty label key or value.*", conf.ValidateAndAdjust())
+	conf.Labels = map[string]string{"zone": "z1", "tier": "dedicated"}
+	require.Nil(t, conf.ValidateAndAdjust())
+}
+
+func TestAuthConfigValidateAndAdjust(t *testing.T) {
+	t.Parallel()
+	conf := GetDefaultServerConfig()
+	require.False(t, conf.Auth.IsEnabled())
+
+	conf.Auth = &AuthConfig{}
+	require.False(t, conf.Auth.IsEnabled())
+	require.Nil(t, conf.ValidateAndAdjust())
+
+	tokenHash := sha256.Sum256([]byte("token"))
+	conf.Auth.Tokens = []*AuthTokenConfig{{TokenHash: "token", Role: AuthRoleAdmin}}
+	require.True(t, conf.Auth.IsEnabled())
+	require.Regexp(t, ".*SHA-256 hash.*", conf.ValidateAndAdjust())
+	conf.Auth.Tokens[0].TokenHash = hex.EncodeToString(tokenHash[:])
+	conf.Auth.Tokens[0].Role = "root"
+	require.Regexp(t, ".*invalid auth role.*", conf.ValidateAndAdjust())
+	conf.Auth.Tokens[0].Role = AuthRoleAdmin
+	require.Nil(t, conf.ValidateAndAdjust())
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.Nil(t, err)
+	conf.Auth.Users = []*AuthUserConfig{{Name: "ops", PasswordHash: "password", Role: AuthRoleOperator}}
+	require.Regexp(t, ".*bcrypt hash.*", conf.ValidateAndAdjust())
+	conf.Auth.Users[0].PasswordHash = string(passwordHash)
+	require.Nil(t, conf.ValidateAndAdjust())
+	conf.Auth.Users = append(conf.Auth.Users, conf.Auth.Users[0])
+	require.Regexp(t, ".*duplicated auth user.*", conf.ValidateAndAdjust())
+	conf.Auth.Users = conf.Auth.Users[:1]
+
+	conf.Auth.Certs = []*AuthCertConfig{{CommonName: "reader", Role: AuthRoleReadOnly}}
+	require.Regexp(t, ".*require TLS to be enabled.*", conf.ValidateAndAdjust())
+
+	require.True(t, AuthRoleAdmin.Covers(AuthRoleOperator))
+	require.True(t, AuthRoleOperator.Covers(AuthRoleOperator))
+	require.False(t, AuthRoleReadOnly.Covers(AuthRoleOperator))
+	require.False(t, AuthRole("").Covers(AuthRoleReadOnly))
 }
 
 func TestDBConfigValidateAndAdjust(t *testing.T) {
@@ -128,9 +173,22 @@ func TestSchedulerConfigValidateAndAdjust(t *testing.T) {
 	require.Error(t, conf.ValidateAndAdjust())
 	conf.CheckBalanceInterval = TomlDuration(time.Second)
 	require.Error(t, conf.ValidateAndAdjust())
+	conf.CheckBalanceInterval = TomlDuration(time.Minute)
 
 	conf.AddTableBatchSize = 0
 	require.Error(t, conf.ValidateAndAdjust())
+	conf.AddTableBatchSize = 50
+	require.Nil(t, conf.ValidateAndAdjust())
+
+	// The threshold is only checked if tables are balanced by traffic.
+	conf.TrafficImbalanceThreshold = 0
+	require.Nil(t, conf.ValidateAndAdjust())
+	conf.BalanceByTraffic = true
+	require.Error(t, conf.ValidateAndAdjust())
+	conf.TrafficImbalanceThreshold = -0.1
+	require.Error(t, conf.ValidateAndAdjust())
+	conf.TrafficImbalanceThreshold = 0.1
+	require.Nil(t, conf.ValidateAndAdjust())
 }
 
 func TestIsValidClusterID(t *testing.T) {
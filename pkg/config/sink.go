@@ -16,6 +16,7 @@ package config
 import (
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
@@ -59,6 +60,7 @@ var ForceEnableOldValueProtocols = []string{
 	ProtocolCanal.String(),
 	ProtocolCanalJSON.String(),
 	ProtocolMaxwell.String(),
+	ProtocolDebezium.String(),
 }
 
 // SinkConfig represents sink config for a changefeed
@@ -78,7 +80,10 @@ type DispatchRule struct {
 	// PartitionRule is an alias added for DispatcherRule to mitigate confusions.
 	// In the future release, the DispatcherRule is expected to be removed .
 	PartitionRule string `toml:"partition" json:"partition"`
-	TopicRule     string `toml:"topic" json:"topic"`
+	// Columns are the columns used by the `columns` partition rule,
+	// the rows are dispatched by the hash of the values of them.
+	Columns   []string `toml:"columns" json:"columns"`
+	TopicRule string   `toml:"topic" json:"topic"`
 }
 
 // ColumnSelector represents a column selector for a table.
@@ -116,6 +121,11 @@ func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL, enableOldValue bool) er
 			rule.PartitionRule = rule.DispatcherRule
 			rule.DispatcherRule = ""
 		}
+		if strings.ToLower(rule.PartitionRule) == "columns" && len(rule.Columns) == 0 {
+			return cerror.WrapError(cerror.ErrSinkInvalidConfig,
+				errors.New(fmt.Sprintf("columns must be configured for the "+
+					"columns partition rule:%v", rule)))
+		}
 	}
 
 	return nil
@@ -132,7 +142,8 @@ func (s *SinkConfig) applyParameter(sinkURI *url.URL) error {
 	switch AtomicityLevel(txnAtomicity) {
 	case unknowTxnAtomicity:
 		// Set default value according to scheme.
-		if sink.IsMQScheme(sinkURI.Scheme) {
+		if sink.IsMQScheme(sinkURI.Scheme) || sink.IsStorageScheme(sinkURI.Scheme) ||
+			sink.IsWebhookScheme(sinkURI.Scheme) {
 			s.TxnAtomicity = defaultMqTxnAtomicity
 		} else {
 			s.TxnAtomicity = defaultMysqlTxnAtomicity
@@ -175,6 +186,28 @@ func (s *SinkConfig) applyParameter(sinkURI *url.URL) error {
 		if err != nil {
 			return err
 		}
+	} else if sink.IsStorageScheme(sinkURI.Scheme) {
+		var protocol Protocol
+		err := protocol.FromString(s.Protocol)
+		if err != nil {
+			return err
+		}
+		// Only the row-oriented text formats can be written into files.
+		if protocol != ProtocolCsv && protocol != ProtocolCanalJSON {
+			return cerror.ErrSinkURIInvalid.GenWithStackByArgs(fmt.Sprintf("protocol %s "+
+				"is incompatible with %s scheme", s.Protocol, sinkURI.Scheme))
+		}
+	} else if sink.IsWebhookScheme(sinkURI.Scheme) {
+		var protocol Protocol
+		err := protocol.FromString(s.Protocol)
+		if err != nil {
+			return err
+		}
+		if protocol != ProtocolOpen && protocol != ProtocolCanalJSON &&
+			protocol != ProtocolDebezium {
+			return cerror.ErrSinkURIInvalid.GenWithStackByArgs(fmt.Sprintf("protocol %s "+
+				"is incompatible with %s scheme", s.Protocol, sinkURI.Scheme))
+		}
 	} else if s.Protocol != "" {
 		return cerror.ErrSinkURIInvalid.GenWithStackByArgs(fmt.Sprintf("protocol %s "+
 			"is incompatible with %s scheme", s.Protocol, sinkURI.Scheme))
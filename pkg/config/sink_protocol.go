@@ -36,6 +36,8 @@ const (
 	ProtocolCanalJSON
 	ProtocolCraft
 	ProtocolOpen
+	ProtocolCsv
+	ProtocolDebezium
 )
 
 // FromString converts the protocol from string to Protocol enum type.
@@ -57,6 +59,10 @@ func (p *Protocol) FromString(protocol string) error {
 		*p = ProtocolCraft
 	case "open-protocol":
 		*p = ProtocolOpen
+	case "csv":
+		*p = ProtocolCsv
+	case "debezium":
+		*p = ProtocolDebezium
 	default:
 		return cerror.ErrSinkUnknownProtocol.GenWithStackByArgs(protocol)
 	}
@@ -81,6 +87,10 @@ func (p Protocol) String() string {
 		return "craft"
 	case ProtocolOpen:
 		return "open-protocol"
+	case ProtocolCsv:
+		return "csv"
+	case ProtocolDebezium:
+		return "debezium"
 	default:
 		panic("unreachable")
 	}
@@ -62,6 +62,14 @@ func TestFromString(t *testing.T) {
 			protocol:             "open-protocol",
 			expectedProtocolEnum: ProtocolOpen,
 		},
+		{
+			protocol:             "csv",
+			expectedProtocolEnum: ProtocolCsv,
+		},
+		{
+			protocol:             "debezium",
+			expectedProtocolEnum: ProtocolDebezium,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -109,6 +117,14 @@ func TestString(t *testing.T) {
 			protocolEnum:     ProtocolOpen,
 			expectedProtocol: "open-protocol",
 		},
+		{
+			protocolEnum:     ProtocolCsv,
+			expectedProtocol: "csv",
+		},
+		{
+			protocolEnum:     ProtocolDebezium,
+			expectedProtocol: "debezium",
+		},
 	}
 
 	for _, tc := range testCases {
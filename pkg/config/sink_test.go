@@ -145,6 +145,34 @@ func TestValidateApplyParameter(t *testing.T) {
 			sinkURI:     "kafka://127.0.0.1:9092?transaction-atomicity=table",
 			expectedErr: ".*unknown .* message protocol for sink.*",
 		},
+		{
+			sinkURI:       "file:///tmp/cdc?protocol=csv",
+			expectedErr:   "",
+			expectedLevel: noneTxnAtomicity,
+		},
+		{
+			sinkURI:       "s3://bucket/prefix?protocol=canal-json",
+			expectedErr:   "",
+			expectedLevel: noneTxnAtomicity,
+		},
+		{
+			sinkURI:     "file:///tmp/cdc?protocol=open-protocol",
+			expectedErr: ".*protocol open-protocol is incompatible with file scheme.*",
+		},
+		{
+			sinkURI:       "http://127.0.0.1:8080/events?protocol=canal-json",
+			expectedErr:   "",
+			expectedLevel: noneTxnAtomicity,
+		},
+		{
+			sinkURI:       "https://127.0.0.1:8080/events?protocol=debezium",
+			expectedErr:   "",
+			expectedLevel: noneTxnAtomicity,
+		},
+		{
+			sinkURI:     "http://127.0.0.1:8080/events?protocol=avro",
+			expectedErr: ".*protocol avro is incompatible with http scheme.*",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -187,6 +187,18 @@ var (
 		"filter rule is invalid %v",
 		errors.RFCCodeText("CDC:ErrFilterRuleInvalid"),
 	)
+	ErrPlacementRuleInvalid = errors.Normalize(
+		"placement rule is invalid %v",
+		errors.RFCCodeText("CDC:ErrPlacementRuleInvalid"),
+	)
+	ErrColumnSelectorFailed = errors.Normalize(
+		"column selector failed",
+		errors.RFCCodeText("CDC:ErrColumnSelectorFailed"),
+	)
+	ErrDispatcherFailed = errors.Normalize(
+		"dispatcher failed",
+		errors.RFCCodeText("CDC:ErrDispatcherFailed"),
+	)
 
 	// internal errors
 	ErrAdminStopProcessor = errors.Normalize(
@@ -268,6 +280,10 @@ var (
 		"kafka broker config item not found",
 		errors.RFCCodeText("CDC:ErrKafkaBrokerConfigNotFound"),
 	)
+	ErrKafkaTransaction = errors.Normalize(
+		"kafka transaction failed",
+		errors.RFCCodeText("CDC:ErrKafkaTransaction"),
+	)
 	ErrKafkaTopicNotExists = errors.Normalize("kafka topic not exists after creation",
 		errors.RFCCodeText("CDC:ErrKafkaTopicNotExists"),
 	)
@@ -275,6 +291,10 @@ var (
 		"new pulsar producer",
 		errors.RFCCodeText("CDC:ErrPulsarNewProducer"),
 	)
+	ErrPulsarProducerClosed = errors.Normalize(
+		"pulsar producer closed",
+		errors.RFCCodeText("CDC:ErrPulsarProducerClosed"),
+	)
 	ErrPulsarSendMessage = errors.Normalize(
 		"pulsar send message failed",
 		errors.RFCCodeText("CDC:ErrPulsarSendMessage"),
@@ -303,6 +323,10 @@ var (
 		"initialize meta for redo log",
 		errors.RFCCodeText("CDC:ErrRedoMetaInitialize"),
 	)
+	ErrRedoCodecFailed = errors.Normalize(
+		"redo log codec failed",
+		errors.RFCCodeText("CDC:ErrRedoCodecFailed"),
+	)
 	ErrFileSizeExceed = errors.Normalize(
 		"rawData size %d exceeds maximum file size %d",
 		errors.RFCCodeText("CDC:ErrFileSizeExceed"),
@@ -315,6 +339,22 @@ var (
 		"new s3 storage for redo log",
 		errors.RFCCodeText("CDC:ErrS3StorageInitialize"),
 	)
+	ErrStorageSinkInvalidConfig = errors.Normalize(
+		"storage sink config invalid",
+		errors.RFCCodeText("CDC:ErrStorageSinkInvalidConfig"),
+	)
+	ErrStorageSinkAPI = errors.Normalize(
+		"storage sink api",
+		errors.RFCCodeText("CDC:ErrStorageSinkAPI"),
+	)
+	ErrWebhookSinkInvalidConfig = errors.Normalize(
+		"webhook sink config invalid",
+		errors.RFCCodeText("CDC:ErrWebhookSinkInvalidConfig"),
+	)
+	ErrWebhookSinkAPI = errors.Normalize(
+		"webhook sink api",
+		errors.RFCCodeText("CDC:ErrWebhookSinkAPI"),
+	)
 	ErrCodecInvalidConfig = errors.Normalize(
 		"Codec invalid config",
 		errors.RFCCodeText("CDC:ErrCodecInvalidConfig"),
@@ -347,6 +387,10 @@ var (
 		"MySQL config invalid",
 		errors.RFCCodeText("CDC:ErrMySQLInvalidConfig"),
 	)
+	ErrSyncPointNotFound = errors.Normalize(
+		"syncpoint %d of changefeed %s is not found in downstream",
+		errors.RFCCodeText("CDC:ErrSyncPointNotFound"),
+	)
 	ErrMySQLWorkerPanic = errors.Normalize(
 		"MySQL worker panic",
 		errors.RFCCodeText("CDC:ErrMySQLWorkerPanic"),
@@ -371,6 +415,10 @@ var (
 		"encode to binray from native",
 		errors.RFCCodeText("CDC:ErrAvroEncodeToBinary"),
 	)
+	ErrAvroDecodeFailed = errors.Normalize(
+		"decode avro message failed",
+		errors.RFCCodeText("CDC:ErrAvroDecodeFailed"),
+	)
 	ErrAvroSchemaAPIError = errors.Normalize(
 		"schema manager API error",
 		errors.RFCCodeText("CDC:ErrAvroSchemaAPIError"),
@@ -387,6 +435,22 @@ var (
 		"maxwell invalid data",
 		errors.RFCCodeText("CDC:ErrMaxwellInvalidData"),
 	)
+	ErrDebeziumEncodeFailed = errors.Normalize(
+		"debezium encode failed",
+		errors.RFCCodeText("CDC:ErrDebeziumEncodeFailed"),
+	)
+	ErrDebeziumDecodeFailed = errors.Normalize(
+		"debezium decode failed",
+		errors.RFCCodeText("CDC:ErrDebeziumDecodeFailed"),
+	)
+	ErrMessageTooLarge = errors.Normalize(
+		"message is too large",
+		errors.RFCCodeText("CDC:ErrMessageTooLarge"),
+	)
+	ErrClaimCheckFailed = errors.Normalize(
+		"claim-check failed",
+		errors.RFCCodeText("CDC:ErrClaimCheckFailed"),
+	)
 	ErrOpenProtocolCodecInvalidData = errors.Normalize(
 		"open-protocol codec invalid data",
 		errors.RFCCodeText("CDC:ErrOpenProtocolCodecInvalidData"),
@@ -403,6 +467,10 @@ var (
 		"canal encode failed",
 		errors.RFCCodeText("CDC:ErrCanalEncodeFailed"),
 	)
+	ErrCSVEncodeFailed = errors.Normalize(
+		"csv encode failed",
+		errors.RFCCodeText("CDC:ErrCSVEncodeFailed"),
+	)
 	ErrOldValueNotEnabled = errors.Normalize(
 		"old value is not enabled",
 		errors.RFCCodeText("CDC:ErrOldValueNotEnabled"),
@@ -411,6 +479,10 @@ var (
 		"sink config invalid",
 		errors.RFCCodeText("CDC:ErrSinkInvalidConfig"),
 	)
+	ErrSyncPointNotSupported = errors.Normalize(
+		"syncpoint is not supported by protocol %s, %s",
+		errors.RFCCodeText("CDC:ErrSyncPointNotSupported"),
+	)
 	ErrCraftCodecInvalidData = errors.Normalize(
 		"craft codec invalid data",
 		errors.RFCCodeText("CDC:ErrCraftCodecInvalidData"),
@@ -1067,6 +1139,14 @@ var (
 		"cdc server is not ready",
 		errors.RFCCodeText("CDC:ErrServerIsNotReady"),
 	)
+	ErrAPIUnauthenticated = errors.Normalize(
+		"the request is not authenticated: %s",
+		errors.RFCCodeText("CDC:ErrAPIUnauthenticated"),
+	)
+	ErrAPIPermissionDenied = errors.Normalize(
+		"role %s is not allowed to access %s %s",
+		errors.RFCCodeText("CDC:ErrAPIPermissionDenied"),
+	)
 
 	// cli error
 	ErrCliInvalidCheckpointTs = errors.Normalize(
@@ -124,6 +124,10 @@ type CDCEtcdClient interface {
 		id model.ChangeFeedID,
 	) (*model.ChangeFeedStatus, int64, error)
 
+	GetChangefeedHistory(ctx context.Context,
+		id model.ChangeFeedID,
+	) (*model.ChangefeedHistory, error)
+
 	GetUpstreamInfo(ctx context.Context,
 		upstreamID model.UpstreamID,
 		namespace string,
@@ -367,6 +371,28 @@ func (c *CDCEtcdClientImpl) GetChangeFeedStatus(ctx context.Context,
 	return info, resp.Kvs[0].ModRevision, errors.Trace(err)
 }
 
+// GetChangefeedHistory queries the history of a given changefeed,
+// an empty history is returned if no event is recorded.
+func (c *CDCEtcdClientImpl) GetChangefeedHistory(ctx context.Context,
+	id model.ChangeFeedID,
+) (*model.ChangefeedHistory, error) {
+	key := CDCKey{
+		Tp:           CDCKeyTypeChangefeedHistory,
+		ClusterID:    c.ClusterID,
+		ChangefeedID: id,
+	}
+	resp, err := c.Client.Get(ctx, key.String())
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPDEtcdAPIError, err)
+	}
+	history := &model.ChangefeedHistory{}
+	if resp.Count == 0 {
+		return history, nil
+	}
+	err = history.Unmarshal(resp.Kvs[0].Value)
+	return history, errors.Trace(err)
+}
+
 // GetCaptures returns kv revision and CaptureInfo list
 func (c *CDCEtcdClientImpl) GetCaptures(ctx context.Context) (int64, []*model.CaptureInfo, error) {
 	key := CaptureInfoKeyPrefix(c.ClusterID)
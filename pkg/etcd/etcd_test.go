@@ -210,6 +210,37 @@ func TestGetAllChangeFeedStatus(t *testing.T) {
 	require.Equal(t, statuses, changefeeds)
 }
 
+func TestGetChangefeedHistory(t *testing.T) {
+	s := &Tester{}
+	s.SetUpTest(t)
+	defer s.TearDownTest(t)
+
+	ctx := context.Background()
+	id := model.DefaultChangeFeedID("cf1")
+	history, err := s.client.GetChangefeedHistory(ctx, id)
+	require.NoError(t, err)
+	require.Empty(t, history.Events)
+
+	expected := &model.ChangefeedHistory{}
+	expected.Append(&model.ChangefeedEvent{
+		Type:     model.ChangefeedEventAdminJob,
+		AdminJob: model.AdminStop.String(),
+		Origin:   model.AdminJobOriginAPI,
+	})
+	value, err := expected.Marshal()
+	require.NoError(t, err)
+	key := CDCKey{
+		Tp:           CDCKeyTypeChangefeedHistory,
+		ClusterID:    DefaultCDCClusterID,
+		ChangefeedID: id,
+	}
+	_, err = s.client.GetEtcdClient().Put(ctx, key.String(), value)
+	require.NoError(t, err)
+	history, err = s.client.GetChangefeedHistory(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, expected, history)
+}
+
 func TestCheckMultipleCDCClusterExist(t *testing.T) {
 	s := &Tester{}
 	s.SetUpTest(t)
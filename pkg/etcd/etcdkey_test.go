@@ -74,6 +74,15 @@ func TestEtcdKey(t *testing.T) {
 			ClusterID:    DefaultCDCClusterID,
 			Namespace:    model.DefaultNamespace,
 		},
+	}, {
+		key: fmt.Sprintf("%s", DefaultClusterAndNamespacePrefix) +
+			"/changefeed/history/test-changefeed",
+		expected: &CDCKey{
+			Tp:           CDCKeyTypeChangefeedHistory,
+			ChangefeedID: model.DefaultChangeFeedID("test-changefeed"),
+			ClusterID:    DefaultCDCClusterID,
+			Namespace:    model.DefaultNamespace,
+		},
 	}, {
 		key: "/tidb/cdc/default/name/task" +
 			"/position/6bbc01c8-0605-4f86-a0f9-b3119109b225/test-changefeed",
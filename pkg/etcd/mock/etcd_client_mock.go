@@ -157,6 +157,21 @@ func (mr *MockCDCEtcdClientMockRecorder) GetChangeFeedStatus(ctx, id interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeFeedStatus", reflect.TypeOf((*MockCDCEtcdClient)(nil).GetChangeFeedStatus), ctx, id)
 }
 
+// GetChangefeedHistory mocks base method.
+func (m *MockCDCEtcdClient) GetChangefeedHistory(ctx context.Context, id model.ChangeFeedID) (*model.ChangefeedHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangefeedHistory", ctx, id)
+	ret0, _ := ret[0].(*model.ChangefeedHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangefeedHistory indicates an expected call of GetChangefeedHistory.
+func (mr *MockCDCEtcdClientMockRecorder) GetChangefeedHistory(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangefeedHistory", reflect.TypeOf((*MockCDCEtcdClient)(nil).GetChangefeedHistory), ctx, id)
+}
+
 // GetClusterID mocks base method.
 func (m *MockCDCEtcdClient) GetClusterID() string {
 	m.ctrl.T.Helper()
@@ -90,6 +90,7 @@ func (s *GlobalReactorState) Update(key util.EtcdKey, value []byte, _ bool) erro
 		s.Captures[k.CaptureID] = &newCaptureInfo
 	case etcd.CDCKeyTypeChangefeedInfo,
 		etcd.CDCKeyTypeChangeFeedStatus,
+		etcd.CDCKeyTypeChangefeedHistory,
 		etcd.CDCKeyTypeTaskPosition:
 		changefeedState, exist := s.Changefeeds[k.ChangefeedID]
 		if !exist {
@@ -157,6 +158,7 @@ type ChangefeedReactorState struct {
 	ID            model.ChangeFeedID
 	Info          *model.ChangeFeedInfo
 	Status        *model.ChangeFeedStatus
+	History       *model.ChangefeedHistory
 	TaskPositions map[model.CaptureID]*model.TaskPosition
 
 	pendingPatches        []DataPatch
@@ -211,6 +213,16 @@ func (s *ChangefeedReactorState) UpdateCDCKey(key *etcd.CDCKey, value []byte) er
 		}
 		s.Status = new(model.ChangeFeedStatus)
 		e = s.Status
+	case etcd.CDCKeyTypeChangefeedHistory:
+		if key.ChangefeedID != s.ID {
+			return nil
+		}
+		if value == nil {
+			s.History = nil
+			return nil
+		}
+		s.History = new(model.ChangefeedHistory)
+		e = s.History
 	case etcd.CDCKeyTypeTaskPosition:
 		if key.ChangefeedID != s.ID {
 			return nil
@@ -238,7 +250,8 @@ func (s *ChangefeedReactorState) UpdateCDCKey(key *etcd.CDCKey, value []byte) er
 
 // Exist returns false if all keys of this changefeed in ETCD is not exist
 func (s *ChangefeedReactorState) Exist() bool {
-	return s.Info != nil || s.Status != nil || len(s.TaskPositions) != 0
+	return s.Info != nil || s.Status != nil || s.History != nil ||
+		len(s.TaskPositions) != 0
 }
 
 // Active return true if the changefeed is ready to be processed
@@ -337,6 +350,22 @@ func (s *ChangefeedReactorState) PatchStatus(fn func(*model.ChangeFeedStatus) (*
 	})
 }
 
+// PatchHistory appends a DataPatch which can modify the ChangefeedHistory
+func (s *ChangefeedReactorState) PatchHistory(fn func(*model.ChangefeedHistory) (*model.ChangefeedHistory, bool, error)) {
+	key := &etcd.CDCKey{
+		ClusterID:    s.ClusterID,
+		Tp:           etcd.CDCKeyTypeChangefeedHistory,
+		ChangefeedID: s.ID,
+	}
+	s.patchAny(key.String(), changefeedHistoryTPI, func(e interface{}) (interface{}, bool, error) {
+		// e == nil means that the key is not exist before this patch
+		if e == nil {
+			return fn(nil)
+		}
+		return fn(e.(*model.ChangefeedHistory))
+	})
+}
+
 // PatchTaskPosition appends a DataPatch which can modify the TaskPosition of a specified capture
 func (s *ChangefeedReactorState) PatchTaskPosition(captureID model.CaptureID, fn func(*model.TaskPosition) (*model.TaskPosition, bool, error)) {
 	key := &etcd.CDCKey{
@@ -355,9 +384,10 @@ func (s *ChangefeedReactorState) PatchTaskPosition(captureID model.CaptureID, fn
 }
 
 var (
-	taskPositionTPI     *model.TaskPosition
-	changefeedStatusTPI *model.ChangeFeedStatus
-	changefeedInfoTPI   *model.ChangeFeedInfo
+	taskPositionTPI      *model.TaskPosition
+	changefeedStatusTPI  *model.ChangeFeedStatus
+	changefeedInfoTPI    *model.ChangeFeedInfo
+	changefeedHistoryTPI *model.ChangefeedHistory
 )
 
 func (s *ChangefeedReactorState) patchAny(key string, tpi interface{}, fn func(interface{}) (interface{}, bool, error)) {
@@ -367,6 +367,32 @@ func TestPatchStatus(t *testing.T) {
 	require.Nil(t, state.Status)
 }
 
+func TestPatchHistory(t *testing.T) {
+	state := NewChangefeedReactorState(etcd.DefaultCDCClusterID,
+		model.DefaultChangeFeedID("test1"))
+	stateTester := NewReactorStateTester(t, state, nil)
+	event := &model.ChangefeedEvent{
+		Type:     model.ChangefeedEventStateChanged,
+		OldState: model.StateNormal,
+		State:    model.StateStopped,
+	}
+	state.PatchHistory(func(history *model.ChangefeedHistory) (*model.ChangefeedHistory, bool, error) {
+		require.Nil(t, history)
+		history = &model.ChangefeedHistory{}
+		history.Append(event)
+		return history, true, nil
+	})
+	stateTester.MustApplyPatches()
+	require.Equal(t, []*model.ChangefeedEvent{event}, state.History.Events)
+	require.True(t, state.Exist())
+	state.PatchHistory(func(history *model.ChangefeedHistory) (*model.ChangefeedHistory, bool, error) {
+		return nil, true, nil
+	})
+	stateTester.MustApplyPatches()
+	require.Nil(t, state.History)
+	require.False(t, state.Exist())
+}
+
 func TestPatchTaskPosition(t *testing.T) {
 	state := NewChangefeedReactorState(etcd.DefaultCDCClusterID,
 		model.DefaultChangeFeedID("test1"))
@@ -0,0 +1,42 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import "encoding/base64"
+
+// APICredential holds the credential used by clients to authenticate to
+// the TiCDC HTTP API. The bearer token takes precedence over the user.
+type APICredential struct {
+	Token    string
+	User     string
+	Password string
+}
+
+// IsEmpty checks whether APICredential is empty or not.
+func (c *APICredential) IsEmpty() bool {
+	return c == nil || (c.Token == "" && c.User == "")
+}
+
+// AuthorizationHeader returns the value of the HTTP Authorization header,
+// it returns an empty string if the credential is empty.
+func (c *APICredential) AuthorizationHeader() string {
+	if c.IsEmpty() {
+		return ""
+	}
+	if c.Token != "" {
+		return "Bearer " + c.Token
+	}
+	auth := c.User + ":" + c.Password
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
+}
@@ -0,0 +1,35 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPICredentialAuthorizationHeader(t *testing.T) {
+	t.Parallel()
+
+	var c *APICredential
+	require.True(t, c.IsEmpty())
+	require.Equal(t, "", c.AuthorizationHeader())
+
+	c = &APICredential{User: "ops", Password: "secret"}
+	require.False(t, c.IsEmpty())
+	require.Equal(t, "Basic b3BzOnNlY3JldA==", c.AuthorizationHeader())
+
+	c.Token = "token"
+	require.Equal(t, "Bearer token", c.AuthorizationHeader())
+}
@@ -0,0 +1,147 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+)
+
+const (
+	// defaultFlushInterval is the default interval of flushing the
+	// buffered rows to the external storage.
+	defaultFlushInterval = 5 * time.Second
+	// minFlushInterval is the lower limit of the flush interval.
+	minFlushInterval = 500 * time.Millisecond
+	// maxFlushInterval is the upper limit of the flush interval.
+	maxFlushInterval = 10 * time.Minute
+	// defaultFileSize is the default size of a data file,
+	// a new data file is created once the size is exceeded.
+	defaultFileSize = 64 * 1024 * 1024
+	// minFileSize is the lower limit of the file size.
+	minFileSize = 1024
+	// maxFileSize is the upper limit of the file size.
+	maxFileSize = 512 * 1024 * 1024
+	// defaultWindowDuration is the default duration of a time window.
+	// Rows are grouped into windows by the physical time of their commit ts.
+	defaultWindowDuration = time.Hour
+	// minWindowDuration is the lower limit of the window duration.
+	minWindowDuration = time.Minute
+)
+
+// Config is the configs for the cloud storage sink.
+type Config struct {
+	FlushInterval  time.Duration
+	FileSize       int
+	WindowDuration time.Duration
+	Protocol       config.Protocol
+}
+
+// NewConfig returns the default cloud storage sink config.
+func NewConfig() *Config {
+	return &Config{
+		FlushInterval:  defaultFlushInterval,
+		FileSize:       defaultFileSize,
+		WindowDuration: defaultWindowDuration,
+	}
+}
+
+// Apply applies the sink URI parameters to the config.
+func (c *Config) Apply(
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+) (err error) {
+	if sinkURI == nil {
+		return cerror.ErrStorageSinkInvalidConfig.GenWithStack(
+			"failed to open cloud storage sink, empty SinkURI")
+	}
+
+	scheme := strings.ToLower(sinkURI.Scheme)
+	if !sink.IsStorageScheme(scheme) {
+		return cerror.ErrStorageSinkInvalidConfig.GenWithStack(
+			"can't create cloud storage sink with unsupported scheme: %s", scheme)
+	}
+	query := sinkURI.Query()
+	if err = getDuration(query, "flush-interval", minFlushInterval,
+		maxFlushInterval, &c.FlushInterval); err != nil {
+		return err
+	}
+	if err = getFileSize(query, &c.FileSize); err != nil {
+		return err
+	}
+	if err = getDuration(query, "window-duration", minWindowDuration,
+		0, &c.WindowDuration); err != nil {
+		return err
+	}
+
+	if err = c.Protocol.FromString(replicaConfig.Sink.Protocol); err != nil {
+		return cerror.WrapError(cerror.ErrStorageSinkInvalidConfig, err)
+	}
+	if c.Protocol != config.ProtocolCsv && c.Protocol != config.ProtocolCanalJSON {
+		return cerror.ErrStorageSinkInvalidConfig.GenWithStack(
+			"protocol %s is not supported by cloud storage sink", c.Protocol)
+	}
+
+	return nil
+}
+
+// getDuration parses the duration of the key, the value must be in the
+// range of [lower, upper]. The upper limit is ignored if it is zero.
+func getDuration(values url.Values, key string,
+	lower, upper time.Duration, target *time.Duration,
+) error {
+	s := values.Get(key)
+	if len(s) == 0 {
+		return nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrStorageSinkInvalidConfig, err)
+	}
+	if d < lower || (upper > 0 && d > upper) {
+		return cerror.WrapError(cerror.ErrStorageSinkInvalidConfig,
+			fmt.Errorf("invalid %s %s, which is out of range", key, s))
+	}
+
+	*target = d
+	return nil
+}
+
+func getFileSize(values url.Values, fileSize *int) error {
+	s := values.Get("file-size")
+	if len(s) == 0 {
+		return nil
+	}
+
+	sz, err := strconv.Atoi(s)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrStorageSinkInvalidConfig, err)
+	}
+	if sz < minFileSize || sz > maxFileSize {
+		return cerror.WrapError(cerror.ErrStorageSinkInvalidConfig,
+			fmt.Errorf("invalid file-size %d, which must be in [%d, %d]",
+				sz, minFileSize, maxFileSize))
+	}
+
+	*fileSize = sz
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigApply(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		uri       string
+		protocol  string
+		expected  *Config
+		expectErr string
+	}{
+		{
+			name:     "default config",
+			uri:      "file:///tmp/test",
+			protocol: "csv",
+			expected: &Config{
+				FlushInterval:  defaultFlushInterval,
+				FileSize:       defaultFileSize,
+				WindowDuration: defaultWindowDuration,
+				Protocol:       config.ProtocolCsv,
+			},
+		},
+		{
+			name:     "custom config",
+			uri:      "s3://bucket/prefix?flush-interval=2s&file-size=1048576&window-duration=10m",
+			protocol: "canal-json",
+			expected: &Config{
+				FlushInterval:  2 * time.Second,
+				FileSize:       1048576,
+				WindowDuration: 10 * time.Minute,
+				Protocol:       config.ProtocolCanalJSON,
+			},
+		},
+		{
+			name:      "invalid flush interval",
+			uri:       "file:///tmp/test?flush-interval=1ms",
+			protocol:  "csv",
+			expectErr: "invalid flush-interval 1ms",
+		},
+		{
+			name:      "invalid file size",
+			uri:       "file:///tmp/test?file-size=1",
+			protocol:  "csv",
+			expectErr: "invalid file-size 1",
+		},
+		{
+			name:      "unsupported protocol",
+			uri:       "file:///tmp/test",
+			protocol:  "open-protocol",
+			expectErr: "protocol open-protocol is not supported",
+		},
+		{
+			name:      "unsupported scheme",
+			uri:       "kafka://127.0.0.1:9092/test",
+			protocol:  "csv",
+			expectErr: "unsupported scheme",
+		},
+	}
+
+	for _, tc := range testCases {
+		sinkURI, err := url.Parse(tc.uri)
+		require.Nil(t, err)
+		replicaConfig := config.GetDefaultReplicaConfig()
+		replicaConfig.Sink.Protocol = tc.protocol
+
+		cfg := NewConfig()
+		err = cfg.Apply(sinkURI, replicaConfig)
+		if tc.expectErr != "" {
+			require.ErrorContains(t, err, tc.expectErr, tc.name)
+			continue
+		}
+		require.Nil(t, err, tc.name)
+		require.Equal(t, tc.expected, cfg, tc.name)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+// The layout of the files written by the cloud storage sink is:
+//
+//	metadata
+//	syncpoint/<syncpoint ts>.json
+//	<schema>/<table>/schema_<commit ts>.json
+//	<schema>/<table>/<window>/manifest.json
+//	<schema>/<table>/<window>/CDC000001.csv
+//	<schema>/<table>/<window>/CDC000002.csv
+//	...
+//
+// The metadata file records the checkpoint ts of the changefeed,
+// all the rows committed before it have been written.
+// A syncpoint file is written for each syncpoint, the rows committed before
+// the syncpoint ts of the listed tables form a consistent snapshot.
+// A schema file is written for each DDL which changes the table.
+// Rows are grouped into windows by the physical time of their commit ts,
+// each window has a manifest which describes all the data files in it.
+const (
+	// MetadataFileName is the name of the metadata file.
+	MetadataFileName = "metadata"
+	// syncPointDir is the directory of the syncpoint files.
+	syncPointDir = "syncpoint"
+	// manifestFileName is the name of the manifest file of a window.
+	manifestFileName = "manifest.json"
+	// windowLayout is the time layout of the window directory.
+	windowLayout = "2006-01-02-15-04"
+)
+
+// FileInfo describes a data file in a window.
+type FileInfo struct {
+	Name        string `json:"name"`
+	Rows        int    `json:"rows"`
+	MinCommitTs uint64 `json:"min-commit-ts"`
+	MaxCommitTs uint64 `json:"max-commit-ts"`
+}
+
+// Manifest describes all the data files of a table in a window.
+type Manifest struct {
+	Schema   string     `json:"schema"`
+	Table    string     `json:"table"`
+	Window   string     `json:"window"`
+	Protocol string     `json:"protocol"`
+	Files    []FileInfo `json:"files"`
+}
+
+// Metadata is the content of the metadata file.
+type Metadata struct {
+	CheckpointTs uint64 `json:"checkpoint-ts"`
+}
+
+// SyncPoint is the content of the syncpoint file.
+type SyncPoint struct {
+	SyncPointTs uint64           `json:"syncpoint-ts"`
+	Tables      []SyncPointTable `json:"tables"`
+}
+
+// SyncPointTable is a table replicated at the syncpoint.
+type SyncPointTable struct {
+	Schema string `json:"schema"`
+	Table  string `json:"table"`
+}
+
+// ColumnDef is the definition of a column in the schema file.
+type ColumnDef struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TableDef is the definition of a table in the schema file.
+type TableDef struct {
+	Schema  string      `json:"schema"`
+	Table   string      `json:"table"`
+	Version uint64      `json:"version"`
+	Query   string      `json:"query"`
+	Columns []ColumnDef `json:"columns"`
+}
+
+// NewTableDef creates the table definition from the DDL event.
+// The version of the definition is the commit ts of the DDL.
+func NewTableDef(ddl *model.DDLEvent) TableDef {
+	def := TableDef{
+		Schema:  ddl.TableInfo.Schema,
+		Table:   ddl.TableInfo.Table,
+		Version: ddl.CommitTs,
+		Query:   ddl.Query,
+		Columns: make([]ColumnDef, 0, len(ddl.TableInfo.ColumnInfo)),
+	}
+	for _, col := range ddl.TableInfo.ColumnInfo {
+		def.Columns = append(def.Columns, ColumnDef{
+			Name: col.Name,
+			Type: types.TypeStr(col.Type),
+		})
+	}
+	return def
+}
+
+// GetWindow returns the window which the commit ts belongs to.
+func GetWindow(commitTs uint64, windowDuration time.Duration) string {
+	t := oracle.GetTimeFromTS(commitTs).UTC().Truncate(windowDuration)
+	return t.Format(windowLayout)
+}
+
+// SchemaFilePath returns the path of the schema file.
+func SchemaFilePath(schema, table string, commitTs uint64) string {
+	return path.Join(schema, table, fmt.Sprintf("schema_%d.json", commitTs))
+}
+
+// SyncPointFilePath returns the path of the syncpoint file.
+func SyncPointFilePath(ts uint64) string {
+	return path.Join(syncPointDir, fmt.Sprintf("%d.json", ts))
+}
+
+// ManifestFilePath returns the path of the manifest file of the window.
+func ManifestFilePath(schema, table, window string) string {
+	return path.Join(schema, table, window, manifestFileName)
+}
+
+// DataFileName returns the name of the data file with the given index.
+func DataFileName(index int, protocol config.Protocol) string {
+	ext := "json"
+	if protocol == config.ProtocolCsv {
+		ext = "csv"
+	}
+	return fmt.Sprintf("CDC%06d.%s", index, ext)
+}
+
+// DataFilePath returns the path of the data file in the window.
+func DataFilePath(schema, table, window, name string) string {
+	return path.Join(schema, table, window, name)
+}
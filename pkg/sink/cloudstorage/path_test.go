@@ -0,0 +1,69 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestPaths(t *testing.T) {
+	t.Parallel()
+
+	commitTs := oracle.GoTimeToTS(time.Date(2022, 9, 1, 10, 35, 12, 0, time.UTC))
+	window := GetWindow(commitTs, time.Hour)
+	require.Equal(t, "2022-09-01-10-00", window)
+	require.Equal(t, "2022-09-01-10-30", GetWindow(commitTs, 10*time.Minute))
+
+	require.Equal(t, "test/t1/2022-09-01-10-00/manifest.json",
+		ManifestFilePath("test", "t1", window))
+	require.Equal(t, "test/t1/2022-09-01-10-00/CDC000001.csv",
+		DataFilePath("test", "t1", window, DataFileName(1, config.ProtocolCsv)))
+	require.Equal(t, "CDC000012.json", DataFileName(12, config.ProtocolCanalJSON))
+	require.Equal(t, "test/t1/schema_100.json", SchemaFilePath("test", "t1", 100))
+	require.Equal(t, "syncpoint/100.json", SyncPointFilePath(100))
+}
+
+func TestNewTableDef(t *testing.T) {
+	t.Parallel()
+
+	ddl := &model.DDLEvent{
+		CommitTs: 100,
+		Query:    "create table test.t1(id int primary key, name varchar(32))",
+		TableInfo: &model.SimpleTableInfo{
+			Schema: "test",
+			Table:  "t1",
+			ColumnInfo: []*model.ColumnInfo{
+				{Name: "id", Type: mysql.TypeLong},
+				{Name: "name", Type: mysql.TypeVarchar},
+			},
+		},
+	}
+	require.Equal(t, TableDef{
+		Schema:  "test",
+		Table:   "t1",
+		Version: 100,
+		Query:   ddl.Query,
+		Columns: []ColumnDef{
+			{Name: "id", Type: "int"},
+			{Name: "name", Type: "varchar"},
+		},
+	}, NewTableDef(ddl))
+}
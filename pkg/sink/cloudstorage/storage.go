@@ -0,0 +1,80 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+)
+
+// GetExternalStorage creates the external storage from the sink URI.
+func GetExternalStorage(ctx context.Context, sinkURI *url.URL) (storage.ExternalStorage, error) {
+	// ParseBackend consumes the query parameters of the URI,
+	// so we have to pass a copy of it.
+	uri := *sinkURI
+	backend, err := storage.ParseBackend(uri.String(), nil)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrStorageSinkInvalidConfig, err)
+	}
+	s, err := storage.New(ctx, backend, &storage.ExternalStorageOptions{
+		SendCredentials: false,
+	})
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrStorageSinkInvalidConfig, err)
+	}
+
+	if strings.ToLower(sinkURI.Scheme) == sink.FileSchema {
+		return &localStorage{
+			ExternalStorage: s,
+			base:            backend.GetLocal().GetPath(),
+		}, nil
+	}
+	return s, nil
+}
+
+// localStorage wraps the local storage to create the parent directories
+// before writing files, object storages don't need it.
+type localStorage struct {
+	storage.ExternalStorage
+	base string
+}
+
+// WriteFile implements storage.ExternalStorage.
+func (l *localStorage) WriteFile(ctx context.Context, name string, data []byte) error {
+	if err := l.mkdirAll(name); err != nil {
+		return err
+	}
+	return l.ExternalStorage.WriteFile(ctx, name, data)
+}
+
+// Create implements storage.ExternalStorage.
+func (l *localStorage) Create(ctx context.Context, name string) (storage.ExternalFileWriter, error) {
+	if err := l.mkdirAll(name); err != nil {
+		return nil, err
+	}
+	return l.ExternalStorage.Create(ctx, name)
+}
+
+func (l *localStorage) mkdirAll(name string) error {
+	dir := filepath.Dir(filepath.Join(l.base, name))
+	return errors.Trace(os.MkdirAll(dir, 0o755))
+}
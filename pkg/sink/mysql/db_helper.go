@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tiflow/cdc/model"
 	dmutils "github.com/pingcap/tiflow/dm/pkg/utils"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"go.uber.org/zap"
@@ -235,3 +236,26 @@ func checkTiDBVariable(ctx context.Context, db *sql.DB, variableName, defaultVal
 	// session variable not exists, return "" to ignore it
 	return "", nil
 }
+
+// GetMissingTables returns the tables that can not be found in the downstream.
+// Note that information_schema only shows the tables that the user has any
+// privilege on, so the tables without privileges are regarded as missing too.
+func GetMissingTables(
+	ctx context.Context, db *sql.DB, tables []model.TableName,
+) ([]model.TableName, error) {
+	var missing []model.TableName
+	querySQL := "SELECT TABLE_NAME FROM information_schema.TABLES " +
+		"WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?;"
+	for _, table := range tables {
+		var name string
+		err := db.QueryRowContext(ctx, querySQL, table.Schema, table.Table).Scan(&name)
+		if err == sql.ErrNoRows {
+			missing = append(missing, table)
+			continue
+		}
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+	}
+	return missing, nil
+}
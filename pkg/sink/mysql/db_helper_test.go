@@ -0,0 +1,49 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMissingTables(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.Nil(t, err)
+	defer db.Close() //nolint:errcheck
+
+	querySQL := "SELECT TABLE_NAME FROM information_schema.TABLES"
+	mock.ExpectQuery(querySQL).WithArgs("test", "t1").WillReturnRows(
+		sqlmock.NewRows([]string{"TABLE_NAME"}).AddRow("t1"))
+	mock.ExpectQuery(querySQL).WithArgs("test", "t2").WillReturnError(sql.ErrNoRows)
+	tables := []model.TableName{
+		{Schema: "test", Table: "t1"},
+		{Schema: "test", Table: "t2"},
+	}
+	missing, err := GetMissingTables(context.TODO(), db, tables)
+	require.Nil(t, err)
+	require.Equal(t, []model.TableName{{Schema: "test", Table: "t2"}}, missing)
+
+	mock.ExpectQuery(querySQL).WithArgs("test", "t1").WillReturnError(sql.ErrConnDone)
+	_, err = GetMissingTables(context.TODO(), db, tables)
+	require.NotNil(t, err)
+	require.Nil(t, mock.ExpectationsWereMet())
+}
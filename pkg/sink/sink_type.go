@@ -53,12 +53,24 @@ const (
 	TiDBSchema = "tidb"
 	// TiDBSSLSchema indicates the schema is TiDB+ssl.
 	TiDBSSLSchema = "tidb+ssl"
+	// FileSchema indicates the schema is local file system.
+	FileSchema = "file"
+	// S3Schema indicates the schema is s3.
+	S3Schema = "s3"
+	// HTTPSchema indicates the schema is http webhook.
+	HTTPSchema = "http"
+	// HTTPSSchema indicates the schema is https webhook.
+	HTTPSSchema = "https"
 )
 
 // IsMQScheme returns true if the scheme belong to mq schema.
 func IsMQScheme(scheme string) bool {
-	return scheme == KafkaSchema || scheme == KafkaSSLSchema ||
-		IsPulsarScheme(scheme)
+	return IsKafkaScheme(scheme) || IsPulsarScheme(scheme)
+}
+
+// IsKafkaScheme returns true if the scheme belong to kafka schema.
+func IsKafkaScheme(scheme string) bool {
+	return scheme == KafkaSchema || scheme == KafkaSSLSchema
 }
 
 // IsPulsarScheme returns true if the scheme belong to pulsar schema.
@@ -71,3 +83,13 @@ func IsMySQLCompatibleScheme(scheme string) bool {
 	return scheme == MySQLSchema || scheme == MySQLSSLSchema ||
 		scheme == TiDBSchema || scheme == TiDBSSLSchema
 }
+
+// IsStorageScheme returns true if the scheme belong to storage schema.
+func IsStorageScheme(scheme string) bool {
+	return scheme == FileSchema || scheme == S3Schema
+}
+
+// IsWebhookScheme returns true if the scheme belong to webhook schema.
+func IsWebhookScheme(scheme string) bool {
+	return scheme == HTTPSchema || scheme == HTTPSSchema
+}
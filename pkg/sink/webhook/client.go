@@ -0,0 +1,190 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/httputil"
+	"github.com/pingcap/tiflow/pkg/retry"
+	"go.uber.org/zap"
+)
+
+const (
+	// ProtocolHeader is the header which carries the protocol of the body.
+	ProtocolHeader = "X-TiCDC-Protocol"
+	// MessageTypeHeader is the header which carries the type of the
+	// messages in the body, it is one of "row", "ddl", "resolved" and "syncpoint".
+	MessageTypeHeader = "X-TiCDC-Message-Type"
+
+	contentTypeNDJSON = "application/x-ndjson"
+	contentTypeBinary = "application/octet-stream"
+
+	// maxErrorBodySize is the max size of the response body kept in errors.
+	maxErrorBodySize = 1024
+)
+
+// statusError is returned when the endpoint responds with a non-2xx code.
+type statusError struct {
+	code int
+	body string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("[%d] %s", e.code, e.body)
+}
+
+// Client posts the encoded messages to the endpoint of the webhook sink.
+type Client struct {
+	config *Config
+	client *httputil.Client
+}
+
+// NewClient creates a Client by the config.
+func NewClient(cfg *Config) (*Client, error) {
+	client, err := httputil.NewClient(cfg.Credential)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrWebhookSinkInvalidConfig, err)
+	}
+	return &Client{config: cfg, client: client}, nil
+}
+
+// Send posts the messages to the endpoint in one request, the request is
+// retried with backoff until it succeeds, the max retry times is reached,
+// or the endpoint rejects it with a 4xx code.
+//
+// The messages of JSON based protocols are sent as newline delimited JSON,
+// each line is the value of a message. The messages of open-protocol are
+// sent as a sequence of length prefixed keys and values, the lengths are
+// 8 bytes big endian integers.
+func (c *Client) Send(
+	ctx context.Context, tp model.MessageType, msgs []*common.Message,
+) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	contentType, body := c.encodeBody(msgs)
+	err := retry.Do(ctx, func() error {
+		err := c.post(ctx, tp, contentType, body)
+		if err != nil {
+			log.Warn("Webhook sink failed to send messages, retrying",
+				zap.String("messageType", messageTypeString(tp)),
+				zap.Int("count", len(msgs)), zap.Error(err))
+		}
+		return err
+	}, retry.WithBackoffBaseDelay(c.config.BackoffBaseDelay.Milliseconds()),
+		retry.WithBackoffMaxDelay(c.config.BackoffMaxDelay.Milliseconds()),
+		retry.WithMaxTries(c.config.MaxRetryTimes+1),
+		retry.WithIsRetryableErr(isRetryableError))
+	if err != nil {
+		return cerror.WrapError(cerror.ErrWebhookSinkAPI, err)
+	}
+	return nil
+}
+
+func (c *Client) post(
+	ctx context.Context, tp model.MessageType, contentType string, body []byte,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, c.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set(ProtocolHeader, c.config.Protocol.String())
+	req.Header.Set(MessageTypeHeader, messageTypeString(tp))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		// Drain the body so that the connection can be reused.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	content, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	return &statusError{code: resp.StatusCode, body: string(content)}
+}
+
+func (c *Client) encodeBody(msgs []*common.Message) (string, []byte) {
+	var buf bytes.Buffer
+	if c.config.Protocol == config.ProtocolOpen {
+		var length [8]byte
+		for _, msg := range msgs {
+			binary.BigEndian.PutUint64(length[:], uint64(len(msg.Key)))
+			buf.Write(length[:])
+			buf.Write(msg.Key)
+			binary.BigEndian.PutUint64(length[:], uint64(len(msg.Value)))
+			buf.Write(length[:])
+			buf.Write(msg.Value)
+		}
+		return contentTypeBinary, buf.Bytes()
+	}
+
+	for _, msg := range msgs {
+		buf.Write(msg.Value)
+		buf.WriteByte('\n')
+	}
+	return contentTypeNDJSON, buf.Bytes()
+}
+
+// Close closes the idle connections.
+func (c *Client) Close() {
+	c.client.CloseIdleConnections()
+}
+
+// isRetryableError returns false if the request is rejected by the endpoint,
+// except the request timeout and too many requests, the same request will
+// be rejected again.
+func isRetryableError(err error) bool {
+	if !cerror.IsRetryableError(err) {
+		return false
+	}
+	if e, ok := errors.Cause(err).(*statusError); ok {
+		return e.code >= 500 ||
+			e.code == http.StatusRequestTimeout ||
+			e.code == http.StatusTooManyRequests
+	}
+	return true
+}
+
+func messageTypeString(tp model.MessageType) string {
+	switch tp {
+	case model.MessageTypeRow:
+		return "row"
+	case model.MessageTypeDDL:
+		return "ddl"
+	case model.MessageTypeResolved:
+		return "resolved"
+	case model.MessageTypeSyncPoint:
+		return "syncpoint"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfig(endpoint string, protocol config.Protocol) *Config {
+	cfg := NewConfig()
+	cfg.Endpoint = endpoint
+	cfg.Protocol = protocol
+	cfg.MaxRetryTimes = 3
+	cfg.BackoffBaseDelay = time.Millisecond
+	cfg.BackoffMaxDelay = 10 * time.Millisecond
+	return cfg
+}
+
+func TestClientSend(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	var body []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first request fails, and the retry succeeds.
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(newTestConfig(server.URL, config.ProtocolCanalJSON))
+	require.NoError(t, err)
+	defer client.Close()
+
+	msgs := []*common.Message{
+		{Key: []byte("k1"), Value: []byte(`{"a":1}`)},
+		{Key: []byte("k2"), Value: []byte(`{"a":2}`)},
+	}
+	err = client.Send(context.Background(), model.MessageTypeRow, msgs)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	require.Equal(t, "{\"a\":1}\n{\"a\":2}\n", string(body))
+	require.Equal(t, contentTypeNDJSON, header.Get("Content-Type"))
+	require.Equal(t, "canal-json", header.Get(ProtocolHeader))
+	require.Equal(t, "row", header.Get(MessageTypeHeader))
+}
+
+func TestClientSendOpenProtocol(t *testing.T) {
+	t.Parallel()
+
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, contentTypeBinary, r.Header.Get("Content-Type"))
+		require.Equal(t, "resolved", r.Header.Get(MessageTypeHeader))
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(newTestConfig(server.URL, config.ProtocolOpen))
+	require.NoError(t, err)
+	defer client.Close()
+
+	msg := &common.Message{Key: []byte("key"), Value: []byte("value")}
+	err = client.Send(context.Background(), model.MessageTypeResolved, []*common.Message{msg})
+	require.NoError(t, err)
+
+	require.Len(t, body, 8+3+8+5)
+	require.Equal(t, uint64(3), binary.BigEndian.Uint64(body[:8]))
+	require.Equal(t, "key", string(body[8:11]))
+	require.Equal(t, uint64(5), binary.BigEndian.Uint64(body[11:19]))
+	require.Equal(t, "value", string(body[19:]))
+}
+
+func TestClientSendFailed(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	code := int32(http.StatusBadRequest)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(int(atomic.LoadInt32(&code)))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(newTestConfig(server.URL, config.ProtocolCanalJSON))
+	require.NoError(t, err)
+	defer client.Close()
+
+	// The rejected request is not retried.
+	msgs := []*common.Message{{Value: []byte(`{}`)}}
+	err = client.Send(context.Background(), model.MessageTypeDDL, msgs)
+	require.True(t, cerror.ErrWebhookSinkAPI.Equal(err))
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// The server error is retried until the max retry times is reached.
+	atomic.StoreInt32(&requests, 0)
+	atomic.StoreInt32(&code, http.StatusServiceUnavailable)
+	err = client.Send(context.Background(), model.MessageTypeDDL, msgs)
+	require.True(t, cerror.ErrWebhookSinkAPI.Equal(err))
+	require.Equal(t, int32(4), atomic.LoadInt32(&requests))
+}
@@ -0,0 +1,208 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/pingcap/tiflow/pkg/sink"
+)
+
+const (
+	// defaultBatchSize is the default max number of rows sent in a request.
+	defaultBatchSize = 256
+	// maxBatchSize is the upper limit of the batch size.
+	maxBatchSize = 16384
+	// defaultFlushInterval is the default interval of sending the
+	// buffered rows even if the batch is not full.
+	defaultFlushInterval = time.Second
+	// minFlushInterval is the lower limit of the flush interval.
+	minFlushInterval = 10 * time.Millisecond
+	// maxFlushInterval is the upper limit of the flush interval.
+	maxFlushInterval = time.Minute
+	// defaultTimeout is the default timeout of a request.
+	defaultTimeout = 10 * time.Second
+	// defaultMaxRetryTimes is the default max number of retries of a request.
+	defaultMaxRetryTimes = 10
+	// defaultBackoffBaseDelay is the default initial delay of retries.
+	defaultBackoffBaseDelay = 500 * time.Millisecond
+	// defaultBackoffMaxDelay is the default max delay of retries.
+	defaultBackoffMaxDelay = 30 * time.Second
+)
+
+// sinkParams are the parameters of the sink URI consumed by TiCDC, they are
+// removed from the endpoint, the other parameters are sent to the endpoint.
+var sinkParams = []string{
+	config.ProtocolKey, "transaction-atomicity",
+	"batch-size", "flush-interval", "timeout",
+	"max-retry-times", "backoff-base-delay", "backoff-max-delay",
+	"ssl-ca", "ssl-cert", "ssl-key",
+	"enable-tidb-extension", "max-batch-size", "max-message-bytes",
+	"debezium-disable-schema", "large-message-handle-option", "claim-check-storage-uri",
+	"claim-check-retention",
+}
+
+// Config is the configs for the webhook sink.
+type Config struct {
+	// Endpoint is the URL the events are posted to.
+	Endpoint         string
+	Protocol         config.Protocol
+	BatchSize        int
+	FlushInterval    time.Duration
+	Timeout          time.Duration
+	MaxRetryTimes    uint64
+	BackoffBaseDelay time.Duration
+	BackoffMaxDelay  time.Duration
+	// Credential is used to verify the server and authenticate the
+	// client by TLS, it is nil if no certificate is configured.
+	Credential *security.Credential
+}
+
+// NewConfig returns the default webhook sink config.
+func NewConfig() *Config {
+	return &Config{
+		BatchSize:        defaultBatchSize,
+		FlushInterval:    defaultFlushInterval,
+		Timeout:          defaultTimeout,
+		MaxRetryTimes:    defaultMaxRetryTimes,
+		BackoffBaseDelay: defaultBackoffBaseDelay,
+		BackoffMaxDelay:  defaultBackoffMaxDelay,
+	}
+}
+
+// Apply applies the sink URI parameters to the config.
+func (c *Config) Apply(
+	sinkURI *url.URL,
+	replicaConfig *config.ReplicaConfig,
+) (err error) {
+	if sinkURI == nil {
+		return cerror.ErrWebhookSinkInvalidConfig.GenWithStack(
+			"failed to open webhook sink, empty SinkURI")
+	}
+
+	scheme := strings.ToLower(sinkURI.Scheme)
+	if !sink.IsWebhookScheme(scheme) {
+		return cerror.ErrWebhookSinkInvalidConfig.GenWithStack(
+			"can't create webhook sink with unsupported scheme: %s", scheme)
+	}
+	query := sinkURI.Query()
+	if err = getBatchSize(query, &c.BatchSize); err != nil {
+		return err
+	}
+	if err = getDuration(query, "flush-interval", minFlushInterval,
+		maxFlushInterval, &c.FlushInterval); err != nil {
+		return err
+	}
+	if err = getDuration(query, "timeout", time.Millisecond, 0, &c.Timeout); err != nil {
+		return err
+	}
+	if err = getDuration(query, "backoff-base-delay", time.Millisecond,
+		0, &c.BackoffBaseDelay); err != nil {
+		return err
+	}
+	if err = getDuration(query, "backoff-max-delay", time.Millisecond,
+		0, &c.BackoffMaxDelay); err != nil {
+		return err
+	}
+	if c.BackoffMaxDelay < c.BackoffBaseDelay {
+		return cerror.ErrWebhookSinkInvalidConfig.GenWithStack(
+			"backoff-max-delay %s is less than backoff-base-delay %s",
+			c.BackoffMaxDelay, c.BackoffBaseDelay)
+	}
+	if s := query.Get("max-retry-times"); len(s) > 0 {
+		c.MaxRetryTimes, err = strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrWebhookSinkInvalidConfig, err)
+		}
+	}
+
+	if len(query.Get("ssl-ca")) > 0 {
+		if scheme != sink.HTTPSSchema {
+			return cerror.ErrWebhookSinkInvalidConfig.GenWithStack(
+				"ssl-ca is only supported by the https scheme")
+		}
+		c.Credential = &security.Credential{
+			CAPath:   query.Get("ssl-ca"),
+			CertPath: query.Get("ssl-cert"),
+			KeyPath:  query.Get("ssl-key"),
+		}
+	}
+
+	if err = c.Protocol.FromString(replicaConfig.Sink.Protocol); err != nil {
+		return cerror.WrapError(cerror.ErrWebhookSinkInvalidConfig, err)
+	}
+	if c.Protocol != config.ProtocolOpen && c.Protocol != config.ProtocolCanalJSON &&
+		c.Protocol != config.ProtocolDebezium {
+		return cerror.ErrWebhookSinkInvalidConfig.GenWithStack(
+			"protocol %s is not supported by webhook sink", c.Protocol)
+	}
+
+	endpoint := *sinkURI
+	for _, param := range sinkParams {
+		query.Del(param)
+	}
+	endpoint.RawQuery = query.Encode()
+	c.Endpoint = endpoint.String()
+	return nil
+}
+
+// getDuration parses the duration of the key, the value must be in the
+// range of [lower, upper]. The upper limit is ignored if it is zero.
+func getDuration(values url.Values, key string,
+	lower, upper time.Duration, target *time.Duration,
+) error {
+	s := values.Get(key)
+	if len(s) == 0 {
+		return nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrWebhookSinkInvalidConfig, err)
+	}
+	if d < lower || (upper > 0 && d > upper) {
+		return cerror.WrapError(cerror.ErrWebhookSinkInvalidConfig,
+			fmt.Errorf("invalid %s %s, which is out of range", key, s))
+	}
+
+	*target = d
+	return nil
+}
+
+func getBatchSize(values url.Values, batchSize *int) error {
+	s := values.Get("batch-size")
+	if len(s) == 0 {
+		return nil
+	}
+
+	sz, err := strconv.Atoi(s)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrWebhookSinkInvalidConfig, err)
+	}
+	if sz <= 0 || sz > maxBatchSize {
+		return cerror.WrapError(cerror.ErrWebhookSinkInvalidConfig,
+			fmt.Errorf("invalid batch-size %d, which must be in [1, %d]",
+				sz, maxBatchSize))
+	}
+
+	*batchSize = sz
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigApply(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		uri       string
+		protocol  string
+		expected  *Config
+		expectErr string
+	}{
+		{
+			name:     "default config",
+			uri:      "http://127.0.0.1:8080/events?protocol=canal-json",
+			protocol: "canal-json",
+			expected: &Config{
+				Endpoint:         "http://127.0.0.1:8080/events",
+				Protocol:         config.ProtocolCanalJSON,
+				BatchSize:        defaultBatchSize,
+				FlushInterval:    defaultFlushInterval,
+				Timeout:          defaultTimeout,
+				MaxRetryTimes:    defaultMaxRetryTimes,
+				BackoffBaseDelay: defaultBackoffBaseDelay,
+				BackoffMaxDelay:  defaultBackoffMaxDelay,
+			},
+		},
+		{
+			name: "custom config",
+			uri: "https://example.com/events?token=abc&batch-size=16&flush-interval=100ms" +
+				"&timeout=3s&max-retry-times=3&backoff-base-delay=10ms&backoff-max-delay=1s" +
+				"&ssl-ca=ca.pem&ssl-cert=cert.pem&ssl-key=key.pem&enable-tidb-extension=true",
+			protocol: "open-protocol",
+			expected: &Config{
+				Endpoint:         "https://example.com/events?token=abc",
+				Protocol:         config.ProtocolOpen,
+				BatchSize:        16,
+				FlushInterval:    100 * time.Millisecond,
+				Timeout:          3 * time.Second,
+				MaxRetryTimes:    3,
+				BackoffBaseDelay: 10 * time.Millisecond,
+				BackoffMaxDelay:  time.Second,
+				Credential: &security.Credential{
+					CAPath:   "ca.pem",
+					CertPath: "cert.pem",
+					KeyPath:  "key.pem",
+				},
+			},
+		},
+		{
+			name:      "invalid batch size",
+			uri:       "http://127.0.0.1:8080/events?batch-size=0",
+			protocol:  "canal-json",
+			expectErr: "invalid batch-size 0",
+		},
+		{
+			name:      "invalid flush interval",
+			uri:       "http://127.0.0.1:8080/events?flush-interval=1h",
+			protocol:  "canal-json",
+			expectErr: "invalid flush-interval 1h",
+		},
+		{
+			name:      "invalid backoff delay",
+			uri:       "http://127.0.0.1:8080/events?backoff-base-delay=1s&backoff-max-delay=10ms",
+			protocol:  "canal-json",
+			expectErr: "less than backoff-base-delay",
+		},
+		{
+			name:      "tls without https",
+			uri:       "http://127.0.0.1:8080/events?ssl-ca=ca.pem",
+			protocol:  "canal-json",
+			expectErr: "ssl-ca is only supported by the https scheme",
+		},
+		{
+			name:      "unsupported protocol",
+			uri:       "http://127.0.0.1:8080/events",
+			protocol:  "avro",
+			expectErr: "protocol avro is not supported",
+		},
+		{
+			name:      "unsupported scheme",
+			uri:       "kafka://127.0.0.1:9092/test",
+			protocol:  "canal-json",
+			expectErr: "unsupported scheme",
+		},
+	}
+
+	for _, tc := range testCases {
+		sinkURI, err := url.Parse(tc.uri)
+		require.Nil(t, err)
+		replicaConfig := config.GetDefaultReplicaConfig()
+		replicaConfig.Sink.Protocol = tc.protocol
+
+		cfg := NewConfig()
+		err = cfg.Apply(sinkURI, replicaConfig)
+		if tc.expectErr != "" {
+			require.ErrorContains(t, err, tc.expectErr, tc.name)
+			continue
+		}
+		require.Nil(t, err, tc.name)
+		require.Equal(t, tc.expected, cfg, tc.name)
+	}
+}
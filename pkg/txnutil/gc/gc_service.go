@@ -33,6 +33,8 @@ const (
 	EnsureGCServiceResuming = "-resuming-"
 	// EnsureGCServiceInitializing is a tag of GC service id for changefeed initialization
 	EnsureGCServiceInitializing = "-initializing-"
+	// EnsureGCServiceChecking is a tag of GC service id for changefeed pre-flight check
+	EnsureGCServiceChecking = "-checking-"
 )
 
 // EnsureChangefeedStartTsSafety checks if the startTs less than the minimum of
@@ -59,6 +61,29 @@ func EnsureChangefeedStartTsSafety(
 	return nil
 }
 
+// CheckChangefeedStartTsSafety checks if the startTs less than the minimum of
+// service GC safepoint, it does not set any service GC safepoint.
+func CheckChangefeedStartTsSafety(
+	ctx context.Context, pdCli pd.Client,
+	gcServiceIDPrefix string,
+	changefeedID model.ChangeFeedID,
+	startTs uint64,
+) error {
+	// Removing a service GC safepoint returns the minimum of service GC
+	// safepoint, so the startTs can be checked without setting one.
+	minServiceGCTs, err := SetServiceGCSafepoint(
+		ctx, pdCli,
+		gcServiceIDPrefix+changefeedID.Namespace+"_"+changefeedID.ID,
+		0, math.MaxUint64)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if startTs > 0 && startTs < minServiceGCTs+1 {
+		return cerrors.ErrStartTsBeforeGC.GenWithStackByArgs(startTs, minServiceGCTs)
+	}
+	return nil
+}
+
 // UndoEnsureChangefeedStartTsSafety cleans the service GC safepoint of a changefeed
 // if something goes wrong after successfully calling EnsureChangefeedStartTsSafety().
 func UndoEnsureChangefeedStartTsSafety(
@@ -51,6 +51,9 @@ var (
 
 	// MinTiCDCVersion is the version of the minimal allowed TiCDC version.
 	MinTiCDCVersion = semver.New("6.3.0-alpha")
+	// minChangefeedHistoryVersion is the minimal TiCDC version which can parse
+	// the etcd keys of the changefeed history.
+	minChangefeedHistoryVersion = semver.New("6.4.0-alpha")
 	// MaxTiCDCVersion is the version of the maximum allowed TiCDC version.
 	// for version `x.y.z`, max allowed `x+2.0.0`
 	MaxTiCDCVersion = semver.New("8.0.0-alpha")
@@ -249,6 +252,16 @@ func (v *TiCDCClusterVersion) ShouldRunCliWithOpenAPI() bool {
 	return !v.LessThan(*semver.New("6.2.0")) || (v.Major == 6 && v.Minor == 2 && v.Patch == 0)
 }
 
+// ShouldRecordChangefeedHistory returns whether the changefeed history can be
+// written to etcd, older captures fail to parse the keys of the history.
+func (v *TiCDCClusterVersion) ShouldRecordChangefeedHistory() bool {
+	// we assume the unknown version to be the latest version
+	if v.Version == nil {
+		return true
+	}
+	return !v.LessThan(*minChangefeedHistoryVersion)
+}
+
 // ticdcClusterVersionUnknown is a read-only variable to represent the unknown cluster version
 var ticdcClusterVersionUnknown = TiCDCClusterVersion{}
 
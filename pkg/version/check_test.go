@@ -342,6 +342,14 @@ func TestTiCDCClusterVersionFeaturesCompatible(t *testing.T) {
 
 	require.Equal(t, ticdcClusterVersionUnknown.ShouldEnableUnifiedSorterByDefault(), true)
 	require.Equal(t, ticdcClusterVersionUnknown.ShouldEnableOldValueByDefault(), true)
+
+	ver = TiCDCClusterVersion{semver.New("6.3.0")}
+	require.False(t, ver.ShouldRecordChangefeedHistory())
+	ver = TiCDCClusterVersion{semver.New("6.4.0-alpha")}
+	require.True(t, ver.ShouldRecordChangefeedHistory())
+	ver = TiCDCClusterVersion{semver.New("6.4.0")}
+	require.True(t, ver.ShouldRecordChangefeedHistory())
+	require.True(t, ticdcClusterVersionUnknown.ShouldRecordChangefeedHistory())
 }
 
 func TestCheckPDVersionError(t *testing.T) {